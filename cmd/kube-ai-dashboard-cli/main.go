@@ -1,10 +1,13 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"os"
+	"os/signal"
 	"runtime/debug"
+	"syscall"
 
 	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/config"
 	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/db"
@@ -24,6 +27,8 @@ func main() {
 	// Command line flags (k9s compatible)
 	webMode := flag.Bool("web", false, "Start web server mode")
 	webPort := flag.Int("port", 8080, "Web server port (used with -web)")
+	bindAddr := flag.String("bind", "", "Web server bind address, overrides bind_address in config (used with -web)")
+	insecureAdmin := flag.Bool("insecure-default-admin", false, "Allow the well-known default admin password admin123 (used with -web)")
 	namespace := flag.String("n", "", "Initial namespace (use 'all' for all namespaces)")
 	allNamespaces := flag.Bool("A", false, "Start with all namespaces")
 	showVersion := flag.Bool("version", false, "Show version information")
@@ -61,6 +66,10 @@ func main() {
 
 	// Web mode
 	if *webMode {
+		if *bindAddr != "" {
+			cfg.BindAddress = *bindAddr
+		}
+		cfg.Auth.InsecureDefaultAdmin = *insecureAdmin
 		runWebServer(cfg, *webPort)
 		return
 	}
@@ -80,9 +89,28 @@ func runWebServer(cfg *config.Config, port int) {
 		os.Exit(1)
 	}
 
-	if err := server.Start(); err != nil {
-		log.Errorf("Web server error: %v", err)
-		os.Exit(1)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.Start()
+	}()
+
+	select {
+	case err := <-errCh:
+		if err != nil {
+			log.Errorf("Web server error: %v", err)
+			os.Exit(1)
+		}
+	case <-ctx.Done():
+		stop()
+		fmt.Println("\n  Shutting down web server...")
+		if err := server.Stop(); err != nil {
+			log.Errorf("Web server shutdown error: %v", err)
+			os.Exit(1)
+		}
+		log.Infof("Web server stopped cleanly.")
 	}
 }
 
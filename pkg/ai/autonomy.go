@@ -0,0 +1,178 @@
+package ai
+
+import (
+	"strings"
+
+	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/config"
+)
+
+// AutoApprove reports whether the AI may run cmd without asking the user,
+// given the autonomy level and command lists of cfg. Commands in the
+// denylist, composite commands such as pipes, and dangerous, interactive or
+// unrecognized commands always ask.
+func (f *CommandFilter) AutoApprove(cmd string, cfg *config.Config) bool {
+	sub := kubectlSubcommand(cmd)
+	if isCompositeCommand(sub) || deniesCommand(sub, cfg.AIDenyCommands) {
+		return false
+	}
+
+	switch f.ClassifyCommand(sub) {
+	case CommandTypeReadOnly:
+		return cfg.AIAutonomyLevel() != config.AIAutonomyManual
+	case CommandTypeWrite:
+		return cfg.AIAutonomyLevel() == config.AIAutonomyAutonomous && matchesCommand(sub, cfg.AIAllowCommands)
+	default:
+		return false
+	}
+}
+
+// kubectlSubcommand strips "kubectl" and any namespace flags before the
+// subcommand, so "kubectl -n shop scale deploy/web" becomes
+// "scale deploy/web". Other commands are returned trimmed.
+func kubectlSubcommand(cmd string) string {
+	fields := strings.Fields(cmd)
+	if len(fields) == 0 || fields[0] != "kubectl" {
+		return strings.TrimSpace(cmd)
+	}
+	fields = fields[1:]
+	for len(fields) > 0 {
+		switch {
+		case (fields[0] == "-n" || fields[0] == "--namespace") && len(fields) > 1:
+			fields = fields[2:]
+		case strings.HasPrefix(fields[0], "--namespace="):
+			fields = fields[1:]
+		default:
+			return strings.Join(fields, " ")
+		}
+	}
+	return ""
+}
+
+// matchesCommand reports whether subcommand starts with one of commands,
+// matching whole words after dropping flags: "rollout" matches
+// "rollout restart deploy/web" but "roll" does not
+func matchesCommand(subcommand string, commands []string) bool {
+	words := commandWords(subcommand)
+	for _, c := range commands {
+		want := commandWords(c)
+		if len(want) == 0 || len(want) > len(words) {
+			continue
+		}
+		matched := true
+		for i, w := range want {
+			if words[i] != w {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// deniesCommand reports whether subcommand is covered by one of commands.
+// It matches more loosely than matchesCommand, so flags or a name cannot
+// slip a command past the denylist: the verb must be the same and every
+// other word must appear somewhere in the command, so "get secrets" denies
+// "get -A secrets", "get -o yaml secrets" and "get secret db".
+func deniesCommand(subcommand string, commands []string) bool {
+	words := commandWords(subcommand)
+	if len(words) == 0 {
+		return false
+	}
+	present := make(map[string]bool, len(words))
+	for _, w := range words[1:] {
+		present[w] = true
+	}
+	for _, c := range commands {
+		want := commandWords(c)
+		if len(want) == 0 || want[0] != words[0] {
+			continue
+		}
+		denied := true
+		for _, w := range want[1:] {
+			if !present[w] {
+				denied = false
+				break
+			}
+		}
+		if denied {
+			return true
+		}
+	}
+	return false
+}
+
+// flagsWithValue are kubectl flags whose value may follow as a separate
+// word, e.g. "-o yaml"
+var flagsWithValue = map[string]bool{
+	"-n": true, "--namespace": true, "-o": true, "--output": true,
+	"-l": true, "--selector": true, "-f": true, "--filename": true,
+	"-c": true, "--container": true, "-L": true, "--label-columns": true,
+	"-p": true, "--patch": true, "-s": true, "--server": true,
+	"--context": true, "--cluster": true, "--user": true, "--kubeconfig": true,
+	"--field-selector": true, "--sort-by": true, "--template": true,
+	"--replicas": true, "--timeout": true, "--chunk-size": true, "--since": true,
+	"--tail": true, "--type": true, "--token": true, "--as": true, "--as-group": true,
+	"--request-timeout": true, "--image": true,
+}
+
+// commandWords returns the lowercased words of a kubectl subcommand without
+// flags and their values. Words after the verb are resource types or
+// names: "type/name" keeps the type, comma lists are split and resource
+// names are normalized, so "get secret/db,cm" gives
+// [get secrets configmaps].
+func commandWords(subcommand string) []string {
+	fields := strings.Fields(strings.ToLower(subcommand))
+	var words []string
+	for i := 0; i < len(fields); i++ {
+		field := fields[i]
+		if strings.HasPrefix(field, "-") {
+			if flagsWithValue[field] {
+				i++
+			}
+			continue
+		}
+		if len(words) == 0 {
+			words = append(words, field)
+			continue
+		}
+		if kind, _, ok := strings.Cut(field, "/"); ok {
+			field = kind
+		}
+		for _, part := range strings.Split(field, ",") {
+			if part != "" {
+				words = append(words, canonicalResource(part))
+			}
+		}
+	}
+	return words
+}
+
+// resourceAliases maps the short and irregular names of common resources
+// to their plural
+var resourceAliases = map[string]string{
+	"po": "pods", "svc": "services", "deploy": "deployments", "rs": "replicasets",
+	"sts": "statefulsets", "ds": "daemonsets", "cj": "cronjobs", "cm": "configmaps",
+	"ns": "namespaces", "no": "nodes", "ev": "events", "sa": "serviceaccounts",
+	"pv": "persistentvolumes", "pvc": "persistentvolumeclaims", "sc": "storageclasses",
+	"ing": "ingresses", "ingress": "ingresses", "netpol": "networkpolicies",
+	"networkpolicy": "networkpolicies", "hpa": "horizontalpodautoscalers",
+	"pdb": "poddisruptionbudgets", "crd": "customresourcedefinitions",
+	"crds": "customresourcedefinitions", "ep": "endpoints",
+}
+
+// canonicalResource returns the plural of a resource name given as its
+// short name, singular or plural, dropping any API group ("deployments.apps")
+func canonicalResource(name string) string {
+	name, _, _ = strings.Cut(name, ".")
+	if plural, ok := resourceAliases[name]; ok {
+		return plural
+	}
+	if name != "" && !strings.HasSuffix(name, "s") {
+		return name + "s"
+	}
+	return name
+}
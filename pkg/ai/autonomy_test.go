@@ -0,0 +1,47 @@
+package ai
+
+import (
+	"testing"
+
+	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/config"
+)
+
+func TestAutoApprove(t *testing.T) {
+	filter := NewCommandFilter()
+	allow := []string{"scale", "rollout restart"}
+	deny := []string{"get secrets"}
+
+	tests := []struct {
+		autonomy string
+		command  string
+		want     bool
+	}{
+		{config.AIAutonomyManual, "kubectl get pods", false},
+		{config.AIAutonomyAssisted, "kubectl get pods", true},
+		{"", "kubectl get pods", true},
+		{config.AIAutonomyAssisted, "kubectl scale deploy/web --replicas=3", false},
+		{config.AIAutonomyAutonomous, "kubectl scale deploy/web --replicas=3", true},
+		{config.AIAutonomyAutonomous, "kubectl -n shop scale deploy/web --replicas=3", true},
+		{config.AIAutonomyAutonomous, "kubectl rollout restart deploy/web", true},
+		{config.AIAutonomyAutonomous, "kubectl rollout undo deploy/web", false},
+		{config.AIAutonomyAutonomous, "kubectl apply -f web.yaml", false},
+		{config.AIAutonomyAutonomous, "kubectl delete pod web-1 --force", false},
+		{config.AIAutonomyAutonomous, "kubectl get secrets -o yaml", false},
+		{config.AIAutonomyAutonomous, "kubectl get pods | grep web", false},
+		{config.AIAutonomyAssisted, "kubectl get -o yaml secrets", false},
+		{config.AIAutonomyAssisted, "kubectl get -A secrets", false},
+		{config.AIAutonomyAssisted, "kubectl get secret db -o yaml", false},
+		{config.AIAutonomyAssisted, "kubectl get secret/db", false},
+		{config.AIAutonomyAssisted, "kubectl get configmaps,secrets", false},
+		{config.AIAutonomyAssisted, "kubectl -n shop get secrets.v1 db", false},
+		{config.AIAutonomyAssisted, "kubectl get -o yaml configmaps", true},
+		{config.AIAutonomyAutonomous, "kubectl --namespace=shop scale --replicas=2 deploy/web", true},
+	}
+
+	for _, tt := range tests {
+		cfg := &config.Config{AIAutonomy: tt.autonomy, AIAllowCommands: allow, AIDenyCommands: deny}
+		if got := filter.AutoApprove(tt.command, cfg); got != tt.want {
+			t.Errorf("AutoApprove(%q) at %q = %v, want %v", tt.command, tt.autonomy, got, tt.want)
+		}
+	}
+}
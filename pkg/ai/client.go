@@ -120,6 +120,12 @@ func GetAvailableProviders() string {
 // The toolApprovalCallback is called before executing each tool for user approval
 // Returns error if provider doesn't support tool calling
 func (c *Client) AskWithTools(ctx context.Context, prompt string, callback func(string), toolApprovalCallback func(toolName string, args string) bool) error {
+	return c.AskWithToolResults(ctx, prompt, callback, toolApprovalCallback, nil)
+}
+
+// AskWithToolResults is AskWithTools that also passes the output of every
+// executed tool call to resultCallback, e.g. to keep a transcript
+func (c *Client) AskWithToolResults(ctx context.Context, prompt string, callback func(string), toolApprovalCallback func(toolName string, args string) bool, resultCallback func(toolName, args, output string, isError bool)) error {
 	if c.provider == nil {
 		return fmt.Errorf("AI provider not initialized")
 	}
@@ -168,6 +174,9 @@ func (c *Client) AskWithTools(ctx context.Context, prompt string, callback func(
 		}
 
 		result := c.toolRegistry.Execute(ctx, toolCall)
+		if resultCallback != nil {
+			resultCallback(call.Function.Name, call.Function.Arguments, result.Content, result.IsError)
+		}
 		return providers.ToolResult{
 			ToolCallID: result.ToolCallID,
 			Content:    result.Content,
@@ -9,12 +9,212 @@ import (
 )
 
 type Config struct {
-	LLM          LLMConfig `yaml:"llm" json:"llm"`
-	ReportPath   string    `yaml:"report_path" json:"report_path"`
-	EnableAudit  bool      `yaml:"enable_audit" json:"enable_audit"`
-	Language     string    `yaml:"language" json:"language"`
-	BeginnerMode bool      `yaml:"beginner_mode" json:"beginner_mode"`
-	LogLevel     string    `yaml:"log_level" json:"log_level"`
+	LLM          LLMConfig     `yaml:"llm" json:"llm"`
+	ReportPath   string        `yaml:"report_path" json:"report_path"`
+	EnableAudit  bool          `yaml:"enable_audit" json:"enable_audit"`
+	Language     string        `yaml:"language" json:"language"`
+	BeginnerMode bool          `yaml:"beginner_mode" json:"beginner_mode"`
+	LogLevel     string        `yaml:"log_level" json:"log_level"`
+	Refresh      RefreshConfig `yaml:"refresh" json:"refresh"`
+
+	// CommandTimeout bounds non-interactive kubectl/shell commands (seconds)
+	CommandTimeout int `yaml:"command_timeout" json:"command_timeout"`
+
+	// DrainGracePeriod is the grace period (seconds) given to pods evicted by
+	// a node drain; 0 keeps each pod's own terminationGracePeriodSeconds
+	DrainGracePeriod int64 `yaml:"drain_grace_period" json:"drain_grace_period"`
+
+	// ASCIIMode draws the TUI with ASCII instead of box-drawing characters
+	// and emoji, and without color, for limited terminals and screen readers
+	ASCIIMode bool `yaml:"ascii_mode" json:"ascii_mode"`
+
+	// ConfirmActions lists the TUI actions that ask for confirmation, from
+	// ConfirmableActions; AlwaysConfirmActions ask regardless
+	ConfirmActions []string `yaml:"confirm_actions" json:"confirm_actions"`
+
+	// AIAutonomy is how much the AI assistant may run without asking, one
+	// of AIAutonomyLevels. AIDenyCommands always ask; in autonomous mode,
+	// write commands in AIAllowCommands run unattended. Both list kubectl
+	// subcommands, e.g. "scale" or "rollout restart".
+	AIAutonomy      string   `yaml:"ai_autonomy" json:"ai_autonomy"`
+	AIAllowCommands []string `yaml:"ai_allow_commands" json:"ai_allow_commands"`
+	AIDenyCommands  []string `yaml:"ai_deny_commands" json:"ai_deny_commands"`
+
+	// AutoAttachContext sends the manifest, related events and recent logs
+	// of the selected resource with every TUI question to the AI; without
+	// it only the selected table row goes along. Alt+Enter flips it for one
+	// question.
+	AutoAttachContext bool `yaml:"auto_attach_context" json:"auto_attach_context"`
+
+	// ColumnOrder reorders the columns of a TUI view, keyed by resource name
+	// (e.g. "pods"). Listed headers come first, in the given order; the rest
+	// keep their usual order after them.
+	ColumnOrder map[string][]string `yaml:"column_order" json:"column_order"`
+
+	// Aliases are extra command-mode shorthands: each name expands to the
+	// command it maps to, e.g. "dep" to "deployments" or "kp" to
+	// "pods -n kube-system". They take precedence over the built-in ones.
+	Aliases map[string]string `yaml:"aliases" json:"aliases"`
+
+	// BindAddress is the interface the web server listens on; empty means
+	// all interfaces
+	BindAddress string    `yaml:"bind_address" json:"bind_address"`
+	TLS         TLSConfig `yaml:"tls" json:"tls"`
+
+	// Auth selects how users sign in to the web server
+	Auth AuthConfig `yaml:"auth" json:"auth"`
+
+	Pricing PricingConfig `yaml:"pricing" json:"pricing"`
+
+	ReportLimits ReportLimits `yaml:"report_limits" json:"report_limits"`
+	ReportAI     ReportAI     `yaml:"report_ai" json:"report_ai"`
+}
+
+// ReportLimits caps the rows each section of an HTML report shows; 0 shows
+// every row. CSV exports are complete unless limits are passed per request.
+type ReportLimits struct {
+	Pods       int `yaml:"pods" json:"pods"`
+	Images     int `yaml:"images" json:"images"`
+	Events     int `yaml:"events" json:"events"`
+	Namespaces int `yaml:"namespaces" json:"namespaces"`
+}
+
+// ReportAI shapes the AI analysis added to reports
+type ReportAI struct {
+	// MaxWords is the length the analysis is asked to stay under
+	MaxWords int `yaml:"max_words" json:"max_words"`
+	// Focus lists the topics to emphasize: "security", "reliability" and/or
+	// "finops". Empty gives a balanced analysis.
+	Focus []string `yaml:"focus" json:"focus"`
+}
+
+// ReportAIFocuses are the topics a report's AI analysis can emphasize
+var ReportAIFocuses = []string{"security", "reliability", "finops"}
+
+// AI autonomy levels, from asking before every tool call to running
+// allowlisted writes unattended
+const (
+	AIAutonomyManual     = "manual"     // approve every command
+	AIAutonomyAssisted   = "assisted"   // read-only commands run unattended
+	AIAutonomyAutonomous = "autonomous" // so do write commands in AIAllowCommands
+)
+
+// AIAutonomyLevels are the values AIAutonomy accepts
+var AIAutonomyLevels = []string{AIAutonomyManual, AIAutonomyAssisted, AIAutonomyAutonomous}
+
+// AIAutonomyLevel returns AIAutonomy, or assisted when it is unset or not
+// one of AIAutonomyLevels
+func (c *Config) AIAutonomyLevel() string {
+	for _, level := range AIAutonomyLevels {
+		if c.AIAutonomy == level {
+			return level
+		}
+	}
+	return AIAutonomyAssisted
+}
+
+// ConfirmableActions are the actions ConfirmActions can turn confirmation
+// on or off for
+var ConfirmableActions = []string{"cordon", "uncordon", "finalizers", "restart", "rollback", "scale", "trigger"}
+
+// AlwaysConfirmActions are destructive or hard to undo and always ask for
+// confirmation, whatever ConfirmActions says
+var AlwaysConfirmActions = []string{"delete", "drain", "kill", "scale-to-0"}
+
+// RequiresConfirmation reports whether the TUI asks before running action
+func (c *Config) RequiresConfirmation(action string) bool {
+	for _, a := range AlwaysConfirmActions {
+		if a == action {
+			return true
+		}
+	}
+	for _, a := range c.ConfirmActions {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+// PricingConfig holds the prices used for cost estimates in reports
+type PricingConfig struct {
+	LoadBalancerHourly float64 `yaml:"load_balancer_hourly" json:"load_balancer_hourly"` // USD per load balancer-hour
+
+	// Provider selects the list prices for compute: aws, gcp, azure or
+	// onprem. CPUHourly and MemoryGBHourly override the provider's rates
+	// when set; onprem has none of its own.
+	Provider       string  `yaml:"provider" json:"provider"`
+	CPUHourly      float64 `yaml:"cpu_hourly" json:"cpu_hourly"`             // USD per vCPU-hour
+	MemoryGBHourly float64 `yaml:"memory_gb_hourly" json:"memory_gb_hourly"` // USD per GB-hour
+}
+
+// TLSConfig controls HTTPS for the web server. Without a certificate, plain
+// HTTP is only used on loopback; any other bind address gets a generated
+// self-signed certificate unless Disable is set.
+type TLSConfig struct {
+	CertFile   string `yaml:"cert_file" json:"cert_file"`
+	KeyFile    string `yaml:"key_file" json:"key_file"`
+	SelfSigned bool   `yaml:"self_signed" json:"self_signed"` // also use a self-signed certificate on loopback
+
+	// Disable serves plain HTTP on every interface, e.g. behind a
+	// TLS-terminating proxy
+	Disable bool `yaml:"disable" json:"disable"`
+}
+
+// Web auth modes. Local users sign in with a password; in OIDC mode the
+// web server also accepts ID tokens of the configured issuer as bearer
+// tokens, and local sign-in keeps working.
+const (
+	AuthModeLocal = "local"
+	AuthModeOIDC  = "oidc"
+)
+
+// AuthConfig selects how users sign in to the web server
+type AuthConfig struct {
+	// Mode is AuthModeLocal (the default) or AuthModeOIDC
+	Mode string     `yaml:"mode" json:"mode"`
+	OIDC OIDCConfig `yaml:"oidc" json:"oidc"`
+
+	// InsecureDefaultAdmin lets the default admin keep the well-known
+	// password of earlier versions. Only the -insecure-default-admin flag
+	// sets it, never the config file.
+	InsecureDefaultAdmin bool `yaml:"-" json:"-"`
+}
+
+// OIDCConfig names the OpenID Connect issuer whose tokens the web server
+// accepts and how their claims map to users. Tokens must be issued for
+// ClientID. Members of AdminGroups are admins, of OperatorGroups operators,
+// and everyone else a viewer.
+type OIDCConfig struct {
+	// IssuerURL is discovered through <IssuerURL>/.well-known/openid-configuration
+	IssuerURL string `yaml:"issuer_url" json:"issuer_url"`
+	ClientID  string `yaml:"client_id" json:"client_id"`
+
+	// UsernameClaim defaults to preferred_username, falling back to email
+	// and sub; GroupsClaim defaults to groups
+	UsernameClaim string `yaml:"username_claim" json:"username_claim"`
+	GroupsClaim   string `yaml:"groups_claim" json:"groups_claim"`
+
+	AdminGroups    []string `yaml:"admin_groups" json:"admin_groups"`
+	OperatorGroups []string `yaml:"operator_groups" json:"operator_groups"`
+}
+
+// RefreshConfig controls the retry/backoff used when a resource list fails to load
+type RefreshConfig struct {
+	InitialInterval float64 `yaml:"initial_interval" json:"initial_interval"` // seconds
+	MaxElapsedTime  float64 `yaml:"max_elapsed_time" json:"max_elapsed_time"` // seconds
+	MaxRetries      int     `yaml:"max_retries" json:"max_retries"`
+
+	// DiscoveryInterval re-discovers API resources (e.g. new CRDs) in the
+	// background; 0 disables it
+	DiscoveryInterval float64 `yaml:"discovery_interval" json:"discovery_interval"` // seconds
+
+	// Interval auto-refreshes the current view; 0 disables it
+	Interval float64 `yaml:"interval" json:"interval"` // seconds
+
+	// IdlePause holds auto-refresh back until no key has been pressed for
+	// this long, so the table doesn't change under the user; 0 disables it
+	IdlePause float64 `yaml:"idle_pause" json:"idle_pause"` // seconds
 }
 
 type LLMConfig struct {
@@ -54,6 +254,31 @@ func NewDefaultConfig() *Config {
 		LogLevel:     "debug",
 		ReportPath:   "report.md",
 		EnableAudit:  true,
+		Refresh: RefreshConfig{
+			InitialInterval: 0.3,
+			MaxElapsedTime:  10.0,
+			MaxRetries:      5,
+
+			DiscoveryInterval: 300,
+			Interval:          2,
+			IdlePause:         3,
+		},
+		CommandTimeout: 30,
+		ConfirmActions: []string{"finalizers", "restart", "scale", "trigger"},
+		AIAutonomy:     AIAutonomyAssisted,
+		BindAddress:    "127.0.0.1",
+		Pricing: PricingConfig{
+			LoadBalancerHourly: 0.025,
+			Provider:           "aws",
+		},
+		ReportLimits: ReportLimits{
+			Pods:   50,
+			Images: 20,
+			Events: 20,
+		},
+		ReportAI: ReportAI{
+			MaxWords: 500,
+		},
 	}
 }
 
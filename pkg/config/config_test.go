@@ -40,3 +40,84 @@ func TestDefaultConfig(t *testing.T) {
 		t.Errorf("Expected provider openai, got %s", cfg.LLM.Provider)
 	}
 }
+
+func TestDefaultRefreshConfig(t *testing.T) {
+	cfg := NewDefaultConfig()
+	if cfg.Refresh.InitialInterval != 0.3 {
+		t.Errorf("Expected initial interval 0.3, got %v", cfg.Refresh.InitialInterval)
+	}
+	if cfg.Refresh.MaxElapsedTime != 10.0 {
+		t.Errorf("Expected max elapsed time 10, got %v", cfg.Refresh.MaxElapsedTime)
+	}
+	if cfg.Refresh.MaxRetries != 5 {
+		t.Errorf("Expected max retries 5, got %d", cfg.Refresh.MaxRetries)
+	}
+	if cfg.Refresh.DiscoveryInterval != 300 {
+		t.Errorf("Expected discovery interval 300, got %v", cfg.Refresh.DiscoveryInterval)
+	}
+	if cfg.Refresh.Interval != 2 {
+		t.Errorf("Expected auto-refresh interval 2, got %v", cfg.Refresh.Interval)
+	}
+}
+
+func TestDefaultBindAddress(t *testing.T) {
+	cfg := NewDefaultConfig()
+	if cfg.BindAddress != "127.0.0.1" {
+		t.Errorf("Expected bind address 127.0.0.1, got %q", cfg.BindAddress)
+	}
+}
+
+func TestDefaultPricing(t *testing.T) {
+	cfg := NewDefaultConfig()
+	if cfg.Pricing.LoadBalancerHourly != 0.025 {
+		t.Errorf("Expected load balancer price 0.025, got %v", cfg.Pricing.LoadBalancerHourly)
+	}
+	if cfg.Pricing.Provider != "aws" {
+		t.Errorf("Expected pricing provider aws, got %q", cfg.Pricing.Provider)
+	}
+}
+
+func TestDefaultReportLimits(t *testing.T) {
+	cfg := NewDefaultConfig()
+	want := ReportLimits{Pods: 50, Images: 20, Events: 20}
+	if cfg.ReportLimits != want {
+		t.Errorf("Expected report limits %+v, got %+v", want, cfg.ReportLimits)
+	}
+}
+
+func TestDefaultReportAI(t *testing.T) {
+	cfg := NewDefaultConfig()
+	if cfg.ReportAI.MaxWords != 500 || len(cfg.ReportAI.Focus) != 0 {
+		t.Errorf("Expected a balanced 500-word analysis, got %+v", cfg.ReportAI)
+	}
+}
+
+func TestRequiresConfirmation(t *testing.T) {
+	cfg := NewDefaultConfig()
+	for _, action := range []string{"delete", "drain", "kill", "scale-to-0", "restart", "scale"} {
+		if !cfg.RequiresConfirmation(action) {
+			t.Errorf("Expected %q to require confirmation by default", action)
+		}
+	}
+	if cfg.RequiresConfirmation("cordon") {
+		t.Error("Expected cordon not to require confirmation by default")
+	}
+
+	// Dangerous actions can't be configured away
+	cfg.ConfirmActions = nil
+	if cfg.RequiresConfirmation("restart") || !cfg.RequiresConfirmation("delete") {
+		t.Errorf("Unexpected confirmations with an empty confirm_actions")
+	}
+}
+
+func TestAIAutonomyLevel(t *testing.T) {
+	if level := NewDefaultConfig().AIAutonomyLevel(); level != AIAutonomyAssisted {
+		t.Errorf("Expected assisted autonomy by default, got %q", level)
+	}
+	for value, want := range map[string]string{"": AIAutonomyAssisted, "yolo": AIAutonomyAssisted, "manual": AIAutonomyManual} {
+		cfg := &Config{AIAutonomy: value}
+		if level := cfg.AIAutonomyLevel(); level != want {
+			t.Errorf("AIAutonomyLevel() for %q = %q, want %q", value, level, want)
+		}
+	}
+}
@@ -47,8 +47,10 @@ func createTables() error {
 		llm_request TEXT,
 		llm_response TEXT
 	);`
-	_, err := DB.Exec(query)
-	return err
+	if _, err := DB.Exec(query); err != nil {
+		return err
+	}
+	return createReportsTable()
 }
 
 func Close() error {
@@ -0,0 +1,102 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// MaxStoredReportBytes caps the total size of the stored report snapshots,
+// which hold a whole report each; the oldest are deleted as new ones are
+// saved. The newest snapshot is always kept.
+var MaxStoredReportBytes int64 = 32 << 20
+
+// ErrReportNotFound is returned by GetReport for an unknown id
+var ErrReportNotFound = errors.New("report not found")
+
+// ReportRecord describes a stored report snapshot without its data
+type ReportRecord struct {
+	ID          int64     `json:"id"`
+	GeneratedAt time.Time `json:"generated_at"`
+	User        string    `json:"user"`
+	Namespace   string    `json:"namespace,omitempty"` // empty for cluster-wide reports
+	HealthScore float64   `json:"health_score"`
+}
+
+func createReportsTable() error {
+	_, err := DB.Exec(`
+	CREATE TABLE IF NOT EXISTS reports (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		generated_at DATETIME,
+		user TEXT,
+		namespace TEXT,
+		health_score REAL,
+		data TEXT
+	);`)
+	return err
+}
+
+// SaveReport stores a report snapshot, data being the report as JSON, and
+// returns its id. Without a database nothing is stored and the id is 0.
+func SaveReport(rec ReportRecord, data []byte) (int64, error) {
+	if DB == nil {
+		return 0, nil
+	}
+
+	res, err := DB.Exec(`INSERT INTO reports (generated_at, user, namespace, health_score, data) VALUES (?, ?, ?, ?, ?)`,
+		rec.GeneratedAt, rec.User, rec.Namespace, rec.HealthScore, string(data))
+	if err != nil {
+		return 0, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = DB.Exec(`DELETE FROM reports WHERE id != ? AND id IN (
+		SELECT id FROM (SELECT id, SUM(LENGTH(CAST(data AS BLOB))) OVER (ORDER BY id DESC) AS total FROM reports)
+		WHERE total > ?)`, id, MaxStoredReportBytes)
+	return id, err
+}
+
+// ListReports returns up to limit stored reports, newest first. A non-empty
+// namespace lists only the reports scoped to it; "" lists cluster-wide ones.
+// A non-empty user lists only the reports that user generated.
+func ListReports(namespace, user string, limit int) ([]ReportRecord, error) {
+	if DB == nil {
+		return nil, nil
+	}
+
+	rows, err := DB.Query(`SELECT id, generated_at, user, namespace, health_score FROM reports
+		WHERE namespace = ? AND (? = '' OR user = ?) ORDER BY id DESC LIMIT ?`, namespace, user, user, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []ReportRecord
+	for rows.Next() {
+		var rec ReportRecord
+		if err := rows.Scan(&rec.ID, &rec.GeneratedAt, &rec.User, &rec.Namespace, &rec.HealthScore); err != nil {
+			return nil, err
+		}
+		records = append(records, rec)
+	}
+	return records, rows.Err()
+}
+
+// GetReport returns the stored report id and its JSON data
+func GetReport(id int64) (ReportRecord, []byte, error) {
+	if DB == nil {
+		return ReportRecord{}, nil, ErrReportNotFound
+	}
+
+	var rec ReportRecord
+	var data string
+	err := DB.QueryRow(`SELECT id, generated_at, user, namespace, health_score, data FROM reports WHERE id = ?`, id).
+		Scan(&rec.ID, &rec.GeneratedAt, &rec.User, &rec.Namespace, &rec.HealthScore, &data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ReportRecord{}, nil, ErrReportNotFound
+	}
+	return rec, []byte(data), err
+}
@@ -0,0 +1,76 @@
+package db
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReportHistory(t *testing.T) {
+	if err := Init(filepath.Join(t.TempDir(), "audit.db")); err != nil {
+		t.Fatalf("Failed to init DB: %v", err)
+	}
+	defer Close()
+
+	now := time.Now().UTC().Truncate(time.Second)
+	first, err := SaveReport(ReportRecord{GeneratedAt: now.Add(-time.Hour), User: "alice", HealthScore: 80}, []byte(`{"health_score":80}`))
+	if err != nil {
+		t.Fatalf("SaveReport failed: %v", err)
+	}
+	second, _ := SaveReport(ReportRecord{GeneratedAt: now, User: "bob", HealthScore: 90}, []byte(`{"health_score":90}`))
+	SaveReport(ReportRecord{GeneratedAt: now, User: "bob", Namespace: "shop"}, []byte(`{}`))
+
+	records, err := ListReports("", "", 10)
+	if err != nil {
+		t.Fatalf("ListReports failed: %v", err)
+	}
+	if len(records) != 2 || records[0].ID != second || records[1].ID != first || records[0].User != "bob" || records[0].HealthScore != 90 {
+		t.Errorf("unexpected records: %+v", records)
+	}
+	if records, _ := ListReports("", "alice", 10); len(records) != 1 || records[0].ID != first {
+		t.Errorf("unexpected records of alice: %+v", records)
+	}
+	if records, _ := ListReports("shop", "", 10); len(records) != 1 || records[0].Namespace != "shop" {
+		t.Errorf("unexpected namespace records: %+v", records)
+	}
+
+	rec, data, err := GetReport(first)
+	if err != nil || string(data) != `{"health_score":80}` || rec.User != "alice" {
+		t.Errorf("GetReport = %+v, %s, %v", rec, data, err)
+	}
+	if _, _, err := GetReport(999); err != ErrReportNotFound {
+		t.Errorf("expected ErrReportNotFound, got %v", err)
+	}
+}
+
+func TestReportHistoryPrunesBySize(t *testing.T) {
+	if err := Init(filepath.Join(t.TempDir(), "audit.db")); err != nil {
+		t.Fatalf("Failed to init DB: %v", err)
+	}
+	defer Close()
+
+	prev := MaxStoredReportBytes
+	MaxStoredReportBytes = 25
+	defer func() { MaxStoredReportBytes = prev }()
+
+	data := []byte(strings.Repeat("x", 10))
+	var ids []int64
+	for i := 0; i < 4; i++ {
+		id, err := SaveReport(ReportRecord{GeneratedAt: time.Now(), User: "alice"}, data)
+		if err != nil {
+			t.Fatalf("SaveReport failed: %v", err)
+		}
+		ids = append(ids, id)
+	}
+	records, _ := ListReports("", "", 10)
+	if len(records) != 2 || records[0].ID != ids[3] || records[1].ID != ids[2] {
+		t.Errorf("expected the two newest reports to be kept, got %+v", records)
+	}
+
+	// A report above the cap on its own is still kept until the next one
+	id, _ := SaveReport(ReportRecord{GeneratedAt: time.Now()}, []byte(strings.Repeat("x", 30)))
+	if records, _ := ListReports("", "", 10); len(records) != 1 || records[0].ID != id {
+		t.Errorf("expected only the oversized newest report, got %+v", records)
+	}
+}
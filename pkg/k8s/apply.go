@@ -0,0 +1,174 @@
+package k8s
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// ApplyFieldManager is the field manager k13s applies manifests as
+const ApplyFieldManager = "k13s"
+
+// ApplyYAML applies a single manifest with server-side apply, like
+// kubectl apply --server-side --force-conflicts, and returns the object the
+// server stored. The manifest may come straight from GetResourceYAML:
+// managed fields are dropped before applying. Its resourceVersion is kept,
+// so applying a copy of an object that changed since it was fetched fails
+// with a conflict (apierrors.IsConflict) instead of reverting the change.
+// Validation errors from the server are returned as they are.
+func (c *Client) ApplyYAML(ctx context.Context, data []byte) (*unstructured.Unstructured, error) {
+	obj, err := parseManifest(data)
+	if err != nil {
+		return nil, err
+	}
+	return c.applyObject(ctx, obj, "")
+}
+
+// parseManifest decodes a single YAML manifest
+func parseManifest(data []byte) (*unstructured.Unstructured, error) {
+	raw, err := utilyaml.ToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+	obj := &unstructured.Unstructured{}
+	if err := obj.UnmarshalJSON(raw); err != nil {
+		return nil, fmt.Errorf("invalid manifest: %w", err)
+	}
+	if obj.GetName() == "" {
+		return nil, fmt.Errorf("invalid manifest: metadata.name is required")
+	}
+	return obj, nil
+}
+
+// applyObject sends obj as a forced server-side apply. A namespaced object
+// without metadata.namespace goes to defaultNamespace, or is rejected when
+// that is empty.
+func (c *Client) applyObject(ctx context.Context, obj *unstructured.Unstructured, defaultNamespace string) (*unstructured.Unstructured, error) {
+	gvk := obj.GroupVersionKind()
+	gvr, namespaced, err := c.resourceForKind(gvk)
+	if err != nil {
+		return nil, err
+	}
+	if namespaced && obj.GetNamespace() == "" && defaultNamespace != "" {
+		obj.SetNamespace(defaultNamespace)
+	}
+	if namespaced && obj.GetNamespace() == "" {
+		return nil, fmt.Errorf("invalid manifest: %s %s needs metadata.namespace", gvk.Kind, obj.GetName())
+	}
+
+	obj.SetManagedFields(nil)
+	body, err := obj.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	dyn, err := c.dynamicClient()
+	if err != nil {
+		return nil, err
+	}
+	force := true
+	opts := metav1.PatchOptions{FieldManager: ApplyFieldManager, Force: &force}
+	if namespaced {
+		return dyn.Resource(gvr).Namespace(obj.GetNamespace()).Patch(ctx, obj.GetName(), types.ApplyPatchType, body, opts)
+	}
+	return dyn.Resource(gvr).Patch(ctx, obj.GetName(), types.ApplyPatchType, body, opts)
+}
+
+// ApplyResult is the outcome of applying one document of a manifest file
+type ApplyResult struct {
+	Document  int // 1-based position in the file
+	Kind      string
+	Namespace string
+	Name      string
+	Err       error
+}
+
+// ApplyManifests applies every object of a YAML file, which may hold
+// several documents separated by "---", like ApplyYAML. A file describes
+// the desired state rather than an edit of a fetched copy, so any
+// resourceVersion in it is dropped, and namespaced objects without
+// metadata.namespace go to namespace, as kubectl apply does. Objects are
+// applied in order and a failure doesn't stop the rest; each gets an
+// ApplyResult. Documents without an object, e.g. only comments, are skipped.
+func (c *Client) ApplyManifests(ctx context.Context, data []byte, namespace string) ([]ApplyResult, error) {
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(data)))
+
+	var results []ApplyResult
+	for doc := 1; ; doc++ {
+		chunk, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return results, nil
+		}
+		if err != nil {
+			return results, fmt.Errorf("document %d: %w", doc, err)
+		}
+
+		if len(bytes.TrimSpace(stripYAMLComments(chunk))) == 0 {
+			doc--
+			continue
+		}
+
+		// Best effort, so even manifests that fail to apply can be named
+		var meta struct {
+			Kind     string `json:"kind"`
+			Metadata struct {
+				Name      string `json:"name"`
+				Namespace string `json:"namespace"`
+			} `json:"metadata"`
+		}
+		_ = utilyaml.Unmarshal(chunk, &meta)
+
+		result := ApplyResult{Document: doc, Kind: meta.Kind, Namespace: meta.Metadata.Namespace, Name: meta.Metadata.Name}
+		obj, err := parseManifest(chunk)
+		if err == nil {
+			obj.SetResourceVersion("")
+			obj, err = c.applyObject(ctx, obj, namespace)
+		}
+		if err != nil {
+			result.Err = err
+		} else {
+			result.Namespace = obj.GetNamespace()
+		}
+		results = append(results, result)
+	}
+}
+
+// stripYAMLComments drops comment lines
+func stripYAMLComments(data []byte) []byte {
+	var out [][]byte
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		if !bytes.HasPrefix(bytes.TrimSpace(line), []byte("#")) {
+			out = append(out, line)
+		}
+	}
+	return bytes.Join(out, []byte("\n"))
+}
+
+// resourceForKind looks up the resource serving a kind, and whether it is
+// namespaced, through discovery
+func (c *Client) resourceForKind(gvk schema.GroupVersionKind) (schema.GroupVersionResource, bool, error) {
+	if gvk.Kind == "" || gvk.Version == "" {
+		return schema.GroupVersionResource{}, false, fmt.Errorf("invalid manifest: apiVersion and kind are required")
+	}
+
+	list, err := c.Clientset.Discovery().ServerResourcesForGroupVersion(gvk.GroupVersion().String())
+	if err != nil {
+		return schema.GroupVersionResource{}, false, fmt.Errorf("unknown apiVersion %s: %w", gvk.GroupVersion(), err)
+	}
+	for _, r := range list.APIResources {
+		if r.Kind == gvk.Kind && !strings.Contains(r.Name, "/") {
+			return gvk.GroupVersion().WithResource(r.Name), r.Namespaced, nil
+		}
+	}
+	return schema.GroupVersionResource{}, false, fmt.Errorf("unknown kind %s in %s", gvk.Kind, gvk.GroupVersion())
+}
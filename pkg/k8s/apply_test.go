@@ -0,0 +1,211 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	ktesting "k8s.io/client-go/testing"
+)
+
+func TestApplyYAML(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	clientset.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{
+				{Name: "configmaps", Kind: "ConfigMap", Namespaced: true},
+				{Name: "namespaces", Kind: "Namespace"},
+			},
+		},
+	}
+	dyn := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+
+	var patches []ktesting.PatchAction
+	dyn.PrependReactor("patch", "*", func(action ktesting.Action) (bool, runtime.Object, error) {
+		patch := action.(ktesting.PatchAction)
+		patches = append(patches, patch)
+		obj := &unstructured.Unstructured{}
+		if err := obj.UnmarshalJSON(patch.GetPatch()); err != nil {
+			return true, nil, err
+		}
+		return true, obj, nil
+	})
+	client := &Client{Clientset: clientset, Dynamic: dyn}
+
+	manifest := `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+  namespace: shop
+  resourceVersion: "42"
+  managedFields:
+  - manager: kubectl-edit
+    operation: Update
+data:
+  mode: fast
+`
+	obj, err := client.ApplyYAML(context.Background(), []byte(manifest))
+	if err != nil {
+		t.Fatalf("ApplyYAML failed: %v", err)
+	}
+	if obj.GetName() != "settings" {
+		t.Errorf("expected the applied object back, got %v", obj)
+	}
+	if len(patches) != 1 {
+		t.Fatalf("expected 1 patch, got %d", len(patches))
+	}
+	patch := patches[0]
+	if patch.GetPatchType() != types.ApplyPatchType || patch.GetNamespace() != "shop" || patch.GetResource().Resource != "configmaps" {
+		t.Errorf("expected a server-side apply of shop/configmaps, got %s %s/%s", patch.GetPatchType(), patch.GetNamespace(), patch.GetResource().Resource)
+	}
+	var sent map[string]interface{}
+	json.Unmarshal(patch.GetPatch(), &sent)
+	metadata := sent["metadata"].(map[string]interface{})
+	if _, ok := metadata["managedFields"]; ok {
+		t.Error("expected managed fields to be dropped")
+	}
+	if metadata["resourceVersion"] != "42" {
+		t.Errorf("expected the resource version to be kept as a precondition, got %v", metadata["resourceVersion"])
+	}
+
+	for _, bad := range []string{
+		"kind: ConfigMap\nmetadata:\n  name: x\n",                             // no apiVersion
+		"apiVersion: v1\nkind: Widget\nmetadata:\n  name: x\n",                // unknown kind
+		"apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: x\n",             // no namespace
+		"apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: [unterminated\n", // not YAML
+	} {
+		if _, err := client.ApplyYAML(context.Background(), []byte(bad)); err == nil {
+			t.Errorf("expected an error for %q", bad)
+		}
+	}
+	if len(patches) != 1 {
+		t.Errorf("expected invalid manifests not to be sent, got %d patches", len(patches))
+	}
+
+	if _, err := client.ApplyYAML(context.Background(), []byte("apiVersion: v1\nkind: Namespace\nmetadata:\n  name: shop\n")); err != nil {
+		t.Errorf("expected cluster-scoped objects without a namespace to apply, got %v", err)
+	}
+	if !strings.Contains(patches[len(patches)-1].GetResource().Resource, "namespaces") {
+		t.Errorf("expected a namespaces patch, got %v", patches[len(patches)-1].GetResource())
+	}
+}
+
+func TestApplyYAMLConflict(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	clientset.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{{Name: "configmaps", Kind: "ConfigMap", Namespaced: true}},
+		},
+	}
+	dyn := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+	// The stored object is at resourceVersion 43, like the API server the
+	// patch fails on any other precondition
+	dyn.PrependReactor("patch", "*", func(action ktesting.Action) (bool, runtime.Object, error) {
+		patch := action.(ktesting.PatchAction)
+		obj := &unstructured.Unstructured{}
+		if err := obj.UnmarshalJSON(patch.GetPatch()); err != nil {
+			return true, nil, err
+		}
+		if rv := obj.GetResourceVersion(); rv != "" && rv != "43" {
+			return true, nil, apierrors.NewConflict(schema.GroupResource{Resource: "configmaps"}, obj.GetName(), errors.New("the object has been modified"))
+		}
+		return true, obj, nil
+	})
+	client := &Client{Clientset: clientset, Dynamic: dyn}
+
+	stale := "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: settings\n  namespace: shop\n  resourceVersion: \"42\"\n"
+	if _, err := client.ApplyYAML(context.Background(), []byte(stale)); !apierrors.IsConflict(err) {
+		t.Errorf("expected a conflict applying a stale copy, got %v", err)
+	}
+	results, err := client.ApplyManifests(context.Background(), []byte(stale), "default")
+	if err != nil || len(results) != 1 || results[0].Err != nil {
+		t.Errorf("expected a manifest file to apply regardless of its resourceVersion, got %+v, %v", results, err)
+	}
+}
+
+func TestApplyManifests(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	clientset.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{
+				{Name: "configmaps", Kind: "ConfigMap", Namespaced: true},
+			},
+		},
+	}
+	dyn := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+
+	var applied []string
+	dyn.PrependReactor("patch", "*", func(action ktesting.Action) (bool, runtime.Object, error) {
+		patch := action.(ktesting.PatchAction)
+		applied = append(applied, patch.GetNamespace()+"/"+patch.GetName())
+		obj := &unstructured.Unstructured{}
+		if err := obj.UnmarshalJSON(patch.GetPatch()); err != nil {
+			return true, nil, err
+		}
+		return true, obj, nil
+	})
+	client := &Client{Clientset: clientset, Dynamic: dyn}
+
+	manifests := `# shop settings
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: first
+  namespace: shop
+---
+# nothing here
+---
+apiVersion: v1
+kind: Widget
+metadata:
+  name: broken
+  namespace: shop
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: second
+  namespace: shop
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: unscoped
+`
+	results, err := client.ApplyManifests(context.Background(), []byte(manifests), "dev")
+	if err != nil {
+		t.Fatalf("ApplyManifests failed: %v", err)
+	}
+	if len(results) != 4 {
+		t.Fatalf("expected 4 results, the comment-only document skipped, got %+v", results)
+	}
+	if results[0].Err != nil || results[0].Name != "first" || results[0].Kind != "ConfigMap" || results[0].Namespace != "shop" {
+		t.Errorf("unexpected first result %+v", results[0])
+	}
+	if results[1].Err == nil || results[1].Name != "broken" || results[1].Document != 2 {
+		t.Errorf("expected the unknown kind to fail as document 2, got %+v", results[1])
+	}
+	if results[2].Err != nil || results[2].Name != "second" {
+		t.Errorf("expected a failure not to stop later documents, got %+v", results[2])
+	}
+	if results[3].Err != nil || results[3].Namespace != "dev" {
+		t.Errorf("expected an object without a namespace to go to the default namespace, got %+v", results[3])
+	}
+	if strings.Join(applied, ",") != "shop/first,shop/second,dev/unscoped" {
+		t.Errorf("expected first, second and unscoped to be applied, got %v", applied)
+	}
+}
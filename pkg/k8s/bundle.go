@@ -0,0 +1,217 @@
+package k8s
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	// bundleMaxPods bounds the pods whose logs go into a bundle
+	bundleMaxPods = 10
+
+	// bundleLogLines is how many lines of each container log are collected
+	bundleLogLines = 1000
+)
+
+// WriteDiagnosticsBundle writes a gzipped tar "support bundle" for one
+// object to w: its manifest (Secrets redacted), describe output, events,
+// the AI context summary and recent logs of its pods. For a namespace the
+// bundle covers every event and up to bundleMaxPods pods in it; for
+// workloads and services, the pods they select. Parts that can't be
+// collected are listed in errors.txt instead of failing the bundle.
+// All files are under dir/ in the archive.
+func (c *Client) WriteDiagnosticsBundle(ctx context.Context, w io.Writer, dir, resource, namespace, name string) error {
+	gvr, ok := c.GetGVR(resource)
+	if !ok {
+		return fmt.Errorf("unknown resource: %s", resource)
+	}
+	obj, err := c.GetResource(ctx, namespace, name, gvr)
+	if err != nil {
+		return err
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	now := time.Now()
+	var problems []string
+	add := func(file, content string) error {
+		hdr := &tar.Header{Name: dir + "/" + file, Mode: 0644, Size: int64(len(content)), ModTime: now}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		_, err := io.WriteString(tw, content)
+		return err
+	}
+	addOrNote := func(file, content string, err error) error {
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", file, err))
+			return nil
+		}
+		return add(file, content)
+	}
+
+	isSecret := obj.GetKind() == "Secret"
+	RedactSecret(obj)
+	manifest, err := yaml.Marshal(obj.Object)
+	if err := addOrNote("manifest.yaml", string(manifest), err); err != nil {
+		return err
+	}
+
+	describe, err := c.DescribeResource(ctx, gvr.Resource, namespace, name)
+	if err := addOrNote("describe.txt", describe, err); err != nil {
+		return err
+	}
+
+	// The AI context repeats the unredacted manifest, so Secrets go without
+	if !isSecret {
+		summary, err := c.GetResourceContext(ctx, namespace, name, gvr.Resource)
+		if err := addOrNote("context.md", summary, err); err != nil {
+			return err
+		}
+	}
+
+	pods, err := c.bundlePods(ctx, gvr.Resource, namespace, obj)
+	if err != nil {
+		problems = append(problems, fmt.Sprintf("pods: %v", err))
+	}
+
+	eventsNS := namespace
+	if gvr.Resource == "namespaces" {
+		eventsNS = name
+	}
+	involved := map[string]bool{name: true}
+	for _, pod := range pods {
+		involved[pod.Name] = true
+	}
+	events, err := c.ListEvents(ctx, eventsNS)
+	if err == nil {
+		if gvr.Resource != "namespaces" {
+			events = filterEvents(events, involved)
+		}
+		err = add("events.txt", formatBundleEvents(events))
+		if err != nil {
+			return err
+		}
+	} else {
+		problems = append(problems, fmt.Sprintf("events.txt: %v", err))
+	}
+
+	for _, pod := range pods {
+		for _, cs := range pod.Status.ContainerStatuses {
+			file := fmt.Sprintf("logs/%s/%s.log", pod.Name, cs.Name)
+			logs, err := c.GetPodLogs(ctx, pod.Namespace, pod.Name, cs.Name, bundleLogLines)
+			if err := addOrNote(file, logs, err); err != nil {
+				return err
+			}
+			if cs.RestartCount > 0 {
+				file := fmt.Sprintf("logs/%s/%s.previous.log", pod.Name, cs.Name)
+				logs, err := c.GetPodLogsPrevious(ctx, pod.Namespace, pod.Name, cs.Name, bundleLogLines)
+				if err := addOrNote(file, logs, err); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		if err := add("errors.txt", strings.Join(problems, "\n")+"\n"); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// bundlePods returns the pods a bundle collects logs from, at most
+// bundleMaxPods in name order
+func (c *Client) bundlePods(ctx context.Context, resource, namespace string, obj *unstructured.Unstructured) ([]corev1.Pod, error) {
+	var opts metav1.ListOptions
+	switch resource {
+	case "pods":
+		pod, err := c.Clientset.CoreV1().Pods(namespace).Get(ctx, obj.GetName(), metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return []corev1.Pod{*pod}, nil
+	case "namespaces":
+		namespace = obj.GetName()
+	default:
+		sel, err := podSelectorOf(resource, obj)
+		if err != nil {
+			return nil, err
+		}
+		if sel.Empty() {
+			return nil, nil
+		}
+		opts.LabelSelector = sel.String()
+	}
+
+	list, err := c.Clientset.CoreV1().Pods(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	pods := list.Items
+	sort.Slice(pods, func(i, j int) bool { return pods[i].Name < pods[j].Name })
+	if len(pods) > bundleMaxPods {
+		pods = pods[:bundleMaxPods]
+	}
+	return pods, nil
+}
+
+// filterEvents keeps the events about the named objects
+func filterEvents(events []corev1.Event, names map[string]bool) []corev1.Event {
+	var kept []corev1.Event
+	for _, ev := range events {
+		if names[ev.InvolvedObject.Name] {
+			kept = append(kept, ev)
+		}
+	}
+	return kept
+}
+
+// formatBundleEvents renders events oldest first, like kubectl get events
+func formatBundleEvents(events []corev1.Event) string {
+	if len(events) == 0 {
+		return "No events found.\n"
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return eventTime(events[i]).Before(eventTime(events[j]))
+	})
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%-25s %-8s %-20s %-40s %s\n", "LAST SEEN", "TYPE", "REASON", "OBJECT", "MESSAGE"))
+	for _, ev := range events {
+		object := strings.ToLower(ev.InvolvedObject.Kind) + "/" + ev.InvolvedObject.Name
+		sb.WriteString(fmt.Sprintf("%-25s %-8s %-20s %-40s %s\n",
+			eventTime(ev).Format(time.RFC3339), ev.Type, ev.Reason, object, ev.Message))
+	}
+	return sb.String()
+}
+
+// eventTime is when an event was last seen, for old and new style events
+func eventTime(ev corev1.Event) time.Time {
+	switch {
+	case !ev.LastTimestamp.IsZero():
+		return ev.LastTimestamp.Time
+	case !ev.EventTime.IsZero():
+		return ev.EventTime.Time
+	case !ev.FirstTimestamp.IsZero():
+		return ev.FirstTimestamp.Time
+	default:
+		return ev.CreationTimestamp.Time
+	}
+}
@@ -0,0 +1,98 @@
+package k8s
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// readBundle returns the files of a gzipped tar by name
+func readBundle(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := make(map[string]string)
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return files
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, _ := io.ReadAll(tr)
+		files[hdr.Name] = string(content)
+	}
+}
+
+func TestWriteDiagnosticsBundle(t *testing.T) {
+	dep := testDeployment("web", 1, map[string]string{"app": "web"})
+	dep.TypeMeta = metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"}
+	dep.Spec.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(dep)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default", Labels: map[string]string{"app": "web"}},
+		Status:     corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{Name: "app", RestartCount: 1}}},
+	}
+	other := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "db-1", Namespace: "default", Labels: map[string]string{"app": "db"}}}
+	event := &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: "web-1.1", Namespace: "default"},
+		InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "web-1"},
+		Type:           "Warning", Reason: "BackOff", Message: "Back-off restarting failed container",
+	}
+	client := &Client{
+		Clientset: fake.NewSimpleClientset(dep, pod, other, event),
+		Dynamic:   dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), &unstructured.Unstructured{Object: obj}),
+	}
+
+	var buf bytes.Buffer
+	if err := client.WriteDiagnosticsBundle(context.Background(), &buf, "bundle", "deployments", "default", "web"); err != nil {
+		t.Fatalf("WriteDiagnosticsBundle failed: %v", err)
+	}
+	files := readBundle(t, buf.Bytes())
+
+	for _, name := range []string{"bundle/manifest.yaml", "bundle/describe.txt", "bundle/context.md", "bundle/events.txt",
+		"bundle/logs/web-1/app.log", "bundle/logs/web-1/app.previous.log"} {
+		if _, ok := files[name]; !ok {
+			t.Errorf("expected %s in bundle, got %v", name, bundleFileNames(files))
+		}
+	}
+	if _, ok := files["bundle/logs/db-1/app.log"]; ok {
+		t.Error("expected only the deployment's pods to be collected")
+	}
+	if !strings.Contains(files["bundle/manifest.yaml"], "name: web") {
+		t.Errorf("unexpected manifest:\n%s", files["bundle/manifest.yaml"])
+	}
+	if !strings.Contains(files["bundle/events.txt"], "BackOff") {
+		t.Errorf("expected the pod's events, got:\n%s", files["bundle/events.txt"])
+	}
+
+	if err := client.WriteDiagnosticsBundle(context.Background(), &buf, "bundle", "deployments", "default", "missing"); err == nil {
+		t.Error("expected an error for a missing object")
+	}
+}
+
+func bundleFileNames(m map[string]string) []string {
+	var out []string
+	for k := range m {
+		out = append(out, k)
+	}
+	return out
+}
@@ -0,0 +1,99 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DeploymentRevisionAnnotation is bumped by the deployment controller on
+// every rollout
+const DeploymentRevisionAnnotation = "deployment.kubernetes.io/revision"
+
+// RecentChange is the latest known modification of an object. Kubernetes
+// has no change feed, so it is approximated from metadata.
+type RecentChange struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Time      time.Time
+	Manager   string // field manager of the change, e.g. "kubectl-client-side-apply"
+	Operation string // "Update" or "Apply"; "Create" when only the creation time is known
+	Revision  string // deployment revision, if any
+}
+
+// LastChange returns the newest managedFields entry of obj, ignoring status
+// subresource writes from controllers, and falls back to the creation time.
+func LastChange(kind string, obj metav1.Object) RecentChange {
+	change := RecentChange{
+		Kind:      kind,
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+		Time:      obj.GetCreationTimestamp().Time,
+		Operation: "Create",
+		Revision:  obj.GetAnnotations()[DeploymentRevisionAnnotation],
+	}
+	for _, mf := range obj.GetManagedFields() {
+		if mf.Subresource != "" || mf.Time == nil || !mf.Time.After(change.Time) {
+			continue
+		}
+		change.Time = mf.Time.Time
+		change.Manager = mf.Manager
+		change.Operation = string(mf.Operation)
+	}
+	return change
+}
+
+// RecentChanges lists workloads, services, ingresses and configuration in a
+// namespace ("" for all namespaces), newest change first. Kinds that fail to
+// list are left out and their errors joined.
+func (c *Client) RecentChanges(ctx context.Context, namespace string) ([]RecentChange, error) {
+	var changes []RecentChange
+	var errs []error
+	add := func(kind string, objs []metav1.Object, err error) {
+		if err != nil {
+			errs = append(errs, err)
+			return
+		}
+		for _, obj := range objs {
+			changes = append(changes, LastChange(kind, obj))
+		}
+	}
+
+	deployments, err := c.ListDeployments(ctx, namespace)
+	add("Deployment", objectsOf(deployments), err)
+	statefulsets, err := c.ListStatefulSets(ctx, namespace)
+	add("StatefulSet", objectsOf(statefulsets), err)
+	daemonsets, err := c.ListDaemonSets(ctx, namespace)
+	add("DaemonSet", objectsOf(daemonsets), err)
+	cronjobs, err := c.ListCronJobs(ctx, namespace)
+	add("CronJob", objectsOf(cronjobs), err)
+	services, err := c.ListServices(ctx, namespace)
+	add("Service", objectsOf(services), err)
+	ingresses, err := c.ListIngresses(ctx, namespace)
+	add("Ingress", objectsOf(ingresses), err)
+	configmaps, err := c.ListConfigMaps(ctx, namespace)
+	add("ConfigMap", objectsOf(configmaps), err)
+	secrets, err := c.ListSecrets(ctx, namespace)
+	add("Secret", objectsOf(secrets), err)
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Time.After(changes[j].Time)
+	})
+	return changes, errors.Join(errs...)
+}
+
+// objectsOf converts a list of API objects to their metadata accessors
+func objectsOf[T any, PT interface {
+	*T
+	metav1.Object
+}](items []T) []metav1.Object {
+	objs := make([]metav1.Object, 0, len(items))
+	for i := range items {
+		objs = append(objs, PT(&items[i]))
+	}
+	return objs
+}
@@ -0,0 +1,58 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestLastChange(t *testing.T) {
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	applied := metav1.NewTime(created.Add(time.Hour))
+	status := metav1.NewTime(created.Add(2 * time.Hour))
+
+	dep := testDeployment("web", 2, nil)
+	dep.CreationTimestamp = metav1.NewTime(created)
+	dep.Annotations = map[string]string{DeploymentRevisionAnnotation: "4"}
+	dep.ManagedFields = []metav1.ManagedFieldsEntry{
+		{Manager: "kubectl-client-side-apply", Operation: metav1.ManagedFieldsOperationUpdate, Time: &applied},
+		// Controller status writes are not changes to the object
+		{Manager: "kube-controller-manager", Operation: metav1.ManagedFieldsOperationUpdate, Time: &status, Subresource: "status"},
+	}
+
+	got := LastChange("Deployment", dep)
+	want := RecentChange{
+		Kind: "Deployment", Namespace: "default", Name: "web",
+		Time: applied.Time, Manager: "kubectl-client-side-apply", Operation: "Update", Revision: "4",
+	}
+	if got != want {
+		t.Errorf("LastChange = %+v, want %+v", got, want)
+	}
+
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "plain", CreationTimestamp: metav1.NewTime(created)}}
+	if got := LastChange("ConfigMap", cm); got.Operation != "Create" || !got.Time.Equal(created) {
+		t.Errorf("expected creation time fallback, got %+v", got)
+	}
+}
+
+func TestRecentChanges(t *testing.T) {
+	now := time.Now()
+	older := testDeployment("older", 1, nil)
+	older.CreationTimestamp = metav1.NewTime(now.Add(-48 * time.Hour))
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+		Name: "settings", Namespace: "default", CreationTimestamp: metav1.NewTime(now.Add(-time.Hour)),
+	}}
+	client := &Client{Clientset: fake.NewSimpleClientset(older, cm)}
+
+	changes, err := client.RecentChanges(context.Background(), "default")
+	if err != nil {
+		t.Fatalf("RecentChanges failed: %v", err)
+	}
+	if len(changes) != 2 || changes[0].Name != "settings" || changes[1].Name != "older" {
+		t.Errorf("expected newest change first, got %+v", changes)
+	}
+}
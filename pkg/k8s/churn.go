@@ -0,0 +1,121 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// churnWindow is how far back pod recreations are counted
+	churnWindow = time.Hour
+
+	// replacementGrace separates pods created with their ReplicaSet, e.g. by
+	// a rollout, from pods that replaced a deleted or evicted one
+	replacementGrace = 2 * time.Minute
+)
+
+// PodChurn summarizes the stability of a workload's current pods. Restart
+// counts reset when a pod is recreated, so a flapping workload can show few
+// restarts while its pods keep being replaced.
+type PodChurn struct {
+	Pods      int
+	Restarts  int32 // summed over the containers of the current pods
+	Recreated int   // pods created within churnWindow, after their ReplicaSet
+	Newest    time.Time
+	Oldest    time.Time
+}
+
+// ComputePodChurn summarizes pods; rsCreated holds the creation time of
+// each owning ReplicaSet by name. A pod counts as recreated if it is younger
+// than churnWindow and was created more than replacementGrace after its
+// ReplicaSet; scale-ups are counted too.
+func ComputePodChurn(pods []corev1.Pod, rsCreated map[string]time.Time, now time.Time) PodChurn {
+	var churn PodChurn
+	for _, pod := range pods {
+		churn.Pods++
+		for _, cs := range pod.Status.ContainerStatuses {
+			churn.Restarts += cs.RestartCount
+		}
+
+		created := pod.CreationTimestamp.Time
+		if churn.Newest.IsZero() || created.After(churn.Newest) {
+			churn.Newest = created
+		}
+		if churn.Oldest.IsZero() || created.Before(churn.Oldest) {
+			churn.Oldest = created
+		}
+
+		if now.Sub(created) > churnWindow {
+			continue
+		}
+		for _, ref := range pod.OwnerReferences {
+			if ref.Kind != "ReplicaSet" {
+				continue
+			}
+			if rsTime, ok := rsCreated[ref.Name]; ok && created.Sub(rsTime) > replacementGrace {
+				churn.Recreated++
+			}
+		}
+	}
+	return churn
+}
+
+// podChurn lists the pods and ReplicaSets matching selector in namespace
+// and summarizes them
+func (c *Client) podChurn(ctx context.Context, namespace string, selector *metav1.LabelSelector) (PodChurn, error) {
+	sel, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return PodChurn{}, err
+	}
+	opts := metav1.ListOptions{LabelSelector: sel.String()}
+
+	pods, err := c.Clientset.CoreV1().Pods(namespace).List(ctx, opts)
+	if err != nil {
+		return PodChurn{}, err
+	}
+	rsCreated := make(map[string]time.Time)
+	replicaSets, err := c.Clientset.AppsV1().ReplicaSets(namespace).List(ctx, opts)
+	if err != nil {
+		return PodChurn{}, err
+	}
+	for _, rs := range replicaSets.Items {
+		rsCreated[rs.Name] = rs.CreationTimestamp.Time
+	}
+	return ComputePodChurn(pods.Items, rsCreated, time.Now()), nil
+}
+
+// writePodChurn adds the "Pod Stability" section of a deployment or
+// ReplicaSet description
+func writePodChurn(sb *strings.Builder, churn PodChurn, now time.Time) {
+	sb.WriteString("\nPod Stability:\n")
+	if churn.Pods == 0 {
+		sb.WriteString("  <no pods>\n")
+		return
+	}
+	sb.WriteString(fmt.Sprintf("  Pods:         %d (newest %s, oldest %s old)\n",
+		churn.Pods, shortDuration(now.Sub(churn.Newest)), shortDuration(now.Sub(churn.Oldest))))
+	sb.WriteString(fmt.Sprintf("  Restarts:     %d across current pods\n", churn.Restarts))
+	sb.WriteString(fmt.Sprintf("  Recreated:    %d in the last %s (replacements or scale-ups)\n", churn.Recreated, shortDuration(churnWindow)))
+	if churn.Recreated > 0 {
+		sb.WriteString("  Warning:      pods are being replaced; restart counts of replaced pods are lost\n")
+	}
+}
+
+// shortDuration formats d in its largest unit, e.g. "3d", "5h" or "42s"
+func shortDuration(d time.Duration) string {
+	switch {
+	case d >= 24*time.Hour:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	case d >= time.Minute:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	default:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	}
+}
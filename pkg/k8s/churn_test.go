@@ -0,0 +1,73 @@
+package k8s
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func churnPod(name string, created time.Time, restarts int32) corev1.Pod {
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "default",
+			Labels:            map[string]string{"app": "web"},
+			CreationTimestamp: metav1.NewTime(created),
+			OwnerReferences:   []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "web-abc"}},
+		},
+		Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{Name: "app", RestartCount: restarts}}},
+	}
+}
+
+func TestComputePodChurn(t *testing.T) {
+	now := time.Now()
+	rsCreated := map[string]time.Time{"web-abc": now.Add(-3 * time.Hour)}
+	pods := []corev1.Pod{
+		churnPod("web-1", now.Add(-3*time.Hour), 2),    // created with the ReplicaSet
+		churnPod("web-2", now.Add(-10*time.Minute), 0), // replacement
+		churnPod("web-3", now.Add(-5*time.Minute), 1),  // replacement
+	}
+
+	churn := ComputePodChurn(pods, rsCreated, now)
+	if churn.Pods != 3 || churn.Restarts != 3 || churn.Recreated != 2 {
+		t.Errorf("unexpected churn %+v", churn)
+	}
+	if !churn.Newest.Equal(now.Add(-5*time.Minute)) || !churn.Oldest.Equal(now.Add(-3*time.Hour)) {
+		t.Errorf("unexpected pod ages %+v", churn)
+	}
+
+	// A fresh rollout creates its pods with the ReplicaSet: no churn
+	rsCreated["web-abc"] = now.Add(-6 * time.Minute)
+	if churn := ComputePodChurn(pods[2:], rsCreated, now); churn.Recreated != 0 {
+		t.Errorf("expected rollout pods not to count as recreated, got %d", churn.Recreated)
+	}
+}
+
+func TestDescribeDeploymentPodStability(t *testing.T) {
+	now := time.Now()
+	dep := testDeployment("web", 2, nil)
+	dep.Spec.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}
+	rs := &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+		Name: "web-abc", Namespace: "default", Labels: map[string]string{"app": "web"},
+		CreationTimestamp: metav1.NewTime(now.Add(-2 * time.Hour)),
+	}}
+	stable := churnPod("web-1", now.Add(-2*time.Hour), 1)
+	replaced := churnPod("web-2", now.Add(-20*time.Minute), 0)
+	client := &Client{Clientset: fake.NewSimpleClientset(dep, rs, &stable, &replaced)}
+
+	out, err := client.DescribeResource(context.Background(), "deployments", "default", "web")
+	if err != nil {
+		t.Fatalf("DescribeResource failed: %v", err)
+	}
+	for _, want := range []string{"Pod Stability:", "Pods:         2 (newest 20m, oldest 2h old)", "Restarts:     1", "Recreated:    1 in the last 1h", "Warning:"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in describe output:\n%s", want, out)
+		}
+	}
+}
@@ -2,11 +2,14 @@ package k8s
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/log"
@@ -20,6 +23,7 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 	storagev1 "k8s.io/api/storage/v1"
 	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -33,11 +37,27 @@ import (
 	metricsv1beta1 "k8s.io/metrics/pkg/client/clientset/versioned/typed/metrics/v1beta1"
 )
 
+// ErrDynamicUnavailable is returned by calls that need the dynamic client
+// when the client was only partly initialized without one
+var ErrDynamicUnavailable = errors.New("dynamic client unavailable")
+
 type Client struct {
 	Clientset kubernetes.Interface
 	Dynamic   dynamic.Interface
 	Config    *rest.Config
 	Metrics   *metricsv1beta1.MetricsV1beta1Client
+
+	// Watch-backed caches, see StartInformers
+	informerMu       sync.RWMutex
+	informers        *informerCache
+	onInformerChange func(resource string)
+
+	// Whether the metrics API is served, see DetectMetrics
+	metricsState int32
+
+	// contextName is the kubeconfig context chosen with SwitchContext, ""
+	// for the kubeconfig's current context
+	contextName string
 }
 
 func NewClient() (*Client, error) {
@@ -93,12 +113,24 @@ func (c *Client) SwitchContext(contextName string) error {
 		return err
 	}
 
+	metricsClient, err := metricsv1beta1.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+
 	c.Clientset = clientset
 	c.Dynamic = dynamicClient
+	c.Metrics = metricsClient
+	c.contextName = contextName
+	atomic.StoreInt32(&c.metricsState, metricsUnknown)
+	c.restartInformers()
 	return nil
 }
 
-func (c *Client) ListPods(ctx context.Context, namespace string) ([]corev1.Pod, error) {
+func (c *Client) ListPods(ctx context.Context, namespace string, opts ...ListOption) ([]corev1.Pod, error) {
+	if pods, ok := c.cachedPods(namespace, opts...); ok {
+		return pods, nil
+	}
 	log.Infof("ListPods: ENTER (namespace: %s)", namespace)
 
 	type result struct {
@@ -109,7 +141,7 @@ func (c *Client) ListPods(ctx context.Context, namespace string) ([]corev1.Pod,
 
 	go func() {
 		log.Infof("ListPods: GOROUTINE START: calling c.Clientset.CoreV1().Pods(%s).List", namespace)
-		pods, err := c.Clientset.CoreV1().Pods(namespace).List(context.Background(), metav1.ListOptions{})
+		pods, err := c.Clientset.CoreV1().Pods(namespace).List(context.Background(), listOptions(opts))
 		if err != nil {
 			ch <- result{err: err}
 			return
@@ -143,48 +175,54 @@ func (c *Client) ListNodes(ctx context.Context) ([]corev1.Node, error) {
 	return nodes.Items, nil
 }
 
-func (c *Client) ListDeployments(ctx context.Context, namespace string) ([]appsv1.Deployment, error) {
-	deps, err := c.Clientset.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+func (c *Client) ListDeployments(ctx context.Context, namespace string, opts ...ListOption) ([]appsv1.Deployment, error) {
+	if deps, ok := c.cachedDeployments(namespace, opts...); ok {
+		return deps, nil
+	}
+	deps, err := c.Clientset.AppsV1().Deployments(namespace).List(ctx, listOptions(opts))
 	if err != nil {
 		return nil, err
 	}
 	return deps.Items, nil
 }
 
-func (c *Client) ListStatefulSets(ctx context.Context, namespace string) ([]appsv1.StatefulSet, error) {
-	stses, err := c.Clientset.AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+func (c *Client) ListStatefulSets(ctx context.Context, namespace string, opts ...ListOption) ([]appsv1.StatefulSet, error) {
+	stses, err := c.Clientset.AppsV1().StatefulSets(namespace).List(ctx, listOptions(opts))
 	if err != nil {
 		return nil, err
 	}
 	return stses.Items, nil
 }
 
-func (c *Client) ListServices(ctx context.Context, namespace string) ([]corev1.Service, error) {
-	svcs, err := c.Clientset.CoreV1().Services(namespace).List(ctx, metav1.ListOptions{})
+func (c *Client) ListServices(ctx context.Context, namespace string, opts ...ListOption) ([]corev1.Service, error) {
+	if svcs, ok := c.cachedServices(namespace, opts...); ok {
+		return svcs, nil
+	}
+	svcs, err := c.Clientset.CoreV1().Services(namespace).List(ctx, listOptions(opts))
 	if err != nil {
 		return nil, err
 	}
 	return svcs.Items, nil
 }
 
-func (c *Client) ListConfigMaps(ctx context.Context, namespace string) ([]corev1.ConfigMap, error) {
-	cms, err := c.Clientset.CoreV1().ConfigMaps(namespace).List(ctx, metav1.ListOptions{})
+func (c *Client) ListConfigMaps(ctx context.Context, namespace string, opts ...ListOption) ([]corev1.ConfigMap, error) {
+	cms, err := c.Clientset.CoreV1().ConfigMaps(namespace).List(ctx, listOptions(opts))
 	if err != nil {
 		return nil, err
 	}
 	return cms.Items, nil
 }
 
-func (c *Client) ListSecrets(ctx context.Context, namespace string) ([]corev1.Secret, error) {
-	secrets, err := c.Clientset.CoreV1().Secrets(namespace).List(ctx, metav1.ListOptions{})
+func (c *Client) ListSecrets(ctx context.Context, namespace string, opts ...ListOption) ([]corev1.Secret, error) {
+	secrets, err := c.Clientset.CoreV1().Secrets(namespace).List(ctx, listOptions(opts))
 	if err != nil {
 		return nil, err
 	}
 	return secrets.Items, nil
 }
 
-func (c *Client) ListIngresses(ctx context.Context, namespace string) ([]networkingv1.Ingress, error) {
-	ings, err := c.Clientset.NetworkingV1().Ingresses(namespace).List(ctx, metav1.ListOptions{})
+func (c *Client) ListIngresses(ctx context.Context, namespace string, opts ...ListOption) ([]networkingv1.Ingress, error) {
+	ings, err := c.Clientset.NetworkingV1().Ingresses(namespace).List(ctx, listOptions(opts))
 	if err != nil {
 		return nil, err
 	}
@@ -218,6 +256,20 @@ func (c *Client) ListNamespaces(ctx context.Context) ([]corev1.Namespace, error)
 	}
 }
 
+// GetPodContainers returns the names of a pod's containers, init containers
+// excluded, in spec order
+func (c *Client) GetPodContainers(ctx context.Context, namespace, name string) ([]string, error) {
+	pod, err := c.Clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(pod.Spec.Containers))
+	for _, ctr := range pod.Spec.Containers {
+		names = append(names, ctr.Name)
+	}
+	return names, nil
+}
+
 func (c *Client) GetPodLogs(ctx context.Context, namespace, name, container string, tailLines int64) (string, error) {
 	opts := &corev1.PodLogOptions{
 		Container: container,
@@ -250,6 +302,9 @@ func (c *Client) GetContextInfo() (ctxName, cluster, user string, err error) {
 	}
 
 	ctxName = rawConfig.CurrentContext
+	if c.contextName != "" {
+		ctxName = c.contextName
+	}
 	if ctx, ok := rawConfig.Contexts[ctxName]; ok {
 		cluster = ctx.Cluster
 		user = ctx.AuthInfo
@@ -286,8 +341,17 @@ func (c *Client) ListEvents(ctx context.Context, namespace string) ([]corev1.Eve
 	return events.Items, nil
 }
 
-func (c *Client) GetPodLogsStream(ctx context.Context, namespace, name string) (io.ReadCloser, error) {
-	req := c.Clientset.CoreV1().Pods(namespace).GetLogs(name, &corev1.PodLogOptions{Follow: true})
+// GetPodLogsStream opens a log stream for a pod container. With follow set
+// the stream stays open until ctx is cancelled or the container exits.
+func (c *Client) GetPodLogsStream(ctx context.Context, namespace, name, container string, tailLines int64, follow bool) (io.ReadCloser, error) {
+	opts := &corev1.PodLogOptions{
+		Container: container,
+		Follow:    follow,
+	}
+	if tailLines > 0 {
+		opts.TailLines = &tailLines
+	}
+	req := c.Clientset.CoreV1().Pods(namespace).GetLogs(name, opts)
 	return req.Stream(ctx)
 }
 
@@ -336,8 +400,8 @@ func (c *Client) ListPersistentVolumes(ctx context.Context) ([]corev1.Persistent
 	return pv.Items, nil
 }
 
-func (c *Client) ListPersistentVolumeClaims(ctx context.Context, namespace string) ([]corev1.PersistentVolumeClaim, error) {
-	pvc, err := c.Clientset.CoreV1().PersistentVolumeClaims(namespace).List(ctx, metav1.ListOptions{})
+func (c *Client) ListPersistentVolumeClaims(ctx context.Context, namespace string, opts ...ListOption) ([]corev1.PersistentVolumeClaim, error) {
+	pvc, err := c.Clientset.CoreV1().PersistentVolumeClaims(namespace).List(ctx, listOptions(opts))
 	if err != nil {
 		return nil, err
 	}
@@ -352,32 +416,32 @@ func (c *Client) ListStorageClasses(ctx context.Context) ([]storagev1.StorageCla
 	return sc.Items, nil
 }
 
-func (c *Client) ListServiceAccounts(ctx context.Context, namespace string) ([]corev1.ServiceAccount, error) {
-	sa, err := c.Clientset.CoreV1().ServiceAccounts(namespace).List(ctx, metav1.ListOptions{})
+func (c *Client) ListServiceAccounts(ctx context.Context, namespace string, opts ...ListOption) ([]corev1.ServiceAccount, error) {
+	sa, err := c.Clientset.CoreV1().ServiceAccounts(namespace).List(ctx, listOptions(opts))
 	if err != nil {
 		return nil, err
 	}
 	return sa.Items, nil
 }
 
-func (c *Client) ListDaemonSets(ctx context.Context, namespace string) ([]appsv1.DaemonSet, error) {
-	dss, err := c.Clientset.AppsV1().DaemonSets(namespace).List(ctx, metav1.ListOptions{})
+func (c *Client) ListDaemonSets(ctx context.Context, namespace string, opts ...ListOption) ([]appsv1.DaemonSet, error) {
+	dss, err := c.Clientset.AppsV1().DaemonSets(namespace).List(ctx, listOptions(opts))
 	if err != nil {
 		return nil, err
 	}
 	return dss.Items, nil
 }
 
-func (c *Client) ListJobs(ctx context.Context, namespace string) ([]batchv1.Job, error) {
-	jobs, err := c.Clientset.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{})
+func (c *Client) ListJobs(ctx context.Context, namespace string, opts ...ListOption) ([]batchv1.Job, error) {
+	jobs, err := c.Clientset.BatchV1().Jobs(namespace).List(ctx, listOptions(opts))
 	if err != nil {
 		return nil, err
 	}
 	return jobs.Items, nil
 }
 
-func (c *Client) ListCronJobs(ctx context.Context, namespace string) ([]batchv1.CronJob, error) {
-	cjs, err := c.Clientset.BatchV1().CronJobs(namespace).List(ctx, metav1.ListOptions{})
+func (c *Client) ListCronJobs(ctx context.Context, namespace string, opts ...ListOption) ([]batchv1.CronJob, error) {
+	cjs, err := c.Clientset.BatchV1().CronJobs(namespace).List(ctx, listOptions(opts))
 	if err != nil {
 		return nil, err
 	}
@@ -415,16 +479,24 @@ func (c *Client) ListContexts() ([]string, string, error) {
 
 func (c *Client) ScaleResource(ctx context.Context, gvr schema.GroupVersionResource, namespace, name string, replicas int32) error {
 	// For deployments, statefulsets, etc.
+	dyn, err := c.dynamicClient()
+	if err != nil {
+		return err
+	}
 	payload := []byte(fmt.Sprintf(`{"spec":{"replicas":%d}}`, replicas))
-	_, err := c.Dynamic.Resource(gvr).Namespace(namespace).Patch(ctx, name, types.MergePatchType, payload, metav1.PatchOptions{})
+	_, err = dyn.Resource(gvr).Namespace(namespace).Patch(ctx, name, types.MergePatchType, payload, metav1.PatchOptions{})
 	return err
 }
 
 func (c *Client) RolloutRestart(ctx context.Context, gvr schema.GroupVersionResource, namespace, name string) error {
+	dyn, err := c.dynamicClient()
+	if err != nil {
+		return err
+	}
 	// Trigger restart by updating annotation
 	timestamp := time.Now().Format(time.RFC3339)
 	payload := []byte(fmt.Sprintf(`{"spec":{"template":{"metadata":{"annotations":{"kubectl.kubernetes.io/restartedAt":"%s"}}}}}`, timestamp))
-	_, err := c.Dynamic.Resource(gvr).Namespace(namespace).Patch(ctx, name, types.MergePatchType, payload, metav1.PatchOptions{})
+	_, err = dyn.Resource(gvr).Namespace(namespace).Patch(ctx, name, types.MergePatchType, payload, metav1.PatchOptions{})
 	return err
 }
 
@@ -434,11 +506,12 @@ func (c *Client) PortForward(ctx context.Context, namespace, podName string, loc
 		return err
 	}
 
-	path := fmt.Sprintf("/api/v1/namespaces/%s/pods/%s/portforward", namespace, podName)
-	hostIP := strings.TrimLeft(c.Config.Host, "htps:/")
-	serverURL := url.URL{Scheme: "https", Path: path, Host: hostIP}
+	serverURL, err := portForwardURL(c.Config.Host, namespace, podName)
+	if err != nil {
+		return err
+	}
 
-	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: roundTripper}, http.MethodPost, &serverURL)
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: roundTripper}, http.MethodPost, serverURL)
 
 	ports := []string{fmt.Sprintf("%d:%d", localPort, podPort)}
 	pf, err := portforward.New(dialer, ports, stopCh, readyCh, nil, nil)
@@ -449,8 +522,31 @@ func (c *Client) PortForward(ctx context.Context, namespace, podName string, loc
 	return pf.ForwardPorts()
 }
 
+// portForwardURL builds the portforward subresource URL of a pod from the
+// rest config host, which may be a bare host[:port] or a URL with a path
+// prefix (e.g. behind an API proxy)
+func portForwardURL(host, namespace, podName string) (*url.URL, error) {
+	if !strings.Contains(host, "://") {
+		host = "https://" + host
+	}
+	u, err := url.Parse(host)
+	if err != nil {
+		return nil, fmt.Errorf("invalid API server host %q: %w", host, err)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid API server host %q: no host", host)
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/") + fmt.Sprintf("/api/v1/namespaces/%s/pods/%s/portforward", namespace, podName)
+	u.RawPath = ""
+	return u, nil
+}
+
 func (c *Client) DeleteResource(ctx context.Context, gvr schema.GroupVersionResource, namespace, name string) error {
-	return c.Dynamic.Resource(gvr).Namespace(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	dyn, err := c.dynamicClient()
+	if err != nil {
+		return err
+	}
+	return dyn.Resource(gvr).Namespace(namespace).Delete(ctx, name, metav1.DeleteOptions{})
 }
 
 // GetPodLogsPrevious gets logs from the previous container instance
@@ -487,13 +583,16 @@ func (c *Client) DeletePodForce(ctx context.Context, namespace, name string) err
 	return c.Clientset.CoreV1().Pods(namespace).Delete(ctx, name, deleteOptions)
 }
 
+// GetPodMetrics returns the CPU (millicores) and memory (MB) usage of each
+// pod in namespace. It fails with ErrMetricsUnavailable without
+// metrics-server.
 func (c *Client) GetPodMetrics(ctx context.Context, namespace string) (map[string][]int64, error) {
-	if c.Metrics == nil {
-		return nil, fmt.Errorf("metrics client not initialized")
+	if err := c.checkMetrics(); err != nil {
+		return nil, err
 	}
 	podMetrics, err := c.Metrics.PodMetricses(namespace).List(ctx, metav1.ListOptions{})
 	if err != nil {
-		return nil, err
+		return nil, c.metricsError(err)
 	}
 	res := make(map[string][]int64)
 	for _, pm := range podMetrics.Items {
@@ -507,13 +606,15 @@ func (c *Client) GetPodMetrics(ctx context.Context, namespace string) (map[strin
 	return res, nil
 }
 
+// GetNodeMetrics returns the CPU (millicores) and memory (MB) usage of each
+// node. It fails with ErrMetricsUnavailable without metrics-server.
 func (c *Client) GetNodeMetrics(ctx context.Context) (map[string][]int64, error) {
-	if c.Metrics == nil {
-		return nil, fmt.Errorf("metrics client not initialized")
+	if err := c.checkMetrics(); err != nil {
+		return nil, err
 	}
 	nodeMetrics, err := c.Metrics.NodeMetricses().List(ctx, metav1.ListOptions{})
 	if err != nil {
-		return nil, err
+		return nil, c.metricsError(err)
 	}
 	res := make(map[string][]int64)
 	for _, nm := range nodeMetrics.Items {
@@ -573,16 +674,54 @@ func (c *Client) GetResourceContext(ctx context.Context, ns, name, resource stri
 	return contextBuilder.String(), nil
 }
 
+// dynamicClient returns the dynamic client, or ErrDynamicUnavailable
+func (c *Client) dynamicClient() (dynamic.Interface, error) {
+	if c.Dynamic == nil {
+		return nil, ErrDynamicUnavailable
+	}
+	return c.Dynamic, nil
+}
+
+// GetResource fetches a single object without its managed fields.
+// namespace is ignored for cluster-scoped resources.
+func (c *Client) GetResource(ctx context.Context, namespace, name string, gvr schema.GroupVersionResource) (*unstructured.Unstructured, error) {
+	dyn, err := c.dynamicClient()
+	if err != nil {
+		return nil, err
+	}
+	obj, err := dyn.Resource(gvr).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	obj.SetManagedFields(nil)
+	return obj, nil
+}
+
 func (c *Client) GetResourceYAML(ctx context.Context, namespace, name string, gvr schema.GroupVersionResource) (string, error) {
-	obj, err := c.Dynamic.Resource(gvr).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	obj, err := c.GetResource(ctx, namespace, name, gvr)
 	if err != nil {
 		return "", err
 	}
 
-	obj.SetManagedFields(nil)
 	obj.SetResourceVersion("")
 
-	data, err := yaml.Marshal(obj)
+	data, err := yaml.Marshal(obj.Object)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// GetResourceYAMLForEdit is GetResourceYAML keeping the resourceVersion, so
+// applying the edited manifest with ApplyYAML fails with a conflict if the
+// object changed in the meantime
+func (c *Client) GetResourceYAMLForEdit(ctx context.Context, namespace, name string, gvr schema.GroupVersionResource) (string, error) {
+	obj, err := c.GetResource(ctx, namespace, name, gvr)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := yaml.Marshal(obj.Object)
 	if err != nil {
 		return "", err
 	}
@@ -633,6 +772,38 @@ func (c *Client) GetGVR(resource string) (schema.GroupVersionResource, bool) {
 		"hpa":                    {Group: "autoscaling", Version: "v2", Resource: "horizontalpodautoscalers"},
 		"networkpolicies":        {Group: "networking.k8s.io", Version: "v1", Resource: "networkpolicies"},
 		"netpol":                 {Group: "networking.k8s.io", Version: "v1", Resource: "networkpolicies"},
+		"events":                 {Group: "", Version: "v1", Resource: "events"},
+		"ev":                     {Group: "", Version: "v1", Resource: "events"},
+		"endpoints":              {Group: "", Version: "v1", Resource: "endpoints"},
+		"ep":                     {Group: "", Version: "v1", Resource: "endpoints"},
+		"replicationcontrollers": {Group: "", Version: "v1", Resource: "replicationcontrollers"},
+		"rc":                     {Group: "", Version: "v1", Resource: "replicationcontrollers"},
+		"limitranges":            {Group: "", Version: "v1", Resource: "limitranges"},
+		"limits":                 {Group: "", Version: "v1", Resource: "limitranges"},
+		"resourcequotas":         {Group: "", Version: "v1", Resource: "resourcequotas"},
+		"quota":                  {Group: "", Version: "v1", Resource: "resourcequotas"},
+		"sec":                    {Group: "", Version: "v1", Resource: "secrets"},
+		"job":                    {Group: "batch", Version: "v1", Resource: "jobs"},
+		"ingressclasses":         {Group: "networking.k8s.io", Version: "v1", Resource: "ingressclasses"},
+		"ic":                     {Group: "networking.k8s.io", Version: "v1", Resource: "ingressclasses"},
+		"role":                   {Group: "rbac.authorization.k8s.io", Version: "v1", Resource: "roles"},
+		"cr":                     {Group: "rbac.authorization.k8s.io", Version: "v1", Resource: "clusterroles"},
+		"poddisruptionbudgets":   {Group: "policy", Version: "v1", Resource: "poddisruptionbudgets"},
+		"pdb":                    {Group: "policy", Version: "v1", Resource: "poddisruptionbudgets"},
+		"customresourcedefinitions": {Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"},
+		"crd":                    {Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"},
+		"priorityclasses":        {Group: "scheduling.k8s.io", Version: "v1", Resource: "priorityclasses"},
+		"pc":                     {Group: "scheduling.k8s.io", Version: "v1", Resource: "priorityclasses"},
+		"runtimeclasses":         {Group: "node.k8s.io", Version: "v1", Resource: "runtimeclasses"},
+		"rtc":                    {Group: "node.k8s.io", Version: "v1", Resource: "runtimeclasses"},
+		"leases":                 {Group: "coordination.k8s.io", Version: "v1", Resource: "leases"},
+		"lease":                  {Group: "coordination.k8s.io", Version: "v1", Resource: "leases"},
+		"volumeattachments":      {Group: "storage.k8s.io", Version: "v1", Resource: "volumeattachments"},
+		"va":                     {Group: "storage.k8s.io", Version: "v1", Resource: "volumeattachments"},
+		"csidrivers":             {Group: "storage.k8s.io", Version: "v1", Resource: "csidrivers"},
+		"csidriver":              {Group: "storage.k8s.io", Version: "v1", Resource: "csidrivers"},
+		"csinodes":               {Group: "storage.k8s.io", Version: "v1", Resource: "csinodes"},
+		"csinode":                {Group: "storage.k8s.io", Version: "v1", Resource: "csinodes"},
 	}
 	gvr, ok := m[strings.ToLower(resource)]
 	return gvr, ok
@@ -773,7 +944,8 @@ func (c *Client) TriggerCronJob(ctx context.Context, namespace, name string) (*b
 		return nil, fmt.Errorf("failed to get cronjob: %w", err)
 	}
 
-	// Create a Job from the CronJob spec
+	// Create a Job from the CronJob spec, owned by the CronJob like kubectl create job --from
+	controller := true
 	job := &batchv1.Job{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      fmt.Sprintf("%s-manual-%d", name, time.Now().Unix()),
@@ -782,6 +954,13 @@ func (c *Client) TriggerCronJob(ctx context.Context, namespace, name string) (*b
 			Annotations: map[string]string{
 				"cronjob.kubernetes.io/instantiate": "manual",
 			},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "batch/v1",
+				Kind:       "CronJob",
+				Name:       cronJob.Name,
+				UID:        cronJob.UID,
+				Controller: &controller,
+			}},
 		},
 		Spec: cronJob.Spec.JobTemplate.Spec,
 	}
@@ -789,56 +968,95 @@ func (c *Client) TriggerCronJob(ctx context.Context, namespace, name string) (*b
 	return c.Clientset.BatchV1().Jobs(namespace).Create(ctx, job, metav1.CreateOptions{})
 }
 
-// DrainNode cordons and drains a node
-func (c *Client) DrainNode(ctx context.Context, nodeName string, gracePeriod int64) error {
+// SetCronJobSuspended suspends a CronJob, so it schedules no new jobs, or
+// resumes it
+func (c *Client) SetCronJobSuspended(ctx context.Context, namespace, name string, suspend bool) error {
+	payload := []byte(fmt.Sprintf(`{"spec":{"suspend":%t}}`, suspend))
+	_, err := c.Clientset.BatchV1().CronJobs(namespace).Patch(ctx, name, types.MergePatchType, payload, metav1.PatchOptions{})
+	return err
+}
+
+// GetReplicas returns the desired replicas of a scalable workload
+func (c *Client) GetReplicas(ctx context.Context, gvr schema.GroupVersionResource, namespace, name string) (int32, error) {
+	obj, err := c.GetResource(ctx, namespace, name, gvr)
+	if err != nil {
+		return 0, err
+	}
+	replicas, found, err := unstructured.NestedInt64(obj.Object, "spec", "replicas")
+	if err != nil {
+		return 0, err
+	}
+	if !found {
+		return 1, nil // the API default
+	}
+	return int32(replicas), nil
+}
+
+// DrainNode cordons a node and evicts its pods through the Eviction API, so
+// PodDisruptionBudgets are respected. With force, pods whose eviction fails
+// (e.g. a PDB still blocks it after retrying) are deleted instead.
+func (c *Client) DrainNode(ctx context.Context, nodeName string, gracePeriod int64, force bool) error {
+	return c.DrainNodeWithProgress(ctx, nodeName, gracePeriod, force, nil)
+}
+
+// DrainNodeWithProgress is DrainNode reporting progress: after each pod it
+// calls progress, if not nil, with the pods evicted so far and the number
+// of pods to evict
+func (c *Client) DrainNodeWithProgress(ctx context.Context, nodeName string, gracePeriod int64, force bool, progress func(evicted, total int)) error {
 	// First, cordon the node
 	if err := c.CordonNode(ctx, nodeName); err != nil {
 		return fmt.Errorf("failed to cordon node: %w", err)
 	}
 
-	// Get all pods on the node
-	pods, err := c.Clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{
-		FieldSelector: fmt.Sprintf("spec.nodeName=%s", nodeName),
-	})
+	// Get the pods to evict (skips DaemonSet pods and mirror pods)
+	pods, err := c.DrainablePodsOnNode(ctx, nodeName)
 	if err != nil {
-		return fmt.Errorf("failed to list pods on node: %w", err)
+		return err
 	}
 
-	// Evict each pod (skip DaemonSet pods and mirror pods)
-	for _, pod := range pods.Items {
-		// Skip DaemonSet pods
-		if pod.OwnerReferences != nil {
-			isDaemonSet := false
-			for _, ref := range pod.OwnerReferences {
-				if ref.Kind == "DaemonSet" {
-					isDaemonSet = true
-					break
-				}
+	var errs []error
+	evicted := 0
+	if progress != nil {
+		progress(0, len(pods))
+	}
+	for _, pod := range pods {
+		err := c.EvictPod(ctx, pod.Namespace, pod.Name, gracePeriod)
+		if err != nil && force {
+			log.Infof("Eviction of %s/%s failed, deleting: %v", pod.Namespace, pod.Name, err)
+			deleteOptions := metav1.DeleteOptions{}
+			if gracePeriod > 0 {
+				deleteOptions.GracePeriodSeconds = &gracePeriod
 			}
-			if isDaemonSet {
-				continue
+			err = c.Clientset.CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, deleteOptions)
+			if apierrors.IsNotFound(err) {
+				err = nil
 			}
 		}
-
-		// Skip mirror pods
-		if _, ok := pod.Annotations["kubernetes.io/config.mirror"]; ok {
+		if err != nil {
+			log.Errorf("Failed to evict pod %s/%s: %v", pod.Namespace, pod.Name, err)
+			errs = append(errs, fmt.Errorf("%s/%s: %w", pod.Namespace, pod.Name, err))
 			continue
 		}
-
-		// Delete pod with grace period
-		deleteOptions := metav1.DeleteOptions{}
-		if gracePeriod > 0 {
-			deleteOptions.GracePeriodSeconds = &gracePeriod
-		}
-		err := c.Clientset.CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, deleteOptions)
-		if err != nil {
-			log.Errorf("Failed to delete pod %s/%s: %v", pod.Namespace, pod.Name, err)
+		evicted++
+		if progress != nil {
+			progress(evicted, len(pods))
 		}
 	}
-
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to evict %d of %d pod(s): %w", len(errs), len(pods), errors.Join(errs...))
+	}
 	return nil
 }
 
+// IsNodeCordoned reports whether a node is marked unschedulable
+func (c *Client) IsNodeCordoned(ctx context.Context, nodeName string) (bool, error) {
+	node, err := c.Clientset.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		return false, err
+	}
+	return node.Spec.Unschedulable, nil
+}
+
 // CordonNode marks a node as unschedulable
 func (c *Client) CordonNode(ctx context.Context, nodeName string) error {
 	payload := []byte(`{"spec":{"unschedulable":true}}`)
@@ -855,16 +1073,16 @@ func (c *Client) UncordonNode(ctx context.Context, nodeName string) error {
 
 // Extended List functions for additional resources
 
-func (c *Client) ListReplicaSets(ctx context.Context, namespace string) ([]appsv1.ReplicaSet, error) {
-	opts := metav1.ListOptions{}
+func (c *Client) ListReplicaSets(ctx context.Context, namespace string, opts ...ListOption) ([]appsv1.ReplicaSet, error) {
+	listOpts := listOptions(opts)
 	if namespace == "" {
-		list, err := c.Clientset.AppsV1().ReplicaSets("").List(ctx, opts)
+		list, err := c.Clientset.AppsV1().ReplicaSets("").List(ctx, listOpts)
 		if err != nil {
 			return nil, err
 		}
 		return list.Items, nil
 	}
-	list, err := c.Clientset.AppsV1().ReplicaSets(namespace).List(ctx, opts)
+	list, err := c.Clientset.AppsV1().ReplicaSets(namespace).List(ctx, listOpts)
 	if err != nil {
 		return nil, err
 	}
@@ -973,7 +1191,11 @@ func (c *Client) ListCRDs(ctx context.Context) ([]apiextv1.CustomResourceDefinit
 		Version:  "v1",
 		Resource: "customresourcedefinitions",
 	}
-	list, err := c.Dynamic.Resource(gvr).List(ctx, metav1.ListOptions{})
+	dyn, err := c.dynamicClient()
+	if err != nil {
+		return nil, err
+	}
+	list, err := dyn.Resource(gvr).List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return nil, err
 	}
@@ -1017,6 +1239,16 @@ func (c *Client) DescribeResource(ctx context.Context, resource, namespace, name
 				ports = append(ports, fmt.Sprintf("%d/%s", p.ContainerPort, p.Protocol))
 			}
 			result.WriteString(strings.Join(ports, ", ") + "\n")
+			for _, cs := range pod.Status.ContainerStatuses {
+				if cs.Name != c.Name {
+					continue
+				}
+				result.WriteString(fmt.Sprintf("    State:      %s\n", FormatContainerState(cs.State)))
+				if cs.LastTerminationState.Terminated != nil {
+					result.WriteString(fmt.Sprintf("    Last State: %s\n", FormatContainerState(cs.LastTerminationState)))
+				}
+				result.WriteString(fmt.Sprintf("    Restarts:   %d\n", cs.RestartCount))
+			}
 		}
 		result.WriteString("\nConditions:\n")
 		for _, cond := range pod.Status.Conditions {
@@ -1066,6 +1298,48 @@ func (c *Client) DescribeResource(ctx context.Context, resource, namespace, name
 		for _, cond := range dep.Status.Conditions {
 			result.WriteString(fmt.Sprintf("  Type: %s, Status: %s, Reason: %s\n", cond.Type, cond.Status, cond.Reason))
 		}
+		if churn, err := c.podChurn(ctx, namespace, dep.Spec.Selector); err == nil {
+			writePodChurn(&result, churn, time.Now())
+		}
+
+	case "replicasets":
+		rs, err := c.Clientset.AppsV1().ReplicaSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return "", err
+		}
+		replicas := int32(1)
+		if rs.Spec.Replicas != nil {
+			replicas = *rs.Spec.Replicas
+		}
+		result.WriteString(fmt.Sprintf("Name:         %s\n", rs.Name))
+		result.WriteString(fmt.Sprintf("Namespace:    %s\n", rs.Namespace))
+		result.WriteString(fmt.Sprintf("Replicas:     %d desired | %d current | %d ready\n",
+			replicas, rs.Status.Replicas, rs.Status.ReadyReplicas))
+		if owner := metav1.GetControllerOf(rs); owner != nil {
+			result.WriteString(fmt.Sprintf("Controlled By: %s/%s\n", owner.Kind, owner.Name))
+		}
+		if rev := rs.Annotations[DeploymentRevisionAnnotation]; rev != "" {
+			result.WriteString(fmt.Sprintf("Revision:     %s\n", rev))
+		}
+		result.WriteString(fmt.Sprintf("Created:      %s\n", rs.CreationTimestamp.Format(time.RFC3339)))
+		result.WriteString("\nPod Template:\n")
+		for _, c := range rs.Spec.Template.Spec.Containers {
+			result.WriteString(fmt.Sprintf("  Container: %s\n", c.Name))
+			result.WriteString(fmt.Sprintf("    Image:   %s\n", c.Image))
+		}
+		// Only this ReplicaSet's pods: the pod-template-hash label tells them
+		// apart from the pods of other revisions
+		selector := rs.Spec.Selector
+		if hash := rs.Labels["pod-template-hash"]; hash != "" && selector != nil {
+			selector = selector.DeepCopy()
+			if selector.MatchLabels == nil {
+				selector.MatchLabels = map[string]string{}
+			}
+			selector.MatchLabels["pod-template-hash"] = hash
+		}
+		if churn, err := c.podChurn(ctx, namespace, selector); err == nil {
+			writePodChurn(&result, churn, time.Now())
+		}
 
 	case "services":
 		svc, err := c.Clientset.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
@@ -1118,6 +1392,19 @@ func (c *Client) DescribeResource(ctx context.Context, resource, namespace, name
 		result.WriteString(fmt.Sprintf("  Kernel Version:       %s\n", node.Status.NodeInfo.KernelVersion))
 		result.WriteString(fmt.Sprintf("  Container Runtime:    %s\n", node.Status.NodeInfo.ContainerRuntimeVersion))
 		result.WriteString(fmt.Sprintf("  Kubelet Version:      %s\n", node.Status.NodeInfo.KubeletVersion))
+		if alloc, err := c.nodeAllocation(ctx, node); err == nil {
+			writeNodeAllocation(&result, alloc)
+		}
+
+	case "horizontalpodautoscalers", "hpa":
+		return c.DescribeHPA(ctx, namespace, name)
+
+	case "secrets":
+		secret, err := c.Clientset.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return "", err
+		}
+		return FormatSecretDescription(secret), nil
 
 	default:
 		// Generic describe using dynamic client
@@ -1125,15 +1412,19 @@ func (c *Client) DescribeResource(ctx context.Context, resource, namespace, name
 		if err != nil {
 			return "", err
 		}
+		dyn, err := c.dynamicClient()
+		if err != nil {
+			return "", err
+		}
 		var obj interface{}
 		if namespace != "" {
-			unstructured, err := c.Dynamic.Resource(gvr).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+			unstructured, err := dyn.Resource(gvr).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
 			if err != nil {
 				return "", err
 			}
 			obj = unstructured.Object
 		} else {
-			unstructured, err := c.Dynamic.Resource(gvr).Get(ctx, name, metav1.GetOptions{})
+			unstructured, err := dyn.Resource(gvr).Get(ctx, name, metav1.GetOptions{})
 			if err != nil {
 				return "", err
 			}
@@ -1275,13 +1566,16 @@ func (c *Client) GetCommonResources() []APIResource {
 
 // ListDynamicResource lists resources using the dynamic client for any resource type
 func (c *Client) ListDynamicResource(ctx context.Context, gvr schema.GroupVersionResource, namespace string) ([]map[string]interface{}, error) {
-	var uList *unstructured.UnstructuredList
-	var err error
+	dyn, err := c.dynamicClient()
+	if err != nil {
+		return nil, err
+	}
 
+	var uList *unstructured.UnstructuredList
 	if namespace == "" {
-		uList, err = c.Dynamic.Resource(gvr).List(ctx, metav1.ListOptions{})
+		uList, err = dyn.Resource(gvr).List(ctx, metav1.ListOptions{})
 	} else {
-		uList, err = c.Dynamic.Resource(gvr).Namespace(namespace).List(ctx, metav1.ListOptions{})
+		uList, err = dyn.Resource(gvr).Namespace(namespace).List(ctx, metav1.ListOptions{})
 	}
 	if err != nil {
 		return nil, err
@@ -2,6 +2,8 @@ package k8s
 
 import (
 	"context"
+	"errors"
+	"reflect"
 	"testing"
 
 	appsv1 "k8s.io/api/apps/v1"
@@ -11,6 +13,10 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 	storagev1 "k8s.io/api/storage/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
 	"k8s.io/client-go/kubernetes/fake"
 )
 
@@ -461,6 +467,30 @@ func TestListNetworkPolicies(t *testing.T) {
 	}
 }
 
+func TestTriggerCronJob(t *testing.T) {
+	ctx := context.Background()
+	clientset := fake.NewSimpleClientset(&batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nightly",
+			Namespace: "default",
+			UID:       "cj-uid",
+		},
+	})
+	client := &Client{Clientset: clientset}
+
+	job, err := client.TriggerCronJob(ctx, "default", "nightly")
+	if err != nil {
+		t.Fatalf("TriggerCronJob failed: %v", err)
+	}
+
+	if len(job.OwnerReferences) != 1 || job.OwnerReferences[0].UID != "cj-uid" {
+		t.Errorf("Expected job to be owned by the cronjob, got %v", job.OwnerReferences)
+	}
+	if job.Annotations["cronjob.kubernetes.io/instantiate"] != "manual" {
+		t.Errorf("Expected manual instantiate annotation, got %v", job.Annotations)
+	}
+}
+
 func TestGetGVR(t *testing.T) {
 	client := &Client{}
 
@@ -563,8 +593,8 @@ func TestClient_GetPodMetrics_NoMetricsClient(t *testing.T) {
 	client := &Client{Metrics: nil}
 
 	_, err := client.GetPodMetrics(ctx, "default")
-	if err == nil {
-		t.Error("expected error when metrics client is nil")
+	if !errors.Is(err, ErrMetricsUnavailable) {
+		t.Errorf("expected ErrMetricsUnavailable when metrics client is nil, got %v", err)
 	}
 }
 
@@ -573,7 +603,185 @@ func TestClient_GetNodeMetrics_NoMetricsClient(t *testing.T) {
 	client := &Client{Metrics: nil}
 
 	_, err := client.GetNodeMetrics(ctx)
-	if err == nil {
-		t.Error("expected error when metrics client is nil")
+	if !errors.Is(err, ErrMetricsUnavailable) {
+		t.Errorf("expected ErrMetricsUnavailable when metrics client is nil, got %v", err)
+	}
+}
+
+func TestClient_NoDynamicClient(t *testing.T) {
+	ctx := context.Background()
+	client := &Client{Dynamic: nil}
+	gvr := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "statefulsets"}
+
+	if _, err := client.GetResourceYAML(ctx, "default", "db", gvr); !errors.Is(err, ErrDynamicUnavailable) {
+		t.Errorf("GetResourceYAML: expected ErrDynamicUnavailable, got %v", err)
+	}
+	if _, err := client.DescribeResource(ctx, "statefulsets", "default", "db"); !errors.Is(err, ErrDynamicUnavailable) {
+		t.Errorf("DescribeResource: expected ErrDynamicUnavailable, got %v", err)
+	}
+	if _, err := client.ListDynamicResource(ctx, gvr, "default"); !errors.Is(err, ErrDynamicUnavailable) {
+		t.Errorf("ListDynamicResource: expected ErrDynamicUnavailable, got %v", err)
+	}
+
+	calls := map[string]func() error{
+		"GetRolloutStatus": func() error { _, err := client.GetRolloutStatus(ctx, gvr, "default", "db"); return err },
+		"GetCRD":           func() error { _, err := client.GetCRD(ctx, "widgets.example.com"); return err },
+		"PendingFinalizers": func() error {
+			_, _, err := client.PendingFinalizers(ctx, gvr, "default", "db")
+			return err
+		},
+		"RemoveFinalizers": func() error { return client.RemoveFinalizers(ctx, gvr, "default", "db") },
+		"ScaleResource":    func() error { return client.ScaleResource(ctx, gvr, "default", "db", 2) },
+		"RolloutRestart":   func() error { return client.RolloutRestart(ctx, gvr, "default", "db") },
+		"DeleteResource":   func() error { return client.DeleteResource(ctx, gvr, "default", "db") },
+		"PatchMetadata": func() error {
+			return client.PatchMetadata(ctx, gvr, "default", "db", MetadataLabels, map[string]string{"tier": "db"}, nil)
+		},
+		"countResource": func() error {
+			return client.countResource(ctx, &InventoryEntry{Version: "v1", Resource: "statefulsets", Group: "apps"}, "default")
+		},
+	}
+	for name, call := range calls {
+		if err := call(); !errors.Is(err, ErrDynamicUnavailable) {
+			t.Errorf("%s: expected ErrDynamicUnavailable, got %v", name, err)
+		}
+	}
+}
+
+func TestPortForwardURL(t *testing.T) {
+	tests := []struct {
+		host string
+		want string
+	}{
+		{"https://tahoe.example", "https://tahoe.example/api/v1/namespaces/default/pods/web/portforward"},
+		{"https://tahoe.example:6443", "https://tahoe.example:6443/api/v1/namespaces/default/pods/web/portforward"},
+		{"http://localhost:8080", "http://localhost:8080/api/v1/namespaces/default/pods/web/portforward"},
+		{"10.0.0.1:6443", "https://10.0.0.1:6443/api/v1/namespaces/default/pods/web/portforward"},
+		{"https://[::1]:6443", "https://[::1]:6443/api/v1/namespaces/default/pods/web/portforward"},
+		{"https://proxy.example/k8s/clusters/c-1/", "https://proxy.example/k8s/clusters/c-1/api/v1/namespaces/default/pods/web/portforward"},
+	}
+	for _, tt := range tests {
+		u, err := portForwardURL(tt.host, "default", "web")
+		if err != nil {
+			t.Errorf("portForwardURL(%q) failed: %v", tt.host, err)
+			continue
+		}
+		if u.String() != tt.want {
+			t.Errorf("portForwardURL(%q) = %s, want %s", tt.host, u, tt.want)
+		}
+	}
+
+	if _, err := portForwardURL("https://", "default", "web"); err == nil {
+		t.Error("expected an error for a host-less URL")
+	}
+}
+
+func TestPodSelector(t *testing.T) {
+	ctx := context.Background()
+	dep := testDeployment("web", 1, map[string]string{"app": "web"})
+	dep.TypeMeta = metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"}
+	dep.Spec.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}
+	svc := &corev1.Service{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": "web", "tier": "frontend"}},
+	}
+	external := &corev1.Service{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+		ObjectMeta: metav1.ObjectMeta{Name: "external", Namespace: "default"},
+	}
+	var objs []runtime.Object
+	for _, o := range []interface{}{dep, svc, external} {
+		u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(o)
+		if err != nil {
+			t.Fatal(err)
+		}
+		objs = append(objs, &unstructured.Unstructured{Object: u})
+	}
+	client := &Client{Dynamic: dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), objs...)}
+
+	if sel, err := client.PodSelector(ctx, "deploy", "default", "web"); err != nil || sel != "app=web" {
+		t.Errorf("deployment selector = %q (%v), want app=web", sel, err)
+	}
+	if sel, err := client.PodSelector(ctx, "services", "default", "web"); err != nil || sel != "app=web,tier=frontend" {
+		t.Errorf("service selector = %q (%v), want app=web,tier=frontend", sel, err)
+	}
+	if _, err := client.PodSelector(ctx, "services", "default", "external"); err == nil {
+		t.Error("expected an error for a service without a selector")
+	}
+}
+
+func TestServiceWorkloads(t *testing.T) {
+	ctx := context.Background()
+	db := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"},
+		Spec: appsv1.StatefulSetSpec{Template: corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "web", "tier": "frontend"}},
+		}},
+	}
+	client := &Client{Clientset: fake.NewSimpleClientset(
+		testDeployment("web", 0, map[string]string{"app": "web", "tier": "frontend", "track": "stable"}),
+		testDeployment("api", 1, map[string]string{"app": "api"}),
+		testDeployment("canary", 1, map[string]string{"app": "web", "tier": "frontend"}),
+		db,
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+			Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": "web", "tier": "frontend"}},
+		},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "external", Namespace: "default"}},
+	)}
+
+	refs, err := client.ServiceWorkloads(ctx, "default", "web")
+	if err != nil {
+		t.Fatalf("ServiceWorkloads failed: %v", err)
+	}
+	want := []WorkloadRef{{"deployments", "canary"}, {"deployments", "web"}, {"statefulsets", "db"}}
+	if !reflect.DeepEqual(refs, want) {
+		t.Errorf("ServiceWorkloads = %v, want %v", refs, want)
+	}
+	if _, err := client.ServiceWorkloads(ctx, "default", "external"); err == nil {
+		t.Error("expected an error for a service without a selector")
+	}
+}
+
+func TestReversibleActionState(t *testing.T) {
+	ctx := context.Background()
+	suspended := true
+	client := &Client{Clientset: fake.NewSimpleClientset(
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}},
+		&batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "default"}, Spec: batchv1.CronJobSpec{Suspend: &suspended}},
+	)}
+
+	if cordoned, err := client.IsNodeCordoned(ctx, "node-1"); err != nil || cordoned {
+		t.Errorf("IsNodeCordoned = %v (%v), want false", cordoned, err)
+	}
+	if err := client.CordonNode(ctx, "node-1"); err != nil {
+		t.Fatalf("CordonNode failed: %v", err)
+	}
+	if cordoned, err := client.IsNodeCordoned(ctx, "node-1"); err != nil || !cordoned {
+		t.Errorf("IsNodeCordoned = %v (%v), want true", cordoned, err)
+	}
+
+	if err := client.SetCronJobSuspended(ctx, "default", "backup", false); err != nil {
+		t.Fatalf("SetCronJobSuspended failed: %v", err)
+	}
+	cj, _ := client.Clientset.BatchV1().CronJobs("default").Get(ctx, "backup", metav1.GetOptions{})
+	if cj.Spec.Suspend == nil || *cj.Spec.Suspend {
+		t.Errorf("expected the cronjob to be resumed, got suspend=%v", cj.Spec.Suspend)
+	}
+
+	replicas := int32(3)
+	dep, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&appsv1.Deployment{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.Dynamic = dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), &unstructured.Unstructured{Object: dep})
+	gvr, _ := client.GetGVR("deployments")
+	if got, err := client.GetReplicas(ctx, gvr, "default", "web"); err != nil || got != 3 {
+		t.Errorf("GetReplicas = %d (%v), want 3", got, err)
 	}
 }
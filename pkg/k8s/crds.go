@@ -0,0 +1,62 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var crdGVR = schema.GroupVersionResource{Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"}
+
+// GetCRD returns a CustomResourceDefinition by name, e.g.
+// "certificates.cert-manager.io"
+func (c *Client) GetCRD(ctx context.Context, name string) (*apiextv1.CustomResourceDefinition, error) {
+	dyn, err := c.dynamicClient()
+	if err != nil {
+		return nil, err
+	}
+	obj, err := dyn.Resource(crdGVR).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	var crd apiextv1.CustomResourceDefinition
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &crd); err != nil {
+		return nil, fmt.Errorf("failed to decode CRD %s: %w", name, err)
+	}
+	return &crd, nil
+}
+
+// CRDAPIResource returns the API resource of a CRD's instances. Of several
+// served versions the storage version is preferred, as every object can be
+// read in it.
+func CRDAPIResource(crd *apiextv1.CustomResourceDefinition) (APIResource, error) {
+	version := ""
+	for _, v := range crd.Spec.Versions {
+		if !v.Served {
+			continue
+		}
+		if v.Storage {
+			version = v.Name
+			break
+		}
+		if version == "" {
+			version = v.Name
+		}
+	}
+	if version == "" {
+		return APIResource{}, fmt.Errorf("CRD %s serves no version", crd.Name)
+	}
+
+	return APIResource{
+		Name:       crd.Spec.Names.Plural,
+		ShortNames: crd.Spec.Names.ShortNames,
+		Kind:       crd.Spec.Names.Kind,
+		Group:      crd.Spec.Group,
+		Version:    version,
+		Namespaced: crd.Spec.Scope == apiextv1.NamespaceScoped,
+	}, nil
+}
@@ -0,0 +1,68 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func testCRD() *apiextv1.CustomResourceDefinition {
+	return &apiextv1.CustomResourceDefinition{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apiextensions.k8s.io/v1", Kind: "CustomResourceDefinition"},
+		ObjectMeta: metav1.ObjectMeta{Name: "widgets.example.com"},
+		Spec: apiextv1.CustomResourceDefinitionSpec{
+			Group: "example.com",
+			Names: apiextv1.CustomResourceDefinitionNames{Plural: "widgets", Kind: "Widget", ShortNames: []string{"wd"}},
+			Scope: apiextv1.NamespaceScoped,
+			Versions: []apiextv1.CustomResourceDefinitionVersion{
+				{Name: "v1alpha1", Served: false},
+				{Name: "v1beta1", Served: true},
+				{Name: "v1", Served: true, Storage: true},
+			},
+		},
+	}
+}
+
+func TestCRDAPIResource(t *testing.T) {
+	crd := testCRD()
+	res, err := CRDAPIResource(crd)
+	if err != nil {
+		t.Fatalf("CRDAPIResource failed: %v", err)
+	}
+	if res.Name != "widgets" || res.Group != "example.com" || res.Version != "v1" || !res.Namespaced || res.Kind != "Widget" {
+		t.Errorf("unexpected resource %+v", res)
+	}
+
+	// Without a served storage version the first served one is used
+	crd.Spec.Versions[2].Served = false
+	crd.Spec.Scope = apiextv1.ClusterScoped
+	if res, _ := CRDAPIResource(crd); res.Version != "v1beta1" || res.Namespaced {
+		t.Errorf("expected cluster-scoped v1beta1, got %+v", res)
+	}
+
+	crd.Spec.Versions[1].Served = false
+	if _, err := CRDAPIResource(crd); err == nil {
+		t.Error("expected an error for a CRD without served versions")
+	}
+}
+
+func TestGetCRD(t *testing.T) {
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(testCRD())
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &Client{Dynamic: dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), &unstructured.Unstructured{Object: obj})}
+
+	crd, err := client.GetCRD(context.Background(), "widgets.example.com")
+	if err != nil {
+		t.Fatalf("GetCRD failed: %v", err)
+	}
+	if crd.Spec.Names.Plural != "widgets" || len(crd.Spec.Versions) != 3 {
+		t.Errorf("CRD not decoded: %+v", crd.Spec)
+	}
+}
@@ -0,0 +1,93 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+)
+
+// DefaultDebugImage is the image of debug containers unless another is given
+const DefaultDebugImage = "busybox:latest"
+
+// AddEphemeralContainer adds an interactive debug container running image
+// to a pod, like kubectl debug -it, and returns its name. It shares the
+// process namespace of the pod's first container, so tools in the debug
+// image can inspect distroless containers that have no shell.
+func (c *Client) AddEphemeralContainer(ctx context.Context, namespace, pod, image string) (string, error) {
+	p, err := c.Clientset.CoreV1().Pods(namespace).Get(ctx, pod, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	if len(p.Spec.Containers) == 0 {
+		return "", fmt.Errorf("pod %s has no containers", pod)
+	}
+	if image == "" {
+		image = DefaultDebugImage
+	}
+
+	name := "debugger-" + utilrand.String(5)
+	container := corev1.EphemeralContainer{
+		EphemeralContainerCommon: corev1.EphemeralContainerCommon{
+			Name:                     name,
+			Image:                    image,
+			ImagePullPolicy:          corev1.PullIfNotPresent,
+			Stdin:                    true,
+			TTY:                      true,
+			TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+		},
+		TargetContainerName: p.Spec.Containers[0].Name,
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"ephemeralContainers": []corev1.EphemeralContainer{container},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	_, err = c.Clientset.CoreV1().Pods(namespace).Patch(ctx, pod, types.StrategicMergePatchType, patch, metav1.PatchOptions{}, "ephemeralcontainers")
+	if err != nil {
+		return "", fmt.Errorf("failed to add debug container: %w", err)
+	}
+	return name, nil
+}
+
+// WaitForEphemeralContainer waits until an ephemeral container is running.
+// It fails if the container terminates first or ctx is done.
+func (c *Client) WaitForEphemeralContainer(ctx context.Context, namespace, pod, container string) error {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		p, err := c.Clientset.CoreV1().Pods(namespace).Get(ctx, pod, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		for _, cs := range p.Status.EphemeralContainerStatuses {
+			if cs.Name != container {
+				continue
+			}
+			switch {
+			case cs.State.Running != nil:
+				return nil
+			case cs.State.Terminated != nil:
+				return fmt.Errorf("debug container %s exited: %s", container, cs.State.Terminated.Reason)
+			case cs.State.Waiting != nil && (cs.State.Waiting.Reason == "ErrImagePull" || cs.State.Waiting.Reason == "ImagePullBackOff"):
+				return fmt.Errorf("debug container %s cannot pull its image: %s", container, cs.State.Waiting.Reason)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("debug container %s did not start: %w", container, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package k8s
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestAddEphemeralContainer(t *testing.T) {
+	ctx := context.Background()
+	client := &Client{Clientset: fake.NewSimpleClientset(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default"},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "distroless"}}},
+	})}
+
+	name, err := client.AddEphemeralContainer(ctx, "default", "web-1", "")
+	if err != nil {
+		t.Fatalf("AddEphemeralContainer failed: %v", err)
+	}
+	if !strings.HasPrefix(name, "debugger-") {
+		t.Errorf("unexpected container name %q", name)
+	}
+
+	pod, _ := client.Clientset.CoreV1().Pods("default").Get(ctx, "web-1", metav1.GetOptions{})
+	if len(pod.Spec.EphemeralContainers) != 1 {
+		t.Fatalf("expected one ephemeral container, got %v", pod.Spec.EphemeralContainers)
+	}
+	ec := pod.Spec.EphemeralContainers[0]
+	if ec.Name != name || ec.Image != DefaultDebugImage || ec.TargetContainerName != "app" || !ec.Stdin || !ec.TTY {
+		t.Errorf("unexpected ephemeral container %+v", ec)
+	}
+
+	if _, err := client.AddEphemeralContainer(ctx, "default", "gone", "busybox"); err == nil {
+		t.Error("expected an error for a missing pod")
+	}
+}
@@ -0,0 +1,148 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Eviction retry policy: the API server answers 429 TooManyRequests while a
+// PodDisruptionBudget does not allow the disruption yet
+var (
+	evictionRetries    = 5
+	evictionBackoff    = time.Second
+	evictionMaxBackoff = 16 * time.Second
+)
+
+// DrainPreflight checks what draining a node would do without changing
+// anything. It reports PDBs the drain would violate and pods without a
+// controller, which are not recreated once evicted.
+func (c *Client) DrainPreflight(ctx context.Context, nodeName string) ([]Finding, error) {
+	pods, err := c.Clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{
+		FieldSelector: fmt.Sprintf("spec.nodeName=%s", nodeName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods on node: %w", err)
+	}
+	pdbs, err := c.ListPodDisruptionBudgets(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pod disruption budgets: %w", err)
+	}
+	return CheckDrainSafety(DrainablePods(pods.Items), pdbs), nil
+}
+
+// EvictPod evicts a pod through the policy/v1 Eviction subresource, which
+// respects PodDisruptionBudgets. While the eviction is refused with 429 it is
+// retried with exponential backoff. A pod that is already gone counts as
+// evicted.
+func (c *Client) EvictPod(ctx context.Context, namespace, name string, gracePeriod int64) error {
+	eviction := &policyv1.Eviction{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+	if gracePeriod > 0 {
+		eviction.DeleteOptions = &metav1.DeleteOptions{GracePeriodSeconds: &gracePeriod}
+	}
+
+	bf := backoff.NewExponentialBackOff()
+	bf.InitialInterval = evictionBackoff
+	bf.MaxInterval = evictionMaxBackoff
+	bf.MaxElapsedTime = 0 // bounded by evictionRetries
+
+	var lastErr error
+	err := backoff.Retry(func() error {
+		err := c.Clientset.CoreV1().Pods(namespace).EvictV1(ctx, eviction)
+		switch {
+		case err == nil, apierrors.IsNotFound(err):
+			return nil
+		case !apierrors.IsTooManyRequests(err):
+			return backoff.Permanent(err)
+		}
+		lastErr = err
+		return err
+	}, backoff.WithContext(backoff.WithMaxRetries(bf, uint64(evictionRetries)), ctx))
+	if err != nil && ctx.Err() != nil && lastErr != nil {
+		return fmt.Errorf("%w (last error: %v)", ctx.Err(), lastErr)
+	}
+	return err
+}
+
+// DrainablePodsOnNode lists the pods a drain of nodeName would evict
+func (c *Client) DrainablePodsOnNode(ctx context.Context, nodeName string) ([]corev1.Pod, error) {
+	pods, err := c.Clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{
+		FieldSelector: fmt.Sprintf("spec.nodeName=%s", nodeName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods on node: %w", err)
+	}
+	return DrainablePods(pods.Items), nil
+}
+
+// DrainablePods returns the pods a drain evicts, skipping DaemonSet-managed
+// and mirror (static) pods
+func DrainablePods(pods []corev1.Pod) []corev1.Pod {
+	var result []corev1.Pod
+	for _, pod := range pods {
+		isDaemonSet := false
+		for _, ref := range pod.OwnerReferences {
+			if ref.Kind == "DaemonSet" {
+				isDaemonSet = true
+				break
+			}
+		}
+		if isDaemonSet {
+			continue
+		}
+		if _, ok := pod.Annotations["kubernetes.io/config.mirror"]; ok {
+			continue
+		}
+		result = append(result, pod)
+	}
+	return result
+}
+
+// CheckDrainSafety evaluates evicting pods against pdbs. A PDB is violated
+// when more of its pods would be evicted than it currently allows. Pods that
+// have already completed are ignored.
+func CheckDrainSafety(pods []corev1.Pod, pdbs []policyv1.PodDisruptionBudget) []Finding {
+	var findings []Finding
+	evicted := make([]int32, len(pdbs))
+
+	for _, pod := range pods {
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		if metav1.GetControllerOf(&pod) == nil {
+			findings = append(findings, Finding{
+				Check:     CheckDrainUnmanagedPod,
+				Kind:      "Pod",
+				Namespace: pod.Namespace,
+				Name:      pod.Name,
+				Message:   "has no controller and will not be rescheduled",
+			})
+		}
+		for i, pdb := range pdbs {
+			if pdbSelects(pdb, pod.Namespace, pod.Labels) {
+				evicted[i]++
+			}
+		}
+	}
+
+	for i, pdb := range pdbs {
+		if evicted[i] > pdb.Status.DisruptionsAllowed {
+			findings = append(findings, Finding{
+				Check:     CheckDrainPDBViolation,
+				Kind:      "PodDisruptionBudget",
+				Namespace: pdb.Namespace,
+				Name:      pdb.Name,
+				Message: fmt.Sprintf("drain evicts %d pod(s) but only %d disruption(s) allowed",
+					evicted[i], pdb.Status.DisruptionsAllowed),
+			})
+		}
+	}
+
+	return findings
+}
@@ -0,0 +1,159 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func nodePod(name string, podLabels map[string]string, ownerKind string) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: podLabels},
+		Spec:       corev1.PodSpec{NodeName: "node-1"},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	if ownerKind != "" {
+		controller := true
+		pod.OwnerReferences = []metav1.OwnerReference{{Kind: ownerKind, Name: "owner", Controller: &controller}}
+	}
+	return pod
+}
+
+func TestDrainablePods(t *testing.T) {
+	mirror := nodePod("static", nil, "")
+	mirror.Annotations = map[string]string{"kubernetes.io/config.mirror": "abc"}
+
+	pods := DrainablePods([]corev1.Pod{
+		*nodePod("web-1", nil, "ReplicaSet"),
+		*nodePod("fluentd", nil, "DaemonSet"),
+		*mirror,
+	})
+	if len(pods) != 1 || pods[0].Name != "web-1" {
+		t.Errorf("expected only web-1 to be drainable, got %v", pods)
+	}
+}
+
+func TestDrainPreflight(t *testing.T) {
+	web := map[string]string{"app": "web"}
+	done := nodePod("job-1", nil, "")
+	done.Status.Phase = corev1.PodSucceeded
+
+	clientset := fake.NewSimpleClientset(
+		nodePod("web-1", web, "ReplicaSet"),
+		nodePod("web-2", web, "ReplicaSet"),
+		nodePod("debug", nil, ""),
+		done,
+		testPDB("web-pdb", web, 1),
+	)
+	client := &Client{Clientset: clientset}
+
+	findings, err := client.DrainPreflight(context.Background(), "node-1")
+	if err != nil {
+		t.Fatalf("DrainPreflight failed: %v", err)
+	}
+	if len(findings) != 2 {
+		t.Fatalf("expected 2 findings, got %+v", findings)
+	}
+	if findings[0].Check != CheckDrainUnmanagedPod || findings[0].Name != "debug" {
+		t.Errorf("expected unmanaged pod finding for debug, got %+v", findings[0])
+	}
+	if findings[1].Check != CheckDrainPDBViolation || findings[1].Name != "web-pdb" {
+		t.Errorf("expected PDB violation for web-pdb, got %+v", findings[1])
+	}
+}
+
+// evictionClient fakes the Eviction API: pods named in blocked are refused
+// with 429 as if a PodDisruptionBudget prevented the disruption
+func evictionClient(t *testing.T, blocked map[string]bool, objects ...runtime.Object) (*Client, *[]string, *[]string) {
+	t.Helper()
+	oldBackoff := evictionBackoff
+	evictionBackoff = time.Millisecond
+	t.Cleanup(func() { evictionBackoff = oldBackoff })
+
+	clientset := fake.NewSimpleClientset(objects...)
+	var evicted, deleted []string
+	clientset.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "eviction" {
+			return false, nil, nil
+		}
+		name := action.(k8stesting.CreateAction).GetObject().(metav1.Object).GetName()
+		if blocked[name] {
+			return true, nil, apierrors.NewTooManyRequests("disruption budget exceeded", 0)
+		}
+		evicted = append(evicted, name)
+		return true, nil, nil
+	})
+	clientset.PrependReactor("delete", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		deleted = append(deleted, action.(k8stesting.DeleteAction).GetName())
+		return true, nil, nil
+	})
+	return &Client{Clientset: clientset}, &evicted, &deleted
+}
+
+func TestDrainNodeEvicts(t *testing.T) {
+	client, evicted, deleted := evictionClient(t, map[string]bool{"web-2": true},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}},
+		nodePod("web-1", nil, "ReplicaSet"),
+		nodePod("web-2", nil, "ReplicaSet"),
+		nodePod("fluentd", nil, "DaemonSet"),
+	)
+
+	err := client.DrainNode(context.Background(), "node-1", 0, false)
+	if err == nil {
+		t.Fatal("expected an error for the pod a PDB keeps blocking")
+	}
+	if len(*evicted) != 1 || (*evicted)[0] != "web-1" {
+		t.Errorf("expected only web-1 evicted, got %v", *evicted)
+	}
+	if len(*deleted) != 0 {
+		t.Errorf("pods deleted without force: %v", *deleted)
+	}
+
+	node, _ := client.Clientset.CoreV1().Nodes().Get(context.Background(), "node-1", metav1.GetOptions{})
+	if !node.Spec.Unschedulable {
+		t.Error("expected the node to be cordoned")
+	}
+}
+
+func TestDrainNodeForce(t *testing.T) {
+	client, _, deleted := evictionClient(t, map[string]bool{"web-2": true},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}},
+		nodePod("web-1", nil, "ReplicaSet"),
+		nodePod("web-2", nil, "ReplicaSet"),
+	)
+
+	if err := client.DrainNode(context.Background(), "node-1", 0, true); err != nil {
+		t.Fatalf("forced drain failed: %v", err)
+	}
+	if len(*deleted) != 1 || (*deleted)[0] != "web-2" {
+		t.Errorf("expected only the blocked pod deleted, got %v", *deleted)
+	}
+}
+
+func TestDrainNodeWithProgress(t *testing.T) {
+	client, _, _ := evictionClient(t, nil,
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}},
+		nodePod("web-1", nil, "ReplicaSet"),
+		nodePod("web-2", nil, "ReplicaSet"),
+	)
+
+	var reported []string
+	err := client.DrainNodeWithProgress(context.Background(), "node-1", 0, false, func(evicted, total int) {
+		reported = append(reported, fmt.Sprintf("%d/%d", evicted, total))
+	})
+	if err != nil {
+		t.Fatalf("DrainNodeWithProgress failed: %v", err)
+	}
+	if strings.Join(reported, " ") != "0/2 1/2 2/2" {
+		t.Errorf("unexpected progress %v", reported)
+	}
+}
@@ -0,0 +1,112 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"syscall"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// ErrorKind is a coarse category for failures returned by the API server
+type ErrorKind string
+
+const (
+	ErrorKindForbidden    ErrorKind = "Forbidden"
+	ErrorKindUnauthorized ErrorKind = "Unauthorized"
+	ErrorKindNotFound     ErrorKind = "NotFound"
+	ErrorKindTimeout      ErrorKind = "Timeout"
+	ErrorKindUnreachable  ErrorKind = "Unreachable"
+	ErrorKindUnknown      ErrorKind = "Unknown"
+)
+
+// ClassifiedError wraps a raw client-go error with a user-facing message and
+// a suggested fix.
+type ClassifiedError struct {
+	Kind       ErrorKind
+	Message    string
+	Suggestion string
+	Err        error
+}
+
+func (e *ClassifiedError) Error() string {
+	if e.Suggestion == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("%s (%s)", e.Message, e.Suggestion)
+}
+
+func (e *ClassifiedError) Unwrap() error {
+	return e.Err
+}
+
+// ClassifyError turns an error from listing or getting a resource into a
+// ClassifiedError. resource and namespace describe what was being accessed;
+// an empty namespace means all namespaces or a cluster-scoped resource.
+func ClassifyError(err error, resource, namespace string) *ClassifiedError {
+	if err == nil {
+		return nil
+	}
+
+	var classified *ClassifiedError
+	if errors.As(err, &classified) {
+		return classified
+	}
+
+	scope := "cluster-wide"
+	if namespace != "" {
+		scope = fmt.Sprintf("in namespace %s", namespace)
+	}
+
+	switch {
+	case apierrors.IsForbidden(err):
+		return &ClassifiedError{
+			Kind:       ErrorKindForbidden,
+			Message:    fmt.Sprintf("You lack permission to list %s %s", resource, scope),
+			Suggestion: "ask a cluster admin for RBAC access or switch to a namespace you can read",
+			Err:        err,
+		}
+	case apierrors.IsUnauthorized(err):
+		return &ClassifiedError{
+			Kind:       ErrorKindUnauthorized,
+			Message:    "Token expired or credentials rejected",
+			Suggestion: "re-login to the cluster and refresh your kubeconfig",
+			Err:        err,
+		}
+	case apierrors.IsNotFound(err):
+		return &ClassifiedError{
+			Kind:       ErrorKindNotFound,
+			Message:    fmt.Sprintf("%s not found %s", resource, scope),
+			Suggestion: "the resource type may not be installed or the object was deleted",
+			Err:        err,
+		}
+	case apierrors.IsTimeout(err), apierrors.IsServerTimeout(err), errors.Is(err, context.DeadlineExceeded), isNetTimeout(err):
+		return &ClassifiedError{
+			Kind:       ErrorKindTimeout,
+			Message:    "Request to the API server timed out",
+			Suggestion: "the cluster may be overloaded or the network slow; try again",
+			Err:        err,
+		}
+	case errors.Is(err, syscall.ECONNREFUSED), strings.Contains(err.Error(), "connection refused"), strings.Contains(err.Error(), "no such host"):
+		return &ClassifiedError{
+			Kind:       ErrorKindUnreachable,
+			Message:    "Cannot reach the API server",
+			Suggestion: "check that the cluster is running and your kubeconfig context is correct",
+			Err:        err,
+		}
+	}
+
+	return &ClassifiedError{
+		Kind:    ErrorKindUnknown,
+		Message: err.Error(),
+		Err:     err,
+	}
+}
+
+func isNetTimeout(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
@@ -0,0 +1,50 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestClassifyError(t *testing.T) {
+	gr := schema.GroupResource{Resource: "pods"}
+
+	tests := []struct {
+		name     string
+		err      error
+		expected ErrorKind
+		contains string
+	}{
+		{"forbidden", apierrors.NewForbidden(gr, "", errors.New("denied")), ErrorKindForbidden, "namespace team-a"},
+		{"unauthorized", apierrors.NewUnauthorized("expired"), ErrorKindUnauthorized, "re-login"},
+		{"not found", apierrors.NewNotFound(gr, "web"), ErrorKindNotFound, "pods not found"},
+		{"server timeout", apierrors.NewTimeoutError("slow", 1), ErrorKindTimeout, "timed out"},
+		{"deadline", fmt.Errorf("list: %w", context.DeadlineExceeded), ErrorKindTimeout, "timed out"},
+		{"connection refused", errors.New("dial tcp 127.0.0.1:6443: connect: connection refused"), ErrorKindUnreachable, "Cannot reach"},
+		{"unknown", errors.New("boom"), ErrorKindUnknown, "boom"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ce := ClassifyError(tt.err, "pods", "team-a")
+			if ce.Kind != tt.expected {
+				t.Errorf("ClassifyError kind = %s, expected %s", ce.Kind, tt.expected)
+			}
+			if !strings.Contains(ce.Error(), tt.contains) {
+				t.Errorf("ClassifyError message %q does not contain %q", ce.Error(), tt.contains)
+			}
+			if !errors.Is(ce, tt.err) {
+				t.Error("expected classified error to unwrap to the original error")
+			}
+		})
+	}
+
+	if ClassifyError(nil, "pods", "") != nil {
+		t.Error("expected nil for nil error")
+	}
+}
@@ -0,0 +1,65 @@
+package k8s
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// exitCodeLabels explains the container exit codes worth recognising;
+// codes above 128 are 128 + the number of the signal that killed the process
+var exitCodeLabels = map[int32]string{
+	0:   "completed",
+	1:   "application error",
+	2:   "misuse of shell builtin",
+	126: "command not executable",
+	127: "command not found",
+	128: "invalid exit argument",
+	130: "SIGINT, interrupted",
+	134: "SIGABRT, aborted",
+	137: "SIGKILL, OOM killed or force-stopped",
+	139: "SIGSEGV, segmentation fault",
+	143: "SIGTERM, graceful termination",
+}
+
+// ExitCodeLabel describes a container exit code, e.g. "137 (SIGKILL, OOM
+// killed or force-stopped)"
+func ExitCodeLabel(code int32) string {
+	if label, ok := exitCodeLabels[code]; ok {
+		return fmt.Sprintf("%d (%s)", code, label)
+	}
+	if code > 128 && code < 160 {
+		return fmt.Sprintf("%d (signal %d)", code, code-128)
+	}
+	return fmt.Sprintf("%d", code)
+}
+
+// FormatContainerState describes a container state in one line, e.g.
+// "Terminated: OOMKilled, exit code 137 (SIGKILL, ...) at 2026-01-01T10:00:00Z"
+func FormatContainerState(state corev1.ContainerState) string {
+	switch {
+	case state.Running != nil:
+		return fmt.Sprintf("Running since %s", state.Running.StartedAt.Format(time.RFC3339))
+	case state.Waiting != nil:
+		if state.Waiting.Message != "" {
+			return fmt.Sprintf("Waiting: %s (%s)", state.Waiting.Reason, state.Waiting.Message)
+		}
+		return "Waiting: " + state.Waiting.Reason
+	case state.Terminated != nil:
+		t := state.Terminated
+		reason := t.Reason
+		if reason == "" {
+			reason = "Terminated"
+		}
+		s := fmt.Sprintf("Terminated: %s, exit code %s", reason, ExitCodeLabel(t.ExitCode))
+		if t.Signal != 0 {
+			s += fmt.Sprintf(", signal %d", t.Signal)
+		}
+		if !t.FinishedAt.IsZero() {
+			s += " at " + t.FinishedAt.Format(time.RFC3339)
+		}
+		return s
+	}
+	return "<none>"
+}
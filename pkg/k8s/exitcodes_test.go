@@ -0,0 +1,59 @@
+package k8s
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestExitCodeLabel(t *testing.T) {
+	tests := map[int32]string{
+		0:   "0 (completed)",
+		1:   "1 (application error)",
+		137: "137 (SIGKILL, OOM killed or force-stopped)",
+		143: "143 (SIGTERM, graceful termination)",
+		135: "135 (signal 7)",
+		42:  "42",
+	}
+	for code, want := range tests {
+		if got := ExitCodeLabel(code); got != want {
+			t.Errorf("ExitCodeLabel(%d) = %q, want %q", code, got, want)
+		}
+	}
+}
+
+func TestDescribePodContainerStates(t *testing.T) {
+	finished := metav1.NewTime(time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC))
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "api:1"}}},
+		Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+			Name:         "app",
+			RestartCount: 4,
+			State:        corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+			LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+				Reason: "OOMKilled", ExitCode: 137, FinishedAt: finished,
+			}},
+		}}},
+	}
+	client := &Client{Clientset: fake.NewSimpleClientset(pod)}
+
+	out, err := client.DescribeResource(context.Background(), "pods", "default", "api")
+	if err != nil {
+		t.Fatalf("DescribeResource failed: %v", err)
+	}
+	for _, want := range []string{
+		"State:      Waiting: CrashLoopBackOff",
+		"Last State: Terminated: OOMKilled, exit code 137 (SIGKILL, OOM killed or force-stopped) at 2026-01-01T10:00:00Z",
+		"Restarts:   4",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in describe output:\n%s", want, out)
+		}
+	}
+}
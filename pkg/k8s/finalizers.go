@@ -0,0 +1,61 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// IsTerminating reports whether an object has been marked for deletion but
+// still exists, which almost always means it is waiting on finalizers.
+func IsTerminating(obj metav1.Object) bool {
+	return obj.GetDeletionTimestamp() != nil
+}
+
+// PendingFinalizers returns the finalizers on an object and whether the object
+// is currently terminating (deletionTimestamp set).
+func (c *Client) PendingFinalizers(ctx context.Context, gvr schema.GroupVersionResource, namespace, name string) ([]string, bool, error) {
+	dyn, err := c.dynamicClient()
+	if err != nil {
+		return nil, false, err
+	}
+	obj, err := dyn.Resource(gvr).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, false, err
+	}
+	return obj.GetFinalizers(), IsTerminating(obj), nil
+}
+
+// RemoveFinalizers clears metadata.finalizers so the API server can finish a
+// pending deletion. This skips whatever cleanup the owning controller would
+// have done, so it should only be used on objects that are truly stuck.
+func (c *Client) RemoveFinalizers(ctx context.Context, gvr schema.GroupVersionResource, namespace, name string) error {
+	dyn, err := c.dynamicClient()
+	if err != nil {
+		return err
+	}
+	payload := []byte(`{"metadata":{"finalizers":null}}`)
+	_, err = dyn.Resource(gvr).Namespace(namespace).Patch(ctx, name, types.MergePatchType, payload, metav1.PatchOptions{})
+	return err
+}
+
+// FinalizeNamespace clears spec.finalizers of a namespace stuck in Terminating
+// through the /finalize subresource. Namespaces that are not terminating are
+// rejected so this cannot be used to skip a normal deletion.
+func (c *Client) FinalizeNamespace(ctx context.Context, name string) error {
+	ns, err := c.Clientset.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if ns.Status.Phase != corev1.NamespaceTerminating {
+		return fmt.Errorf("namespace %s is not terminating (phase: %s)", name, ns.Status.Phase)
+	}
+
+	ns.Spec.Finalizers = nil
+	_, err = c.Clientset.CoreV1().Namespaces().Finalize(ctx, ns, metav1.UpdateOptions{})
+	return err
+}
@@ -0,0 +1,83 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newTerminatingConfigMap(name string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("v1")
+	obj.SetKind("ConfigMap")
+	obj.SetNamespace("default")
+	obj.SetName(name)
+	obj.SetFinalizers([]string{"example.com/cleanup"})
+	now := metav1.Now()
+	obj.SetDeletionTimestamp(&now)
+	return obj
+}
+
+func TestIsTerminating(t *testing.T) {
+	obj := &unstructured.Unstructured{}
+	if IsTerminating(obj) {
+		t.Error("expected object without deletionTimestamp to not be terminating")
+	}
+	now := metav1.Now()
+	obj.SetDeletionTimestamp(&now)
+	if !IsTerminating(obj) {
+		t.Error("expected object with deletionTimestamp to be terminating")
+	}
+}
+
+func TestRemoveFinalizers(t *testing.T) {
+	ctx := context.Background()
+	gvr := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{gvr: "ConfigMapList"},
+		newTerminatingConfigMap("stuck"))
+	client := &Client{Dynamic: dyn}
+
+	finalizers, terminating, err := client.PendingFinalizers(ctx, gvr, "default", "stuck")
+	if err != nil {
+		t.Fatalf("PendingFinalizers failed: %v", err)
+	}
+	if !terminating {
+		t.Error("expected object to be terminating")
+	}
+	if len(finalizers) != 1 || finalizers[0] != "example.com/cleanup" {
+		t.Errorf("unexpected finalizers: %v", finalizers)
+	}
+
+	if err := client.RemoveFinalizers(ctx, gvr, "default", "stuck"); err != nil {
+		t.Fatalf("RemoveFinalizers failed: %v", err)
+	}
+
+	finalizers, _, err = client.PendingFinalizers(ctx, gvr, "default", "stuck")
+	if err != nil {
+		t.Fatalf("PendingFinalizers failed: %v", err)
+	}
+	if len(finalizers) != 0 {
+		t.Errorf("expected finalizers to be cleared, got %v", finalizers)
+	}
+}
+
+func TestFinalizeNamespaceRejectsActive(t *testing.T) {
+	clientset := fake.NewSimpleClientset(&corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-a"},
+		Spec:       corev1.NamespaceSpec{Finalizers: []corev1.FinalizerName{corev1.FinalizerKubernetes}},
+		Status:     corev1.NamespaceStatus{Phase: corev1.NamespaceActive},
+	})
+	client := &Client{Clientset: clientset}
+
+	if err := client.FinalizeNamespace(context.Background(), "team-a"); err == nil {
+		t.Error("expected error when finalizing an active namespace")
+	}
+}
@@ -0,0 +1,229 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"sort"
+)
+
+// Finding is a single issue reported by one of the cluster analyses. Findings
+// are shown in the TUI findings view and included in generated reports.
+type Finding struct {
+	Check     string `json:"check"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Message   string `json:"message"`
+
+	// Severity, Category and Remediation are filled in from the check by
+	// ClassifyFindings
+	Severity    string `json:"severity,omitempty"`
+	Category    string `json:"category,omitempty"`
+	Remediation string `json:"remediation,omitempty"`
+}
+
+// Finding severities, most severe first
+const (
+	SeverityCritical = "critical"
+	SeverityHigh     = "high"
+	SeverityMedium   = "medium"
+	SeverityLow      = "low"
+)
+
+// Severities lists the finding severities, most severe first
+var Severities = []string{SeverityCritical, SeverityHigh, SeverityMedium, SeverityLow}
+
+// Finding categories
+const (
+	CategorySecurity    = "security"
+	CategoryReliability = "reliability"
+	CategoryCost        = "cost"
+)
+
+// Finding checks
+const (
+	CheckPDBMissing  = "pdb-missing"
+	CheckPDBBlocking = "pdb-blocking"
+
+	CheckDrainPDBViolation = "drain-pdb-violation"
+	CheckDrainUnmanagedPod = "drain-unmanaged-pod"
+
+	CheckLimitBelowRequest = "limit-below-request"
+	CheckLimitRatioHigh    = "limit-ratio-high"
+	CheckLimitMissing      = "limit-missing"
+
+	CheckUnusedConfigMap = "unused-configmap"
+	CheckUnusedSecret    = "unused-secret"
+	CheckUnusedPVC       = "unused-pvc"
+
+	CheckLBConsolidation = "lb-consolidation"
+
+	CheckMutableImageTag = "mutable-image-tag"
+
+	CheckPrivilegedContainer   = "privileged-container"
+	CheckHostNetwork           = "host-network"
+	CheckRunAsRoot             = "run-as-root"
+	CheckReadinessProbeMissing = "readiness-probe-missing"
+	CheckLivenessProbeMissing  = "liveness-probe-missing"
+
+	CheckRBACWildcard     = "rbac-wildcard"
+	CheckRBACClusterAdmin = "rbac-cluster-admin"
+
+	CheckDefaultServiceAccount = "default-serviceaccount"
+	CheckTokenAutomount        = "token-automount"
+	CheckDefaultSAAutomount    = "default-serviceaccount-automount"
+)
+
+// checkInfo classifies the findings of one check
+type checkInfo struct {
+	severity    string
+	category    string
+	remediation string
+}
+
+var checks = map[string]checkInfo{
+	CheckPDBMissing: {SeverityMedium, CategoryReliability,
+		"Add a PodDisruptionBudget selecting the workload's pods so voluntary disruptions keep some replicas running"},
+	CheckPDBBlocking: {SeverityHigh, CategoryReliability,
+		"Scale up the selected workload or relax minAvailable/maxUnavailable so at least one disruption is allowed"},
+	CheckDrainPDBViolation: {SeverityHigh, CategoryReliability,
+		"Scale up the workload or drain the nodes one at a time"},
+	CheckDrainUnmanagedPod: {SeverityMedium, CategoryReliability,
+		"Run the pod under a controller such as a Deployment so it is recreated elsewhere"},
+	CheckLimitBelowRequest: {SeverityHigh, CategoryReliability,
+		"Raise the limit to at least the request"},
+	CheckLimitRatioHigh: {SeverityMedium, CategoryReliability,
+		"Raise the request towards real usage or lower the limit"},
+	CheckLimitMissing: {SeverityLow, CategoryReliability,
+		"Set a limit so the container cannot starve its neighbours"},
+	CheckUnusedConfigMap: {SeverityLow, CategoryCost,
+		"Delete the ConfigMap if nothing outside the cluster uses it"},
+	CheckUnusedSecret: {SeverityLow, CategorySecurity,
+		"Delete the Secret if nothing outside the cluster uses it; unused credentials widen exposure"},
+	CheckUnusedPVC: {SeverityMedium, CategoryCost,
+		"Delete the claim or back up and release its volume; unused storage is still billed"},
+	CheckLBConsolidation: {SeverityMedium, CategoryCost,
+		"Expose the services through an Ingress or Gateway instead of one LoadBalancer each"},
+	CheckMutableImageTag: {SeverityMedium, CategorySecurity,
+		"Pin the image to a version tag or a digest so every pod runs the image that was tested"},
+	CheckPrivilegedContainer: {SeverityCritical, CategorySecurity,
+		"Remove privileged: true and grant only the capabilities the container needs"},
+	CheckHostNetwork: {SeverityHigh, CategorySecurity,
+		"Remove hostNetwork: true unless the pod must bind node ports directly"},
+	CheckRunAsRoot: {SeverityHigh, CategorySecurity,
+		"Set runAsNonRoot: true and a non-zero runAsUser"},
+	CheckReadinessProbeMissing: {SeverityMedium, CategoryReliability,
+		"Add a readinessProbe so traffic only reaches pods that are ready"},
+	CheckLivenessProbeMissing: {SeverityLow, CategoryReliability,
+		"Add a livenessProbe so hung containers are restarted"},
+	CheckRBACWildcard: {SeverityMedium, CategorySecurity,
+		"List the verbs and resources the role needs instead of *"},
+	CheckRBACClusterAdmin: {SeverityHigh, CategorySecurity,
+		"Bind a role scoped to what the subject needs instead of cluster-admin"},
+	CheckDefaultServiceAccount: {SeverityMedium, CategorySecurity,
+		"Create a dedicated ServiceAccount for the workload and set serviceAccountName"},
+	CheckTokenAutomount: {SeverityLow, CategorySecurity,
+		"Set automountServiceAccountToken: false on the pod template unless it calls the Kubernetes API"},
+	CheckDefaultSAAutomount: {SeverityMedium, CategorySecurity,
+		"Set automountServiceAccountToken: false on the namespace's default ServiceAccount"},
+}
+
+// ClassifyFindings fills in the severity, category and remediation of each
+// finding from its check, keeping values that are already set, and orders
+// the findings by severity. Findings of unknown checks are ranked low.
+func ClassifyFindings(findings []Finding) []Finding {
+	for i := range findings {
+		info, ok := checks[findings[i].Check]
+		if !ok {
+			info = checkInfo{severity: SeverityLow}
+		}
+		if findings[i].Severity == "" {
+			findings[i].Severity = info.severity
+		}
+		if findings[i].Category == "" {
+			findings[i].Category = info.category
+		}
+		if findings[i].Remediation == "" {
+			findings[i].Remediation = info.remediation
+		}
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		return severityRank(findings[i].Severity) < severityRank(findings[j].Severity)
+	})
+	return findings
+}
+
+func severityRank(severity string) int {
+	for i, s := range Severities {
+		if s == severity {
+			return i
+		}
+	}
+	return len(Severities)
+}
+
+// FindingSummary counts findings by severity
+type FindingSummary struct {
+	Total    int `json:"total"`
+	Critical int `json:"critical"`
+	High     int `json:"high"`
+	Medium   int `json:"medium"`
+	Low      int `json:"low"`
+}
+
+// SummarizeFindings counts classified findings by severity
+func SummarizeFindings(findings []Finding) FindingSummary {
+	s := FindingSummary{Total: len(findings)}
+	for _, f := range findings {
+		switch f.Severity {
+		case SeverityCritical:
+			s.Critical++
+		case SeverityHigh:
+			s.High++
+		case SeverityMedium:
+			s.Medium++
+		default:
+			s.Low++
+		}
+	}
+	return s
+}
+
+// AnalyzeFindings runs every cluster analysis for a namespace ("" for all
+// namespaces). An analysis that fails does not stop the others; their
+// findings are returned together with the joined errors, classified and
+// ordered by severity.
+func (c *Client) AnalyzeFindings(ctx context.Context, namespace string) ([]Finding, error) {
+	return c.runAnalyses(ctx, namespace,
+		c.AnalyzeWorkloadHardening,
+		c.AnalyzeRBAC,
+		c.AnalyzeServiceAccounts,
+		c.AnalyzePDBCoverage,
+		c.AnalyzeResourceRatios,
+		c.AnalyzeOrphans,
+	)
+}
+
+// AnalyzeSecurity runs only the security posture checks: pod hardening,
+// probes, RBAC and service account usage
+func (c *Client) AnalyzeSecurity(ctx context.Context, namespace string) ([]Finding, error) {
+	return c.runAnalyses(ctx, namespace,
+		c.AnalyzeWorkloadHardening,
+		c.AnalyzeRBAC,
+		c.AnalyzeServiceAccounts,
+	)
+}
+
+func (c *Client) runAnalyses(ctx context.Context, namespace string, analyses ...func(context.Context, string) ([]Finding, error)) ([]Finding, error) {
+	var findings []Finding
+	var errs []error
+	for _, analyze := range analyses {
+		f, err := analyze(ctx, namespace)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		findings = append(findings, f...)
+	}
+	return ClassifyFindings(findings), errors.Join(errs...)
+}
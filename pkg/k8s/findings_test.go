@@ -0,0 +1,40 @@
+package k8s
+
+import "testing"
+
+func TestClassifyFindings(t *testing.T) {
+	findings := ClassifyFindings([]Finding{
+		{Check: CheckLimitMissing, Name: "a"},
+		{Check: CheckPrivilegedContainer, Name: "b"},
+		{Check: "custom-check", Name: "c"},
+		{Check: CheckPDBBlocking, Name: "d", Severity: SeverityLow},
+		{Check: CheckUnusedPVC, Name: "e"},
+	})
+
+	var order []string
+	for _, f := range findings {
+		order = append(order, f.Name)
+	}
+	if got := order; len(got) != 5 || got[0] != "b" || got[1] != "e" || got[2] != "a" {
+		t.Errorf("expected findings ordered by severity, got %v", got)
+	}
+
+	b := findings[0]
+	if b.Severity != SeverityCritical || b.Category != CategorySecurity || b.Remediation == "" {
+		t.Errorf("privileged container not classified: %+v", b)
+	}
+	for _, f := range findings {
+		if f.Name == "d" && f.Severity != SeverityLow {
+			t.Errorf("preset severity was overwritten: %+v", f)
+		}
+		if f.Name == "c" && f.Severity != SeverityLow {
+			t.Errorf("unknown check should rank low: %+v", f)
+		}
+	}
+
+	sum := SummarizeFindings(findings)
+	want := FindingSummary{Total: 5, Critical: 1, Medium: 1, Low: 3}
+	if sum != want {
+		t.Errorf("SummarizeFindings = %+v, want %+v", sum, want)
+	}
+}
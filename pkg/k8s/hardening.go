@@ -0,0 +1,78 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// AnalyzeWorkloadHardening checks the pod templates of the workloads in a
+// namespace ("" for all namespaces) for security and probe issues.
+func (c *Client) AnalyzeWorkloadHardening(ctx context.Context, namespace string) ([]Finding, error) {
+	deployments, err := c.ListDeployments(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	statefulsets, err := c.ListStatefulSets(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	daemonsets, err := c.ListDaemonSets(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	return CheckWorkloadHardening(deployments, statefulsets, daemonsets), nil
+}
+
+// CheckWorkloadHardening flags privileged containers, host networking,
+// containers running as root and containers without readiness or liveness
+// probes. Init containers only get the security checks since probes do not
+// apply to them.
+func CheckWorkloadHardening(deployments []appsv1.Deployment, statefulsets []appsv1.StatefulSet, daemonsets []appsv1.DaemonSet) []Finding {
+	var findings []Finding
+
+	check := func(kind, namespace, name string, spec corev1.PodSpec) {
+		add := func(check, message string) {
+			findings = append(findings, Finding{Check: check, Kind: kind, Namespace: namespace, Name: name, Message: message})
+		}
+		if spec.HostNetwork {
+			add(CheckHostNetwork, "uses the host network namespace")
+		}
+		podRunsAsRoot := spec.SecurityContext != nil && spec.SecurityContext.RunAsUser != nil && *spec.SecurityContext.RunAsUser == 0
+
+		for _, ctr := range append(append([]corev1.Container{}, spec.InitContainers...), spec.Containers...) {
+			sc := ctr.SecurityContext
+			if sc != nil && sc.Privileged != nil && *sc.Privileged {
+				add(CheckPrivilegedContainer, fmt.Sprintf("container %q runs privileged", ctr.Name))
+			}
+			runsAsRoot := podRunsAsRoot
+			if sc != nil && sc.RunAsUser != nil {
+				runsAsRoot = *sc.RunAsUser == 0
+			}
+			if runsAsRoot {
+				add(CheckRunAsRoot, fmt.Sprintf("container %q runs as UID 0", ctr.Name))
+			}
+		}
+
+		for _, ctr := range spec.Containers {
+			if ctr.ReadinessProbe == nil {
+				add(CheckReadinessProbeMissing, fmt.Sprintf("container %q has no readiness probe", ctr.Name))
+			}
+			if ctr.LivenessProbe == nil {
+				add(CheckLivenessProbeMissing, fmt.Sprintf("container %q has no liveness probe", ctr.Name))
+			}
+		}
+	}
+	for _, d := range deployments {
+		check("Deployment", d.Namespace, d.Name, d.Spec.Template.Spec)
+	}
+	for _, s := range statefulsets {
+		check("StatefulSet", s.Namespace, s.Name, s.Spec.Template.Spec)
+	}
+	for _, ds := range daemonsets {
+		check("DaemonSet", ds.Namespace, ds.Name, ds.Spec.Template.Spec)
+	}
+	return findings
+}
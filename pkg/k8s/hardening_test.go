@@ -0,0 +1,42 @@
+package k8s
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestCheckWorkloadHardening(t *testing.T) {
+	root, privileged := int64(0), true
+	probe := &corev1.Probe{ProbeHandler: corev1.ProbeHandler{TCPSocket: &corev1.TCPSocketAction{}}}
+
+	hardened := testDeployment("hardened", 1, nil)
+	hardened.Spec.Template.Spec.Containers = []corev1.Container{
+		{Name: "app", ReadinessProbe: probe, LivenessProbe: probe},
+	}
+
+	risky := testDeployment("risky", 1, nil)
+	risky.Spec.Template.Spec.HostNetwork = true
+	risky.Spec.Template.Spec.SecurityContext = &corev1.PodSecurityContext{RunAsUser: &root}
+	risky.Spec.Template.Spec.Containers = []corev1.Container{
+		{Name: "app", ReadinessProbe: probe, SecurityContext: &corev1.SecurityContext{Privileged: &privileged}},
+	}
+
+	findings := CheckWorkloadHardening([]appsv1.Deployment{*hardened, *risky}, nil, nil)
+	got := make(map[string]bool)
+	for _, f := range findings {
+		if f.Name != "risky" {
+			t.Errorf("unexpected finding for %s: %+v", f.Name, f)
+		}
+		got[f.Check] = true
+	}
+	for _, check := range []string{CheckHostNetwork, CheckRunAsRoot, CheckPrivilegedContainer, CheckLivenessProbeMissing} {
+		if !got[check] {
+			t.Errorf("expected %s finding, got %+v", check, findings)
+		}
+	}
+	if got[CheckReadinessProbeMissing] {
+		t.Errorf("container with a readiness probe was flagged: %+v", findings)
+	}
+}
@@ -0,0 +1,189 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// hpaEventLimit caps how many of the newest events DescribeHPA shows
+const hpaEventLimit = 10
+
+// DescribeHPA renders a HorizontalPodAutoscaler with per-metric current vs
+// target values and its recent scaling events.
+func (c *Client) DescribeHPA(ctx context.Context, namespace, name string) (string, error) {
+	hpa, err := c.Clientset.AutoscalingV2().HorizontalPodAutoscalers(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	var events []corev1.Event
+	list, eventsErr := c.Clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: fmt.Sprintf("involvedObject.name=%s,involvedObject.kind=HorizontalPodAutoscaler", name),
+	})
+	if eventsErr == nil {
+		events = list.Items
+	}
+
+	return FormatHPADescription(hpa, events, eventsErr), nil
+}
+
+// FormatHPADescription formats an HPA and its events like kubectl describe.
+// Events are listed newest first, at most hpaEventLimit of them; eventsErr
+// is shown in their place when they could not be listed.
+func FormatHPADescription(hpa *autoscalingv2.HorizontalPodAutoscaler, events []corev1.Event, eventsErr error) string {
+	var result strings.Builder
+
+	minReplicas := int32(1)
+	if hpa.Spec.MinReplicas != nil {
+		minReplicas = *hpa.Spec.MinReplicas
+	}
+
+	result.WriteString(fmt.Sprintf("Name:         %s\n", hpa.Name))
+	result.WriteString(fmt.Sprintf("Namespace:    %s\n", hpa.Namespace))
+	result.WriteString(fmt.Sprintf("Target:       %s/%s\n", hpa.Spec.ScaleTargetRef.Kind, hpa.Spec.ScaleTargetRef.Name))
+	result.WriteString(fmt.Sprintf("Min/Max:      %d / %d\n", minReplicas, hpa.Spec.MaxReplicas))
+	result.WriteString(fmt.Sprintf("Replicas:     %d current / %d desired\n", hpa.Status.CurrentReplicas, hpa.Status.DesiredReplicas))
+	if hpa.Status.LastScaleTime != nil {
+		result.WriteString(fmt.Sprintf("Last Scale:   %s (%s ago)\n", hpa.Status.LastScaleTime.Format(time.RFC3339),
+			time.Since(hpa.Status.LastScaleTime.Time).Round(time.Second)))
+	} else {
+		result.WriteString("Last Scale:   <never>\n")
+	}
+
+	result.WriteString("\nMetrics:      (current / target)\n")
+	if len(hpa.Spec.Metrics) == 0 {
+		result.WriteString("  <none>\n")
+	}
+	for _, spec := range hpa.Spec.Metrics {
+		name, current, target := HPAMetricValues(spec, hpa.Status.CurrentMetrics)
+		result.WriteString(fmt.Sprintf("  %s:  %s / %s\n", name, current, target))
+	}
+
+	result.WriteString("\nConditions:\n")
+	for _, cond := range hpa.Status.Conditions {
+		result.WriteString(fmt.Sprintf("  Type: %s, Status: %s, Reason: %s\n    %s\n", cond.Type, cond.Status, cond.Reason, cond.Message))
+	}
+
+	result.WriteString("\nEvents:\n")
+	switch {
+	case eventsErr != nil:
+		result.WriteString(fmt.Sprintf("  events unavailable: %v\n", eventsErr))
+	case len(events) == 0:
+		result.WriteString("  <none>\n")
+	}
+	events = append([]corev1.Event(nil), events...)
+	sort.SliceStable(events, func(i, j int) bool {
+		return eventTime(events[i]).After(eventTime(events[j]))
+	})
+	if len(events) > hpaEventLimit {
+		events = events[:hpaEventLimit]
+	}
+	for _, e := range events {
+		result.WriteString(fmt.Sprintf("  %s  %s  %s\n", eventTime(e).Format("15:04:05"), e.Reason, e.Message))
+	}
+
+	return result.String()
+}
+
+// HPAMetricValues returns a display name plus the current and target values
+// for one metric of an HPA. current is "<unknown>" until the HPA controller
+// has reported it.
+func HPAMetricValues(spec autoscalingv2.MetricSpec, statuses []autoscalingv2.MetricStatus) (string, string, string) {
+	current := "<unknown>"
+
+	switch spec.Type {
+	case autoscalingv2.ResourceMetricSourceType:
+		if spec.Resource == nil {
+			break
+		}
+		name := fmt.Sprintf("resource %s", spec.Resource.Name)
+		for _, st := range statuses {
+			if st.Type == spec.Type && st.Resource != nil && st.Resource.Name == spec.Resource.Name {
+				current = formatMetricValue(st.Resource.Current)
+			}
+		}
+		return name, current, formatMetricTarget(spec.Resource.Target)
+
+	case autoscalingv2.ContainerResourceMetricSourceType:
+		if spec.ContainerResource == nil {
+			break
+		}
+		name := fmt.Sprintf("resource %s of container %s", spec.ContainerResource.Name, spec.ContainerResource.Container)
+		for _, st := range statuses {
+			if st.Type == spec.Type && st.ContainerResource != nil && st.ContainerResource.Name == spec.ContainerResource.Name &&
+				st.ContainerResource.Container == spec.ContainerResource.Container {
+				current = formatMetricValue(st.ContainerResource.Current)
+			}
+		}
+		return name, current, formatMetricTarget(spec.ContainerResource.Target)
+
+	case autoscalingv2.PodsMetricSourceType:
+		if spec.Pods == nil {
+			break
+		}
+		name := fmt.Sprintf("pods metric %s", spec.Pods.Metric.Name)
+		for _, st := range statuses {
+			if st.Type == spec.Type && st.Pods != nil && st.Pods.Metric.Name == spec.Pods.Metric.Name {
+				current = formatMetricValue(st.Pods.Current)
+			}
+		}
+		return name, current, formatMetricTarget(spec.Pods.Target)
+
+	case autoscalingv2.ObjectMetricSourceType:
+		if spec.Object == nil {
+			break
+		}
+		name := fmt.Sprintf("object metric %s on %s/%s", spec.Object.Metric.Name, spec.Object.DescribedObject.Kind, spec.Object.DescribedObject.Name)
+		for _, st := range statuses {
+			if st.Type == spec.Type && st.Object != nil && st.Object.Metric.Name == spec.Object.Metric.Name {
+				current = formatMetricValue(st.Object.Current)
+			}
+		}
+		return name, current, formatMetricTarget(spec.Object.Target)
+
+	case autoscalingv2.ExternalMetricSourceType:
+		if spec.External == nil {
+			break
+		}
+		name := fmt.Sprintf("external metric %s", spec.External.Metric.Name)
+		for _, st := range statuses {
+			if st.Type == spec.Type && st.External != nil && st.External.Metric.Name == spec.External.Metric.Name {
+				current = formatMetricValue(st.External.Current)
+			}
+		}
+		return name, current, formatMetricTarget(spec.External.Target)
+	}
+
+	return string(spec.Type), current, "<unknown>"
+}
+
+func formatMetricTarget(t autoscalingv2.MetricTarget) string {
+	switch {
+	case t.AverageUtilization != nil:
+		return fmt.Sprintf("%d%%", *t.AverageUtilization)
+	case t.AverageValue != nil:
+		return t.AverageValue.String() + " (avg)"
+	case t.Value != nil:
+		return t.Value.String()
+	}
+	return "<unset>"
+}
+
+func formatMetricValue(v autoscalingv2.MetricValueStatus) string {
+	switch {
+	case v.AverageUtilization != nil:
+		return fmt.Sprintf("%d%%", *v.AverageUtilization)
+	case v.AverageValue != nil:
+		return v.AverageValue.String() + " (avg)"
+	case v.Value != nil:
+		return v.Value.String()
+	}
+	return "<unknown>"
+}
@@ -0,0 +1,142 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	ktesting "k8s.io/client-go/testing"
+)
+
+func TestHPAMetricValues(t *testing.T) {
+	target := int32(70)
+	current := int32(85)
+	spec := autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ResourceMetricSourceType,
+		Resource: &autoscalingv2.ResourceMetricSource{
+			Name:   corev1.ResourceCPU,
+			Target: autoscalingv2.MetricTarget{Type: autoscalingv2.UtilizationMetricType, AverageUtilization: &target},
+		},
+	}
+	statuses := []autoscalingv2.MetricStatus{{
+		Type: autoscalingv2.ResourceMetricSourceType,
+		Resource: &autoscalingv2.ResourceMetricStatus{
+			Name:    corev1.ResourceCPU,
+			Current: autoscalingv2.MetricValueStatus{AverageUtilization: &current},
+		},
+	}}
+
+	name, cur, tgt := HPAMetricValues(spec, statuses)
+	if name != "resource cpu" || cur != "85%" || tgt != "70%" {
+		t.Errorf("HPAMetricValues = %q, %q, %q", name, cur, tgt)
+	}
+
+	_, cur, _ = HPAMetricValues(spec, nil)
+	if cur != "<unknown>" {
+		t.Errorf("expected <unknown> current without status, got %q", cur)
+	}
+
+	avg := resource.MustParse("100")
+	podsSpec := autoscalingv2.MetricSpec{
+		Type: autoscalingv2.PodsMetricSourceType,
+		Pods: &autoscalingv2.PodsMetricSource{
+			Metric: autoscalingv2.MetricIdentifier{Name: "requests_per_second"},
+			Target: autoscalingv2.MetricTarget{Type: autoscalingv2.AverageValueMetricType, AverageValue: &avg},
+		},
+	}
+	name, _, tgt = HPAMetricValues(podsSpec, nil)
+	if name != "pods metric requests_per_second" || tgt != "100 (avg)" {
+		t.Errorf("HPAMetricValues(pods) = %q, %q", name, tgt)
+	}
+}
+
+func TestDescribeHPA(t *testing.T) {
+	minReplicas := int32(2)
+	clientset := fake.NewSimpleClientset(&autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{Kind: "Deployment", Name: "web"},
+			MinReplicas:    &minReplicas,
+			MaxReplicas:    10,
+		},
+		Status: autoscalingv2.HorizontalPodAutoscalerStatus{CurrentReplicas: 3, DesiredReplicas: 4},
+	})
+	client := &Client{Clientset: clientset}
+
+	out, err := client.DescribeHPA(context.Background(), "default", "web")
+	if err != nil {
+		t.Fatalf("DescribeHPA failed: %v", err)
+	}
+	for _, want := range []string{"Deployment/web", "2 / 10", "3 current / 4 desired", "<never>"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("DescribeHPA output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestFormatHPADescriptionEvents(t *testing.T) {
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}}
+	base := time.Date(2026, 3, 1, 10, 0, 0, 0, time.Local)
+
+	var events []corev1.Event
+	for i := 0; i < hpaEventLimit+2; i++ {
+		events = append(events, corev1.Event{
+			Reason:        fmt.Sprintf("Old%d", i),
+			LastTimestamp: metav1.NewTime(base.Add(time.Duration(i) * time.Minute)),
+		})
+	}
+	// events.k8s.io events, as the HPA controller records them, only carry
+	// EventTime
+	events = append(events, corev1.Event{
+		Reason:    "SuccessfulRescale",
+		Message:   "New size: 4",
+		EventTime: metav1.NewMicroTime(base.Add(time.Hour)),
+	})
+
+	out := FormatHPADescription(hpa, events, nil)
+	lines := strings.Split(out[strings.Index(out, "Events:\n")+len("Events:\n"):], "\n")
+	if want := "  11:00:00  SuccessfulRescale  New size: 4"; lines[0] != want {
+		t.Errorf("expected the newest event first as %q, got %q", want, lines[0])
+	}
+	if strings.Contains(out, "00:00:00") {
+		t.Errorf("expected no zero timestamps:\n%s", out)
+	}
+	if n := strings.Count(out, "  Old"); n != hpaEventLimit-1 {
+		t.Errorf("expected %d older events within the limit, got %d:\n%s", hpaEventLimit-1, n, out)
+	}
+	if strings.Contains(out, "Old0 ") || strings.Contains(out, "Old1 ") {
+		t.Errorf("expected the oldest events to be cut:\n%s", out)
+	}
+
+	out = FormatHPADescription(hpa, nil, errors.New("forbidden"))
+	if !strings.Contains(out, "events unavailable: forbidden") || strings.Contains(out, "Events:\n  <none>") {
+		t.Errorf("expected the list error instead of <none>:\n%s", out)
+	}
+}
+
+func TestDescribeHPAEventsUnavailable(t *testing.T) {
+	clientset := fake.NewSimpleClientset(&autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+	})
+	clientset.PrependReactor("list", "events", func(ktesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("events is forbidden")
+	})
+	client := &Client{Clientset: clientset}
+
+	out, err := client.DescribeHPA(context.Background(), "default", "web")
+	if err != nil {
+		t.Fatalf("DescribeHPA failed: %v", err)
+	}
+	if !strings.Contains(out, "events unavailable: events is forbidden") {
+		t.Errorf("expected the events error in the output:\n%s", out)
+	}
+}
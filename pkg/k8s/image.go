@@ -0,0 +1,142 @@
+package k8s
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// DefaultRegistry is the registry of image references without a host
+const DefaultRegistry = "docker.io"
+
+// dockerHubAliases are other host names for DefaultRegistry
+var dockerHubAliases = map[string]bool{
+	"index.docker.io":      true,
+	"registry-1.docker.io": true,
+}
+
+// ImageRef is a parsed container image reference,
+// [registry[:port]/]repository[:tag][@digest]
+type ImageRef struct {
+	Registry   string // registry host, with port if any; DefaultRegistry when omitted
+	Repository string // path within the registry, as written
+	Tag        string // "latest" when neither a tag nor a digest is given
+	Digest     string // e.g. "sha256:..."; empty when not pinned
+}
+
+// ParseImageRef splits an image reference into its parts. Unlike splitting
+// on ":", it keeps a registry port (registry:5000/app:1.2) out of the tag.
+func ParseImageRef(image string) ImageRef {
+	var ref ImageRef
+
+	name := strings.TrimSpace(image)
+	if i := strings.Index(name, "@"); i >= 0 {
+		name, ref.Digest = name[:i], name[i+1:]
+	}
+
+	// The first path component is a registry host if it looks like one
+	ref.Registry = DefaultRegistry
+	if i := strings.Index(name, "/"); i >= 0 {
+		host := name[:i]
+		if strings.ContainsAny(host, ".:") || host == "localhost" {
+			ref.Registry, name = host, name[i+1:]
+		}
+		if dockerHubAliases[ref.Registry] {
+			ref.Registry = DefaultRegistry
+		}
+	}
+
+	// A tag can only follow the last path component
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name, ref.Tag = name[:i], name[i+1:]
+	}
+	ref.Repository = name
+
+	if ref.Tag == "" && ref.Digest == "" {
+		ref.Tag = "latest"
+	}
+	return ref
+}
+
+// Pinned reports whether the reference names an immutable digest
+func (r ImageRef) Pinned() bool {
+	return r.Digest != ""
+}
+
+// MutableTag reports whether the reference can resolve to a different image
+// on the next pull: it is not pinned to a digest and its tag is "latest" or
+// missing
+func (r ImageRef) MutableTag() bool {
+	return !r.Pinned() && (r.Tag == "" || r.Tag == "latest")
+}
+
+// FullName returns the normalized reference as the container runtime reports
+// it, e.g. "nginx:1.25" becomes "docker.io/library/nginx:1.25".
+func (r ImageRef) FullName() string {
+	repo := r.Repository
+	if r.Registry == DefaultRegistry && !strings.Contains(repo, "/") {
+		repo = "library/" + repo
+	}
+	name := r.Registry + "/" + repo
+	if r.Tag != "" {
+		name += ":" + r.Tag
+	}
+	if r.Digest != "" {
+		name += "@" + r.Digest
+	}
+	return name
+}
+
+// ImageUsage is an image reference and the number of pods running it
+type ImageUsage struct {
+	Image    string // as written in the pod spec
+	Ref      ImageRef
+	PodCount int
+}
+
+// CountImages counts the pods that run each image in their containers, most
+// used first. A pod that runs an image in several containers counts once.
+func CountImages(pods []corev1.Pod) []ImageUsage {
+	counts := make(map[string]int)
+	for _, pod := range pods {
+		seen := make(map[string]bool, len(pod.Spec.Containers))
+		for _, c := range pod.Spec.Containers {
+			if !seen[c.Image] {
+				seen[c.Image] = true
+				counts[c.Image]++
+			}
+		}
+	}
+
+	usage := make([]ImageUsage, 0, len(counts))
+	for image, count := range counts {
+		usage = append(usage, ImageUsage{Image: image, Ref: ParseImageRef(image), PodCount: count})
+	}
+	sort.Slice(usage, func(i, j int) bool {
+		if usage[i].PodCount != usage[j].PodCount {
+			return usage[i].PodCount > usage[j].PodCount
+		}
+		return usage[i].Image < usage[j].Image
+	})
+	return usage
+}
+
+// CheckMutableImageTags reports each image in usage that is referenced by a
+// mutable tag. Pods of the same workload may then run different images.
+func CheckMutableImageTags(usage []ImageUsage) []Finding {
+	var findings []Finding
+	for _, u := range usage {
+		if !u.Ref.MutableTag() {
+			continue
+		}
+		findings = append(findings, Finding{
+			Check:   CheckMutableImageTag,
+			Kind:    "Image",
+			Name:    u.Image,
+			Message: fmt.Sprintf("%d pods run a mutable tag; it points at a new image after every push", u.PodCount),
+		})
+	}
+	return findings
+}
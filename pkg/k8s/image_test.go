@@ -0,0 +1,107 @@
+package k8s
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestParseImageRef(t *testing.T) {
+	tests := []struct {
+		name     string
+		image    string
+		want     ImageRef
+		fullName string
+	}{
+		{"bare name", "nginx",
+			ImageRef{Registry: "docker.io", Repository: "nginx", Tag: "latest"}, "docker.io/library/nginx:latest"},
+		{"bare name with tag", "nginx:1.25",
+			ImageRef{Registry: "docker.io", Repository: "nginx", Tag: "1.25"}, "docker.io/library/nginx:1.25"},
+		{"docker hub namespace", "bitnami/redis:7.2",
+			ImageRef{Registry: "docker.io", Repository: "bitnami/redis", Tag: "7.2"}, "docker.io/bitnami/redis:7.2"},
+		{"docker hub alias", "index.docker.io/library/nginx:1.25",
+			ImageRef{Registry: "docker.io", Repository: "library/nginx", Tag: "1.25"}, "docker.io/library/nginx:1.25"},
+		{"registry with port and tag", "registry.local:5000/team/app:1.2",
+			ImageRef{Registry: "registry.local:5000", Repository: "team/app", Tag: "1.2"}, "registry.local:5000/team/app:1.2"},
+		{"registry with port, no tag", "myregistry:5000/app",
+			ImageRef{Registry: "myregistry:5000", Repository: "app", Tag: "latest"}, "myregistry:5000/app:latest"},
+		{"localhost registry", "localhost/app:dev",
+			ImageRef{Registry: "localhost", Repository: "app", Tag: "dev"}, "localhost/app:dev"},
+		{"digest only", "ghcr.io/org/tool@sha256:abc",
+			ImageRef{Registry: "ghcr.io", Repository: "org/tool", Digest: "sha256:abc"}, "ghcr.io/org/tool@sha256:abc"},
+		{"tag and digest", "quay.io/org/app:v1@sha256:def",
+			ImageRef{Registry: "quay.io", Repository: "org/app", Tag: "v1", Digest: "sha256:def"}, "quay.io/org/app:v1@sha256:def"},
+		{"port and digest", "10.0.0.5:5000/app@sha256:123",
+			ImageRef{Registry: "10.0.0.5:5000", Repository: "app", Digest: "sha256:123"}, "10.0.0.5:5000/app@sha256:123"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseImageRef(tt.image)
+			if got != tt.want {
+				t.Errorf("ParseImageRef(%q) = %+v, want %+v", tt.image, got, tt.want)
+			}
+			if got.FullName() != tt.fullName {
+				t.Errorf("ParseImageRef(%q).FullName() = %q, want %q", tt.image, got.FullName(), tt.fullName)
+			}
+			if got.Pinned() != (tt.want.Digest != "") {
+				t.Errorf("ParseImageRef(%q).Pinned() = %v", tt.image, got.Pinned())
+			}
+		})
+	}
+}
+
+func TestCountImages(t *testing.T) {
+	pod := func(images ...string) corev1.Pod {
+		var p corev1.Pod
+		for _, image := range images {
+			p.Spec.Containers = append(p.Spec.Containers, corev1.Container{Image: image})
+		}
+		return p
+	}
+	pods := []corev1.Pod{
+		pod("nginx:1.25", "envoy:1.30"),
+		pod("nginx:1.25"),
+		pod("app", "app"), // same image twice in one pod
+		pod("envoy:1.30"),
+	}
+
+	usage := CountImages(pods)
+	want := []struct {
+		image string
+		pods  int
+	}{
+		{"envoy:1.30", 2},
+		{"nginx:1.25", 2},
+		{"app", 1},
+	}
+	if len(usage) != len(want) {
+		t.Fatalf("expected %d images, got %+v", len(want), usage)
+	}
+	for i, w := range want {
+		if usage[i].Image != w.image || usage[i].PodCount != w.pods {
+			t.Errorf("image %d: got %s (%d pods), want %s (%d pods)", i, usage[i].Image, usage[i].PodCount, w.image, w.pods)
+		}
+	}
+	if usage[2].Ref.Tag != "latest" {
+		t.Errorf("expected the parsed reference, got %+v", usage[2].Ref)
+	}
+}
+
+func TestCheckMutableImageTags(t *testing.T) {
+	var usage []ImageUsage
+	for _, image := range []string{"nginx", "nginx:latest", "nginx:1.25", "nginx:latest@sha256:abc", "app@sha256:def"} {
+		usage = append(usage, ImageUsage{Image: image, Ref: ParseImageRef(image), PodCount: 1})
+	}
+
+	findings := CheckMutableImageTags(usage)
+	var names []string
+	for _, f := range findings {
+		if f.Check != CheckMutableImageTag || f.Kind != "Image" {
+			t.Errorf("unexpected finding %+v", f)
+		}
+		names = append(names, f.Name)
+	}
+	if len(names) != 2 || names[0] != "nginx" || names[1] != "nginx:latest" {
+		t.Errorf("expected nginx and nginx:latest flagged, got %v", names)
+	}
+}
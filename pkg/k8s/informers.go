@@ -0,0 +1,235 @@
+package k8s
+
+import (
+	"sort"
+
+	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/log"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// informerCache holds watch-backed caches of the most listed resources so
+// refreshes don't LIST them from the API server every time
+type informerCache struct {
+	stop chan struct{}
+
+	podsSynced        cache.InformerSynced
+	deploymentsSynced cache.InformerSynced
+	servicesSynced    cache.InformerSynced
+
+	pods        corelisters.PodLister
+	deployments appslisters.DeploymentLister
+	services    corelisters.ServiceLister
+}
+
+// newInformerCache starts watching pods, deployments and services across
+// all namespaces. onChange, if set, is called with the resource name for
+// every change after the initial list.
+func newInformerCache(clientset kubernetes.Interface, onChange func(resource string)) *informerCache {
+	// No periodic resync: the watch keeps the cache current
+	factory := informers.NewSharedInformerFactory(clientset, 0)
+	ic := &informerCache{stop: make(chan struct{})}
+
+	watch := func(resource string, informer cache.SharedIndexInformer) cache.InformerSynced {
+		informer.SetWatchErrorHandler(func(_ *cache.Reflector, err error) {
+			// Keep client-go from logging over the terminal UI
+			log.Warnf("Watch of %s failed, listing directly until it recovers: %v", resource, err)
+		})
+		if onChange != nil {
+			changed := func(interface{}) { onChange(resource) }
+			informer.AddEventHandler(cache.ResourceEventHandlerDetailedFuncs{
+				AddFunc: func(_ interface{}, isInInitialList bool) {
+					if !isInInitialList {
+						onChange(resource)
+					}
+				},
+				UpdateFunc: func(_, obj interface{}) { changed(obj) },
+				DeleteFunc: changed,
+			})
+		}
+		return informer.HasSynced
+	}
+
+	podInformer := factory.Core().V1().Pods()
+	ic.podsSynced = watch("pods", podInformer.Informer())
+	ic.pods = podInformer.Lister()
+	deploymentInformer := factory.Apps().V1().Deployments()
+	ic.deploymentsSynced = watch("deployments", deploymentInformer.Informer())
+	ic.deployments = deploymentInformer.Lister()
+	serviceInformer := factory.Core().V1().Services()
+	ic.servicesSynced = watch("services", serviceInformer.Informer())
+	ic.services = serviceInformer.Lister()
+
+	factory.Start(ic.stop)
+	return ic
+}
+
+// StartInformers caches pods, deployments and services through watches.
+// Once a cache has synced, ListPods, ListDeployments and ListServices read
+// from it instead of the API server; until then they LIST as before.
+// onChange is called from the watch goroutines with "pods", "deployments"
+// or "services" whenever one of them changes.
+func (c *Client) StartInformers(onChange func(resource string)) {
+	c.informerMu.Lock()
+	defer c.informerMu.Unlock()
+
+	if c.informers != nil || c.Clientset == nil {
+		return
+	}
+	c.onInformerChange = onChange
+	c.informers = newInformerCache(c.Clientset, onChange)
+}
+
+// StopInformers stops the watches and drops the caches
+func (c *Client) StopInformers() {
+	c.informerMu.Lock()
+	defer c.informerMu.Unlock()
+
+	if c.informers != nil {
+		close(c.informers.stop)
+		c.informers = nil
+	}
+}
+
+// restartInformers rebuilds running caches for a new clientset, e.g. after
+// a context switch
+func (c *Client) restartInformers() {
+	c.informerMu.Lock()
+	defer c.informerMu.Unlock()
+
+	if c.informers == nil {
+		return
+	}
+	close(c.informers.stop)
+	c.informers = newInformerCache(c.Clientset, c.onInformerChange)
+}
+
+// IsWatched reports whether listing resource ("pods", "deployments" or
+// "services") with opts is served from a synced watch cache, which stays
+// current, rather than by a LIST of the API server
+func (c *Client) IsWatched(resource string, opts ...ListOption) bool {
+	ic := c.informerCache()
+	if ic == nil {
+		return false
+	}
+
+	var synced cache.InformerSynced
+	switch resource {
+	case "pods":
+		synced = ic.podsSynced
+	case "deployments":
+		synced = ic.deploymentsSynced
+	case "services":
+		synced = ic.servicesSynced
+	default:
+		return false
+	}
+	if !synced() {
+		return false
+	}
+	_, ok := cacheSelector(opts)
+	return ok
+}
+
+func (c *Client) informerCache() *informerCache {
+	c.informerMu.RLock()
+	defer c.informerMu.RUnlock()
+	return c.informers
+}
+
+// cachedPods returns the pods of namespace ("" for all) matching opts from
+// the informer cache, or false if it is not running or not synced yet, or
+// opts need the API server
+func (c *Client) cachedPods(namespace string, opts ...ListOption) ([]corev1.Pod, bool) {
+	ic := c.informerCache()
+	if ic == nil || !ic.podsSynced() {
+		return nil, false
+	}
+	selector, ok := cacheSelector(opts)
+	if !ok {
+		return nil, false
+	}
+	var pods []*corev1.Pod
+	var err error
+	if namespace == "" {
+		pods, err = ic.pods.List(selector)
+	} else {
+		pods, err = ic.pods.Pods(namespace).List(selector)
+	}
+	if err != nil {
+		return nil, false
+	}
+	return cachedItems(pods), true
+}
+
+// cachedDeployments is cachedPods for deployments
+func (c *Client) cachedDeployments(namespace string, opts ...ListOption) ([]appsv1.Deployment, bool) {
+	ic := c.informerCache()
+	if ic == nil || !ic.deploymentsSynced() {
+		return nil, false
+	}
+	selector, ok := cacheSelector(opts)
+	if !ok {
+		return nil, false
+	}
+	var deps []*appsv1.Deployment
+	var err error
+	if namespace == "" {
+		deps, err = ic.deployments.List(selector)
+	} else {
+		deps, err = ic.deployments.Deployments(namespace).List(selector)
+	}
+	if err != nil {
+		return nil, false
+	}
+	return cachedItems(deps), true
+}
+
+// cachedServices is cachedPods for services
+func (c *Client) cachedServices(namespace string, opts ...ListOption) ([]corev1.Service, bool) {
+	ic := c.informerCache()
+	if ic == nil || !ic.servicesSynced() {
+		return nil, false
+	}
+	selector, ok := cacheSelector(opts)
+	if !ok {
+		return nil, false
+	}
+	var svcs []*corev1.Service
+	var err error
+	if namespace == "" {
+		svcs, err = ic.services.List(selector)
+	} else {
+		svcs, err = ic.services.Services(namespace).List(selector)
+	}
+	if err != nil {
+		return nil, false
+	}
+	return cachedItems(svcs), true
+}
+
+// cachedItems copies cached objects in the namespace/name order of a LIST,
+// so tables don't reorder between refreshes. The copies share maps and
+// slices with the cache and must not be modified.
+func cachedItems[T any, PT interface {
+	*T
+	metav1.Object
+}](objs []PT) []T {
+	sort.Slice(objs, func(i, j int) bool {
+		if objs[i].GetNamespace() != objs[j].GetNamespace() {
+			return objs[i].GetNamespace() < objs[j].GetNamespace()
+		}
+		return objs[i].GetName() < objs[j].GetName()
+	})
+	items := make([]T, 0, len(objs))
+	for _, obj := range objs {
+		items = append(items, *obj)
+	}
+	return items
+}
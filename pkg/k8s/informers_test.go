@@ -0,0 +1,125 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestInformerCache(t *testing.T) {
+	ctx := context.Background()
+	clientset := fake.NewSimpleClientset(
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-b", Namespace: "team-a"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-a", Namespace: "team-a"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "team-b"}},
+	)
+	client := &Client{Clientset: clientset}
+
+	changes := make(chan string, 10)
+	client.StartInformers(func(resource string) { changes <- resource })
+	defer client.StopInformers()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if _, ok := client.cachedPods(""); ok {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("pod cache did not sync")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if !client.IsWatched("pods") || !client.IsWatched("pods", WithLabelSelector("app=web")) {
+		t.Error("expected synced pods to be watched")
+	}
+	if client.IsWatched("pods", WithFieldSelector("spec.nodeName=node-1")) || client.IsWatched("nodes") {
+		t.Error("expected field selectors and uncached resources to be polled")
+	}
+
+	pods, err := client.ListPods(ctx, "team-a")
+	if err != nil {
+		t.Fatalf("ListPods failed: %v", err)
+	}
+	if len(pods) != 2 || pods[0].Name != "web-a" || pods[1].Name != "web-b" {
+		t.Errorf("expected team-a pods in name order, got %v", pods)
+	}
+
+	// The initial list is not reported; later changes are
+	select {
+	case r := <-changes:
+		t.Fatalf("unexpected change %q before any update", r)
+	default:
+	}
+	_, err = clientset.CoreV1().Pods("team-b").Create(ctx,
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "cache", Namespace: "team-b"}}, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case r := <-changes:
+		if r != "pods" {
+			t.Errorf("expected a pods change, got %q", r)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no change reported for a new pod")
+	}
+	if pods, _ := client.ListPods(ctx, "team-b"); len(pods) != 2 {
+		t.Errorf("expected the new pod in the cache, got %v", pods)
+	}
+
+	// Without informers the API server is listed directly
+	client.StopInformers()
+	if _, ok := client.cachedPods(""); ok || client.IsWatched("pods") {
+		t.Error("expected no cache after StopInformers")
+	}
+	if pods, err := client.ListPods(ctx, ""); err != nil || len(pods) != 4 {
+		t.Errorf("expected 4 pods from LIST, got %d (%v)", len(pods), err)
+	}
+}
+
+func TestListPodsWithLabelSelector(t *testing.T) {
+	ctx := context.Background()
+	pod := func(name, app string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{"app": app}}}
+	}
+	// "web" and "web-admin" overlap by name but not by label
+	client := &Client{Clientset: fake.NewSimpleClientset(pod("web-1", "web"), pod("web-admin-1", "web-admin"))}
+
+	check := func(source string) {
+		t.Helper()
+		pods, err := client.ListPods(ctx, "default", WithLabelSelector("app=web"))
+		if err != nil {
+			t.Fatalf("%s: ListPods failed: %v", source, err)
+		}
+		if len(pods) != 1 || pods[0].Name != "web-1" {
+			t.Errorf("%s: expected only web-1, got %v", source, pods)
+		}
+	}
+	check("LIST")
+
+	client.StartInformers(nil)
+	defer client.StopInformers()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if _, ok := client.cachedPods(""); ok {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("pod cache did not sync")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	check("cache")
+
+	if _, ok := client.cachedPods("default", WithLabelSelector("app in (")); ok {
+		t.Error("expected an invalid selector to be left to the API server")
+	}
+	if _, ok := client.cachedPods("", WithFieldSelector("spec.nodeName=node-1")); ok {
+		t.Error("expected a field selector to be left to the API server")
+	}
+}
@@ -0,0 +1,162 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// inventoryWorkers bounds the concurrent list calls while building an inventory
+const inventoryWorkers = 8
+
+// inventoryPageSize keeps list responses small on large clusters
+const inventoryPageSize = 500
+
+// Inventory counts every listable resource kind of the cluster, in the
+// preferred version of each API group, including CRDs
+type Inventory struct {
+	GeneratedAt time.Time        `json:"generated_at"`
+	Namespace   string           `json:"namespace,omitempty"` // set when scoped to one namespace
+	Resources   []InventoryEntry `json:"resources"`
+	Total       int              `json:"total"`
+
+	// Errors lists the resources that could not be listed, e.g. for lack of
+	// RBAC permissions; their counts are missing from Resources
+	Errors []string `json:"errors,omitempty"`
+}
+
+// InventoryEntry is the object count of one resource kind
+type InventoryEntry struct {
+	Group      string         `json:"group"`
+	Version    string         `json:"version"`
+	Resource   string         `json:"resource"`
+	Kind       string         `json:"kind"`
+	Namespaced bool           `json:"namespaced"`
+	Count      int            `json:"count"`
+	Namespaces map[string]int `json:"namespaces,omitempty"` // count per namespace
+}
+
+// BuildInventory discovers every API resource that supports list and counts
+// its objects, per namespace for namespaced kinds. With a namespace, only
+// namespaced kinds in that namespace are counted. Resources that fail to
+// list are reported in Errors rather than failing the inventory.
+func (c *Client) BuildInventory(ctx context.Context, namespace string) (*Inventory, error) {
+	groups, lists, err := c.Clientset.Discovery().ServerGroupsAndResources()
+	if err != nil && len(lists) == 0 {
+		return nil, fmt.Errorf("discover API resources: %w", err)
+	}
+
+	inv := &Inventory{GeneratedAt: time.Now(), Namespace: namespace}
+	if err != nil {
+		// Partial discovery failure, e.g. an unavailable aggregated API
+		inv.Errors = append(inv.Errors, err.Error())
+	}
+
+	preferred := make(map[string]bool, len(groups))
+	for _, g := range groups {
+		preferred[g.PreferredVersion.GroupVersion] = true
+	}
+
+	var entries []InventoryEntry
+	for _, list := range lists {
+		if !preferred[list.GroupVersion] {
+			continue
+		}
+		gv, err := schema.ParseGroupVersion(list.GroupVersion)
+		if err != nil {
+			continue
+		}
+		for _, r := range list.APIResources {
+			if strings.Contains(r.Name, "/") || !hasVerb(r.Verbs, "list") {
+				continue
+			}
+			if namespace != "" && !r.Namespaced {
+				continue
+			}
+			entries = append(entries, InventoryEntry{
+				Group:      gv.Group,
+				Version:    gv.Version,
+				Resource:   r.Name,
+				Kind:       r.Kind,
+				Namespaced: r.Namespaced,
+			})
+		}
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, inventoryWorkers)
+	for i := range entries {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(e *InventoryEntry) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := c.countResource(ctx, e, namespace); err != nil {
+				mu.Lock()
+				inv.Errors = append(inv.Errors, fmt.Sprintf("%s: %v", e.gvr().String(), err))
+				mu.Unlock()
+			}
+		}(&entries[i])
+	}
+	wg.Wait()
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Group != entries[j].Group {
+			return entries[i].Group < entries[j].Group
+		}
+		return entries[i].Resource < entries[j].Resource
+	})
+	sort.Strings(inv.Errors)
+	for _, e := range entries {
+		inv.Total += e.Count
+	}
+	inv.Resources = entries
+	return inv, nil
+}
+
+// countResource pages through a resource and fills in its counts
+func (c *Client) countResource(ctx context.Context, e *InventoryEntry, namespace string) error {
+	dyn, err := c.dynamicClient()
+	if err != nil {
+		return err
+	}
+	opts := metav1.ListOptions{Limit: inventoryPageSize}
+	for {
+		list, err := dyn.Resource(e.gvr()).Namespace(namespace).List(ctx, opts)
+		if err != nil {
+			return err
+		}
+		for _, item := range list.Items {
+			e.Count++
+			if e.Namespaced {
+				if e.Namespaces == nil {
+					e.Namespaces = make(map[string]int)
+				}
+				e.Namespaces[item.GetNamespace()]++
+			}
+		}
+		if opts.Continue = list.GetContinue(); opts.Continue == "" {
+			return nil
+		}
+	}
+}
+
+func (e InventoryEntry) gvr() schema.GroupVersionResource {
+	return schema.GroupVersionResource{Group: e.Group, Version: e.Version, Resource: e.Resource}
+}
+
+func hasVerb(verbs []string, verb string) bool {
+	for _, v := range verbs {
+		if v == verb {
+			return true
+		}
+	}
+	return false
+}
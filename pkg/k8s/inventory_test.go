@@ -0,0 +1,114 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func inventoryObject(apiVersion, kind, namespace, name string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	return obj
+}
+
+func newInventoryClient() *Client {
+	clientset := fake.NewSimpleClientset()
+	clientset.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{
+				{Name: "configmaps", Kind: "ConfigMap", Namespaced: true, Verbs: []string{"get", "list"}},
+				{Name: "namespaces", Kind: "Namespace", Verbs: []string{"get", "list"}},
+				{Name: "pods/log", Kind: "Pod", Namespaced: true, Verbs: []string{"get"}},
+				{Name: "bindings", Kind: "Binding", Namespaced: true, Verbs: []string{"create"}},
+			},
+		},
+		{
+			GroupVersion: "example.com/v1",
+			APIResources: []metav1.APIResource{
+				{Name: "widgets", Kind: "Widget", Namespaced: true, Verbs: []string{"list"}},
+			},
+		},
+		{
+			// Not the preferred version of example.com, so not counted twice
+			GroupVersion: "example.com/v1beta1",
+			APIResources: []metav1.APIResource{
+				{Name: "widgets", Kind: "Widget", Namespaced: true, Verbs: []string{"list"}},
+			},
+		},
+	}
+
+	dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			{Version: "v1", Resource: "configmaps"}:                    "ConfigMapList",
+			{Version: "v1", Resource: "namespaces"}:                    "NamespaceList",
+			{Group: "example.com", Version: "v1", Resource: "widgets"}: "WidgetList",
+		},
+		inventoryObject("v1", "ConfigMap", "default", "a"),
+		inventoryObject("v1", "ConfigMap", "default", "b"),
+		inventoryObject("v1", "ConfigMap", "shop", "c"),
+		inventoryObject("v1", "Namespace", "", "default"),
+		inventoryObject("example.com/v1", "Widget", "shop", "w"),
+	)
+	return &Client{Clientset: clientset, Dynamic: dyn}
+}
+
+func TestBuildInventory(t *testing.T) {
+	inv, err := newInventoryClient().BuildInventory(context.Background(), "")
+	if err != nil {
+		t.Fatalf("BuildInventory failed: %v", err)
+	}
+	if len(inv.Errors) > 0 {
+		t.Errorf("unexpected errors: %v", inv.Errors)
+	}
+
+	got := make(map[string]InventoryEntry)
+	for _, e := range inv.Resources {
+		got[e.gvr().String()] = e
+	}
+	if len(got) != 3 {
+		t.Fatalf("expected configmaps, namespaces and widgets, got %+v", inv.Resources)
+	}
+
+	cms := got[schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}.String()]
+	if cms.Count != 3 || cms.Namespaces["default"] != 2 || cms.Namespaces["shop"] != 1 {
+		t.Errorf("unexpected configmap counts: %+v", cms)
+	}
+	nss := got[schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}.String()]
+	if nss.Count != 1 || nss.Namespaces != nil {
+		t.Errorf("unexpected namespace counts: %+v", nss)
+	}
+	widgets := got[schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "widgets"}.String()]
+	if widgets.Count != 1 || widgets.Kind != "Widget" {
+		t.Errorf("unexpected widget counts: %+v", widgets)
+	}
+	if inv.Total != 5 {
+		t.Errorf("expected 5 objects in total, got %d", inv.Total)
+	}
+}
+
+func TestBuildInventoryNamespaced(t *testing.T) {
+	inv, err := newInventoryClient().BuildInventory(context.Background(), "shop")
+	if err != nil {
+		t.Fatalf("BuildInventory failed: %v", err)
+	}
+	for _, e := range inv.Resources {
+		if !e.Namespaced {
+			t.Errorf("cluster-scoped %s in namespace inventory", e.Resource)
+		}
+	}
+	if inv.Total != 2 {
+		t.Errorf("expected 2 objects in namespace shop, got %d (%+v)", inv.Total, inv.Resources)
+	}
+}
@@ -0,0 +1,114 @@
+package k8s
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// HoursPerMonth converts hourly prices to monthly estimates
+const HoursPerMonth = 730
+
+// httpPorts are ports commonly serving HTTP(S) that an Ingress can front
+var httpPorts = map[int32]bool{80: true, 443: true, 8080: true, 8443: true}
+
+// CheckLoadBalancerConsolidation names the LoadBalancer services that serve
+// only HTTP(S) and could share an Ingress instead of each paying for its own
+// cloud load balancer. LoadBalancers that look like an ingress controller or
+// gateway are treated as the shared entry point. hourlyCost is the price of
+// one load balancer; 0 leaves the savings out of the message.
+func CheckLoadBalancerConsolidation(services []corev1.Service, hourlyCost float64) []Finding {
+	var candidates []corev1.Service
+	hasIngressLB := false
+	for _, svc := range services {
+		if svc.Spec.Type != corev1.ServiceTypeLoadBalancer {
+			continue
+		}
+		if isIngressEntryPoint(svc) {
+			hasIngressLB = true
+			continue
+		}
+		if servesOnlyHTTP(svc) {
+			candidates = append(candidates, svc)
+		}
+	}
+
+	// Consolidating needs one LoadBalancer for the Ingress itself
+	after := 1
+	if hasIngressLB {
+		after = 0
+	}
+	saved := len(candidates) - after
+	if saved <= 0 {
+		return nil
+	}
+
+	summary := fmt.Sprintf("%d HTTP(S) LoadBalancers could share an Ingress", len(candidates))
+	if hasIngressLB {
+		summary = fmt.Sprintf("%d HTTP(S) LoadBalancers could move behind the existing ingress controller", len(candidates))
+	}
+	if hourlyCost > 0 {
+		monthly := hourlyCost * HoursPerMonth
+		summary += fmt.Sprintf(", saving ~$%.2f/month (~$%.2f per LoadBalancer)", float64(saved)*monthly, monthly)
+	}
+
+	findings := make([]Finding, 0, len(candidates))
+	for _, svc := range candidates {
+		findings = append(findings, Finding{
+			Check:     CheckLBConsolidation,
+			Kind:      "Service",
+			Namespace: svc.Namespace,
+			Name:      svc.Name,
+			Message:   fmt.Sprintf("LoadBalancer on %s; %s", servicePorts(svc), summary),
+		})
+	}
+	return findings
+}
+
+// isIngressEntryPoint guesses from names and labels whether a LoadBalancer
+// fronts an ingress controller or gateway
+func isIngressEntryPoint(svc corev1.Service) bool {
+	names := []string{svc.Name, svc.Namespace, svc.Labels["app.kubernetes.io/name"], svc.Labels["app"]}
+	for _, n := range names {
+		n = strings.ToLower(n)
+		if strings.Contains(n, "ingress") || strings.Contains(n, "gateway") || strings.Contains(n, "traefik") {
+			return true
+		}
+	}
+	return false
+}
+
+// servesOnlyHTTP reports whether every port of svc looks like HTTP(S), by
+// app protocol, port name or well-known port number
+func servesOnlyHTTP(svc corev1.Service) bool {
+	if len(svc.Spec.Ports) == 0 {
+		return false
+	}
+	for _, p := range svc.Spec.Ports {
+		if p.Protocol != "" && p.Protocol != corev1.ProtocolTCP {
+			return false
+		}
+		app := ""
+		if p.AppProtocol != nil {
+			app = strings.ToLower(*p.AppProtocol)
+		}
+		name := strings.ToLower(p.Name)
+		if !strings.HasPrefix(app, "http") && !strings.HasPrefix(name, "http") && !httpPorts[p.Port] {
+			return false
+		}
+	}
+	return true
+}
+
+func servicePorts(svc corev1.Service) string {
+	ports := make([]string, 0, len(svc.Spec.Ports))
+	for _, p := range svc.Spec.Ports {
+		proto := p.Protocol
+		if proto == "" {
+			proto = corev1.ProtocolTCP
+		}
+		ports = append(ports, fmt.Sprintf("%d/%s", p.Port, proto))
+	}
+	return strings.Join(ports, ", ")
+}
@@ -0,0 +1,54 @@
+package k8s
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func testLBService(namespace, name string, ports ...corev1.ServicePort) corev1.Service {
+	return corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer, Ports: ports},
+	}
+}
+
+func TestCheckLoadBalancerConsolidation(t *testing.T) {
+	https := corev1.ServicePort{Name: "https", Port: 443, Protocol: corev1.ProtocolTCP}
+	web := corev1.ServicePort{Name: "web", Port: 3000, Protocol: corev1.ProtocolTCP}
+	postgres := corev1.ServicePort{Name: "pg", Port: 5432, Protocol: corev1.ProtocolTCP}
+
+	services := []corev1.Service{
+		testLBService("shop", "frontend", https),
+		testLBService("shop", "api", corev1.ServicePort{Name: "http-api", Port: 3000}),
+		testLBService("shop", "db", postgres),
+		testLBService("shop", "custom", web),
+		{ObjectMeta: metav1.ObjectMeta{Name: "internal", Namespace: "shop"}, Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP, Ports: []corev1.ServicePort{https}}},
+	}
+
+	findings := CheckLoadBalancerConsolidation(services, 0.025)
+	if len(findings) != 2 || findings[0].Name != "frontend" || findings[1].Name != "api" {
+		t.Fatalf("expected frontend and api as candidates, got %+v", findings)
+	}
+	// Two LoadBalancers become one for the Ingress
+	if !strings.Contains(findings[0].Message, "~$18.25/month") || !strings.Contains(findings[0].Message, "443/TCP") {
+		t.Errorf("unexpected message %q", findings[0].Message)
+	}
+
+	// A single HTTP LoadBalancer has nothing to consolidate with...
+	if got := CheckLoadBalancerConsolidation(services[:1], 0.025); len(got) != 0 {
+		t.Errorf("expected no findings for one LoadBalancer, got %+v", got)
+	}
+
+	// ...unless an ingress controller already has one
+	withIngress := append([]corev1.Service{testLBService("ingress-nginx", "ingress-nginx-controller", https)}, services[:1]...)
+	got := CheckLoadBalancerConsolidation(withIngress, 0)
+	if len(got) != 1 || got[0].Name != "frontend" || !strings.Contains(got[0].Message, "existing ingress controller") {
+		t.Errorf("expected frontend to move behind the ingress controller, got %+v", got)
+	}
+	if strings.Contains(got[0].Message, "$") {
+		t.Errorf("expected no savings without a price, got %q", got[0].Message)
+	}
+}
@@ -0,0 +1,91 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// MetadataField selects which metadata map a patch targets
+type MetadataField string
+
+const (
+	MetadataLabels      MetadataField = "labels"
+	MetadataAnnotations MetadataField = "annotations"
+)
+
+// ParseMetadataChanges parses kubectl-style label arguments separated by
+// whitespace or commas: "key=value" sets a key, "key-" removes it.
+func ParseMetadataChanges(input string) (map[string]string, []string, error) {
+	set := make(map[string]string)
+	var remove []string
+
+	fields := strings.FieldsFunc(input, func(r rune) bool {
+		return r == ' ' || r == ',' || r == '\t'
+	})
+	for _, f := range fields {
+		switch {
+		case strings.Contains(f, "="):
+			kv := strings.SplitN(f, "=", 2)
+			if kv[0] == "" {
+				return nil, nil, fmt.Errorf("invalid change %q: empty key", f)
+			}
+			set[kv[0]] = kv[1]
+		case strings.HasSuffix(f, "-") && len(f) > 1:
+			remove = append(remove, strings.TrimSuffix(f, "-"))
+		default:
+			return nil, nil, fmt.Errorf("invalid change %q: expected key=value or key-", f)
+		}
+	}
+
+	if len(set) == 0 && len(remove) == 0 {
+		return nil, nil, fmt.Errorf("no changes given")
+	}
+	return set, remove, nil
+}
+
+// BuildMetadataPatch builds a patch that sets and removes keys in the given
+// metadata map. Removed keys are sent as null.
+func BuildMetadataPatch(field MetadataField, set map[string]string, remove []string) ([]byte, error) {
+	values := make(map[string]interface{}, len(set)+len(remove))
+	for k, v := range set {
+		values[k] = v
+	}
+	for _, k := range remove {
+		values[k] = nil
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			string(field): values,
+		},
+	})
+}
+
+// PatchMetadata adds or removes labels or annotations on any resource. The
+// patch is sent as a strategic merge patch, like kubectl does; custom
+// resources do not support that, so when the API server refuses it the same
+// patch is sent as a JSON merge patch, which for metadata maps is equivalent.
+func (c *Client) PatchMetadata(ctx context.Context, gvr schema.GroupVersionResource, namespace, name string, field MetadataField, set map[string]string, remove []string) error {
+	payload, err := BuildMetadataPatch(field, set, remove)
+	if err != nil {
+		return err
+	}
+
+	dyn, err := c.dynamicClient()
+	if err != nil {
+		return err
+	}
+	resource := dyn.Resource(gvr).Namespace(namespace)
+	_, err = resource.Patch(ctx, name, types.StrategicMergePatchType, payload, metav1.PatchOptions{})
+	if apierrors.IsUnsupportedMediaType(err) {
+		_, err = resource.Patch(ctx, name, types.MergePatchType, payload, metav1.PatchOptions{})
+	}
+	return err
+}
@@ -0,0 +1,92 @@
+package k8s
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	ktesting "k8s.io/client-go/testing"
+)
+
+func TestParseMetadataChanges(t *testing.T) {
+	set, remove, err := ParseMetadataChanges("team=payments, tier=  old-")
+	if err != nil {
+		t.Fatalf("ParseMetadataChanges failed: %v", err)
+	}
+	if set["team"] != "payments" || set["tier"] != "" {
+		t.Errorf("unexpected set: %v", set)
+	}
+	if len(remove) != 1 || remove[0] != "old" {
+		t.Errorf("unexpected remove: %v", remove)
+	}
+
+	for _, input := range []string{"", "novalue", "=value", "-"} {
+		if _, _, err := ParseMetadataChanges(input); err == nil {
+			t.Errorf("expected error for %q", input)
+		}
+	}
+}
+
+func TestBuildMetadataPatch(t *testing.T) {
+	patch, err := BuildMetadataPatch(MetadataLabels, map[string]string{"team": "payments"}, []string{"old"})
+	if err != nil {
+		t.Fatalf("BuildMetadataPatch failed: %v", err)
+	}
+	expected := `{"metadata":{"labels":{"old":null,"team":"payments"}}}`
+	if string(patch) != expected {
+		t.Errorf("BuildMetadataPatch = %s, expected %s", patch, expected)
+	}
+}
+
+// TestPatchMetadata patches a custom resource, which refuses the strategic
+// merge patch as the API server does, so the merge patch fallback applies it
+func TestPatchMetadata(t *testing.T) {
+	gvr := schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "widgets"}
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("example.com/v1")
+	obj.SetKind("Widget")
+	obj.SetNamespace("default")
+	obj.SetName("gear")
+	obj.SetAnnotations(map[string]string{"stale": "yes"})
+
+	dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{gvr: "WidgetList"}, obj)
+	var patchTypes []types.PatchType
+	dyn.PrependReactor("patch", "*", func(action ktesting.Action) (bool, runtime.Object, error) {
+		pt := action.(ktesting.PatchAction).GetPatchType()
+		patchTypes = append(patchTypes, pt)
+		if pt == types.StrategicMergePatchType {
+			return true, nil, apierrors.NewGenericServerResponse(http.StatusUnsupportedMediaType, "patch", gvr.GroupResource(), "gear", "", 0, false)
+		}
+		return false, nil, nil
+	})
+	client := &Client{Dynamic: dyn}
+
+	err := client.PatchMetadata(context.Background(), gvr, "default", "gear", MetadataAnnotations,
+		map[string]string{"owner": "sre"}, []string{"stale"})
+	if err != nil {
+		t.Fatalf("PatchMetadata failed: %v", err)
+	}
+	if len(patchTypes) != 2 || patchTypes[0] != types.StrategicMergePatchType || patchTypes[1] != types.MergePatchType {
+		t.Errorf("expected a strategic merge patch, then a merge patch; got %v", patchTypes)
+	}
+
+	got, err := dyn.Resource(gvr).Namespace("default").Get(context.Background(), "gear", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	annotations := got.GetAnnotations()
+	if annotations["owner"] != "sre" {
+		t.Errorf("expected owner annotation, got %v", annotations)
+	}
+	if _, ok := annotations["stale"]; ok {
+		t.Errorf("expected stale annotation to be removed, got %v", annotations)
+	}
+}
@@ -0,0 +1,70 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// ErrMetricsUnavailable is returned by GetPodMetrics and GetNodeMetrics when
+// the cluster does not serve the metrics API, i.e. metrics-server is not
+// installed. Other errors from them are transient.
+var ErrMetricsUnavailable = errors.New("metrics-server is not installed")
+
+// metricsGroupVersion is the API metrics-server registers
+const metricsGroupVersion = "metrics.k8s.io/v1beta1"
+
+// Metrics API states of a Client
+const (
+	metricsUnknown int32 = iota
+	metricsAvailable
+	metricsUnavailable
+)
+
+// DetectMetrics asks the API server whether it serves the metrics API and
+// remembers the answer, so metrics calls fail fast with
+// ErrMetricsUnavailable instead of querying on every refresh. Call it at
+// startup and again to pick up a newly installed metrics-server. An error
+// other than ErrMetricsUnavailable means the check itself failed.
+func (c *Client) DetectMetrics(ctx context.Context) error {
+	if c.Metrics == nil || c.Clientset == nil {
+		atomic.StoreInt32(&c.metricsState, metricsUnavailable)
+		return ErrMetricsUnavailable
+	}
+
+	_, err := c.Clientset.Discovery().ServerResourcesForGroupVersion(metricsGroupVersion)
+	switch {
+	case err == nil:
+		atomic.StoreInt32(&c.metricsState, metricsAvailable)
+		return nil
+	case apierrors.IsNotFound(err):
+		atomic.StoreInt32(&c.metricsState, metricsUnavailable)
+		return ErrMetricsUnavailable
+	}
+	return err
+}
+
+// checkMetrics returns ErrMetricsUnavailable when metrics calls cannot
+// succeed, without asking the API server
+func (c *Client) checkMetrics() error {
+	if c.Metrics == nil {
+		return fmt.Errorf("%w: metrics client not initialized", ErrMetricsUnavailable)
+	}
+	if atomic.LoadInt32(&c.metricsState) == metricsUnavailable {
+		return ErrMetricsUnavailable
+	}
+	return nil
+}
+
+// metricsError wraps a failed metrics call in ErrMetricsUnavailable when the
+// API is not served, and remembers that
+func (c *Client) metricsError(err error) error {
+	if apierrors.IsNotFound(err) {
+		atomic.StoreInt32(&c.metricsState, metricsUnavailable)
+		return fmt.Errorf("%w: %v", ErrMetricsUnavailable, err)
+	}
+	return err
+}
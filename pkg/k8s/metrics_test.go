@@ -0,0 +1,46 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	metricsv1beta1 "k8s.io/metrics/pkg/client/clientset/versioned/typed/metrics/v1beta1"
+)
+
+func TestDetectMetrics(t *testing.T) {
+	ctx := context.Background()
+	// Nothing listens here; metrics calls must not reach it once the API is
+	// known to be missing
+	metricsClient, err := metricsv1beta1.NewForConfig(&rest.Config{Host: "http://127.0.0.1:1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clientset := fake.NewSimpleClientset()
+	client := &Client{Clientset: clientset, Metrics: metricsClient}
+	if err := client.DetectMetrics(ctx); !errors.Is(err, ErrMetricsUnavailable) {
+		t.Fatalf("expected ErrMetricsUnavailable without metrics-server, got %v", err)
+	}
+	if _, err := client.GetPodMetrics(ctx, "default"); err != ErrMetricsUnavailable {
+		t.Errorf("GetPodMetrics should fail fast, got %v", err)
+	}
+	if _, err := client.GetNodeMetrics(ctx); err != ErrMetricsUnavailable {
+		t.Errorf("GetNodeMetrics should fail fast, got %v", err)
+	}
+
+	// Installing metrics-server is picked up by detecting again
+	clientset.Resources = []*metav1.APIResourceList{{
+		GroupVersion: metricsGroupVersion,
+		APIResources: []metav1.APIResource{{Name: "pods"}, {Name: "nodes"}},
+	}}
+	if err := client.DetectMetrics(ctx); err != nil {
+		t.Fatalf("expected the metrics API to be detected, got %v", err)
+	}
+	if err := client.checkMetrics(); err != nil {
+		t.Errorf("expected metrics calls to be allowed, got %v", err)
+	}
+}
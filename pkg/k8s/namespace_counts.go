@@ -0,0 +1,53 @@
+package k8s
+
+import (
+	"context"
+	"sync"
+)
+
+// namespaceCountWorkers bounds the namespaces counted at once
+const namespaceCountWorkers = 8
+
+// NamespaceCounts is the number of pods, deployments and services in a
+// namespace. A count is -1 when its objects could not be listed.
+type NamespaceCounts struct {
+	Pods        int
+	Deployments int
+	Services    int
+}
+
+// CountNamespaceObjects counts the pods, deployments and services of each
+// namespace, at most namespaceCountWorkers namespaces at a time so large
+// clusters are not flooded with list calls
+func (c *Client) CountNamespaceObjects(ctx context.Context, namespaces []string) map[string]NamespaceCounts {
+	counts := make(map[string]NamespaceCounts, len(namespaces))
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, namespaceCountWorkers)
+	for _, ns := range namespaces {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(ns string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			nc := NamespaceCounts{Pods: -1, Deployments: -1, Services: -1}
+			if pods, err := c.ListPods(ctx, ns); err == nil {
+				nc.Pods = len(pods)
+			}
+			if deps, err := c.ListDeployments(ctx, ns); err == nil {
+				nc.Deployments = len(deps)
+			}
+			if svcs, err := c.ListServices(ctx, ns); err == nil {
+				nc.Services = len(svcs)
+			}
+
+			mu.Lock()
+			counts[ns] = nc
+			mu.Unlock()
+		}(ns)
+	}
+	wg.Wait()
+	return counts
+}
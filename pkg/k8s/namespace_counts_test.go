@@ -0,0 +1,47 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestCountNamespaceObjects(t *testing.T) {
+	meta := func(ns, name string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Namespace: ns, Name: name}
+	}
+	clientset := fake.NewSimpleClientset(
+		&corev1.Pod{ObjectMeta: meta("shop", "web-1")},
+		&corev1.Pod{ObjectMeta: meta("shop", "web-2")},
+		&appsv1.Deployment{ObjectMeta: meta("shop", "web")},
+		&corev1.Service{ObjectMeta: meta("shop", "web")},
+		&corev1.Pod{ObjectMeta: meta("locked", "db-0")},
+	)
+	// Services of "locked" cannot be listed
+	clientset.PrependReactor("list", "services", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetNamespace() == "locked" {
+			return true, nil, errors.New("forbidden")
+		}
+		return false, nil, nil
+	})
+	client := &Client{Clientset: clientset}
+
+	counts := client.CountNamespaceObjects(context.Background(), []string{"shop", "locked", "empty"})
+	want := map[string]NamespaceCounts{
+		"shop":   {Pods: 2, Deployments: 1, Services: 1},
+		"locked": {Pods: 1, Deployments: 0, Services: -1},
+		"empty":  {},
+	}
+	for ns, w := range want {
+		if got := counts[ns]; got != w {
+			t.Errorf("%s: got %+v, want %+v", ns, got, w)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PodAllocation is what one pod on a node requests and is limited to
+type PodAllocation struct {
+	Namespace      string
+	Name           string
+	CPURequests    resource.Quantity
+	CPULimits      resource.Quantity
+	MemoryRequests resource.Quantity
+	MemoryLimits   resource.Quantity
+}
+
+// NodeAllocation is the share of a node's allocatable CPU and memory that
+// its non-terminated pods request, as kubectl describe node shows it
+type NodeAllocation struct {
+	Pods              []PodAllocation // sorted by namespace and name
+	AllocatableCPU    resource.Quantity
+	AllocatableMemory resource.Quantity
+	CPURequests       resource.Quantity
+	CPULimits         resource.Quantity
+	MemoryRequests    resource.Quantity
+	MemoryLimits      resource.Quantity
+}
+
+// podRequestsAndLimits returns the effective requests and limits of a pod
+// for scheduling: the sum over its containers or the largest init
+// container, whichever is higher, plus the pod overhead
+func podRequestsAndLimits(spec corev1.PodSpec) (requests, limits corev1.ResourceList) {
+	requests, limits = corev1.ResourceList{}, corev1.ResourceList{}
+	add := func(list, from corev1.ResourceList) {
+		for name, q := range from {
+			sum := list[name]
+			sum.Add(q)
+			list[name] = sum
+		}
+	}
+	atLeast := func(list, from corev1.ResourceList) {
+		for name, q := range from {
+			if cur, ok := list[name]; !ok || q.Cmp(cur) > 0 {
+				list[name] = q.DeepCopy()
+			}
+		}
+	}
+
+	for _, c := range spec.Containers {
+		add(requests, c.Resources.Requests)
+		add(limits, c.Resources.Limits)
+	}
+	for _, c := range spec.InitContainers {
+		atLeast(requests, c.Resources.Requests)
+		atLeast(limits, c.Resources.Limits)
+	}
+	add(requests, spec.Overhead)
+	add(limits, spec.Overhead)
+	return requests, limits
+}
+
+// ComputeNodeAllocation sums the requests and limits of the pods on node.
+// Succeeded and failed pods hold no resources and are left out.
+func ComputeNodeAllocation(node *corev1.Node, pods []corev1.Pod) NodeAllocation {
+	alloc := NodeAllocation{
+		AllocatableCPU:    node.Status.Allocatable[corev1.ResourceCPU],
+		AllocatableMemory: node.Status.Allocatable[corev1.ResourceMemory],
+	}
+	for _, pod := range pods {
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		requests, limits := podRequestsAndLimits(pod.Spec)
+		pa := PodAllocation{
+			Namespace:      pod.Namespace,
+			Name:           pod.Name,
+			CPURequests:    requests[corev1.ResourceCPU],
+			CPULimits:      limits[corev1.ResourceCPU],
+			MemoryRequests: requests[corev1.ResourceMemory],
+			MemoryLimits:   limits[corev1.ResourceMemory],
+		}
+		alloc.CPURequests.Add(pa.CPURequests)
+		alloc.CPULimits.Add(pa.CPULimits)
+		alloc.MemoryRequests.Add(pa.MemoryRequests)
+		alloc.MemoryLimits.Add(pa.MemoryLimits)
+		alloc.Pods = append(alloc.Pods, pa)
+	}
+	sort.Slice(alloc.Pods, func(i, j int) bool {
+		a, b := alloc.Pods[i], alloc.Pods[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	return alloc
+}
+
+// nodeAllocation lists the pods bound to node and sums their requests
+func (c *Client) nodeAllocation(ctx context.Context, node *corev1.Node) (NodeAllocation, error) {
+	pods, err := c.Clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{
+		FieldSelector: fmt.Sprintf("spec.nodeName=%s", node.Name),
+	})
+	if err != nil {
+		return NodeAllocation{}, err
+	}
+	return ComputeNodeAllocation(node, pods.Items), nil
+}
+
+// percentOf formats q with its share of total, e.g. "500m (25%)"
+func percentOf(q, total resource.Quantity) string {
+	if total.IsZero() {
+		return q.String()
+	}
+	return fmt.Sprintf("%s (%d%%)", q.String(), q.MilliValue()*100/total.MilliValue())
+}
+
+// writeNodeAllocation writes the pods of a node with their requests and
+// the allocated totals, in the layout of kubectl describe node
+func writeNodeAllocation(sb *strings.Builder, alloc NodeAllocation) {
+	sb.WriteString(fmt.Sprintf("\nNon-terminated Pods: (%d in total)\n", len(alloc.Pods)))
+	tw := tabwriter.NewWriter(sb, 0, 0, 2, ' ', 0)
+	if len(alloc.Pods) == 0 {
+		sb.WriteString("  <none>\n")
+	} else {
+		fmt.Fprintln(tw, "  Namespace\tName\tCPU Requests\tCPU Limits\tMemory Requests\tMemory Limits")
+		for _, p := range alloc.Pods {
+			fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\t%s\t%s\n", p.Namespace, p.Name,
+				percentOf(p.CPURequests, alloc.AllocatableCPU), percentOf(p.CPULimits, alloc.AllocatableCPU),
+				percentOf(p.MemoryRequests, alloc.AllocatableMemory), percentOf(p.MemoryLimits, alloc.AllocatableMemory))
+		}
+		tw.Flush()
+	}
+
+	sb.WriteString("\nAllocated resources:\n")
+	sb.WriteString("  (Total limits may be over 100 percent, i.e., overcommitted.)\n")
+	fmt.Fprintln(tw, "  Resource\tRequests\tLimits")
+	fmt.Fprintf(tw, "  cpu\t%s\t%s\n", percentOf(alloc.CPURequests, alloc.AllocatableCPU), percentOf(alloc.CPULimits, alloc.AllocatableCPU))
+	fmt.Fprintf(tw, "  memory\t%s\t%s\n", percentOf(alloc.MemoryRequests, alloc.AllocatableMemory), percentOf(alloc.MemoryLimits, alloc.AllocatableMemory))
+	tw.Flush()
+}
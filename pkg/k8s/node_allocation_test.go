@@ -0,0 +1,99 @@
+package k8s
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func allocationPod(name string, phase corev1.PodPhase, cpu, mem string) corev1.Pod {
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: corev1.PodSpec{
+			NodeName: "node-1",
+			Containers: []corev1.Container{{
+				Name: "app",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse(cpu),
+						corev1.ResourceMemory: resource.MustParse(mem),
+					},
+					Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse(mem)},
+				},
+			}},
+		},
+		Status: corev1.PodStatus{Phase: phase},
+	}
+}
+
+func TestComputeNodeAllocation(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+		Status: corev1.NodeStatus{Allocatable: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("2"),
+			corev1.ResourceMemory: resource.MustParse("4Gi"),
+		}},
+	}
+	withInit := allocationPod("web", corev1.PodRunning, "250m", "512Mi")
+	withInit.Spec.InitContainers = []corev1.Container{{
+		Name: "migrate",
+		Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{
+			corev1.ResourceCPU: resource.MustParse("500m"),
+		}},
+	}}
+	pods := []corev1.Pod{
+		withInit,
+		allocationPod("api", corev1.PodRunning, "250m", "512Mi"),
+		allocationPod("done", corev1.PodSucceeded, "1", "1Gi"),
+	}
+
+	alloc := ComputeNodeAllocation(node, pods)
+	if len(alloc.Pods) != 2 || alloc.Pods[0].Name != "api" {
+		t.Fatalf("unexpected pods: %+v", alloc.Pods)
+	}
+	if got := alloc.CPURequests.MilliValue(); got != 750 {
+		t.Errorf("CPU requests = %dm, want 750m (init container counts when larger)", got)
+	}
+	if got := alloc.MemoryRequests.String(); got != "1Gi" {
+		t.Errorf("memory requests = %s, want 1Gi", got)
+	}
+
+	var sb strings.Builder
+	writeNodeAllocation(&sb, alloc)
+	out := sb.String()
+	for _, want := range []string{
+		"Non-terminated Pods: (2 in total)",
+		"default    web",
+		"cpu       750m (37%)",
+		"memory    1Gi (25%)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output lacks %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestDescribeNodeAllocation(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+		Status: corev1.NodeStatus{Allocatable: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("1"),
+			corev1.ResourceMemory: resource.MustParse("1Gi"),
+		}},
+	}
+	pod := allocationPod("web", corev1.PodRunning, "500m", "256Mi")
+	client := &Client{Clientset: fake.NewSimpleClientset(node, &pod)}
+
+	out, err := client.DescribeResource(context.Background(), "nodes", "", "node-1")
+	if err != nil {
+		t.Fatalf("DescribeResource failed: %v", err)
+	}
+	if !strings.Contains(out, "Allocated resources:") || !strings.Contains(out, "cpu       500m (50%)") {
+		t.Errorf("describe lacks the allocation:\n%s", out)
+	}
+}
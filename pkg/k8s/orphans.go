@@ -0,0 +1,228 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+// OrphanInputs are the objects CheckOrphanedResources cross-references.
+// Workload templates count as references so that CronJobs between runs and
+// workloads scaled to zero do not make their config look unused.
+type OrphanInputs struct {
+	Pods            []corev1.Pod
+	Deployments     []appsv1.Deployment
+	StatefulSets    []appsv1.StatefulSet
+	DaemonSets      []appsv1.DaemonSet
+	CronJobs        []batchv1.CronJob
+	Ingresses       []networkingv1.Ingress
+	ServiceAccounts []corev1.ServiceAccount
+
+	ConfigMaps []corev1.ConfigMap
+	Secrets    []corev1.Secret
+	PVCs       []corev1.PersistentVolumeClaim
+}
+
+// orphanSkippedNamespaces hold objects consumed by the control plane through
+// the API rather than mounted by pods
+var orphanSkippedNamespaces = map[string]bool{
+	"kube-system":     true,
+	"kube-public":     true,
+	"kube-node-lease": true,
+}
+
+// orphanSkippedSecretTypes are managed by Kubernetes or tools, not by pods
+var orphanSkippedSecretTypes = map[corev1.SecretType]bool{
+	corev1.SecretTypeServiceAccountToken: true,
+	corev1.SecretTypeBootstrapToken:      true,
+	"helm.sh/release.v1":                 true,
+}
+
+// AnalyzeOrphans lists the objects of a namespace ("" for all namespaces)
+// and returns the ConfigMaps, Secrets and PVCs nothing references.
+func (c *Client) AnalyzeOrphans(ctx context.Context, namespace string) ([]Finding, error) {
+	var in OrphanInputs
+	var err error
+	if in.Pods, err = c.ListPods(ctx, namespace); err != nil {
+		return nil, err
+	}
+	if in.Deployments, err = c.ListDeployments(ctx, namespace); err != nil {
+		return nil, err
+	}
+	if in.StatefulSets, err = c.ListStatefulSets(ctx, namespace); err != nil {
+		return nil, err
+	}
+	if in.DaemonSets, err = c.ListDaemonSets(ctx, namespace); err != nil {
+		return nil, err
+	}
+	if in.CronJobs, err = c.ListCronJobs(ctx, namespace); err != nil {
+		return nil, err
+	}
+	if in.Ingresses, err = c.ListIngresses(ctx, namespace); err != nil {
+		return nil, err
+	}
+	if in.ServiceAccounts, err = c.ListServiceAccounts(ctx, namespace); err != nil {
+		return nil, err
+	}
+	if in.ConfigMaps, err = c.ListConfigMaps(ctx, namespace); err != nil {
+		return nil, err
+	}
+	if in.Secrets, err = c.ListSecrets(ctx, namespace); err != nil {
+		return nil, err
+	}
+	if in.PVCs, err = c.ListPersistentVolumeClaims(ctx, namespace); err != nil {
+		return nil, err
+	}
+	return CheckOrphanedResources(in), nil
+}
+
+// CheckOrphanedResources flags ConfigMaps and Secrets that no pod, workload
+// template, Ingress or ServiceAccount references, and PVCs that no pod or
+// workload mounts. Objects with an owner, the per-namespace kube-root-ca.crt
+// and system namespaces are skipped; the rest are cleanup candidates, not
+// proof of disuse.
+func CheckOrphanedResources(in OrphanInputs) []Finding {
+	refs := make(map[string]bool)
+	ref := func(kind, namespace, name string) {
+		refs[kind+"/"+namespace+"/"+name] = true
+	}
+
+	for _, p := range in.Pods {
+		addPodSpecRefs(ref, p.Namespace, p.Spec)
+	}
+	for _, d := range in.Deployments {
+		addPodSpecRefs(ref, d.Namespace, d.Spec.Template.Spec)
+	}
+	var claimPrefixes []string
+	for _, s := range in.StatefulSets {
+		addPodSpecRefs(ref, s.Namespace, s.Spec.Template.Spec)
+		// PVCs created from claim templates are named <template>-<sts>-<ordinal>
+		for _, tmpl := range s.Spec.VolumeClaimTemplates {
+			claimPrefixes = append(claimPrefixes, s.Namespace+"/"+tmpl.Name+"-"+s.Name+"-")
+		}
+	}
+	for _, ds := range in.DaemonSets {
+		addPodSpecRefs(ref, ds.Namespace, ds.Spec.Template.Spec)
+	}
+	for _, cj := range in.CronJobs {
+		addPodSpecRefs(ref, cj.Namespace, cj.Spec.JobTemplate.Spec.Template.Spec)
+	}
+	for _, ing := range in.Ingresses {
+		for _, t := range ing.Spec.TLS {
+			ref("Secret", ing.Namespace, t.SecretName)
+		}
+	}
+	for _, sa := range in.ServiceAccounts {
+		for _, s := range sa.Secrets {
+			ref("Secret", sa.Namespace, s.Name)
+		}
+		for _, s := range sa.ImagePullSecrets {
+			ref("Secret", sa.Namespace, s.Name)
+		}
+	}
+
+	var findings []Finding
+	unused := func(check, kind, namespace, name, message string) {
+		findings = append(findings, Finding{Check: check, Kind: kind, Namespace: namespace, Name: name, Message: message})
+	}
+
+	for _, cm := range in.ConfigMaps {
+		if orphanSkippedNamespaces[cm.Namespace] || len(cm.OwnerReferences) > 0 || cm.Name == "kube-root-ca.crt" {
+			continue
+		}
+		if !refs["ConfigMap/"+cm.Namespace+"/"+cm.Name] {
+			unused(CheckUnusedConfigMap, "ConfigMap", cm.Namespace, cm.Name, "not referenced by any pod or workload")
+		}
+	}
+	for _, s := range in.Secrets {
+		if orphanSkippedNamespaces[s.Namespace] || len(s.OwnerReferences) > 0 || orphanSkippedSecretTypes[s.Type] {
+			continue
+		}
+		if !refs["Secret/"+s.Namespace+"/"+s.Name] {
+			unused(CheckUnusedSecret, "Secret", s.Namespace, s.Name, "not referenced by any pod, workload, Ingress or ServiceAccount")
+		}
+	}
+	for _, pvc := range in.PVCs {
+		if orphanSkippedNamespaces[pvc.Namespace] || refs["PersistentVolumeClaim/"+pvc.Namespace+"/"+pvc.Name] {
+			continue
+		}
+		if hasAnyPrefix(pvc.Namespace+"/"+pvc.Name, claimPrefixes) {
+			continue
+		}
+		msg := "not mounted by any pod or workload"
+		if size, ok := pvc.Status.Capacity[corev1.ResourceStorage]; ok {
+			msg = fmt.Sprintf("%s (%s)", msg, size.String())
+		}
+		unused(CheckUnusedPVC, "PersistentVolumeClaim", pvc.Namespace, pvc.Name, msg)
+	}
+
+	return findings
+}
+
+// addPodSpecRefs records the ConfigMaps, Secrets and PVCs a pod spec uses via
+// volumes, env, envFrom and image pull secrets
+func addPodSpecRefs(ref func(kind, namespace, name string), namespace string, spec corev1.PodSpec) {
+	for _, s := range spec.ImagePullSecrets {
+		ref("Secret", namespace, s.Name)
+	}
+
+	for _, v := range spec.Volumes {
+		switch {
+		case v.ConfigMap != nil:
+			ref("ConfigMap", namespace, v.ConfigMap.Name)
+		case v.Secret != nil:
+			ref("Secret", namespace, v.Secret.SecretName)
+		case v.PersistentVolumeClaim != nil:
+			ref("PersistentVolumeClaim", namespace, v.PersistentVolumeClaim.ClaimName)
+		case v.Projected != nil:
+			for _, src := range v.Projected.Sources {
+				if src.ConfigMap != nil {
+					ref("ConfigMap", namespace, src.ConfigMap.Name)
+				}
+				if src.Secret != nil {
+					ref("Secret", namespace, src.Secret.Name)
+				}
+			}
+		}
+	}
+
+	containers := append(append([]corev1.Container{}, spec.InitContainers...), spec.Containers...)
+	for _, ec := range spec.EphemeralContainers {
+		containers = append(containers, corev1.Container(ec.EphemeralContainerCommon))
+	}
+	for _, ctr := range containers {
+		for _, from := range ctr.EnvFrom {
+			if from.ConfigMapRef != nil {
+				ref("ConfigMap", namespace, from.ConfigMapRef.Name)
+			}
+			if from.SecretRef != nil {
+				ref("Secret", namespace, from.SecretRef.Name)
+			}
+		}
+		for _, env := range ctr.Env {
+			if env.ValueFrom == nil {
+				continue
+			}
+			if env.ValueFrom.ConfigMapKeyRef != nil {
+				ref("ConfigMap", namespace, env.ValueFrom.ConfigMapKeyRef.Name)
+			}
+			if env.ValueFrom.SecretKeyRef != nil {
+				ref("Secret", namespace, env.ValueFrom.SecretKeyRef.Name)
+			}
+		}
+	}
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(s, p) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,110 @@
+package k8s
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCheckOrphanedResources(t *testing.T) {
+	meta := func(name string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Name: name, Namespace: "default"}
+	}
+
+	in := OrphanInputs{
+		Pods: []corev1.Pod{{
+			ObjectMeta: meta("web-1"),
+			Spec: corev1.PodSpec{
+				Volumes: []corev1.Volume{
+					{Name: "cfg", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: "web-config"}}}},
+					{Name: "data", VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "web-data"}}},
+				},
+				Containers: []corev1.Container{{
+					Name:    "web",
+					EnvFrom: []corev1.EnvFromSource{{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "web-env"}}}},
+				}},
+			},
+		}},
+		CronJobs: []batchv1.CronJob{{
+			ObjectMeta: meta("backup"),
+			Spec: batchv1.CronJobSpec{JobTemplate: batchv1.JobTemplateSpec{Spec: batchv1.JobSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Env: []corev1.EnvVar{{
+					Name:      "TOKEN",
+					ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "backup-token"}}},
+				}}}},
+			}}}}},
+		}},
+		StatefulSets: []appsv1.StatefulSet{{
+			ObjectMeta: meta("db"),
+			Spec:       appsv1.StatefulSetSpec{VolumeClaimTemplates: []corev1.PersistentVolumeClaim{{ObjectMeta: metav1.ObjectMeta{Name: "data"}}}},
+		}},
+		Ingresses: []networkingv1.Ingress{{
+			ObjectMeta: meta("web"),
+			Spec:       networkingv1.IngressSpec{TLS: []networkingv1.IngressTLS{{SecretName: "web-tls"}}},
+		}},
+		ConfigMaps: []corev1.ConfigMap{
+			{ObjectMeta: meta("web-config")},
+			{ObjectMeta: meta("old-config")},
+			{ObjectMeta: meta("kube-root-ca.crt")},
+			{ObjectMeta: metav1.ObjectMeta{Name: "coredns", Namespace: "kube-system"}},
+		},
+		Secrets: []corev1.Secret{
+			{ObjectMeta: meta("web-env")},
+			{ObjectMeta: meta("backup-token")},
+			{ObjectMeta: meta("web-tls")},
+			{ObjectMeta: meta("old-password")},
+			{ObjectMeta: meta("release"), Type: "helm.sh/release.v1"},
+		},
+		PVCs: []corev1.PersistentVolumeClaim{
+			{ObjectMeta: meta("web-data")},
+			{ObjectMeta: meta("data-db-0")},
+			{ObjectMeta: meta("scratch"), Status: corev1.PersistentVolumeClaimStatus{
+				Capacity: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+			}},
+		},
+	}
+
+	findings := CheckOrphanedResources(in)
+
+	var got []string
+	for _, f := range findings {
+		got = append(got, f.Check+":"+f.Name)
+	}
+	sort.Strings(got)
+	want := []string{
+		CheckUnusedConfigMap + ":old-config",
+		CheckUnusedPVC + ":scratch",
+		CheckUnusedSecret + ":old-password",
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("CheckOrphanedResources = %v, want %v", got, want)
+	}
+	for _, f := range findings {
+		if f.Check == CheckUnusedPVC && !strings.Contains(f.Message, "10Gi") {
+			t.Errorf("expected PVC size in message, got %q", f.Message)
+		}
+	}
+}
+
+func TestAnalyzeOrphans(t *testing.T) {
+	client := &Client{Clientset: fake.NewSimpleClientset(
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "unused", Namespace: "default"}},
+	)}
+
+	findings, err := client.AnalyzeOrphans(context.Background(), "default")
+	if err != nil {
+		t.Fatalf("AnalyzeOrphans failed: %v", err)
+	}
+	if len(findings) != 1 || findings[0].Check != CheckUnusedConfigMap {
+		t.Errorf("expected one unused ConfigMap, got %+v", findings)
+	}
+}
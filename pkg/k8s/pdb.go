@@ -0,0 +1,96 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// AnalyzePDBCoverage cross-references workloads and PodDisruptionBudgets in a
+// namespace ("" for all namespaces) and returns the coverage findings.
+func (c *Client) AnalyzePDBCoverage(ctx context.Context, namespace string) ([]Finding, error) {
+	deployments, err := c.ListDeployments(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	statefulsets, err := c.ListStatefulSets(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	pdbs, err := c.ListPodDisruptionBudgets(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	return CheckPDBCoverage(deployments, statefulsets, pdbs), nil
+}
+
+// CheckPDBCoverage flags deployments and statefulsets whose pods are not
+// selected by any PodDisruptionBudget, and PDBs that currently allow zero
+// disruptions and would therefore block a node drain. Workloads scaled to
+// zero are skipped.
+func CheckPDBCoverage(deployments []appsv1.Deployment, statefulsets []appsv1.StatefulSet, pdbs []policyv1.PodDisruptionBudget) []Finding {
+	var findings []Finding
+
+	for _, pdb := range pdbs {
+		if pdb.Status.DisruptionsAllowed == 0 {
+			findings = append(findings, Finding{
+				Check:     CheckPDBBlocking,
+				Kind:      "PodDisruptionBudget",
+				Namespace: pdb.Namespace,
+				Name:      pdb.Name,
+				Message: fmt.Sprintf("allows 0 disruptions (%d/%d healthy), node drains will block",
+					pdb.Status.CurrentHealthy, pdb.Status.DesiredHealthy),
+			})
+		}
+	}
+
+	check := func(kind, namespace, name string, replicas *int32, podLabels map[string]string) {
+		if replicas != nil && *replicas == 0 {
+			return
+		}
+		if !pdbCovers(pdbs, namespace, podLabels) {
+			findings = append(findings, Finding{
+				Check:     CheckPDBMissing,
+				Kind:      kind,
+				Namespace: namespace,
+				Name:      name,
+				Message:   "no PodDisruptionBudget selects its pods",
+			})
+		}
+	}
+	for _, d := range deployments {
+		check("Deployment", d.Namespace, d.Name, d.Spec.Replicas, d.Spec.Template.Labels)
+	}
+	for _, s := range statefulsets {
+		check("StatefulSet", s.Namespace, s.Name, s.Spec.Replicas, s.Spec.Template.Labels)
+	}
+
+	return findings
+}
+
+// pdbCovers reports whether any PDB in namespace selects pods with podLabels
+func pdbCovers(pdbs []policyv1.PodDisruptionBudget, namespace string, podLabels map[string]string) bool {
+	for _, pdb := range pdbs {
+		if pdbSelects(pdb, namespace, podLabels) {
+			return true
+		}
+	}
+	return false
+}
+
+// pdbSelects reports whether pdb selects a pod in namespace with podLabels.
+// Like the disruption controller, a PDB with an empty selector matches nothing.
+func pdbSelects(pdb policyv1.PodDisruptionBudget, namespace string, podLabels map[string]string) bool {
+	if pdb.Namespace != namespace || pdb.Spec.Selector == nil {
+		return false
+	}
+	sel, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+	if err != nil || sel.Empty() {
+		return false
+	}
+	return sel.Matches(labels.Set(podLabels))
+}
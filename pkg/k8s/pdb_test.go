@@ -0,0 +1,77 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func testDeployment(name string, replicas int32, podLabels map[string]string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: podLabels}},
+		},
+	}
+}
+
+func testPDB(name string, matchLabels map[string]string, allowed int32) *policyv1.PodDisruptionBudget {
+	return &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec:       policyv1.PodDisruptionBudgetSpec{Selector: &metav1.LabelSelector{MatchLabels: matchLabels}},
+		Status:     policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: allowed},
+	}
+}
+
+func TestCheckPDBCoverage(t *testing.T) {
+	deployments := []appsv1.Deployment{
+		*testDeployment("web", 3, map[string]string{"app": "web", "tier": "frontend"}),
+		*testDeployment("worker", 2, map[string]string{"app": "worker"}),
+		*testDeployment("idle", 0, map[string]string{"app": "idle"}),
+	}
+	pdbs := []policyv1.PodDisruptionBudget{
+		*testPDB("web-pdb", map[string]string{"app": "web"}, 1),
+		*testPDB("db-pdb", map[string]string{"app": "db"}, 0),
+		*testPDB("catch-all", nil, 1),
+	}
+
+	findings := CheckPDBCoverage(deployments, nil, pdbs)
+	if len(findings) != 2 {
+		t.Fatalf("expected 2 findings, got %+v", findings)
+	}
+	if findings[0].Check != CheckPDBBlocking || findings[0].Name != "db-pdb" {
+		t.Errorf("expected blocking finding for db-pdb, got %+v", findings[0])
+	}
+	if findings[1].Check != CheckPDBMissing || findings[1].Name != "worker" {
+		t.Errorf("expected missing-PDB finding for worker, got %+v", findings[1])
+	}
+}
+
+func TestAnalyzePDBCoverage(t *testing.T) {
+	replicas := int32(2)
+	clientset := fake.NewSimpleClientset(
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"},
+			Spec: appsv1.StatefulSetSpec{
+				Replicas: &replicas,
+				Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "db"}}},
+			},
+		},
+		testPDB("db-pdb", map[string]string{"app": "db"}, 1),
+	)
+	client := &Client{Clientset: clientset}
+
+	findings, err := client.AnalyzePDBCoverage(context.Background(), "default")
+	if err != nil {
+		t.Fatalf("AnalyzePDBCoverage failed: %v", err)
+	}
+	if len(findings) != 0 {
+		t.Errorf("expected covered statefulset to produce no findings, got %+v", findings)
+	}
+}
@@ -0,0 +1,101 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// ResolveServicePort picks a running pod behind a service and the container
+// port that service port targets, like kubectl port-forward svc/<name>.
+// port is the service port.
+func (c *Client) ResolveServicePort(ctx context.Context, namespace, service string, port int) (string, int, error) {
+	svc, err := c.Clientset.CoreV1().Services(namespace).Get(ctx, service, metav1.GetOptions{})
+	if err != nil {
+		return "", 0, err
+	}
+	if len(svc.Spec.Selector) == 0 {
+		return "", 0, fmt.Errorf("service %s has no selector", service)
+	}
+
+	var svcPort *corev1.ServicePort
+	for i, p := range svc.Spec.Ports {
+		if int(p.Port) == port {
+			svcPort = &svc.Spec.Ports[i]
+			break
+		}
+	}
+	if svcPort == nil {
+		return "", 0, fmt.Errorf("service %s has no port %d", service, port)
+	}
+
+	pods, err := c.Clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(svc.Spec.Selector).String(),
+	})
+	if err != nil {
+		return "", 0, err
+	}
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodRunning || pod.DeletionTimestamp != nil {
+			continue
+		}
+		if podPort, ok := podTargetPort(&pod, svcPort.TargetPort, svcPort.Port); ok {
+			return pod.Name, podPort, nil
+		}
+	}
+	return "", 0, fmt.Errorf("no running pod of service %s serves port %d", service, port)
+}
+
+// ServiceAddress returns the host:port of a service's ClusterIP for one of
+// its ports, the first when port is 0. Headless services have no address.
+func (c *Client) ServiceAddress(ctx context.Context, namespace, service string, port int) (string, error) {
+	svc, err := c.Clientset.CoreV1().Services(namespace).Get(ctx, service, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	if svc.Spec.ClusterIP == "" || svc.Spec.ClusterIP == corev1.ClusterIPNone {
+		return "", fmt.Errorf("service %s is headless and has no cluster IP", service)
+	}
+	if len(svc.Spec.Ports) == 0 {
+		return "", fmt.Errorf("service %s exposes no ports", service)
+	}
+
+	if port == 0 {
+		port = int(svc.Spec.Ports[0].Port)
+	} else {
+		found := false
+		for _, p := range svc.Spec.Ports {
+			found = found || int(p.Port) == port
+		}
+		if !found {
+			return "", fmt.Errorf("service %s has no port %d", service, port)
+		}
+	}
+	return net.JoinHostPort(svc.Spec.ClusterIP, strconv.Itoa(port)), nil
+}
+
+// podTargetPort resolves a service target port against a pod's containers.
+// An unset target port means the service port itself.
+func podTargetPort(pod *corev1.Pod, target intstr.IntOrString, svcPort int32) (int, bool) {
+	switch {
+	case target.Type == intstr.String && target.StrVal != "":
+		for _, ctr := range pod.Spec.Containers {
+			for _, p := range ctr.Ports {
+				if p.Name == target.StrVal {
+					return int(p.ContainerPort), true
+				}
+			}
+		}
+		return 0, false
+	case target.IntVal != 0:
+		return int(target.IntVal), true
+	default:
+		return int(svcPort), true
+	}
+}
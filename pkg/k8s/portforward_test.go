@@ -0,0 +1,91 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestResolveServicePort(t *testing.T) {
+	ctx := context.Background()
+	pod := func(name string, phase corev1.PodPhase) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{"app": "web"}},
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{
+				Name:  "app",
+				Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: 8080}},
+			}}},
+			Status: corev1.PodStatus{Phase: phase},
+		}
+	}
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app": "web"},
+			Ports: []corev1.ServicePort{
+				{Name: "http", Port: 80, TargetPort: intstr.FromString("http")},
+				{Name: "metrics", Port: 9090, TargetPort: intstr.FromInt32(9091)},
+				{Name: "admin", Port: 7000},
+			},
+		},
+	}
+	client := &Client{Clientset: fake.NewSimpleClientset(svc, pod("web-pending", corev1.PodPending), pod("web-1", corev1.PodRunning))}
+
+	tests := []struct {
+		port    int
+		wantPod string
+		want    int
+	}{
+		{80, "web-1", 8080},   // named target port
+		{9090, "web-1", 9091}, // numeric target port
+		{7000, "web-1", 7000}, // no target port
+	}
+	for _, tt := range tests {
+		podName, podPort, err := client.ResolveServicePort(ctx, "default", "web", tt.port)
+		if err != nil {
+			t.Errorf("port %d: %v", tt.port, err)
+			continue
+		}
+		if podName != tt.wantPod || podPort != tt.want {
+			t.Errorf("port %d: got %s:%d, want %s:%d", tt.port, podName, podPort, tt.wantPod, tt.want)
+		}
+	}
+
+	if _, _, err := client.ResolveServicePort(ctx, "default", "web", 443); err == nil {
+		t.Error("expected an error for a port the service doesn't expose")
+	}
+}
+
+func TestServiceAddress(t *testing.T) {
+	ctx := context.Background()
+	client := &Client{Clientset: fake.NewSimpleClientset(
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+			Spec: corev1.ServiceSpec{
+				ClusterIP: "10.0.0.10",
+				Ports:     []corev1.ServicePort{{Port: 80}, {Port: 9090}},
+			},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"},
+			Spec:       corev1.ServiceSpec{ClusterIP: corev1.ClusterIPNone, Ports: []corev1.ServicePort{{Port: 5432}}},
+		},
+	)}
+
+	for port, want := range map[int]string{0: "10.0.0.10:80", 9090: "10.0.0.10:9090"} {
+		addr, err := client.ServiceAddress(ctx, "default", "web", port)
+		if err != nil || addr != want {
+			t.Errorf("port %d: got %q, %v, want %q", port, addr, err, want)
+		}
+	}
+	if _, err := client.ServiceAddress(ctx, "default", "web", 443); err == nil {
+		t.Error("expected an error for a port the service doesn't expose")
+	}
+	if _, err := client.ServiceAddress(ctx, "default", "db", 0); err == nil {
+		t.Error("expected an error for a headless service")
+	}
+}
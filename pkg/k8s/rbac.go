@@ -0,0 +1,113 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+// rbacDefaultsLabel marks the roles and bindings the API server bootstraps
+const rbacDefaultsLabel = "kubernetes.io/bootstrapping"
+
+// AnalyzeRBAC checks the roles and bindings of a namespace. Cluster roles and
+// cluster role bindings are only checked for all namespaces ("").
+func (c *Client) AnalyzeRBAC(ctx context.Context, namespace string) ([]Finding, error) {
+	roles, err := c.ListRoles(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	bindings, err := c.ListRoleBindings(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	var clusterRoles []rbacv1.ClusterRole
+	var clusterBindings []rbacv1.ClusterRoleBinding
+	if namespace == "" {
+		if clusterRoles, err = c.ListClusterRoles(ctx); err != nil {
+			return nil, err
+		}
+		if clusterBindings, err = c.ListClusterRoleBindings(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return CheckRBAC(roles, clusterRoles, bindings, clusterBindings), nil
+}
+
+// CheckRBAC flags roles with wildcard verbs or resources and bindings that
+// grant cluster-admin to service accounts, users or groups. Built-in roles
+// and bindings (system: names or the bootstrapping label) are skipped.
+func CheckRBAC(roles []rbacv1.Role, clusterRoles []rbacv1.ClusterRole, bindings []rbacv1.RoleBinding, clusterBindings []rbacv1.ClusterRoleBinding) []Finding {
+	var findings []Finding
+
+	checkRules := func(kind, namespace, name string, labels map[string]string, rules []rbacv1.PolicyRule) {
+		if isBuiltinRBAC(name, labels) {
+			return
+		}
+		for _, rule := range rules {
+			verbs, resources := contains(rule.Verbs, "*"), contains(rule.Resources, "*")
+			if !verbs && !resources {
+				continue
+			}
+			findings = append(findings, Finding{
+				Check:     CheckRBACWildcard,
+				Kind:      kind,
+				Namespace: namespace,
+				Name:      name,
+				Message: fmt.Sprintf("grants verbs [%s] on resources [%s]",
+					strings.Join(rule.Verbs, ","), strings.Join(rule.Resources, ",")),
+			})
+			break
+		}
+	}
+	for _, r := range roles {
+		checkRules("Role", r.Namespace, r.Name, r.Labels, r.Rules)
+	}
+	for _, r := range clusterRoles {
+		checkRules("ClusterRole", "", r.Name, r.Labels, r.Rules)
+	}
+
+	checkBinding := func(kind, namespace, name string, labels map[string]string, ref rbacv1.RoleRef, subjects []rbacv1.Subject) {
+		if ref.Kind != "ClusterRole" || ref.Name != "cluster-admin" || isBuiltinRBAC(name, labels) {
+			return
+		}
+		for _, s := range subjects {
+			if strings.HasPrefix(s.Name, "system:") {
+				continue
+			}
+			subject := s.Kind + " " + s.Name
+			if s.Namespace != "" {
+				subject = fmt.Sprintf("%s %s/%s", s.Kind, s.Namespace, s.Name)
+			}
+			findings = append(findings, Finding{
+				Check:     CheckRBACClusterAdmin,
+				Kind:      kind,
+				Namespace: namespace,
+				Name:      name,
+				Message:   fmt.Sprintf("grants cluster-admin to %s", subject),
+			})
+		}
+	}
+	for _, b := range bindings {
+		checkBinding("RoleBinding", b.Namespace, b.Name, b.Labels, b.RoleRef, b.Subjects)
+	}
+	for _, b := range clusterBindings {
+		checkBinding("ClusterRoleBinding", "", b.Name, b.Labels, b.RoleRef, b.Subjects)
+	}
+
+	return findings
+}
+
+func isBuiltinRBAC(name string, labels map[string]string) bool {
+	return strings.HasPrefix(name, "system:") || labels[rbacDefaultsLabel] == "rbac-defaults"
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,39 @@
+package k8s
+
+import (
+	"testing"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCheckRBAC(t *testing.T) {
+	roles := []rbacv1.Role{
+		{ObjectMeta: metav1.ObjectMeta{Name: "everything", Namespace: "shop"},
+			Rules: []rbacv1.PolicyRule{{Verbs: []string{"*"}, Resources: []string{"pods"}}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "reader", Namespace: "shop"},
+			Rules: []rbacv1.PolicyRule{{Verbs: []string{"get", "list"}, Resources: []string{"pods"}}}},
+	}
+	clusterRoles := []rbacv1.ClusterRole{
+		{ObjectMeta: metav1.ObjectMeta{Name: "cluster-admin", Labels: map[string]string{rbacDefaultsLabel: "rbac-defaults"}},
+			Rules: []rbacv1.PolicyRule{{Verbs: []string{"*"}, Resources: []string{"*"}}}},
+	}
+	clusterAdmin := rbacv1.RoleRef{Kind: "ClusterRole", Name: "cluster-admin"}
+	clusterBindings := []rbacv1.ClusterRoleBinding{
+		{ObjectMeta: metav1.ObjectMeta{Name: "ci-admin"}, RoleRef: clusterAdmin,
+			Subjects: []rbacv1.Subject{{Kind: "ServiceAccount", Namespace: "ci", Name: "deployer"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "masters"}, RoleRef: clusterAdmin,
+			Subjects: []rbacv1.Subject{{Kind: "Group", Name: "system:masters"}}},
+	}
+
+	findings := CheckRBAC(roles, clusterRoles, nil, clusterBindings)
+	if len(findings) != 2 {
+		t.Fatalf("expected 2 findings, got %+v", findings)
+	}
+	if findings[0].Check != CheckRBACWildcard || findings[0].Name != "everything" {
+		t.Errorf("expected wildcard finding for everything, got %+v", findings[0])
+	}
+	if findings[1].Check != CheckRBACClusterAdmin || findings[1].Message != "grants cluster-admin to ServiceAccount ci/deployer" {
+		t.Errorf("expected cluster-admin finding for ci-admin, got %+v", findings[1])
+	}
+}
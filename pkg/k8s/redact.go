@@ -0,0 +1,70 @@
+package k8s
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// RedactedValue replaces secret values in redacted output
+const RedactedValue = "<redacted>"
+
+// lastAppliedAnnotation holds a full copy of the applied manifest, which for
+// Secrets includes the data
+const lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// RedactSecret replaces every value in a Secret's data and stringData with
+// RedactedValue, keeping the keys, and drops the last-applied annotation.
+// Objects of any other kind are left untouched.
+func RedactSecret(obj *unstructured.Unstructured) {
+	if obj == nil || obj.GetKind() != "Secret" {
+		return
+	}
+
+	for _, field := range []string{"data", "stringData"} {
+		values, ok := obj.Object[field].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for k := range values {
+			values[k] = RedactedValue
+		}
+	}
+
+	if annotations := obj.GetAnnotations(); annotations != nil {
+		if _, ok := annotations[lastAppliedAnnotation]; ok {
+			delete(annotations, lastAppliedAnnotation)
+			obj.SetAnnotations(annotations)
+		}
+	}
+}
+
+// FormatSecretDescription describes a Secret like kubectl describe, listing
+// only key names and value sizes
+func FormatSecretDescription(secret *corev1.Secret) string {
+	var result strings.Builder
+
+	result.WriteString(fmt.Sprintf("Name:         %s\n", secret.Name))
+	result.WriteString(fmt.Sprintf("Namespace:    %s\n", secret.Namespace))
+	result.WriteString(fmt.Sprintf("Type:         %s\n", secret.Type))
+
+	result.WriteString("\nLabels:\n")
+	for k, v := range secret.Labels {
+		result.WriteString(fmt.Sprintf("  %s=%s\n", k, v))
+	}
+
+	result.WriteString("\nData:\n")
+	keys := make([]string, 0, len(secret.Data))
+	for k := range secret.Data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		result.WriteString(fmt.Sprintf("  %s:  %d bytes\n", k, len(secret.Data[k])))
+	}
+
+	return result.String()
+}
@@ -0,0 +1,64 @@
+package k8s
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestRedactSecret(t *testing.T) {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"metadata": map[string]interface{}{
+			"name": "db",
+			"annotations": map[string]interface{}{
+				lastAppliedAnnotation: `{"data":{"password":"aHVudGVyMg=="}}`,
+				"team":                "payments",
+			},
+		},
+		"data":       map[string]interface{}{"password": "aHVudGVyMg=="},
+		"stringData": map[string]interface{}{"user": "admin"},
+	}}
+
+	RedactSecret(obj)
+
+	data, _, _ := unstructured.NestedStringMap(obj.Object, "data")
+	stringData, _, _ := unstructured.NestedStringMap(obj.Object, "stringData")
+	if data["password"] != RedactedValue || stringData["user"] != RedactedValue {
+		t.Errorf("expected values to be redacted, got data=%v stringData=%v", data, stringData)
+	}
+	annotations := obj.GetAnnotations()
+	if _, ok := annotations[lastAppliedAnnotation]; ok {
+		t.Error("expected last-applied annotation to be removed")
+	}
+	if annotations["team"] != "payments" {
+		t.Errorf("expected other annotations to be kept, got %v", annotations)
+	}
+
+	cm := &unstructured.Unstructured{Object: map[string]interface{}{
+		"kind": "ConfigMap",
+		"data": map[string]interface{}{"key": "value"},
+	}}
+	RedactSecret(cm)
+	if v, _, _ := unstructured.NestedString(cm.Object, "data", "key"); v != "value" {
+		t.Errorf("expected ConfigMap to be left untouched, got %q", v)
+	}
+}
+
+func TestFormatSecretDescription(t *testing.T) {
+	out := FormatSecretDescription(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"},
+		Type:       corev1.SecretTypeOpaque,
+		Data:       map[string][]byte{"password": []byte("hunter2")},
+	})
+	if !strings.Contains(out, "password:  7 bytes") {
+		t.Errorf("expected key size in description:\n%s", out)
+	}
+	if strings.Contains(out, "hunter2") {
+		t.Errorf("description must not contain secret values:\n%s", out)
+	}
+}
@@ -0,0 +1,98 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// LimitRequestRatioThreshold is the limit/request ratio above which a
+// container is flagged: it can burst far beyond what the scheduler reserved
+// and starve its neighbours.
+const LimitRequestRatioThreshold = 4.0
+
+// AnalyzeResourceRatios checks the container requests and limits of the
+// workloads in a namespace ("" for all namespaces).
+func (c *Client) AnalyzeResourceRatios(ctx context.Context, namespace string) ([]Finding, error) {
+	deployments, err := c.ListDeployments(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	statefulsets, err := c.ListStatefulSets(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	daemonsets, err := c.ListDaemonSets(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	return CheckResourceRatios(deployments, statefulsets, daemonsets), nil
+}
+
+// CheckResourceRatios flags workload containers whose limit is below the
+// request, whose limit exceeds LimitRequestRatioThreshold times the request,
+// or that set a request without a limit. Findings are ordered by severity,
+// with the highest ratios first.
+func CheckResourceRatios(deployments []appsv1.Deployment, statefulsets []appsv1.StatefulSet, daemonsets []appsv1.DaemonSet) []Finding {
+	type ranked struct {
+		finding Finding
+		rank    int
+		ratio   float64
+	}
+	var all []ranked
+
+	check := func(kind, namespace, name string, spec corev1.PodSpec) {
+		for _, ctr := range append(append([]corev1.Container{}, spec.InitContainers...), spec.Containers...) {
+			for _, res := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+				req, hasReq := ctr.Resources.Requests[res]
+				limit, hasLimit := ctr.Resources.Limits[res]
+				if !hasReq || req.IsZero() {
+					continue
+				}
+
+				f := Finding{Kind: kind, Namespace: namespace, Name: name}
+				switch {
+				case !hasLimit:
+					f.Check = CheckLimitMissing
+					f.Message = fmt.Sprintf("container %q requests %s %s but sets no limit", ctr.Name, req.String(), res)
+					all = append(all, ranked{f, 2, 0})
+				case limit.Cmp(req) < 0:
+					f.Check = CheckLimitBelowRequest
+					f.Message = fmt.Sprintf("container %q %s limit %s is below its request %s", ctr.Name, res, limit.String(), req.String())
+					all = append(all, ranked{f, 0, 0})
+				default:
+					ratio := float64(limit.MilliValue()) / float64(req.MilliValue())
+					if ratio > LimitRequestRatioThreshold {
+						f.Check = CheckLimitRatioHigh
+						f.Message = fmt.Sprintf("container %q %s limit %s is %.1fx its request %s", ctr.Name, res, limit.String(), ratio, req.String())
+						all = append(all, ranked{f, 1, ratio})
+					}
+				}
+			}
+		}
+	}
+	for _, d := range deployments {
+		check("Deployment", d.Namespace, d.Name, d.Spec.Template.Spec)
+	}
+	for _, s := range statefulsets {
+		check("StatefulSet", s.Namespace, s.Name, s.Spec.Template.Spec)
+	}
+	for _, ds := range daemonsets {
+		check("DaemonSet", ds.Namespace, ds.Name, ds.Spec.Template.Spec)
+	}
+
+	sort.SliceStable(all, func(i, j int) bool {
+		if all[i].rank != all[j].rank {
+			return all[i].rank < all[j].rank
+		}
+		return all[i].ratio > all[j].ratio
+	})
+	findings := make([]Finding, 0, len(all))
+	for _, r := range all {
+		findings = append(findings, r.finding)
+	}
+	return findings
+}
@@ -0,0 +1,80 @@
+package k8s
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func resourcesDeployment(name string, requests, limits corev1.ResourceList) appsv1.Deployment {
+	d := testDeployment(name, 1, map[string]string{"app": name})
+	d.Spec.Template.Spec.Containers = []corev1.Container{{
+		Name:      "app",
+		Resources: corev1.ResourceRequirements{Requests: requests, Limits: limits},
+	}}
+	return *d
+}
+
+func TestCheckResourceRatios(t *testing.T) {
+	deployments := []appsv1.Deployment{
+		resourcesDeployment("balanced",
+			corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+			corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")}),
+		resourcesDeployment("bursty",
+			corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+			corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")}),
+		resourcesDeployment("very-bursty",
+			corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("64Mi")},
+			corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")}),
+		resourcesDeployment("unbounded",
+			corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")}, nil),
+		resourcesDeployment("inverted",
+			corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+			corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")}),
+		resourcesDeployment("best-effort", nil, nil),
+	}
+
+	findings := CheckResourceRatios(deployments, nil, nil)
+
+	var got []string
+	for _, f := range findings {
+		got = append(got, f.Check+":"+f.Name)
+	}
+	want := []string{
+		CheckLimitBelowRequest + ":inverted",
+		CheckLimitRatioHigh + ":very-bursty",
+		CheckLimitRatioHigh + ":bursty",
+		CheckLimitMissing + ":unbounded",
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("CheckResourceRatios = %v, want %v", got, want)
+	}
+	if !strings.Contains(findings[2].Message, "10.0x") {
+		t.Errorf("expected ratio in message, got %q", findings[2].Message)
+	}
+}
+
+func TestAnalyzeFindings(t *testing.T) {
+	d := resourcesDeployment("unbounded", corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")}, nil)
+	client := &Client{Clientset: fake.NewSimpleClientset(&d, &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default"},
+	})}
+
+	findings, err := client.AnalyzeFindings(context.Background(), "default")
+	if err != nil {
+		t.Fatalf("AnalyzeFindings failed: %v", err)
+	}
+	checks := map[string]bool{}
+	for _, f := range findings {
+		checks[f.Check] = true
+	}
+	if !checks[CheckPDBMissing] || !checks[CheckLimitMissing] {
+		t.Errorf("expected PDB and resource findings, got %+v", findings)
+	}
+}
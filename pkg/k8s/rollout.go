@@ -0,0 +1,115 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// RolloutStatus summarizes how far a workload has converged on its spec
+type RolloutStatus struct {
+	Desired   int64  `json:"desired"`
+	Updated   int64  `json:"updated"`
+	Ready     int64  `json:"ready"`
+	Available int64  `json:"available"`
+	Done      bool   `json:"done"`
+	Message   string `json:"message"`
+}
+
+// RolloutStatusFromObject computes the rollout status of a Deployment,
+// StatefulSet, DaemonSet or ReplicaSet from its unstructured form.
+func RolloutStatusFromObject(obj *unstructured.Unstructured) RolloutStatus {
+	var st RolloutStatus
+	intField := func(fields ...string) int64 {
+		v, _, _ := unstructured.NestedInt64(obj.Object, fields...)
+		return v
+	}
+
+	generation := obj.GetGeneration()
+	observed := intField("status", "observedGeneration")
+
+	switch obj.GetKind() {
+	case "DaemonSet":
+		st.Desired = intField("status", "desiredNumberScheduled")
+		st.Updated = intField("status", "updatedNumberScheduled")
+		st.Ready = intField("status", "numberReady")
+		st.Available = intField("status", "numberAvailable")
+	case "ReplicaSet":
+		st.Desired = specReplicas(obj)
+		st.Updated = intField("status", "replicas")
+		st.Ready = intField("status", "readyReplicas")
+		st.Available = intField("status", "availableReplicas")
+	default: // Deployment, StatefulSet
+		st.Desired = specReplicas(obj)
+		st.Updated = intField("status", "updatedReplicas")
+		st.Ready = intField("status", "readyReplicas")
+		st.Available = intField("status", "availableReplicas")
+	}
+	total := intField("status", "replicas")
+
+	switch {
+	case observed < generation:
+		st.Message = "Waiting for controller to observe the change"
+	case st.Updated < st.Desired:
+		st.Message = fmt.Sprintf("%d of %d replicas updated", st.Updated, st.Desired)
+	case obj.GetKind() != "DaemonSet" && total > st.Updated:
+		st.Message = fmt.Sprintf("%d old replicas pending termination", total-st.Updated)
+	case st.Available < st.Desired:
+		st.Message = fmt.Sprintf("%d of %d updated replicas available", st.Available, st.Desired)
+	default:
+		st.Done = true
+		st.Message = "Rollout complete"
+	}
+	return st
+}
+
+func specReplicas(obj *unstructured.Unstructured) int64 {
+	replicas, found, _ := unstructured.NestedInt64(obj.Object, "spec", "replicas")
+	if !found {
+		return 1
+	}
+	return replicas
+}
+
+// GetRolloutStatus fetches a workload and returns its rollout status
+func (c *Client) GetRolloutStatus(ctx context.Context, gvr schema.GroupVersionResource, namespace, name string) (RolloutStatus, error) {
+	dyn, err := c.dynamicClient()
+	if err != nil {
+		return RolloutStatus{}, err
+	}
+	obj, err := dyn.Resource(gvr).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return RolloutStatus{}, err
+	}
+	return RolloutStatusFromObject(obj), nil
+}
+
+// WaitForRollout polls a workload until its rollout completes or ctx is done,
+// calling onProgress with every observed status.
+func (c *Client) WaitForRollout(ctx context.Context, gvr schema.GroupVersionResource, namespace, name string, interval time.Duration, onProgress func(RolloutStatus)) (RolloutStatus, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		st, err := c.GetRolloutStatus(ctx, gvr, namespace, name)
+		if err != nil {
+			return st, err
+		}
+		if onProgress != nil {
+			onProgress(st)
+		}
+		if st.Done {
+			return st, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return st, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
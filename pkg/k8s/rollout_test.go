@@ -0,0 +1,93 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func newDeployment(generation, observed, replicas, updated, total, available int64) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]interface{}{
+			"name":       "web",
+			"namespace":  "default",
+			"generation": generation,
+		},
+		"spec": map[string]interface{}{"replicas": replicas},
+		"status": map[string]interface{}{
+			"observedGeneration": observed,
+			"replicas":           total,
+			"updatedReplicas":    updated,
+			"readyReplicas":      available,
+			"availableReplicas":  available,
+		},
+	}}
+}
+
+func TestRolloutStatusFromObject(t *testing.T) {
+	tests := []struct {
+		name string
+		obj  *unstructured.Unstructured
+		done bool
+	}{
+		{"not observed", newDeployment(2, 1, 3, 3, 3, 3), false},
+		{"updating", newDeployment(2, 2, 3, 1, 4, 3), false},
+		{"old replicas pending", newDeployment(2, 2, 3, 3, 4, 3), false},
+		{"not available", newDeployment(2, 2, 3, 3, 3, 2), false},
+		{"complete", newDeployment(2, 2, 3, 3, 3, 3), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := RolloutStatusFromObject(tt.obj)
+			if st.Done != tt.done {
+				t.Errorf("Done = %v, expected %v (%s)", st.Done, tt.done, st.Message)
+			}
+		})
+	}
+}
+
+func TestRolloutStatusDaemonSet(t *testing.T) {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"kind":     "DaemonSet",
+		"metadata": map[string]interface{}{"generation": int64(1)},
+		"status": map[string]interface{}{
+			"observedGeneration":     int64(1),
+			"desiredNumberScheduled": int64(4),
+			"updatedNumberScheduled": int64(4),
+			"numberReady":            int64(4),
+			"numberAvailable":        int64(3),
+		},
+	}}
+
+	st := RolloutStatusFromObject(obj)
+	if st.Done || st.Desired != 4 || st.Available != 3 {
+		t.Errorf("unexpected daemonset status: %+v", st)
+	}
+}
+
+func TestWaitForRollout(t *testing.T) {
+	gvr := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{gvr: "DeploymentList"},
+		newDeployment(1, 1, 2, 2, 2, 2))
+	client := &Client{Dynamic: dyn}
+
+	var calls int
+	st, err := client.WaitForRollout(context.Background(), gvr, "default", "web", time.Millisecond, func(RolloutStatus) {
+		calls++
+	})
+	if err != nil {
+		t.Fatalf("WaitForRollout failed: %v", err)
+	}
+	if !st.Done || calls != 1 {
+		t.Errorf("expected completed rollout after one poll, got %+v (calls=%d)", st, calls)
+	}
+}
@@ -0,0 +1,206 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ScalePreview describes the expected impact of scaling a workload
+type ScalePreview struct {
+	Current int32
+	Target  int32
+
+	// Terminating lists the pods expected to be removed on scale-down
+	Terminating []string
+
+	// Per-pod requests and the extra capacity a scale-up needs
+	PodCPU           resource.Quantity
+	PodMemory        resource.Quantity
+	AdditionalCPU    resource.Quantity
+	AdditionalMemory resource.Quantity
+
+	// Unrequested allocatable capacity across schedulable nodes
+	FreeCPU    resource.Quantity
+	FreeMemory resource.Quantity
+	Fits       bool
+}
+
+// PreviewScale estimates what scaling a deployment, statefulset or replicaset
+// to target replicas would do, without changing anything.
+func (c *Client) PreviewScale(ctx context.Context, resourceType, namespace, name string, target int32) (*ScalePreview, error) {
+	var current int32 = 1
+	var selector *metav1.LabelSelector
+	var podSpec corev1.PodSpec
+	var kind string
+
+	switch resourceType {
+	case "deployments", "deploy":
+		d, err := c.Clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		if d.Spec.Replicas != nil {
+			current = *d.Spec.Replicas
+		}
+		selector, podSpec, kind = d.Spec.Selector, d.Spec.Template.Spec, "Deployment"
+	case "statefulsets", "sts":
+		s, err := c.Clientset.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		if s.Spec.Replicas != nil {
+			current = *s.Spec.Replicas
+		}
+		selector, podSpec, kind = s.Spec.Selector, s.Spec.Template.Spec, "StatefulSet"
+	case "replicasets", "rs":
+		r, err := c.Clientset.AppsV1().ReplicaSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		if r.Spec.Replicas != nil {
+			current = *r.Spec.Replicas
+		}
+		selector, podSpec, kind = r.Spec.Selector, r.Spec.Template.Spec, "ReplicaSet"
+	default:
+		return nil, fmt.Errorf("scale preview not supported for %s", resourceType)
+	}
+
+	preview := &ScalePreview{Current: current, Target: target}
+	preview.PodCPU, preview.PodMemory = PodSpecRequests(podSpec)
+
+	if target < current {
+		sel, err := metav1.LabelSelectorAsSelector(selector)
+		if err != nil {
+			return nil, err
+		}
+		pods, err := c.Clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: sel.String()})
+		if err != nil {
+			return nil, err
+		}
+		preview.Terminating = PodsToTerminate(kind, pods.Items, int(current-target))
+		return preview, nil
+	}
+
+	extra := int64(target - current)
+	preview.AdditionalCPU = *resource.NewMilliQuantity(preview.PodCPU.MilliValue()*extra, resource.DecimalSI)
+	preview.AdditionalMemory = *resource.NewQuantity(preview.PodMemory.Value()*extra, resource.BinarySI)
+
+	nodes, err := c.Clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	allPods, err := c.Clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	preview.FreeCPU, preview.FreeMemory = FreeCapacity(nodes.Items, allPods.Items)
+	preview.Fits = preview.AdditionalCPU.Cmp(preview.FreeCPU) <= 0 && preview.AdditionalMemory.Cmp(preview.FreeMemory) <= 0
+
+	return preview, nil
+}
+
+// PodSpecRequests sums the CPU and memory requests of a pod's containers
+func PodSpecRequests(spec corev1.PodSpec) (resource.Quantity, resource.Quantity) {
+	cpu := resource.NewMilliQuantity(0, resource.DecimalSI)
+	mem := resource.NewQuantity(0, resource.BinarySI)
+	for _, ctr := range spec.Containers {
+		if q, ok := ctr.Resources.Requests[corev1.ResourceCPU]; ok {
+			cpu.Add(q)
+		}
+		if q, ok := ctr.Resources.Requests[corev1.ResourceMemory]; ok {
+			mem.Add(q)
+		}
+	}
+	return *cpu, *mem
+}
+
+// FreeCapacity returns allocatable CPU and memory on schedulable nodes minus
+// the requests of the non-terminated pods already bound to them.
+func FreeCapacity(nodes []corev1.Node, pods []corev1.Pod) (resource.Quantity, resource.Quantity) {
+	cpu := resource.NewMilliQuantity(0, resource.DecimalSI)
+	mem := resource.NewQuantity(0, resource.BinarySI)
+
+	schedulable := make(map[string]bool)
+	for _, n := range nodes {
+		if n.Spec.Unschedulable {
+			continue
+		}
+		schedulable[n.Name] = true
+		cpu.Add(n.Status.Allocatable[corev1.ResourceCPU])
+		mem.Add(n.Status.Allocatable[corev1.ResourceMemory])
+	}
+
+	for _, p := range pods {
+		if !schedulable[p.Spec.NodeName] || p.Status.Phase == corev1.PodSucceeded || p.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		podCPU, podMem := PodSpecRequests(p.Spec)
+		cpu.Sub(podCPU)
+		mem.Sub(podMem)
+	}
+	return *cpu, *mem
+}
+
+// PodsToTerminate predicts which pods a controller removes when scaling down
+// by count. StatefulSets remove the highest ordinals; ReplicaSet-based
+// workloads prefer pods that are not ready, then the newest ones. This mirrors
+// the controllers' main ranking rules but is only an estimate.
+func PodsToTerminate(kind string, pods []corev1.Pod, count int) []string {
+	candidates := make([]corev1.Pod, 0, len(pods))
+	for _, p := range pods {
+		if p.DeletionTimestamp == nil {
+			candidates = append(candidates, p)
+		}
+	}
+
+	if kind == "StatefulSet" {
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return podOrdinal(candidates[i].Name) > podOrdinal(candidates[j].Name)
+		})
+	} else {
+		sort.SliceStable(candidates, func(i, j int) bool {
+			ri, rj := isPodReady(candidates[i]), isPodReady(candidates[j])
+			if ri != rj {
+				return !ri
+			}
+			return candidates[j].CreationTimestamp.Before(&candidates[i].CreationTimestamp)
+		})
+	}
+
+	if count > len(candidates) {
+		count = len(candidates)
+	}
+	names := make([]string, 0, count)
+	for _, p := range candidates[:count] {
+		names = append(names, p.Name)
+	}
+	return names
+}
+
+func podOrdinal(name string) int {
+	idx := strings.LastIndex(name, "-")
+	if idx < 0 {
+		return -1
+	}
+	n, err := strconv.Atoi(name[idx+1:])
+	if err != nil {
+		return -1
+	}
+	return n
+}
+
+func isPodReady(p corev1.Pod) bool {
+	for _, cond := range p.Status.Conditions {
+		if cond.Type == corev1.PodReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,128 @@
+package k8s
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func readyPod(name string, created time.Time, ready bool) corev1.Pod {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "default",
+			Labels:            map[string]string{"app": "web"},
+			CreationTimestamp: metav1.NewTime(created),
+		},
+		Status: corev1.PodStatus{Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}}},
+	}
+}
+
+func TestPodsToTerminate(t *testing.T) {
+	now := time.Now()
+	pods := []corev1.Pod{
+		readyPod("web-old", now.Add(-3*time.Hour), true),
+		readyPod("web-new", now.Add(-1*time.Hour), true),
+		readyPod("web-broken", now.Add(-2*time.Hour), false),
+	}
+
+	got := PodsToTerminate("Deployment", pods, 2)
+	if !reflect.DeepEqual(got, []string{"web-broken", "web-new"}) {
+		t.Errorf("PodsToTerminate(Deployment) = %v", got)
+	}
+
+	sts := []corev1.Pod{readyPod("db-0", now, true), readyPod("db-2", now, true), readyPod("db-1", now, true)}
+	got = PodsToTerminate("StatefulSet", sts, 2)
+	if !reflect.DeepEqual(got, []string{"db-2", "db-1"}) {
+		t.Errorf("PodsToTerminate(StatefulSet) = %v", got)
+	}
+}
+
+func TestFreeCapacity(t *testing.T) {
+	nodes := []corev1.Node{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+			Status: corev1.NodeStatus{Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2"),
+				corev1.ResourceMemory: resource.MustParse("4Gi"),
+			}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "cordoned"},
+			Spec:       corev1.NodeSpec{Unschedulable: true},
+			Status: corev1.NodeStatus{Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("8"),
+			}},
+		},
+	}
+	pods := []corev1.Pod{{
+		Spec: corev1.PodSpec{
+			NodeName: "node-1",
+			Containers: []corev1.Container{{Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("500m"),
+				corev1.ResourceMemory: resource.MustParse("1Gi"),
+			}}}},
+		},
+	}}
+
+	cpu, mem := FreeCapacity(nodes, pods)
+	if cpu.MilliValue() != 1500 {
+		t.Errorf("expected 1500m free CPU, got %s", cpu.String())
+	}
+	if mem.Value() != 3*1024*1024*1024 {
+		t.Errorf("expected 3Gi free memory, got %s", mem.String())
+	}
+}
+
+func TestPreviewScaleUp(t *testing.T) {
+	replicas := int32(1)
+	clientset := fake.NewSimpleClientset(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: &replicas,
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+				Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{
+					Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("1"),
+					}},
+				}}}},
+			},
+		},
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+			Status: corev1.NodeStatus{Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2"),
+				corev1.ResourceMemory: resource.MustParse("4Gi"),
+			}},
+		},
+	)
+	client := &Client{Clientset: clientset}
+
+	preview, err := client.PreviewScale(context.Background(), "deployments", "default", "web", 3)
+	if err != nil {
+		t.Fatalf("PreviewScale failed: %v", err)
+	}
+	if preview.AdditionalCPU.MilliValue() != 2000 || !preview.Fits {
+		t.Errorf("expected 2 extra CPUs that fit, got %+v", preview)
+	}
+
+	preview, err = client.PreviewScale(context.Background(), "deployments", "default", "web", 4)
+	if err != nil {
+		t.Fatalf("PreviewScale failed: %v", err)
+	}
+	if preview.Fits {
+		t.Error("expected scale to 4 replicas not to fit")
+	}
+}
@@ -0,0 +1,143 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ListOption narrows what the List functions return
+type ListOption func(*metav1.ListOptions)
+
+// WithLabelSelector lists only objects matching selector, e.g.
+// "app=web,tier!=cache"; an empty selector matches everything
+func WithLabelSelector(selector string) ListOption {
+	return func(o *metav1.ListOptions) { o.LabelSelector = selector }
+}
+
+// WithFieldSelector lists only objects matching a field selector, e.g.
+// "spec.nodeName=node-1". The informer caches can't serve these, so such
+// lists always go to the API server.
+func WithFieldSelector(selector string) ListOption {
+	return func(o *metav1.ListOptions) { o.FieldSelector = selector }
+}
+
+// listOptions applies opts to empty ListOptions
+func listOptions(opts []ListOption) metav1.ListOptions {
+	var o metav1.ListOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// cacheSelector returns the label selector for filtering an informer cache,
+// or false if opts can only be served by the API server
+func cacheSelector(opts []ListOption) (labels.Selector, bool) {
+	o := listOptions(opts)
+	if o.FieldSelector != "" {
+		return nil, false
+	}
+	sel, err := labels.Parse(o.LabelSelector)
+	if err != nil {
+		// Let the API server report the bad selector
+		return nil, false
+	}
+	return sel, true
+}
+
+// PodSelector returns the label selector of the pods a workload or service
+// manages, e.g. a deployment's spec.selector, in the string form
+// WithLabelSelector takes. resource is a name GetGVR knows.
+func (c *Client) PodSelector(ctx context.Context, resource, namespace, name string) (string, error) {
+	gvr, ok := c.GetGVR(resource)
+	if !ok {
+		return "", fmt.Errorf("unknown resource: %s", resource)
+	}
+	obj, err := c.GetResource(ctx, namespace, name, gvr)
+	if err != nil {
+		return "", err
+	}
+	sel, err := podSelectorOf(gvr.Resource, obj)
+	if err != nil {
+		return "", err
+	}
+	if sel.Empty() {
+		return "", fmt.Errorf("%s %s has no pod selector", gvr.Resource, name)
+	}
+	return sel.String(), nil
+}
+
+// podSelectorOf reads the pod selector of a service (spec.selector map) or
+// a workload (spec.selector LabelSelector). Objects without one get an
+// empty selector, which matches every pod and must not be listed with.
+func podSelectorOf(resource string, obj *unstructured.Unstructured) (labels.Selector, error) {
+	if resource == "services" {
+		selector, _, _ := unstructured.NestedStringMap(obj.Object, "spec", "selector")
+		return labels.SelectorFromSet(selector), nil
+	}
+
+	raw, found, _ := unstructured.NestedMap(obj.Object, "spec", "selector")
+	if !found {
+		return labels.Everything(), nil
+	}
+	var selector metav1.LabelSelector
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(raw, &selector); err != nil {
+		return nil, err
+	}
+	return metav1.LabelSelectorAsSelector(&selector)
+}
+
+// WorkloadRef names a workload in the namespace of the object it relates to
+type WorkloadRef struct {
+	Resource string // "deployments" or "statefulsets"
+	Name     string
+}
+
+// ServiceWorkloads returns the deployments and statefulsets whose pod
+// template labels match the selector of a service, i.e. the workloads that
+// back it, deployments first and by name. Matching the templates instead of
+// the endpoints also finds workloads scaled to zero.
+func (c *Client) ServiceWorkloads(ctx context.Context, namespace, name string) ([]WorkloadRef, error) {
+	svc, err := c.Clientset.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if len(svc.Spec.Selector) == 0 {
+		return nil, fmt.Errorf("service %s has no selector", name)
+	}
+	selector := labels.SelectorFromSet(svc.Spec.Selector)
+
+	deps, err := c.ListDeployments(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	stses, err := c.ListStatefulSets(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var refs []WorkloadRef
+	for _, d := range deps {
+		if selector.Matches(labels.Set(d.Spec.Template.Labels)) {
+			refs = append(refs, WorkloadRef{Resource: "deployments", Name: d.Name})
+		}
+	}
+	for _, s := range stses {
+		if selector.Matches(labels.Set(s.Spec.Template.Labels)) {
+			refs = append(refs, WorkloadRef{Resource: "statefulsets", Name: s.Name})
+		}
+	}
+	sort.SliceStable(refs, func(i, j int) bool {
+		if refs[i].Resource != refs[j].Resource {
+			return refs[i].Resource == "deployments"
+		}
+		return refs[i].Name < refs[j].Name
+	})
+	return refs, nil
+}
@@ -0,0 +1,137 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// DefaultServiceAccount is the service account pods run as when none is set
+const DefaultServiceAccount = "default"
+
+// AnalyzeServiceAccounts checks how the workloads of a namespace ("" for all
+// namespaces) use service accounts and their API tokens.
+func (c *Client) AnalyzeServiceAccounts(ctx context.Context, namespace string) ([]Finding, error) {
+	deployments, err := c.ListDeployments(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	statefulsets, err := c.ListStatefulSets(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	daemonsets, err := c.ListDaemonSets(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	serviceAccounts, err := c.ListServiceAccounts(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	return CheckServiceAccounts(deployments, statefulsets, daemonsets, serviceAccounts), nil
+}
+
+// CheckServiceAccounts flags workloads running as the default service
+// account, workloads that get an API token mounted, and default service
+// accounts that do not disable token automounting (CIS 5.1.5 and 5.1.6).
+// Workload findings are ordered by replicas, so the workloads running the
+// most pods come first.
+func CheckServiceAccounts(deployments []appsv1.Deployment, statefulsets []appsv1.StatefulSet, daemonsets []appsv1.DaemonSet, serviceAccounts []corev1.ServiceAccount) []Finding {
+	type ranked struct {
+		finding  Finding
+		replicas int32
+	}
+	var all []ranked
+
+	automount := make(map[string]*bool, len(serviceAccounts)) // namespace/name
+	for _, sa := range serviceAccounts {
+		automount[sa.Namespace+"/"+sa.Name] = sa.AutomountServiceAccountToken
+	}
+
+	check := func(kind, namespace, name string, replicas *int32, spec corev1.PodSpec) {
+		if replicas != nil && *replicas == 0 {
+			return
+		}
+		n := int32(1)
+		if replicas != nil {
+			n = *replicas
+		}
+		add := func(check, message string) {
+			all = append(all, ranked{Finding{Check: check, Kind: kind, Namespace: namespace, Name: name, Message: message}, n})
+		}
+
+		sa := PodServiceAccount(spec)
+		if sa == DefaultServiceAccount {
+			add(CheckDefaultServiceAccount, "pods run as the default service account")
+		}
+		// The pod setting wins over the service account's
+		mounted := spec.AutomountServiceAccountToken
+		if mounted == nil {
+			mounted = automount[namespace+"/"+sa]
+		}
+		if mounted == nil || *mounted {
+			add(CheckTokenAutomount, fmt.Sprintf("pods mount an API token for service account %q", sa))
+		}
+	}
+	for _, d := range deployments {
+		check("Deployment", d.Namespace, d.Name, d.Spec.Replicas, d.Spec.Template.Spec)
+	}
+	for _, s := range statefulsets {
+		check("StatefulSet", s.Namespace, s.Name, s.Spec.Replicas, s.Spec.Template.Spec)
+	}
+	for _, ds := range daemonsets {
+		n := ds.Status.DesiredNumberScheduled
+		check("DaemonSet", ds.Namespace, ds.Name, &n, ds.Spec.Template.Spec)
+	}
+
+	sort.SliceStable(all, func(i, j int) bool {
+		return all[i].replicas > all[j].replicas
+	})
+	findings := make([]Finding, 0, len(all))
+	for _, r := range all {
+		findings = append(findings, r.finding)
+	}
+
+	for _, sa := range serviceAccounts {
+		if sa.Name == DefaultServiceAccount && (sa.AutomountServiceAccountToken == nil || *sa.AutomountServiceAccountToken) {
+			findings = append(findings, Finding{
+				Check:     CheckDefaultSAAutomount,
+				Kind:      "ServiceAccount",
+				Namespace: sa.Namespace,
+				Name:      sa.Name,
+				Message:   "does not set automountServiceAccountToken: false",
+			})
+		}
+	}
+	return findings
+}
+
+// PodServiceAccount returns the service account a pod spec runs as
+func PodServiceAccount(spec corev1.PodSpec) string {
+	if spec.ServiceAccountName != "" {
+		return spec.ServiceAccountName
+	}
+	if spec.DeprecatedServiceAccount != "" {
+		return spec.DeprecatedServiceAccount
+	}
+	return DefaultServiceAccount
+}
+
+// HasServiceAccountToken reports whether a pod has an API token mounted by
+// the service account admission controller
+func HasServiceAccountToken(pod corev1.Pod) bool {
+	for _, v := range pod.Spec.Volumes {
+		if v.Projected == nil {
+			continue
+		}
+		for _, src := range v.Projected.Sources {
+			if src.ServiceAccountToken != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
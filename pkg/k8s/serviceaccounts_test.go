@@ -0,0 +1,67 @@
+package k8s
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCheckServiceAccounts(t *testing.T) {
+	off := false
+
+	small := testDeployment("small", 1, nil)
+	big := testDeployment("big", 5, nil)
+	dedicated := testDeployment("dedicated", 3, nil)
+	dedicated.Spec.Template.Spec.ServiceAccountName = "api"
+	optedOut := testDeployment("opted-out", 2, nil)
+	optedOut.Spec.Template.Spec.ServiceAccountName = "api"
+	optedOut.Spec.Template.Spec.AutomountServiceAccountToken = &off
+	quiet := testDeployment("quiet", 2, nil)
+	quiet.Spec.Template.Spec.ServiceAccountName = "quiet"
+
+	serviceAccounts := []corev1.ServiceAccount{
+		{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "quiet", Namespace: "default"}, AutomountServiceAccountToken: &off},
+	}
+
+	findings := CheckServiceAccounts([]appsv1.Deployment{*small, *big, *dedicated, *optedOut, *quiet}, nil, nil, serviceAccounts)
+
+	var got []string
+	for _, f := range findings {
+		got = append(got, f.Check+":"+f.Name)
+	}
+	want := []string{
+		CheckDefaultServiceAccount + ":big",
+		CheckTokenAutomount + ":big",
+		CheckTokenAutomount + ":dedicated",
+		CheckDefaultServiceAccount + ":small",
+		CheckTokenAutomount + ":small",
+		CheckDefaultSAAutomount + ":default",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got findings %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("finding %d = %s, want %s (all: %v)", i, got[i], want[i], got)
+		}
+	}
+}
+
+func TestHasServiceAccountToken(t *testing.T) {
+	pod := corev1.Pod{Spec: corev1.PodSpec{Volumes: []corev1.Volume{{
+		Name: "kube-api-access-abcde",
+		VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{
+			Sources: []corev1.VolumeProjection{{ServiceAccountToken: &corev1.ServiceAccountTokenProjection{Path: "token"}}},
+		}},
+	}}}}
+	if !HasServiceAccountToken(pod) {
+		t.Error("expected projected token volume to count as a mounted token")
+	}
+	if HasServiceAccountToken(corev1.Pod{}) {
+		t.Error("pod without volumes has no token")
+	}
+}
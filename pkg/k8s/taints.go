@@ -0,0 +1,82 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// TaintEffects lists the valid taint effects in the order they are offered to users
+var TaintEffects = []corev1.TaintEffect{
+	corev1.TaintEffectNoSchedule,
+	corev1.TaintEffectPreferNoSchedule,
+	corev1.TaintEffectNoExecute,
+}
+
+// AddTaint returns taints with t added, replacing any taint with the same key and effect
+func AddTaint(taints []corev1.Taint, t corev1.Taint) []corev1.Taint {
+	result := RemoveTaint(taints, t.Key, t.Effect)
+	return append(result, t)
+}
+
+// RemoveTaint returns taints without the ones matching key and effect. An
+// empty effect removes every taint with that key.
+func RemoveTaint(taints []corev1.Taint, key string, effect corev1.TaintEffect) []corev1.Taint {
+	result := make([]corev1.Taint, 0, len(taints))
+	for _, t := range taints {
+		if t.Key == key && (effect == "" || t.Effect == effect) {
+			continue
+		}
+		result = append(result, t)
+	}
+	return result
+}
+
+// BuildTaintPatch returns a merge patch that replaces spec.taints. The
+// resourceVersion makes the patch fail on concurrent edits instead of
+// silently dropping someone else's taint.
+func BuildTaintPatch(resourceVersion string, taints []corev1.Taint) ([]byte, error) {
+	if taints == nil {
+		taints = []corev1.Taint{}
+	}
+	return json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"resourceVersion": resourceVersion,
+		},
+		"spec": map[string]interface{}{
+			"taints": taints,
+		},
+	})
+}
+
+// AddNodeTaint adds or updates a taint on a node
+func (c *Client) AddNodeTaint(ctx context.Context, nodeName string, taint corev1.Taint) error {
+	return c.updateNodeTaints(ctx, nodeName, func(taints []corev1.Taint) []corev1.Taint {
+		return AddTaint(taints, taint)
+	})
+}
+
+// RemoveNodeTaint removes a taint from a node
+func (c *Client) RemoveNodeTaint(ctx context.Context, nodeName, key string, effect corev1.TaintEffect) error {
+	return c.updateNodeTaints(ctx, nodeName, func(taints []corev1.Taint) []corev1.Taint {
+		return RemoveTaint(taints, key, effect)
+	})
+}
+
+func (c *Client) updateNodeTaints(ctx context.Context, nodeName string, mutate func([]corev1.Taint) []corev1.Taint) error {
+	node, err := c.Clientset.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	payload, err := BuildTaintPatch(node.ResourceVersion, mutate(node.Spec.Taints))
+	if err != nil {
+		return err
+	}
+
+	_, err = c.Clientset.CoreV1().Nodes().Patch(ctx, nodeName, types.MergePatchType, payload, metav1.PatchOptions{})
+	return err
+}
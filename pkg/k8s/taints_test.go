@@ -0,0 +1,76 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestBuildTaintPatch(t *testing.T) {
+	taints := []corev1.Taint{{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule}}
+	patch, err := BuildTaintPatch("42", taints)
+	if err != nil {
+		t.Fatalf("BuildTaintPatch failed: %v", err)
+	}
+	expected := `{"metadata":{"resourceVersion":"42"},"spec":{"taints":[{"key":"dedicated","value":"gpu","effect":"NoSchedule"}]}}`
+	if string(patch) != expected {
+		t.Errorf("BuildTaintPatch = %s, expected %s", patch, expected)
+	}
+
+	patch, err = BuildTaintPatch("43", nil)
+	if err != nil {
+		t.Fatalf("BuildTaintPatch failed: %v", err)
+	}
+	expected = `{"metadata":{"resourceVersion":"43"},"spec":{"taints":[]}}`
+	if string(patch) != expected {
+		t.Errorf("BuildTaintPatch = %s, expected %s", patch, expected)
+	}
+}
+
+func TestAddRemoveTaint(t *testing.T) {
+	taints := []corev1.Taint{
+		{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule},
+		{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoExecute},
+	}
+
+	updated := AddTaint(taints, corev1.Taint{Key: "dedicated", Value: "infra", Effect: corev1.TaintEffectNoSchedule})
+	if len(updated) != 2 {
+		t.Fatalf("expected existing taint to be replaced, got %v", updated)
+	}
+	if updated[1].Value != "infra" {
+		t.Errorf("expected updated value infra, got %v", updated)
+	}
+
+	if got := RemoveTaint(taints, "dedicated", corev1.TaintEffectNoExecute); len(got) != 1 || got[0].Effect != corev1.TaintEffectNoSchedule {
+		t.Errorf("unexpected taints after removing one effect: %v", got)
+	}
+	if got := RemoveTaint(taints, "dedicated", ""); len(got) != 0 {
+		t.Errorf("expected all taints with key removed, got %v", got)
+	}
+}
+
+func TestAddNodeTaint(t *testing.T) {
+	clientset := fake.NewSimpleClientset(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}})
+	client := &Client{Clientset: clientset}
+	ctx := context.Background()
+
+	taint := corev1.Taint{Key: "maintenance", Effect: corev1.TaintEffectNoSchedule}
+	if err := client.AddNodeTaint(ctx, "node-1", taint); err != nil {
+		t.Fatalf("AddNodeTaint failed: %v", err)
+	}
+	node, _ := clientset.CoreV1().Nodes().Get(ctx, "node-1", metav1.GetOptions{})
+	if len(node.Spec.Taints) != 1 || node.Spec.Taints[0].Key != "maintenance" {
+		t.Errorf("expected maintenance taint, got %v", node.Spec.Taints)
+	}
+
+	if err := client.RemoveNodeTaint(ctx, "node-1", "maintenance", ""); err != nil {
+		t.Fatalf("RemoveNodeTaint failed: %v", err)
+	}
+	node, _ = clientset.CoreV1().Nodes().Get(ctx, "node-1", metav1.GetOptions{})
+	if len(node.Spec.Taints) != 0 {
+		t.Errorf("expected no taints, got %v", node.Spec.Taints)
+	}
+}
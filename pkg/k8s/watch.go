@@ -0,0 +1,51 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// ErrNotWatchable is returned by WatchResource for resources it cannot watch
+var ErrNotWatchable = errors.New("resource cannot be watched")
+
+// WatchableResources are the resources WatchResource can watch
+var WatchableResources = map[string]bool{
+	"pods":        true,
+	"deployments": true,
+	"services":    true,
+	"namespaces":  true,
+	"nodes":       true,
+	"events":      true,
+}
+
+// WatchResource watches resource in namespace ("" for all namespaces;
+// ignored for nodes and namespaces). It resumes after resourceVersion, or
+// without one starts with an ADDED event per existing object. Bookmarks are
+// requested so that a client resuming after a quiet period does not fall
+// behind the compacted history.
+func (c *Client) WatchResource(ctx context.Context, resource, namespace, resourceVersion string) (watch.Interface, error) {
+	if c.Clientset == nil {
+		return nil, fmt.Errorf("kubernetes client not initialized")
+	}
+
+	opts := metav1.ListOptions{ResourceVersion: resourceVersion, AllowWatchBookmarks: true}
+	switch resource {
+	case "pods":
+		return c.Clientset.CoreV1().Pods(namespace).Watch(ctx, opts)
+	case "deployments":
+		return c.Clientset.AppsV1().Deployments(namespace).Watch(ctx, opts)
+	case "services":
+		return c.Clientset.CoreV1().Services(namespace).Watch(ctx, opts)
+	case "namespaces":
+		return c.Clientset.CoreV1().Namespaces().Watch(ctx, opts)
+	case "nodes":
+		return c.Clientset.CoreV1().Nodes().Watch(ctx, opts)
+	case "events":
+		return c.Clientset.CoreV1().Events(namespace).Watch(ctx, opts)
+	}
+	return nil, fmt.Errorf("%w: %s", ErrNotWatchable, resource)
+}
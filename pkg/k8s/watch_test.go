@@ -0,0 +1,43 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestWatchResource(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	client := &Client{Clientset: clientset}
+	ctx := context.Background()
+
+	w, err := client.WatchResource(ctx, "pods", "default", "")
+	if err != nil {
+		t.Fatalf("WatchResource failed: %v", err)
+	}
+	defer w.Stop()
+
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}}
+	if _, err := clientset.CoreV1().Pods("default").Create(ctx, pod, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("create pod: %v", err)
+	}
+
+	select {
+	case ev := <-w.ResultChan():
+		if got, ok := ev.Object.(*corev1.Pod); ev.Type != watch.Added || !ok || got.Name != "web" {
+			t.Errorf("unexpected event %s %T", ev.Type, ev.Object)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event for the created pod")
+	}
+
+	if _, err := client.WatchResource(ctx, "widgets", "", ""); !errors.Is(err, ErrNotWatchable) {
+		t.Errorf("expected ErrNotWatchable, got %v", err)
+	}
+}
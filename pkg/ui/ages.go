@@ -0,0 +1,126 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rivo/tview"
+)
+
+// ageBucketLabels name the buckets of an age histogram, youngest first
+var ageBucketLabels = [4]string{"<1h", "1-24h", "1-7d", ">7d"}
+
+// ageHistogramWidth is the length of the longest bar
+const ageHistogramWidth = 40
+
+// ageBucket returns the histogram bucket of an AGE cell as formatAge writes
+// it, e.g. "42m" or "3d". Its unit is exact at the bucket bounds, so no
+// timestamp has to be fetched again.
+func ageBucket(age string) (int, bool) {
+	if len(age) < 2 {
+		return 0, false
+	}
+	n, err := strconv.Atoi(age[:len(age)-1])
+	if err != nil || n < 0 {
+		return 0, false
+	}
+	switch age[len(age)-1] {
+	case 's', 'm':
+		return 0, true
+	case 'h':
+		return 1, true
+	case 'd':
+		if n < 7 {
+			return 2, true
+		}
+		return 3, true
+	}
+	return 0, false
+}
+
+// ageHistogram counts the objects of rows per age bucket from the AGE
+// column at col. Rows without a readable age are counted as unknown.
+func ageHistogram(rows [][]string, col int) (buckets [4]int, unknown int) {
+	for _, row := range rows {
+		if col >= len(row) {
+			unknown++
+			continue
+		}
+		b, ok := ageBucket(row[col])
+		if !ok {
+			unknown++
+			continue
+		}
+		buckets[b]++
+	}
+	return buckets, unknown
+}
+
+// formatAgeHistogram renders buckets as horizontal bars scaled to the
+// fullest bucket
+func formatAgeHistogram(buckets [4]int, unknown int) string {
+	total, most := unknown, 0
+	for _, n := range buckets {
+		total += n
+		if n > most {
+			most = n
+		}
+	}
+	if total == 0 {
+		return " [gray]No objects[white]"
+	}
+
+	var sb strings.Builder
+	sb.WriteString("\n")
+	for i, n := range buckets {
+		bar := 0
+		if most > 0 {
+			bar = n * ageHistogramWidth / most
+		}
+		if n > 0 && bar == 0 {
+			bar = 1
+		}
+		sb.WriteString(fmt.Sprintf(" %-6s [cyan]%s[white]%s %d (%d%%)\n",
+			ageBucketLabels[i], strings.Repeat("█", bar), strings.Repeat(" ", ageHistogramWidth-bar), n, n*100/total))
+	}
+	if unknown > 0 {
+		sb.WriteString(fmt.Sprintf("\n [gray]%d without a readable age[white]\n", unknown))
+	}
+
+	// Many young objects among old ones point at churn
+	if young := buckets[0]; young > 0 && young*2 >= total && young < total {
+		sb.WriteString("\n [yellow]⚠[white] Most objects are younger than an hour: they may be flapping or being replaced\n")
+	}
+	return sb.String()
+}
+
+// showAgeHistogram shows the age distribution of the objects in the table,
+// from the AGE column of the last fetch (Esc to close)
+func (a *App) showAgeHistogram() {
+	a.mx.RLock()
+	resource := a.currentResource
+	headers := a.tableHeaders
+	rows := a.tableRows
+	a.mx.RUnlock()
+
+	col := -1
+	for i, h := range headers {
+		if h == "AGE" {
+			col = i
+		}
+	}
+	if col < 0 {
+		a.flashMsg(fmt.Sprintf("%s have no AGE column", resource), true)
+		return
+	}
+
+	buckets, unknown := ageHistogram(rows, col)
+	view := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetText(a.glyphs(formatAgeHistogram(buckets, unknown)))
+	view.SetBorder(true).SetTitle(fmt.Sprintf(" Age of %d %s (Press Esc to close) ", len(rows), resource))
+
+	a.showCentered("ages", view, 72, 14)
+}
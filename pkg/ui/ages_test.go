@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAgeHistogram(t *testing.T) {
+	rows := [][]string{
+		{"web-1", "30s"},
+		{"web-2", "59m"},
+		{"web-3", "1h"},
+		{"web-4", "23h"},
+		{"web-5", "6d"},
+		{"web-6", "7d"},
+		{"web-7", "400d"},
+		{"web-8", "<unknown>"},
+		{"web-9"},
+	}
+
+	buckets, unknown := ageHistogram(rows, 1)
+	if want := [4]int{2, 2, 1, 2}; buckets != want {
+		t.Errorf("buckets = %v, want %v", buckets, want)
+	}
+	if unknown != 2 {
+		t.Errorf("unknown = %d, want 2", unknown)
+	}
+
+	text := formatAgeHistogram(buckets, unknown)
+	for _, want := range []string{"<1h", "1-24h", "1-7d", ">7d", "2 without a readable age"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("histogram is missing %q:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "flapping") {
+		t.Errorf("did not expect a churn warning:\n%s", text)
+	}
+
+	churning, _ := ageHistogram([][]string{{"a", "2m"}, {"b", "5m"}, {"c", "30d"}}, 1)
+	if text := formatAgeHistogram(churning, 0); !strings.Contains(text, "flapping") {
+		t.Errorf("expected a churn warning:\n%s", text)
+	}
+}
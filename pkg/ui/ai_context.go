@@ -0,0 +1,44 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+)
+
+// aiContextLimit caps the attached resource context (bytes) so a large
+// manifest or chatty logs do not crowd out the question
+const aiContextLimit = 16000
+
+// autoAttachContext reports whether questions carry the selected resource's
+// manifest, events and logs unless overridden for one question
+func (a *App) autoAttachContext() bool {
+	return a.config != nil && a.config.AutoAttachContext
+}
+
+// selectedResourceContext returns the manifest, related events and, for
+// pods, recent logs of the selected row of resource for the AI prompt, or
+// "" when nothing is selected or it cannot be fetched. Secrets are never
+// attached, as their manifest holds the unredacted data.
+func (a *App) selectedResourceContext(ctx context.Context, resource string) string {
+	if a.k8s == nil || resource == "secrets" {
+		return ""
+	}
+	ns, name, ok := a.selectedResourceRef(resource)
+	if !ok {
+		return ""
+	}
+	details, err := a.k8s.GetResourceContext(ctx, ns, name, resource)
+	if err != nil {
+		a.logger.Warn("Failed to build AI context", "resource", resource, "namespace", ns, "name", name, "error", err)
+		return ""
+	}
+	return truncateContext(details, aiContextLimit)
+}
+
+// truncateContext cuts s to limit bytes, noting how much was left out
+func truncateContext(s string, limit int) string {
+	if len(s) <= limit {
+		return s
+	}
+	return s[:limit] + fmt.Sprintf("\n... (truncated, %d more bytes)\n", len(s)-limit)
+}
@@ -0,0 +1,46 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/config"
+	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/k8s"
+	"github.com/rivo/tview"
+)
+
+func TestTruncateContext(t *testing.T) {
+	if got := truncateContext("short", 10); got != "short" {
+		t.Errorf("truncateContext kept %q", got)
+	}
+	got := truncateContext(strings.Repeat("x", 30), 10)
+	if !strings.HasPrefix(got, strings.Repeat("x", 10)+"\n") || !strings.Contains(got, "20 more bytes") {
+		t.Errorf("truncateContext = %q", got)
+	}
+}
+
+func TestSelectedResourceContextSkipsSecrets(t *testing.T) {
+	table := tview.NewTable().SetSelectable(true, false)
+	table.SetCell(0, 0, tview.NewTableCell("NAMESPACE"))
+	table.SetCell(1, 0, tview.NewTableCell("default"))
+	table.SetCell(1, 1, tview.NewTableCell("db-password"))
+	table.Select(1, 0)
+
+	// A nil clientset would panic if the secret were fetched
+	app := &App{table: table, k8s: &k8s.Client{}}
+	if got := app.selectedResourceContext(context.Background(), "secrets"); got != "" {
+		t.Errorf("secret context = %q, want none", got)
+	}
+}
+
+func TestAutoAttachContext(t *testing.T) {
+	app := &App{}
+	if app.autoAttachContext() {
+		t.Error("auto attach on without a config")
+	}
+	app.config = &config.Config{AutoAttachContext: true}
+	if !app.autoAttachContext() {
+		t.Error("auto attach off with auto_attach_context set")
+	}
+}
@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"sort"
+	"strings"
+)
+
+// expandAlias replaces the first word of a command line with its expansion
+// from config.Aliases, keeping the rest: with dep: deployments, "dep -n shop"
+// becomes "deployments -n shop". Expansions are not expanded again, so an
+// alias can name a built-in command or alias but not another of its kind.
+func (a *App) expandAlias(cmd string) string {
+	if a.config == nil || len(a.config.Aliases) == 0 {
+		return cmd
+	}
+
+	name, rest, _ := strings.Cut(cmd, " ")
+	expansion, ok := a.config.Aliases[name]
+	expansion = strings.TrimSpace(expansion)
+	if !ok || expansion == "" {
+		return cmd
+	}
+	if rest = strings.TrimSpace(rest); rest != "" {
+		return expansion + " " + rest
+	}
+	return expansion
+}
+
+// aliasCompletions returns the expansions of the aliases from config that
+// start with prefix, ordered by alias
+func (a *App) aliasCompletions(prefix string) []string {
+	if a.config == nil {
+		return nil
+	}
+
+	var names []string
+	for name, expansion := range a.config.Aliases {
+		if strings.TrimSpace(expansion) != "" && strings.HasPrefix(strings.ToLower(name), prefix) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	matches := make([]string, len(names))
+	for i, name := range names {
+		matches[i] = strings.TrimSpace(a.config.Aliases[name])
+	}
+	return matches
+}
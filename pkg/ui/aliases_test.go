@@ -0,0 +1,52 @@
+package ui
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/config"
+)
+
+func TestExpandAlias(t *testing.T) {
+	app := &App{config: &config.Config{Aliases: map[string]string{
+		"dep": "deployments",
+		"kp":  "pods -n kube-system",
+		"po":  "poddisruptionbudgets",
+		"nop": " ",
+	}}}
+
+	tests := map[string]string{
+		"dep":         "deployments",
+		"dep -n shop": "deployments -n shop",
+		"kp":          "pods -n kube-system",
+		"po":          "poddisruptionbudgets",
+		"nop":         "nop",
+		"pods":        "pods",
+		"deployments": "deployments",
+	}
+	for cmd, want := range tests {
+		if got := app.expandAlias(cmd); got != want {
+			t.Errorf("expandAlias(%q) = %q, want %q", cmd, got, want)
+		}
+	}
+
+	if got := (&App{}).expandAlias("dep"); got != "dep" {
+		t.Errorf("expandAlias without config = %q", got)
+	}
+}
+
+func TestGetCompletionsAliases(t *testing.T) {
+	app := &App{config: &config.Config{Aliases: map[string]string{
+		"dep":  "deployments",
+		"wf":   "workflows",
+		"wfkp": "workflows -n kube-system",
+	}}}
+
+	if got, want := app.getCompletions("wf"), []string{"workflows", "workflows -n kube-system"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("getCompletions(wf) = %v, want %v", got, want)
+	}
+	// Built-in matches come first and are not repeated
+	if got, want := app.getCompletions("dep"), []string{"deployments"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("getCompletions(dep) = %v, want %v", got, want)
+	}
+}
@@ -1,14 +1,18 @@
 package ui
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"regexp"
 	"runtime/debug"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -22,6 +26,7 @@ import (
 	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/k8s"
 	"github.com/rivo/tview"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 // Command definitions for autocomplete (k9s-style comprehensive list)
@@ -69,7 +74,6 @@ var commands = []struct {
 
 	// Policy
 	{"poddisruptionbudgets", "pdb", "List pod disruption budgets", "resource"},
-	{"podsecuritypolicies", "psp", "List pod security policies", "resource"},
 	{"limitranges", "limits", "List limit ranges", "resource"},
 	{"resourcequotas", "quota", "List resource quotas", "resource"},
 	{"horizontalpodautoscalers", "hpa", "List horizontal pod autoscalers", "resource"},
@@ -85,9 +89,20 @@ var commands = []struct {
 	{"csidrivers", "csidriver", "List CSI drivers", "resource"},
 	{"csinodes", "csinode", "List CSI nodes", "resource"},
 
+	// Views aggregated from other resources
+	{"images", "img", "List container images by pod count", "view"},
+
 	// Actions
 	{"quit", "q", "Exit application", "action"},
 	{"health", "status", "Show cluster health", "action"},
+	{"findings", "fi", "Show cluster findings", "action"},
+	{"unused", "un", "List unused ConfigMaps, Secrets and PVCs", "action"},
+	{"changes", "chg", "List recently changed resources", "action"},
+	{"ages", "age", "Show the age distribution of the listed objects", "action"},
+	{"refresh-all", "ra", "Re-discover API resources, namespaces and contexts", "action"},
+	{"bundle", "sb", "Save a diagnostics bundle of the selected resource or namespace", "action"},
+	{"transcript", "tr", "Export the AI conversation (transcript md|json)", "action"},
+	{"apply", "ap", "Apply the objects in a YAML file (apply <path>)", "action"},
 	{"context", "ctx", "Switch context", "action"},
 	{"help", "?", "Show help", "action"},
 }
@@ -121,16 +136,48 @@ type App struct {
 	showAIPanel      bool
 	filterText       string     // Current filter text
 	filterRegex      bool       // True if filter is regex (e.g., /pattern/)
+	labelSelector    string     // Server-side pod selector of a drill-down
+	fieldSelector    string     // Server-side pod field selector, e.g. of a node drill-down
+	podContainers    map[string][]string // Container names by "<ns>/<pod>" from the last pod list
+	labelsResource   string                       // Resource of listedLabels
+	listedLabels     map[string]map[string]string // Labels by "<ns>/<name>" from the last list, see withLabels
+	watchEvents      map[string]time.Time // Time of the last watch event per resource
 	tableHeaders     []string   // Original headers
 	tableRows        [][]string // Original rows (unfiltered)
 	apiResources     []k8s.APIResource // Cached API resources from cluster
 	selectedRows     map[int]bool // Multi-select: selected row indices (k9s Space key)
+	rowKeys          []string        // Object identity of each table row below the header (UI goroutine only)
+	rowKeysResource  string          // View rowKeys belong to
+	savedSelection   *tableSelection // Selection to restore after the table is redrawn
+	imagesAscending  bool         // Images view lists the least used first (o key)
+	showLabels       bool         // Resource views get a LABELS column (i key)
 
 	// Atomic guards (k9s pattern for lock-free update deduplication)
-	inUpdate   int32
-	running    int32 // 1 after Application.Run() starts
-	cancelFn   context.CancelFunc
-	cancelLock sync.Mutex
+	inUpdate          int32
+	running           int32 // 1 after Application.Run() starts
+	autoRefreshPaused int32 // 1 while auto-refresh is paused with W
+	liveChanged       int32 // 1 when a watched resource on screen changed
+	lastInput         int64 // UnixNano of the last key press, for the idle pause
+	cancelFn          context.CancelFunc
+	cancelLock        sync.Mutex
+
+	// Port forwards started with Shift+F, listed with f
+	portForwards portForwardRegistry
+
+	// Background tasks shown in the status bar until they finish
+	tasks taskRegistry
+
+	// Last reversible action, undone with Ctrl+Z
+	undo undoSlot
+
+	// Object counts of the namespaces view
+	nsCounts namespaceCountCache
+
+	// AI conversation of this session, exported with :transcript
+	transcript aiTranscript
+
+	// ASCII glyphs instead of Unicode (ascii_mode or a limited TERM)
+	ascii bool
 
 	// Logger
 	logger *slog.Logger
@@ -182,14 +229,19 @@ func NewAppWithNamespace(initialNamespace string) *App {
 		namespaces:       []string{""},
 		showAIPanel:      true,
 		selectedRows:     make(map[int]bool),
+		ascii:            asciiMode(cfg, os.Getenv("TERM")),
 		logger:           logger,
 	}
 
 	app.setupUI()
+	app.applyTerminalCompat()
 	app.setupKeybindings()
 
-	// Load API resources in background (for autocomplete)
+	// Load API resources and namespaces in background (for autocomplete,
+	// namespace cycling and numeric selection)
 	go app.loadAPIResources()
+	go app.loadNamespaces()
+	go app.detectMetrics()
 
 	return app
 }
@@ -206,6 +258,15 @@ func (a *App) loadAPIResources() {
 	resources, err := a.k8s.GetAPIResources(ctx)
 	if err != nil {
 		a.logger.Warn("Failed to load API resources", "error", err)
+
+		// Keep the previous discovery result if there is one
+		a.mx.RLock()
+		cached := len(a.apiResources) > 0
+		a.mx.RUnlock()
+		if cached {
+			return
+		}
+
 		// Use common resources as fallback
 		resources = a.k8s.GetCommonResources()
 	}
@@ -217,6 +278,132 @@ func (a *App) loadAPIResources() {
 	a.logger.Info("Loaded API resources", "count", len(resources))
 }
 
+// discoveryInterval returns how often API resources are re-discovered in
+// the background, or 0 when disabled
+func (a *App) discoveryInterval() time.Duration {
+	rc := config.NewDefaultConfig().Refresh
+	if a.config != nil {
+		rc = a.config.Refresh
+	}
+	return time.Duration(rc.DiscoveryInterval * float64(time.Second))
+}
+
+// refreshAPIResourcesPeriodically re-runs loadAPIResources until ctx is done
+// so CRDs installed mid-session show up in autocomplete and the generic view
+func (a *App) refreshAPIResourcesPeriodically(ctx context.Context) {
+	interval := a.discoveryInterval()
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			a.loadAPIResources()
+		}
+	}
+}
+
+// detectMetrics checks whether metrics-server is installed. Without it the
+// metrics columns are dropped and the user is told once, rather than every
+// refresh failing to read metrics.
+func (a *App) detectMetrics() {
+	if a.k8s == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	err := a.k8s.DetectMetrics(ctx)
+	switch {
+	case errors.Is(err, k8s.ErrMetricsUnavailable):
+		a.logger.Info("metrics-server not installed, metrics columns disabled")
+		a.flashMsg("metrics-server not installed: CPU and memory columns are hidden", false)
+	case err != nil:
+		a.logger.Warn("Failed to detect metrics-server", "error", err)
+	}
+}
+
+// loadNamespaces populates the namespace cache from the cluster
+func (a *App) loadNamespaces() {
+	if a.k8s == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	nss, err := a.k8s.ListNamespaces(ctx)
+	if err != nil {
+		a.logger.Warn("Failed to load namespaces", "error", err)
+		return
+	}
+
+	names := make([]string, 0, len(nss))
+	for _, n := range nss {
+		names = append(names, n.Name)
+	}
+	a.setNamespaceCache(names)
+
+	a.logger.Info("Loaded namespaces", "count", len(names))
+}
+
+// refreshAll drops every cached discovery result and reloads API resources,
+// namespaces and contexts, e.g. after CRDs or namespaces were created
+// out-of-band. The current view is refreshed afterwards.
+func (a *App) refreshAll() {
+	if a.k8s == nil {
+		a.flashMsg("K8s client not available", true)
+		return
+	}
+
+	a.flashMsg("Re-discovering API resources, namespaces and contexts...", false)
+
+	a.mx.Lock()
+	a.apiResources = nil
+	a.namespaces = []string{""}
+	a.tableHeaders = nil
+	a.tableRows = nil
+	a.mx.Unlock()
+	a.nsCounts.reset()
+
+	var wg sync.WaitGroup
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		a.loadAPIResources()
+	}()
+	go func() {
+		defer wg.Done()
+		a.loadNamespaces()
+	}()
+	go func() {
+		defer wg.Done()
+		a.detectMetrics()
+	}()
+	wg.Wait()
+
+	contexts, _, err := a.k8s.ListContexts()
+	if err != nil {
+		a.logger.Warn("Failed to load contexts", "error", err)
+	}
+
+	a.mx.RLock()
+	resourceCount := len(a.apiResources)
+	nsCount := len(a.namespaces) - 1
+	a.mx.RUnlock()
+
+	a.flashMsg(fmt.Sprintf("Rediscovered %d API resources, %d namespaces, %d contexts", resourceCount, nsCount, len(contexts)), false)
+	a.updateHeader()
+	a.refresh()
+}
+
 // setupUI initializes all UI components
 func (a *App) setupUI() {
 	// Header
@@ -248,7 +435,7 @@ func (a *App) setupUI() {
 
 	// AI Input field
 	a.aiInput = tview.NewInputField().
-		SetLabel(" 🤖 ").
+		SetLabel(a.glyphs(" 🤖 ")).
 		SetFieldWidth(0).
 		SetFieldBackgroundColor(tcell.ColorDefault).
 		SetPlaceholder("Ask AI a question...")
@@ -333,7 +520,7 @@ func (a *App) setupAIInput() {
 			question := a.aiInput.GetText()
 			if question != "" {
 				a.aiInput.SetText("")
-				go a.askAI(question)
+				go a.askAI(question, a.autoAttachContext())
 			}
 		}
 	})
@@ -343,6 +530,15 @@ func (a *App) setupAIInput() {
 		case tcell.KeyEsc, tcell.KeyTab:
 			a.SetFocus(a.table)
 			return nil
+		case tcell.KeyEnter:
+			// Alt+Enter asks with the opposite of auto_attach_context
+			if event.Modifiers()&tcell.ModAlt != 0 {
+				if question := a.aiInput.GetText(); question != "" {
+					a.aiInput.SetText("")
+					go a.askAI(question, !a.autoAttachContext())
+				}
+				return nil
+			}
 		}
 		return event
 	})
@@ -373,8 +569,10 @@ var currentToolCallInfo struct {
 	Command string
 }
 
-// askAI sends a question to the AI and displays the response
-func (a *App) askAI(question string) {
+// askAI sends a question to the AI and displays the response. With
+// attachContext the manifest, events and logs of the selected resource go
+// along; otherwise only its table row does.
+func (a *App) askAI(question string, attachContext bool) {
 	// Show loading state
 	a.QueueUpdateDraw(func() {
 		a.aiPanel.SetText(fmt.Sprintf("[yellow]Question:[white] %s\n\n[gray]Thinking...", question))
@@ -409,6 +607,11 @@ func (a *App) askAI(question string) {
 	if selectedInfo != "" {
 		prompt += fmt.Sprintf(`. Selected: %s`, selectedInfo)
 	}
+	if attachContext {
+		if details := a.selectedResourceContext(ctx, resource); details != "" {
+			prompt += "\n\nContext of the selected resource:\n\n" + details
+		}
+	}
 	prompt += fmt.Sprintf(`
 
 User question: %s
@@ -423,6 +626,8 @@ Please provide a concise, helpful answer. If you suggest kubectl commands, wrap
 		return
 	}
 
+	a.transcript.add("question", question, "", false)
+
 	// Check if AI supports tool calling (agentic mode)
 	var fullResponse strings.Builder
 	var err error
@@ -430,47 +635,30 @@ Please provide a concise, helpful answer. If you suggest kubectl commands, wrap
 	if a.aiClient.SupportsTools() {
 		// Use agentic mode with tool calling
 		a.QueueUpdateDraw(func() {
-			a.aiPanel.SetText(fmt.Sprintf("[yellow]Q:[white] %s\n\n[cyan]🤖 Agentic Mode[white] - AI can execute kubectl commands\n\n[gray]Thinking...", question))
+			a.aiPanel.SetText(a.glyphs(fmt.Sprintf("[yellow]Q:[white] %s\n\n[cyan]🤖 Agentic Mode[white] - AI can execute kubectl commands\n\n[gray]Thinking...", question)))
 		})
 
-		err = a.aiClient.AskWithTools(ctx, prompt, func(chunk string) {
+		err = a.aiClient.AskWithToolResults(ctx, prompt, func(chunk string) {
 			fullResponse.WriteString(chunk)
 			response := fullResponse.String()
 			a.QueueUpdateDraw(func() {
-				a.aiPanel.SetText(fmt.Sprintf("[yellow]Q:[white] %s\n\n[cyan]🤖 Agentic Mode[white]\n\n[green]A:[white] %s", question, response))
+				a.aiPanel.SetText(a.glyphs(fmt.Sprintf("[yellow]Q:[white] %s\n\n[cyan]🤖 Agentic Mode[white]\n\n[green]A:[white] %s", question, response)))
 			})
 		}, func(toolName string, args string) bool {
 			// Tool approval callback - kubectl-ai style Decision Required
 			a.logger.Info("Tool callback invoked", "tool", toolName, "args", args)
 
 			filter := ai.NewCommandFilter()
-
-			// Parse command from args
-			var cmdArgs struct {
-				Command   string `json:"command"`
-				Namespace string `json:"namespace,omitempty"`
-			}
-			if err := parseJSON(args, &cmdArgs); err != nil {
-				a.logger.Error("Failed to parse tool args", "error", err, "args", args)
-			}
-
-			fullCmd := ""
-			if toolName == "kubectl" {
-				fullCmd = "kubectl " + cmdArgs.Command
-				if cmdArgs.Namespace != "" && !strings.Contains(cmdArgs.Command, "-n ") {
-					fullCmd = "kubectl -n " + cmdArgs.Namespace + " " + cmdArgs.Command
-				}
-			} else if toolName == "bash" {
-				fullCmd = cmdArgs.Command
-			}
+			fullCmd := a.toolCommandLine(toolName, args)
 
 			a.logger.Info("Analyzed command", "fullCmd", fullCmd)
 
 			// Analyze command safety
 			report := filter.AnalyzeCommand(fullCmd)
 
-			// Read-only commands: auto-approve
-			if report.Type == ai.CommandTypeReadOnly {
+			// Commands the autonomy level lets run unattended
+			if a.config != nil && filter.AutoApprove(fullCmd, a.config) {
+				a.logger.Info("Auto-approved command", "fullCmd", fullCmd, "autonomy", a.config.AIAutonomyLevel())
 				return true
 			}
 
@@ -501,7 +689,7 @@ Please provide a concise, helpful answer. If you suggest kubectl commands, wrap
 				}
 
 				sb.WriteString("\n[gray]Press [green]Y[gray] or [green]Enter[gray] to approve, [red]N[gray] or [red]Esc[gray] to cancel[white]")
-				a.aiPanel.SetText(sb.String())
+				a.aiPanel.SetText(a.glyphs(sb.String()))
 
 				// Focus AI panel for key input
 				a.SetFocus(a.aiPanel)
@@ -520,18 +708,21 @@ Please provide a concise, helpful answer. If you suggest kubectl commands, wrap
 				if approved {
 					a.QueueUpdateDraw(func() {
 						currentText := a.aiPanel.GetText(false)
-						a.aiPanel.SetText(currentText + "\n\n[green]✓ Approved - Executing...[white]")
+						a.aiPanel.SetText(a.glyphs(currentText + "\n\n[green]✓ Approved - Executing...[white]"))
 					})
 				} else {
+					a.transcript.add("command", fullCmd, "Cancelled by user", true)
 					a.QueueUpdateDraw(func() {
 						currentText := a.aiPanel.GetText(false)
-						a.aiPanel.SetText(currentText + "\n\n[red]✗ Cancelled by user[white]")
+						a.aiPanel.SetText(a.glyphs(currentText + "\n\n[red]✗ Cancelled by user[white]"))
 					})
 				}
 				return approved
 			case <-ctx.Done():
 				return false
 			}
+		}, func(toolName, args, output string, isError bool) {
+			a.recordAICommand(a.toolCommandLine(toolName, args), output, isError)
 		})
 	} else {
 		// Fallback to regular streaming
@@ -545,11 +736,13 @@ Please provide a concise, helpful answer. If you suggest kubectl commands, wrap
 	}
 
 	if err != nil {
+		a.transcript.add("answer", fmt.Sprintf("Error: %v", err), "", false)
 		a.QueueUpdateDraw(func() {
 			a.aiPanel.SetText(fmt.Sprintf("[yellow]Q:[white] %s\n\n[red]Error:[white] %v", question, err))
 		})
 		return
 	}
+	a.transcript.add("answer", fullResponse.String(), "", false)
 
 	// After response complete, analyze for commands that need approval (fallback mode)
 	if !a.aiClient.SupportsTools() {
@@ -558,6 +751,29 @@ Please provide a concise, helpful answer. If you suggest kubectl commands, wrap
 	}
 }
 
+// toolCommandLine returns the command an AI tool call runs, e.g.
+// "kubectl -n shop get pods", or "" for other tools
+func (a *App) toolCommandLine(toolName, args string) string {
+	var cmdArgs struct {
+		Command   string `json:"command"`
+		Namespace string `json:"namespace,omitempty"`
+	}
+	if err := parseJSON(args, &cmdArgs); err != nil {
+		a.logger.Error("Failed to parse tool args", "error", err, "args", args)
+	}
+
+	switch toolName {
+	case "kubectl":
+		if cmdArgs.Namespace != "" && !strings.Contains(cmdArgs.Command, "-n ") {
+			return "kubectl -n " + cmdArgs.Namespace + " " + cmdArgs.Command
+		}
+		return "kubectl " + cmdArgs.Command
+	case "bash":
+		return cmdArgs.Command
+	}
+	return ""
+}
+
 // parseJSON is a helper to parse JSON arguments
 func parseJSON(jsonStr string, v interface{}) error {
 	return jsonUnmarshal([]byte(jsonStr), v)
@@ -620,7 +836,7 @@ func (a *App) analyzeAndShowDecisions(question, response string) {
 		}
 
 		sb.WriteString("[gray]Press [yellow]1-9[gray] to execute, [yellow]A[gray] to execute all, [yellow]Esc[gray] to cancel[white]")
-		a.aiPanel.SetText(sb.String())
+		a.aiPanel.SetText(a.glyphs(sb.String()))
 	})
 }
 
@@ -672,12 +888,26 @@ func (a *App) executeDecision(idx int) {
 		return
 	}
 
+	// Drains must pass the safety preflight or be explicitly overridden
+	if node, ok := drainTarget(pendingDecisions[idx].Command); ok {
+		a.preflightDrain([]string{node}, func() { a.runDecision(idx) })
+		return
+	}
+	a.runDecision(idx)
+}
+
+// runDecision runs a pending decision's command and reports the result
+func (a *App) runDecision(idx int) {
+	if idx < 0 || idx >= len(pendingDecisions) {
+		return
+	}
+
 	decision := pendingDecisions[idx]
 	a.flashMsg(fmt.Sprintf("Executing: %s", decision.Command), false)
 
 	// Execute the command
-	cmd := exec.Command("bash", "-c", decision.Command)
-	output, err := cmd.CombinedOutput()
+	output, err := a.runCommand("bash", "-c", decision.Command)
+	a.recordAICommand(decision.Command, string(output), err != nil)
 
 	// Update AI panel with result
 	a.QueueUpdateDraw(func() {
@@ -690,8 +920,8 @@ func (a *App) executeDecision(idx int) {
 
 		// Show execution result
 		currentText := a.aiPanel.GetText(false)
-		a.aiPanel.SetText(currentText + "\n\n[yellow]━━━ EXECUTION RESULT ━━━[white]\n" +
-			fmt.Sprintf("[cyan]%s[white]\n%s", decision.Command, result))
+		a.aiPanel.SetText(a.glyphs(currentText + "\n\n[yellow]━━━ EXECUTION RESULT ━━━[white]\n" +
+			fmt.Sprintf("[cyan]%s[white]\n%s", decision.Command, result)))
 	})
 
 	// Remove executed decision
@@ -707,6 +937,21 @@ func (a *App) executeAllDecisions() {
 		return
 	}
 
+	var nodes []string
+	for _, d := range pendingDecisions {
+		if node, ok := drainTarget(d.Command); ok {
+			nodes = append(nodes, node)
+		}
+	}
+	if len(nodes) > 0 {
+		a.preflightDrain(nodes, a.confirmExecuteAll)
+		return
+	}
+	a.confirmExecuteAll()
+}
+
+// confirmExecuteAll asks for confirmation before executing dangerous decisions
+func (a *App) confirmExecuteAll() {
 	// Show confirmation for dangerous commands
 	hasDangerous := false
 	for _, d := range pendingDecisions {
@@ -721,13 +966,13 @@ func (a *App) executeAllDecisions() {
 			SetText("[red]WARNING:[white] Some commands are dangerous!\n\nAre you sure you want to execute ALL commands?").
 			AddButtons([]string{"Cancel", "Execute All"}).
 			SetDoneFunc(func(buttonIndex int, buttonLabel string) {
-				a.pages.RemovePage("confirm-all")
+				a.closeOverlay("confirm-all")
 				if buttonLabel == "Execute All" {
 					go a.doExecuteAll()
 				}
 			})
 		modal.SetBackgroundColor(tcell.ColorDarkRed)
-		a.pages.AddPage("confirm-all", modal, true, true)
+		a.showOverlay("confirm-all", modal)
 	} else {
 		go a.doExecuteAll()
 	}
@@ -745,8 +990,8 @@ func (a *App) doExecuteAll() {
 	for _, decision := range decisions {
 		a.flashMsg(fmt.Sprintf("Executing: %s", decision.Command), false)
 
-		cmd := exec.Command("bash", "-c", decision.Command)
-		output, err := cmd.CombinedOutput()
+		output, err := a.runCommand("bash", "-c", decision.Command)
+		a.recordAICommand(decision.Command, string(output), err != nil)
 
 		results.WriteString(fmt.Sprintf("\n[cyan]%s[white]\n", decision.Command))
 		if err != nil {
@@ -758,7 +1003,7 @@ func (a *App) doExecuteAll() {
 
 	a.QueueUpdateDraw(func() {
 		currentText := a.aiPanel.GetText(false)
-		a.aiPanel.SetText(currentText + results.String())
+		a.aiPanel.SetText(a.glyphs(currentText + results.String()))
 	})
 
 	a.flashMsg(fmt.Sprintf("Executed %d commands", len(decisions)), false)
@@ -783,7 +1028,7 @@ func (a *App) setupAutocomplete() {
 				remaining := hint[len(text):]
 				a.cmdHint.SetText("[gray]" + remaining)
 			} else {
-				a.cmdHint.SetText("[gray] → " + hint)
+				a.cmdHint.SetText(a.glyphs("[gray] → ") + hint)
 			}
 		} else {
 			a.cmdHint.SetText("")
@@ -818,7 +1063,7 @@ func (a *App) setupAutocomplete() {
 					remaining := hint[len(text):]
 					a.cmdHint.SetText("[gray]" + remaining)
 				} else {
-					a.cmdHint.SetText("[gray] → " + hint)
+					a.cmdHint.SetText(a.glyphs("[gray] → ") + hint)
 				}
 			}
 			return nil
@@ -835,7 +1080,7 @@ func (a *App) setupAutocomplete() {
 					remaining := hint[len(text):]
 					a.cmdHint.SetText("[gray]" + remaining)
 				} else {
-					a.cmdHint.SetText("[gray] → " + hint)
+					a.cmdHint.SetText(a.glyphs("[gray] → ") + hint)
 				}
 			}
 			return nil
@@ -968,16 +1213,24 @@ func (a *App) getCompletions(input string) []string {
 		}
 	}
 
-	// Also match API resources from cluster (including CRDs)
-	a.mx.RLock()
-	apiResources := a.apiResources
-	a.mx.RUnlock()
-
 	seen := make(map[string]bool)
 	for _, m := range matches {
 		seen[m] = true
 	}
 
+	// Then the aliases from config
+	for _, m := range a.aliasCompletions(inputLower) {
+		if !seen[m] {
+			matches = append(matches, m)
+			seen[m] = true
+		}
+	}
+
+	// Also match API resources from cluster (including CRDs)
+	a.mx.RLock()
+	apiResources := a.apiResources
+	a.mx.RUnlock()
+
 	for _, res := range apiResources {
 		if seen[res.Name] {
 			continue
@@ -1119,9 +1372,10 @@ func (a *App) applyFilterText(filter string) {
 	a.mx.RLock()
 	headers := a.tableHeaders
 	rows := a.tableRows
+	display := a.displayColumns(a.currentResource, headers)
 	a.mx.RUnlock()
 
-	if len(headers) == 0 || len(rows) == 0 {
+	if len(headers) == 0 {
 		return
 	}
 
@@ -1148,20 +1402,19 @@ func (a *App) applyFilterText(filter string) {
 	filterLower := strings.ToLower(filterPattern)
 
 	a.QueueUpdateDraw(func() {
+		a.saveSelection()
 		a.table.Clear()
 
-		// Set headers
-		for i, h := range headers {
-			cell := tview.NewTableCell(h).
-				SetTextColor(tcell.ColorYellow).
-				SetAttributes(tcell.AttrBold).
-				SetSelectable(false).
-				SetExpansion(1)
-			a.table.SetCell(0, i, cell)
-		}
+		a.mx.RLock()
+		resource := a.currentResource
+		a.mx.RUnlock()
+
+		// Set headers, in the configured column order
+		a.setTableHeaders(headers, display)
 
 		// Filter and set rows
 		rowIdx := 1
+		var keys []string
 		for _, row := range rows {
 			if filterPattern != "" {
 				match := false
@@ -1183,11 +1436,12 @@ func (a *App) applyFilterText(filter string) {
 				}
 			}
 
-			for c, text := range row {
-				color := tcell.ColorWhite
-				if c == 2 { // Usually status column
-					color = a.statusColor(text)
+			for d, c := range display {
+				if c >= len(row) {
+					continue
 				}
+				text := row[c]
+				color := a.cellColor(headers, c, text)
 				// Highlight matching text
 				displayText := text
 				if filterPattern != "" {
@@ -1200,28 +1454,33 @@ func (a *App) applyFilterText(filter string) {
 				cell := tview.NewTableCell(displayText).
 					SetTextColor(color).
 					SetExpansion(1)
-				a.table.SetCell(rowIdx, c, cell)
+				a.table.SetCell(rowIdx, d, cell)
 			}
+			keys = append(keys, rowIdentity(resource, row))
 			rowIdx++
 		}
 
 		a.mx.RLock()
-		resource := a.currentResource
+		selector := a.podSelector()
+		fieldSelector := a.podFieldSelector()
+		freshness := a.viewFreshness(resource, selector, fieldSelector)
+		sortColumn, sortDesc := a.viewSort(resource)
 		a.mx.RUnlock()
 
-		filterInfo := ""
-		if filter != "" {
-			if isRegex {
-				filterInfo = fmt.Sprintf(" [regex: %s]", filterPattern)
-			} else {
-				filterInfo = fmt.Sprintf(" [filter: %s]", filter)
-			}
-		}
-		a.table.SetTitle(fmt.Sprintf(" %s (%d/%d)%s ", resource, rowIdx-1, len(rows), filterInfo))
-
-		if rowIdx > 1 {
-			a.table.Select(1, 0)
-		}
+		a.table.SetTitle(tableTitle{
+			Resource:      resource,
+			Shown:         rowIdx - 1,
+			Total:         len(rows),
+			Filter:        filterPattern,
+			Regex:         isRegex,
+			SortColumn:    sortColumn,
+			SortDesc:      sortDesc,
+			LabelSelector: selector,
+			FieldSelector: fieldSelector,
+			Freshness:     freshness,
+		}.String())
+
+		a.restoreSelection(resource, keys)
 	})
 }
 
@@ -1271,6 +1530,12 @@ func (a *App) restoreAutocompleteHandler() {
 
 // setupKeybindings configures keyboard shortcuts (k9s compatible)
 func (a *App) setupKeybindings() {
+	// Remember when the user last typed, so auto-refresh can hold off
+	a.Application.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		atomic.StoreInt64(&a.lastInput, time.Now().UnixNano())
+		return event
+	})
+
 	a.table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
 		switch event.Key() {
 		case tcell.KeyRune:
@@ -1293,6 +1558,9 @@ func (a *App) setupKeybindings() {
 			case 'n':
 				a.cycleNamespace()
 				return nil
+			case 'N':
+				a.showNamespaceSwitcher() // type-to-filter namespace picker
+				return nil
 			// k9s style: 0 = all namespaces
 			case '0':
 				go a.switchToAllNamespaces()
@@ -1313,7 +1581,11 @@ func (a *App) setupKeybindings() {
 				a.editResource() // k9s: e = edit
 				return nil
 			case 's':
-				a.execShell() // k9s: s = shell
+				if a.isCronJobView() {
+					a.toggleCronJobSuspend() // k9s: s = suspend/resume (cronjobs)
+				} else {
+					a.execShell() // k9s: s = shell
+				}
 				return nil
 			case 'a':
 				a.attachContainer() // k9s: a = attach
@@ -1331,7 +1603,11 @@ func (a *App) setupKeybindings() {
 				a.useNamespace() // k9s: u = use namespace
 				return nil
 			case 'o':
-				a.showNode() // k9s: o = show node (for pods)
+				if a.isImagesView() {
+					a.toggleImageSort() // o = order by pod count (images)
+				} else {
+					a.showNode() // k9s: o = show node (for pods)
+				}
 				return nil
 			case 'k':
 				a.killPod() // k9s: k or Ctrl+K = kill pod
@@ -1348,12 +1624,48 @@ func (a *App) setupKeybindings() {
 			case 'F':
 				a.portForward() // k9s: Shift+F = port-forward
 				return nil
+			case 'f':
+				a.showPortForwards() // list/stop active port forwards
+				return nil
 			case 'S':
 				a.scaleResource() // k9s: Shift+S = scale
 				return nil
 			case 'R':
 				a.restartResource() // k9s: Shift+R = restart
 				return nil
+			case 'X':
+				a.confirmRemoveFinalizers() // remove finalizers from a stuck object
+				return nil
+			case 'x':
+				a.debugPod() // kubectl debug: ephemeral debug container
+				return nil
+			case 'L':
+				a.showMetadataEditor() // add/remove labels or annotations
+				return nil
+			case 'T':
+				a.showNodeEditor() // node label/taint editor
+				return nil
+			case 'C':
+				a.showNodeActions() // cordon/uncordon/drain node
+				return nil
+			case 'U':
+				a.showRollback() // roll back a deployment to a previous revision
+				return nil
+			case 'W':
+				go a.toggleAutoRefresh() // pause/resume auto-refresh
+				return nil
+			case 'Y':
+				a.copySelectedName() // copy the resource name to the clipboard
+				return nil
+			case 'E':
+				a.editAndApply() // edit the YAML in the TUI and apply it
+				return nil
+			case 'V':
+				a.compareSelected() // side-by-side YAML diff of two selected rows
+				return nil
+			case 'i':
+				a.toggleLabels() // show/hide the LABELS column
+				return nil
 			case ' ':
 				a.toggleSelection() // k9s: Space = toggle selection (multi-select)
 				return nil
@@ -1375,6 +1687,18 @@ func (a *App) setupKeybindings() {
 		case tcell.KeyCtrlK:
 			a.killPod() // k9s: Ctrl+K = kill pod
 			return nil
+		case tcell.KeyCtrlS:
+			a.downloadSelectedLogs() // save logs of the selected pods
+			return nil
+		case tcell.KeyCtrlZ:
+			a.undoLast() // reverse the last cordon/scale/suspend
+			return nil
+		case tcell.KeyCtrlY:
+			a.copySelectedYAML() // copy the resource YAML to the clipboard
+			return nil
+		case tcell.KeyCtrlR:
+			go a.refreshAll() // re-discover API resources, namespaces and contexts
+			return nil
 		case tcell.KeyCtrlU:
 			a.pageUp() // k9s: Ctrl+U = page up
 			return nil
@@ -1487,7 +1811,7 @@ func (a *App) flashMsg(msg string, isError bool) {
 		color = "[red]"
 	}
 	a.QueueUpdateDraw(func() {
-		a.flash.SetText(color + msg + "[white]")
+		a.flash.SetText(color + a.glyphs(msg) + "[white]")
 	})
 
 	// Clear after 3 seconds
@@ -1538,11 +1862,11 @@ func (a *App) updateHeader() {
 	// Use QueueUpdateDraw only after Application.Run() has started (k9s pattern)
 	if atomic.LoadInt32(&a.running) == 1 {
 		a.QueueUpdateDraw(func() {
-			a.header.SetText(header)
+			a.header.SetText(a.glyphs(header))
 		})
 	} else {
 		// Direct update during initialization (before Run())
-		a.header.SetText(header)
+		a.header.SetText(a.glyphs(header))
 	}
 }
 
@@ -1563,11 +1887,13 @@ func (a *App) updateStatusBar() {
 		shortcuts = "[yellow]<S>[white]Scale [yellow]<R>[white]Restart [yellow]<d>[white]Describe " + shortcuts
 	case "namespaces", "ns":
 		shortcuts = "[yellow]<u>[white]Use " + shortcuts
+	case "images":
+		shortcuts = "[yellow]<o>[white]Order " + shortcuts
 	default:
 		shortcuts = "[yellow]<d>[white]Describe [yellow]<y>[white]YAML " + shortcuts
 	}
 
-	a.statusBar.SetText(shortcuts)
+	a.statusBar.SetText(a.tasks.status() + shortcuts + a.autoRefreshStatus())
 }
 
 // prepareContext cancels previous operations and creates new context (k9s pattern)
@@ -1586,34 +1912,55 @@ func (a *App) prepareContext() context.Context {
 
 // refresh reloads the current resource list with atomic guard (k9s pattern)
 func (a *App) refresh() {
+	a.refreshView(false)
+}
+
+// refreshView reloads the current resource list. A quiet refresh, used by
+// auto-refresh, keeps the table on screen while loading, does not retry and
+// leaves the table as it was when the fetch fails.
+func (a *App) refreshView(quiet bool) {
 	// Atomic guard to prevent concurrent updates (k9s pattern)
 	if !atomic.CompareAndSwapInt32(&a.inUpdate, 0, 1) {
 		a.logger.Debug("Dropping refresh - update already in progress")
 		return
 	}
-	defer atomic.StoreInt32(&a.inUpdate, 0)
+	// A refresh dropped while this one ran may have been for another view;
+	// if the view changed, load it once this one is done
+	stale := false
+	defer func() {
+		atomic.StoreInt32(&a.inUpdate, 0)
+		if stale {
+			go a.refresh()
+		}
+	}()
 
 	ctx := a.prepareContext()
 
 	a.mx.RLock()
 	resource := a.currentResource
+	namespace := a.currentNamespace
 	a.mx.RUnlock()
 
 	// Show loading state
-	a.QueueUpdateDraw(func() {
-		a.table.Clear()
-		a.table.SetTitle(fmt.Sprintf(" %s - Loading... ", resource))
-		a.table.SetCell(0, 0, tview.NewTableCell("Loading...").SetTextColor(tcell.ColorYellow))
-	})
+	if !quiet {
+		a.QueueUpdateDraw(func() {
+			a.saveSelection()
+			a.table.Clear()
+			a.table.SetTitle(fmt.Sprintf(" %s - Loading... ", resource))
+			a.table.SetCell(0, 0, tview.NewTableCell("Loading...").SetTextColor(tcell.ColorYellow))
+		})
+	}
 
 	// Fetch with exponential backoff (k9s pattern)
 	var headers []string
 	var rows [][]string
 	var fetchErr error
 
-	bf := backoff.NewExponentialBackOff()
-	bf.InitialInterval = 300 * time.Millisecond
-	bf.MaxElapsedTime = 10 * time.Second
+	bf, maxRetries := a.refreshBackOff()
+	if quiet {
+		maxRetries = 0
+	}
+	attempt := 0
 
 	err := backoff.Retry(func() error {
 		select {
@@ -1622,30 +1969,69 @@ func (a *App) refresh() {
 		default:
 		}
 
+		if attempt > 0 {
+			retry := attempt
+			a.QueueUpdateDraw(func() {
+				a.table.SetTitle(fmt.Sprintf(" %s - Loading... (retrying %d/%d) ", resource, retry, maxRetries))
+			})
+		}
+		attempt++
+
 		headers, rows, fetchErr = a.fetchResources(ctx)
 		if fetchErr != nil {
-			a.logger.Warn("Fetch failed, retrying", "error", fetchErr, "resource", resource)
+			// Permission and auth problems will not fix themselves; don't retry
+			switch k8s.ClassifyError(fetchErr, resource, namespace).Kind {
+			case k8s.ErrorKindForbidden, k8s.ErrorKindUnauthorized, k8s.ErrorKindNotFound:
+				return backoff.Permanent(fetchErr)
+			}
+			a.logger.Warn("Fetch failed, retrying", "error", fetchErr, "resource", resource, "attempt", attempt)
 			return fetchErr
 		}
 		return nil
-	}, backoff.WithContext(bf, ctx))
+	}, backoff.WithContext(backoff.WithMaxRetries(bf, uint64(maxRetries)), ctx))
 
+	a.mx.RLock()
+	stale = a.currentResource != resource || a.currentNamespace != namespace
+	showLabels := a.showLabels
+	a.mx.RUnlock()
+	if stale {
+		return
+	}
+	if err != nil && quiet {
+		a.logger.Warn("Auto-refresh failed", "error", err, "resource", resource)
+		return
+	}
 	if err != nil {
 		a.logger.Error("Fetch failed after retries", "error", err, "resource", resource)
-		a.flashMsg(fmt.Sprintf("Error: %v", err), true)
+		ce := k8s.ClassifyError(err, resource, namespace)
+		a.flashMsg(fmt.Sprintf("Error: %s", ce.Message), true)
 		a.QueueUpdateDraw(func() {
 			a.table.Clear()
 			a.table.SetTitle(fmt.Sprintf(" %s - Error ", resource))
-			a.table.SetCell(0, 0, tview.NewTableCell(fmt.Sprintf("Error: %v", err)).SetTextColor(tcell.ColorRed))
+			a.table.SetCell(0, 0, tview.NewTableCell(fmt.Sprintf("Error: %s", ce.Message)).SetTextColor(tcell.ColorRed))
+			if ce.Suggestion != "" {
+				a.table.SetCell(1, 0, tview.NewTableCell(fmt.Sprintf("Hint: %s", ce.Suggestion)).SetTextColor(tcell.ColorYellow))
+			}
 		})
 		return
 	}
 
+	if showLabels {
+		headers, rows = a.withLabels(resource, headers, rows)
+	}
+
 	// Store original data for filtering
 	a.mx.Lock()
 	a.tableHeaders = headers
 	a.tableRows = rows
 	currentFilter := a.filterText
+	if a.filterRegex && currentFilter != "" {
+		currentFilter = "/" + currentFilter + "/"
+	}
+	selector := a.podSelector()
+	fieldSelector := a.podFieldSelector()
+	freshness := a.viewFreshness(resource, selector, fieldSelector)
+	sortColumn, sortDesc := a.viewSort(resource)
 	a.mx.Unlock()
 
 	// Apply filter if active, otherwise show all
@@ -1654,38 +2040,34 @@ func (a *App) refresh() {
 	} else {
 		// Update UI (k9s pattern: queue all UI updates)
 		a.QueueUpdateDraw(func() {
+			a.saveSelection()
 			a.table.Clear()
 
-			// Set headers
-			for i, h := range headers {
-				cell := tview.NewTableCell(h).
-					SetTextColor(tcell.ColorYellow).
-					SetAttributes(tcell.AttrBold).
-					SetSelectable(false).
-					SetExpansion(1)
-				a.table.SetCell(0, i, cell)
-			}
+			// Set headers, in the configured column order
+			display := a.displayColumns(resource, headers)
+			a.setTableHeaders(headers, display)
 
 			// Set rows
+			keys := make([]string, len(rows))
 			for r, row := range rows {
-				for c, text := range row {
-					color := tcell.ColorWhite
-					if c == 2 { // Usually status column
-						color = a.statusColor(text)
+				keys[r] = rowIdentity(resource, row)
+				for d, c := range display {
+					if c >= len(row) {
+						continue
 					}
+					text := row[c]
+					color := a.cellColor(headers, c, text)
 					cell := tview.NewTableCell(text).
 						SetTextColor(color).
 						SetExpansion(1)
-					a.table.SetCell(r+1, c, cell)
+					a.table.SetCell(r+1, d, cell)
 				}
 			}
 
 			count := len(rows)
-			a.table.SetTitle(fmt.Sprintf(" %s (%d) ", resource, count))
+			a.table.SetTitle(tableTitle{Resource: resource, Shown: count, Total: count, SortColumn: sortColumn, SortDesc: sortDesc, LabelSelector: selector, FieldSelector: fieldSelector, Freshness: freshness}.String())
 
-			if count > 0 {
-				a.table.Select(1, 0)
-			}
+			a.restoreSelection(resource, keys)
 		})
 	}
 
@@ -1697,6 +2079,24 @@ func (a *App) refresh() {
 	a.logger.Info("Refresh completed", "resource", resource, "count", len(rows))
 }
 
+// refreshBackOff builds the retry policy for refresh from config.Refresh
+func (a *App) refreshBackOff() (*backoff.ExponentialBackOff, int) {
+	rc := config.NewDefaultConfig().Refresh
+	if a.config != nil {
+		rc = a.config.Refresh
+	}
+
+	bf := backoff.NewExponentialBackOff()
+	bf.InitialInterval = time.Duration(rc.InitialInterval * float64(time.Second))
+	bf.MaxElapsedTime = time.Duration(rc.MaxElapsedTime * float64(time.Second))
+
+	maxRetries := rc.MaxRetries
+	if maxRetries < 0 {
+		maxRetries = 0
+	}
+	return bf, maxRetries
+}
+
 // fetchResources gets resources from K8s API
 func (a *App) fetchResources(ctx context.Context) ([]string, [][]string, error) {
 	if a.k8s == nil {
@@ -1769,6 +2169,8 @@ func (a *App) fetchResources(ctx context.Context) ([]string, [][]string, error)
 		return a.fetchHPAs(ctx, ns)
 	case "customresourcedefinitions":
 		return a.fetchCRDs(ctx)
+	case "images":
+		return a.fetchImages(ctx, ns)
 	default:
 		// Try generic fetch for unknown resources
 		return a.fetchGenericResource(ctx, resource, ns)
@@ -1776,14 +2178,25 @@ func (a *App) fetchResources(ctx context.Context) ([]string, [][]string, error)
 }
 
 func (a *App) fetchPods(ctx context.Context, ns string) ([]string, [][]string, error) {
-	headers := []string{"NAMESPACE", "NAME", "STATUS", "READY", "RESTARTS", "AGE"}
-	pods, err := a.k8s.ListPods(ctx, ns)
+	a.mx.RLock()
+	selector := a.labelSelector
+	fieldSelector := a.fieldSelector
+	a.mx.RUnlock()
+
+	headers := []string{"NAMESPACE", "NAME", "STATUS", "READY", "RESTARTS", "AGE", "NODE"}
+	pods, err := a.k8s.ListPods(ctx, ns, k8s.WithLabelSelector(selector), k8s.WithFieldSelector(fieldSelector))
 	if err != nil {
 		return headers, nil, err
 	}
+	a.setListedLabels("pods", objectLabels(pods))
 
 	var rows [][]string
+	containers := make(map[string][]string, len(pods))
 	for _, p := range pods {
+		for _, c := range p.Spec.Containers {
+			containers[p.Namespace+"/"+p.Name] = append(containers[p.Namespace+"/"+p.Name], c.Name)
+		}
+
 		ready := 0
 		total := len(p.Status.ContainerStatuses)
 		var restarts int32
@@ -1801,6 +2214,9 @@ func (a *App) fetchPods(ctx context.Context, ns string) ([]string, [][]string, e
 				break
 			}
 		}
+		if p.DeletionTimestamp != nil {
+			status = "Terminating"
+		}
 
 		rows = append(rows, []string{
 			p.Namespace,
@@ -1809,8 +2225,13 @@ func (a *App) fetchPods(ctx context.Context, ns string) ([]string, [][]string, e
 			fmt.Sprintf("%d/%d", ready, total),
 			fmt.Sprintf("%d", restarts),
 			formatAge(p.CreationTimestamp.Time),
+			p.Spec.NodeName,
 		})
 	}
+
+	a.mx.Lock()
+	a.podContainers = containers
+	a.mx.Unlock()
 	return headers, rows, nil
 }
 
@@ -1820,6 +2241,7 @@ func (a *App) fetchDeployments(ctx context.Context, ns string) ([]string, [][]st
 	if err != nil {
 		return headers, nil, err
 	}
+	a.setListedLabels("deployments", objectLabels(deps))
 
 	var rows [][]string
 	for _, d := range deps {
@@ -1853,6 +2275,7 @@ func (a *App) fetchServices(ctx context.Context, ns string) ([]string, [][]strin
 	if err != nil {
 		return headers, nil, err
 	}
+	a.setListedLabels("services", objectLabels(svcs))
 
 	var rows [][]string
 	for _, s := range svcs {
@@ -1874,11 +2297,19 @@ func (a *App) fetchServices(ctx context.Context, ns string) ([]string, [][]strin
 }
 
 func (a *App) fetchNodes(ctx context.Context) ([]string, [][]string, error) {
-	headers := []string{"NAME", "STATUS", "ROLES", "VERSION", "AGE"}
+	headers := []string{"NAME", "STATUS", "ROLES", "VERSION", "CPU%", "MEM%", "AGE"}
 	nodes, err := a.k8s.ListNodes(ctx)
 	if err != nil {
 		return headers, nil, err
 	}
+	a.setListedLabels("nodes", objectLabels(nodes))
+	// Without metrics-server the percentage columns are dropped; when
+	// metrics fail otherwise they read <unknown>
+	metrics, err := a.k8s.GetNodeMetrics(ctx)
+	showMetrics := !errors.Is(err, k8s.ErrMetricsUnavailable)
+	if !showMetrics {
+		headers = []string{"NAME", "STATUS", "ROLES", "VERSION", "AGE"}
+	}
 
 	var rows [][]string
 	for _, n := range nodes {
@@ -1900,41 +2331,50 @@ func (a *App) fetchNodes(ctx context.Context) ([]string, [][]string, error) {
 			roles = []string{"<none>"}
 		}
 
-		rows = append(rows, []string{
-			n.Name,
-			status,
-			strings.Join(roles, ","),
-			n.Status.NodeInfo.KubeletVersion,
-			formatAge(n.CreationTimestamp.Time),
-		})
+		cpuPct, memPct := "<unknown>", "<unknown>"
+		if usage, ok := metrics[n.Name]; ok {
+			cpuPct = formatPercent(usage[0], n.Status.Allocatable.Cpu().MilliValue())
+			memPct = formatPercent(usage[1], n.Status.Allocatable.Memory().Value()/1024/1024)
+		}
+
+		row := []string{n.Name, status, strings.Join(roles, ","), n.Status.NodeInfo.KubeletVersion}
+		if showMetrics {
+			row = append(row, cpuPct, memPct)
+		}
+		rows = append(rows, append(row, formatAge(n.CreationTimestamp.Time)))
 	}
 	return headers, rows, nil
 }
 
 func (a *App) fetchNamespaces(ctx context.Context) ([]string, [][]string, error) {
-	headers := []string{"NAME", "STATUS", "AGE"}
+	headers := []string{"NAME", "STATUS", "PODS", "DEPLOYMENTS", "SERVICES", "AGE"}
 	nss, err := a.k8s.ListNamespaces(ctx)
 	if err != nil {
 		return headers, nil, err
 	}
+	a.setListedLabels("namespaces", objectLabels(nss))
 
-	// Cache namespaces for cycling (protected by mutex)
-	a.mx.Lock()
-	a.namespaces = []string{""}
-	a.mx.Unlock()
+	names := make([]string, 0, len(nss))
+	for _, n := range nss {
+		names = append(names, n.Name)
+	}
+	counts := a.namespaceCounts(ctx, names)
 
 	var rows [][]string
 	for _, n := range nss {
-		a.mx.Lock()
-		a.namespaces = append(a.namespaces, n.Name)
-		a.mx.Unlock()
-
+		c := counts[n.Name]
 		rows = append(rows, []string{
 			n.Name,
 			string(n.Status.Phase),
+			formatCount(c.Pods),
+			formatCount(c.Deployments),
+			formatCount(c.Services),
 			formatAge(n.CreationTimestamp.Time),
 		})
 	}
+
+	// Cache namespaces for cycling
+	a.setNamespaceCache(names)
 	return headers, rows, nil
 }
 
@@ -1962,12 +2402,48 @@ func (a *App) fetchEvents(ctx context.Context, ns string) ([]string, [][]string,
 	return headers, rows, nil
 }
 
+// formatPercent renders used/total as a whole percentage, or <unknown>
+// when total is not known
+func formatPercent(used, total int64) string {
+	if total <= 0 {
+		return "<unknown>"
+	}
+	return fmt.Sprintf("%d%%", used*100/total)
+}
+
+// cellColor picks the text color of a table cell from its column
+func (a *App) cellColor(headers []string, col int, text string) tcell.Color {
+	if col < len(headers) && strings.HasSuffix(headers[col], "%") {
+		return utilizationColor(text)
+	}
+	if col == 2 { // Usually status column
+		return a.statusColor(text)
+	}
+	return tcell.ColorWhite
+}
+
+// utilizationColor colors a percentage green under 70%, yellow under 90%
+// and red above
+func utilizationColor(text string) tcell.Color {
+	pct, err := strconv.Atoi(strings.TrimSuffix(text, "%"))
+	switch {
+	case err != nil:
+		return tcell.ColorWhite
+	case pct < 70:
+		return tcell.ColorGreen
+	case pct < 90:
+		return tcell.ColorYellow
+	default:
+		return tcell.ColorRed
+	}
+}
+
 // statusColor returns color based on status
 func (a *App) statusColor(status string) tcell.Color {
 	switch status {
 	case "Running", "Ready", "Active", "Succeeded", "Normal", "Completed":
 		return tcell.ColorGreen
-	case "Pending", "ContainerCreating", "Warning", "Updating":
+	case "Pending", "ContainerCreating", "Warning", "Updating", "Terminating":
 		return tcell.ColorYellow
 	case "Failed", "Error", "CrashLoopBackOff", "NotReady", "ImagePullBackOff", "ErrImagePull":
 		return tcell.ColorRed
@@ -1980,6 +2456,8 @@ func (a *App) statusColor(status string) tcell.Color {
 func (a *App) setResource(resource string) {
 	a.mx.Lock()
 	a.currentResource = resource
+	a.labelSelector = ""
+	a.fieldSelector = ""
 	a.mx.Unlock()
 
 	// Run both updateHeader and refresh in goroutine to avoid deadlock
@@ -2019,7 +2497,7 @@ func (a *App) cycleNamespace() {
 
 // handleCommand processes command input
 func (a *App) handleCommand(cmd string) {
-	cmd = strings.TrimSpace(cmd)
+	cmd = a.expandAlias(strings.TrimSpace(cmd))
 	if cmd == "" {
 		return
 	}
@@ -2043,6 +2521,13 @@ func (a *App) handleCommand(cmd string) {
 		return
 	}
 
+	// apply takes a path, which must not be mistaken for -n/-A flags
+	if cmd == "apply" || cmd == "ap" || strings.HasPrefix(cmd, "apply ") || strings.HasPrefix(cmd, "ap ") {
+		_, path, _ := strings.Cut(cmd, " ")
+		a.applyFile(strings.TrimSpace(path))
+		return
+	}
+
 	// Parse command with -n/--namespace flag (kubectl style: pods -n kube-system)
 	parts := strings.Fields(cmd)
 	resourceCmd := ""
@@ -2073,12 +2558,18 @@ func (a *App) handleCommand(cmd string) {
 		a.mx.Unlock()
 	}
 
+	// :transcript json picks the export format
+	if (resourceCmd == "transcript" || resourceCmd == "tr") && len(parts) > 1 {
+		a.exportTranscript(parts[1])
+		return
+	}
+
 	// Use the resource command if found
 	if resourceCmd != "" {
 		// Use the commands list to handle all resource types dynamically
 		for _, c := range commands {
 			if resourceCmd == c.name || resourceCmd == c.alias {
-				if c.category == "resource" {
+				if c.category == "resource" || c.category == "view" {
 					a.setResource(c.name)
 					return
 				}
@@ -2089,7 +2580,7 @@ func (a *App) handleCommand(cmd string) {
 	// Fallback: Use the commands list for simple commands without flags
 	for _, c := range commands {
 		if cmd == c.name || cmd == c.alias {
-			if c.category == "resource" {
+			if c.category == "resource" || c.category == "view" {
 				a.setResource(c.name)
 				return
 			}
@@ -2100,12 +2591,31 @@ func (a *App) handleCommand(cmd string) {
 	switch cmd {
 	case "health", "status":
 		a.showHealth()
+	case "findings", "fi":
+		a.showFindings()
+	case "unused", "un":
+		a.showUnused()
+	case "changes", "chg":
+		a.showRecentChanges()
+	case "ages", "age":
+		a.showAgeHistogram()
+	case "refresh-all", "ra":
+		go a.refreshAll()
+	case "bundle", "sb":
+		a.collectBundle()
+	case "transcript", "tr":
+		a.exportTranscript("md")
 	case "context", "ctx":
 		a.showContextSwitcher()
 	case "help", "?":
 		a.showHelp()
 	case "q", "quit", "exit":
 		a.Stop()
+	default:
+		// Any other discovered resource, e.g. a CRD, is listed generically
+		if res, ok := a.lookupAPIResource(resourceCmd); ok {
+			a.setResource(res.Name)
+		}
 	}
 }
 
@@ -2124,46 +2634,300 @@ func (a *App) showLogs() {
 		return
 	}
 
-	ns := a.table.GetCell(row, 0).Text
-	name := a.table.GetCell(row, 1).Text
+	ns := a.tableCell(row, 0).Text
+	name := a.tableCell(row, 1).Text
+
+	a.pickContainer(ns, name, func(containers []string, idx int) {
+		a.openLogView(ns, name, containers, idx)
+	})
+}
+
+// pickContainer looks up the containers of a pod and calls choose on the UI
+// goroutine with the index of the one to use, asking first when there is
+// more than one. Containers come from the last pod list when the pod is in
+// it. If the pod cannot be read, choose gets a single "" so the server
+// picks the default container.
+func (a *App) pickContainer(ns, name string, choose func(containers []string, idx int)) {
+	a.mx.RLock()
+	cached := a.podContainers[ns+"/"+name]
+	a.mx.RUnlock()
+
+	go func() {
+		containers := cached
+		if len(containers) == 0 {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			var err error
+			containers, err = a.k8s.GetPodContainers(ctx, ns, name)
+			if err != nil || len(containers) == 0 {
+				containers = []string{""}
+			}
+		}
+		a.QueueUpdateDraw(func() {
+			if len(containers) == 1 {
+				choose(containers, 0)
+				return
+			}
+
+			list := tview.NewList()
+			list.SetBorder(true).SetTitle(fmt.Sprintf(" Container of %s (Enter to select, Esc to cancel) ", name))
+			for i, c := range containers {
+				shortcut := rune(0)
+				if i < 9 {
+					shortcut = rune('1' + i)
+				}
+				list.AddItem(c, "", shortcut, nil)
+			}
+			list.SetSelectedFunc(func(index int, _, _ string, _ rune) {
+				a.closeOverlay("container-picker")
+				choose(containers, index)
+			})
+			a.showCentered("container-picker", list, 60, min(len(containers)+4, 20))
+		})
+	}()
+}
+
+// allContainers selects every container of the pod in the log view
+const allContainers = -1
 
+// openLogView shows the logs of containers[idx] (allContainers for all of
+// them). In the view, 1-9 switch to another container, 0 shows all and f
+// toggles follow mode.
+func (a *App) openLogView(ns, name string, containers []string, idx int) {
 	logView := tview.NewTextView().
 		SetDynamicColors(true).
 		SetScrollable(true)
-	logView.SetBorder(true).
-		SetTitle(fmt.Sprintf(" Logs: %s/%s (Press Esc to close) ", ns, name))
+	logView.SetBorder(true)
 
 	a.pages.AddPage("logs", logView, true, true)
 	a.SetFocus(logView)
 
-	// Fetch logs
-	go func() {
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
+	follow := &logFollow{}
+	selected := func() []string {
+		if idx == allContainers {
+			return containers
+		}
+		return containers[idx : idx+1]
+	}
+	title := func(following bool) string {
+		target := ns + "/" + name
+		if idx == allContainers {
+			target += " [all containers]"
+		} else if containers[idx] != "" && len(containers) > 1 {
+			target += " [" + containers[idx] + "]"
+		}
+		if following {
+			return fmt.Sprintf(" Logs: %s [green][following][-] (f: stop, Esc: close) ", target)
+		}
+		if len(containers) > 1 {
+			return fmt.Sprintf(" Logs: %s (0-%d: container, f: follow, Ctrl+S: save, Esc: close) ", target, min(len(containers), 9))
+		}
+		return fmt.Sprintf(" Logs: %s (f: follow, Ctrl+S: save, Esc: close) ", target)
+	}
 
-		logs, err := a.k8s.GetPodLogs(ctx, ns, name, "", 100)
-		a.QueueUpdateDraw(func() {
-			if err != nil {
-				logView.SetText(fmt.Sprintf("[red]Error: %v", err))
-			} else if logs == "" {
-				logView.SetText("[gray]No logs available")
-			} else {
-				logView.SetText(logs)
-				logView.ScrollToEnd()
+	// load fetches the last 100 lines of the selected containers
+	load := func() {
+		logView.SetTitle(title(false))
+		logView.SetText("[gray]Loading...")
+		targets := selected()
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			var sb strings.Builder
+			var err error
+			for _, c := range targets {
+				logs, cerr := a.k8s.GetPodLogs(ctx, ns, name, c, 100)
+				if cerr != nil {
+					err = cerr
+					continue
+				}
+				if len(targets) > 1 && logs != "" {
+					fmt.Fprintf(&sb, "[yellow]==> %s <==[-]\n", c)
+				}
+				sb.WriteString(logs)
 			}
-		})
-	}()
+			logs := sb.String()
+			a.QueueUpdateDraw(func() {
+				if follow.running() {
+					return // the stream already shows the latest lines
+				}
+				if err != nil && logs == "" {
+					logView.SetText(fmt.Sprintf("[red]Error: %v", err))
+				} else if logs == "" {
+					logView.SetText("[gray]No logs available")
+				} else {
+					logView.SetText(logs)
+					logView.ScrollToEnd()
+				}
+			})
+		}()
+	}
+	load()
 
 	logView.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-		if event.Key() == tcell.KeyEsc {
+		switch {
+		case event.Key() == tcell.KeyEsc:
+			follow.stop()
 			a.pages.RemovePage("logs")
 			a.SetFocus(a.table)
 			return nil
+		case event.Rune() == 'f':
+			if follow.stop() {
+				logView.SetTitle(title(false))
+			} else {
+				logView.SetTitle(title(true))
+				a.followLogs(follow, logView, ns, name, selected(), func() { logView.SetTitle(title(false)) })
+			}
+			return nil
+		case event.Key() == tcell.KeyCtrlS:
+			a.saveLogView(logView, ns, name, func() string { return title(follow.running()) })
+			return nil
+		case event.Rune() >= '0' && event.Rune() <= '9' && len(containers) > 1:
+			next := int(event.Rune()-'0') - 1 // '0' selects all containers
+			if next >= len(containers) || next == idx {
+				return nil
+			}
+			follow.stop()
+			idx = next
+			load()
+			return nil
 		}
 		return event
 	})
 }
 
+// logsDir is the directory under the home directory that Ctrl+S in a log
+// view saves to
+const logsDir = "k13s-logs"
+
+// saveLogs writes text to ~/k13s-logs/<ns>-<pod>-<timestamp>.log, creating
+// the directory if needed, and returns the file path
+func saveLogs(ns, pod, text string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(home, logsDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, fmt.Sprintf("%s-%s-%s.log", ns, pod, time.Now().Format("20060102-150405")))
+	if err := os.WriteFile(path, []byte(text), 0644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// saveLogView saves what a log view currently shows, even while it is still
+// streaming. The flash bar is hidden behind the view, so the outcome is also
+// shown in the view title for a few seconds before restoreTitle is applied.
+func (a *App) saveLogView(view *tview.TextView, ns, pod string, restoreTitle func() string) {
+	msg, isError := "", false
+	if path, err := saveLogs(ns, pod, view.GetText(true)); err != nil {
+		msg, isError = fmt.Sprintf("Failed to save logs: %v", err), true
+		view.SetTitle(" [red]" + tview.Escape(msg) + "[-] ")
+	} else {
+		msg = "Logs saved to " + path
+		view.SetTitle(" [green]" + tview.Escape(msg) + "[-] ")
+	}
+	a.flashMsg(msg, isError)
+
+	go func() {
+		time.Sleep(3 * time.Second)
+		a.QueueUpdateDraw(func() {
+			view.SetTitle(restoreTitle())
+		})
+	}()
+}
+
+// logFollow tracks the stream of a log view in follow mode
+type logFollow struct {
+	mu     sync.Mutex
+	cancel context.CancelFunc
+}
+
+// stop ends the stream, if any, and reports whether one was running
+func (f *logFollow) stop() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.cancel == nil {
+		return false
+	}
+	f.cancel()
+	f.cancel = nil
+	return true
+}
+
+// running reports whether a stream is open
+func (f *logFollow) running() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.cancel != nil
+}
+
+// followLogs replaces the content of view with the last 100 lines of the
+// container logs and appends new lines as they arrive, until f is stopped or
+// the containers exit; ended then runs on the UI goroutine. With several
+// containers, each line is prefixed with its container name.
+func (a *App) followLogs(f *logFollow, view *tview.TextView, ns, name string, containers []string, ended func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	f.mu.Lock()
+	f.cancel = cancel
+	f.mu.Unlock()
+
+	view.Clear()
+	var wg sync.WaitGroup
+	for _, container := range containers {
+		prefix := ""
+		if len(containers) > 1 {
+			prefix = "[yellow]" + tview.Escape("["+container+"]") + "[-] "
+		}
+		wg.Add(1)
+		go func(container string) {
+			defer wg.Done()
+
+			stream, err := a.k8s.GetPodLogsStream(ctx, ns, name, container, 100, true)
+			if err != nil {
+				if ctx.Err() == nil {
+					a.QueueUpdateDraw(func() {
+						fmt.Fprintf(view, "%s[red]Error: %v[-]\n", prefix, err)
+					})
+				}
+				return
+			}
+			// Closing the stream unblocks the scanner once follow is stopped
+			go func() {
+				<-ctx.Done()
+				stream.Close()
+			}()
+
+			scanner := bufio.NewScanner(stream)
+			scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+			for scanner.Scan() {
+				line := prefix + tview.Escape(scanner.Text())
+				a.QueueUpdateDraw(func() {
+					fmt.Fprintln(view, line)
+					view.ScrollToEnd()
+				})
+			}
+		}(container)
+	}
+
+	go func() {
+		wg.Wait()
+		if ctx.Err() == nil {
+			f.stop()
+			a.QueueUpdateDraw(func() {
+				fmt.Fprintln(view, "[gray]-- log stream ended --[-]")
+				ended()
+			})
+		}
+		cancel()
+	}()
+}
+
 // describeResource shows YAML for selected resource
 func (a *App) describeResource() {
 	row, _ := a.table.GetSelection()
@@ -2178,10 +2942,10 @@ func (a *App) describeResource() {
 	var ns, name string
 	switch resource {
 	case "nodes", "no", "namespaces", "ns":
-		name = a.table.GetCell(row, 0).Text
+		name = a.tableCell(row, 0).Text
 	default:
-		ns = a.table.GetCell(row, 0).Text
-		name = a.table.GetCell(row, 1).Text
+		ns = a.tableCell(row, 0).Text
+		name = a.tableCell(row, 1).Text
 	}
 
 	descView := tview.NewTextView().
@@ -2209,7 +2973,7 @@ func (a *App) describeResource() {
 		yaml, err := a.k8s.GetResourceYAML(ctx, ns, name, gvr)
 		a.QueueUpdateDraw(func() {
 			if err != nil {
-				descView.SetText(fmt.Sprintf("[red]Error: %v", err))
+				descView.SetText(resourceErrorText(err))
 			} else {
 				descView.SetText(yaml)
 			}
@@ -2226,6 +2990,16 @@ func (a *App) describeResource() {
 	})
 }
 
+// resourceErrorText formats an error of the YAML and describe views. A
+// client without its dynamic client gets a hint instead of the bare error.
+func resourceErrorText(err error) string {
+	if errors.Is(err, k8s.ErrDynamicUnavailable) {
+		return "[red]Cannot load this resource: the Kubernetes dynamic client is unavailable.[white]\n\n" +
+			"The connection to the cluster was only partly set up. Switch context with :ctx or restart k13s to reconnect."
+	}
+	return fmt.Sprintf("[red]Error: %v[white]", err)
+}
+
 // confirmDelete shows a delete confirmation dialog
 func (a *App) confirmDelete() {
 	a.mx.RLock()
@@ -2248,10 +3022,10 @@ func (a *App) confirmDelete() {
 	var ns, name string
 	switch resource {
 	case "nodes", "no", "namespaces", "ns":
-		name = a.table.GetCell(row, 0).Text
+		name = a.tableCell(row, 0).Text
 	default:
-		ns = a.table.GetCell(row, 0).Text
-		name = a.table.GetCell(row, 1).Text
+		ns = a.tableCell(row, 0).Text
+		name = a.tableCell(row, 1).Text
 	}
 
 	// Create confirmation modal
@@ -2259,8 +3033,7 @@ func (a *App) confirmDelete() {
 		SetText(fmt.Sprintf("[red]Delete %s?[white]\n\n%s/%s\n\nThis action cannot be undone.", resource, ns, name)).
 		AddButtons([]string{"Cancel", "Delete"}).
 		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
-			a.pages.RemovePage("delete-confirm")
-			a.SetFocus(a.table)
+			a.closeOverlay("delete-confirm")
 
 			if buttonLabel == "Delete" {
 				go a.deleteResource(ns, name, resource)
@@ -2269,7 +3042,7 @@ func (a *App) confirmDelete() {
 
 	modal.SetBackgroundColor(tcell.ColorDarkRed)
 
-	a.pages.AddPage("delete-confirm", modal, true, true)
+	a.showOverlay("delete-confirm", modal)
 }
 
 // confirmDeleteMultiple confirms deletion of multiple selected resources (k9s style)
@@ -2293,10 +3066,10 @@ func (a *App) confirmDeleteMultiple() {
 		var ns, name string
 		switch resource {
 		case "nodes", "no", "namespaces", "ns":
-			name = strings.TrimSpace(tview.TranslateANSI(a.table.GetCell(row, 0).Text))
+			name = strings.TrimSpace(tview.TranslateANSI(a.tableCell(row, 0).Text))
 		default:
-			ns = strings.TrimSpace(tview.TranslateANSI(a.table.GetCell(row, 0).Text))
-			name = strings.TrimSpace(tview.TranslateANSI(a.table.GetCell(row, 1).Text))
+			ns = strings.TrimSpace(tview.TranslateANSI(a.tableCell(row, 0).Text))
+			name = strings.TrimSpace(tview.TranslateANSI(a.tableCell(row, 1).Text))
 		}
 		if name != "" {
 			items = append(items, struct{ ns, name string }{ns, name})
@@ -2308,13 +3081,15 @@ func (a *App) confirmDeleteMultiple() {
 		SetText(fmt.Sprintf("[red]Delete %d %s?[white]\n\nThis action cannot be undone.", len(items), resource)).
 		AddButtons([]string{"Cancel", "Delete All"}).
 		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
-			a.pages.RemovePage("delete-confirm")
-			a.SetFocus(a.table)
+			a.closeOverlay("delete-confirm")
 
 			if buttonLabel == "Delete All" {
 				go func() {
-					for _, item := range items {
+					task := a.startTask(fmt.Sprintf("Deleting %s", resource), "deleted")
+					defer task.finish()
+					for i, item := range items {
 						a.deleteResource(item.ns, item.name, resource)
+						task.progress(i+1, len(items))
 					}
 					a.clearSelections()
 					a.refresh()
@@ -2324,7 +3099,7 @@ func (a *App) confirmDeleteMultiple() {
 
 	modal.SetBackgroundColor(tcell.ColorDarkRed)
 
-	a.pages.AddPage("delete-confirm", modal, true, true)
+	a.showOverlay("delete-confirm", modal)
 }
 
 // deleteResource deletes the specified resource
@@ -2366,29 +3141,42 @@ func (a *App) execShell() {
 		return
 	}
 
-	ns := a.table.GetCell(row, 0).Text
-	name := a.table.GetCell(row, 1).Text
+	ns := a.tableCell(row, 0).Text
+	name := a.tableCell(row, 1).Text
 
-	// Suspend the TUI and run kubectl exec
-	a.Suspend(func() {
-		// Try bash first, fall back to sh
-		cmd := exec.Command("kubectl", "exec", "-it", "-n", ns, name, "--", "/bin/bash")
-		cmd.Stdin = os.Stdin
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
+	a.pickContainer(ns, name, func(containers []string, idx int) {
+		args := append([]string{"exec", "-it", "-n", ns, name}, containerArgs(containers[idx])...)
 
-		err := cmd.Run()
-		if err != nil {
-			// Try sh if bash fails
-			cmd = exec.Command("kubectl", "exec", "-it", "-n", ns, name, "--", "/bin/sh")
+		// Suspend the TUI and run kubectl exec
+		a.Suspend(func() {
+			// Try bash first, fall back to sh
+			cmd := exec.Command("kubectl", append(args, "--", "/bin/bash")...)
 			cmd.Stdin = os.Stdin
 			cmd.Stdout = os.Stdout
 			cmd.Stderr = os.Stderr
-			cmd.Run()
-		}
+
+			err := cmd.Run()
+			if err != nil {
+				// Try sh if bash fails
+				cmd = exec.Command("kubectl", append(args, "--", "/bin/sh")...)
+				cmd.Stdin = os.Stdin
+				cmd.Stdout = os.Stdout
+				cmd.Stderr = os.Stderr
+				cmd.Run()
+			}
+		})
 	})
 }
 
+// containerArgs returns the kubectl flag selecting container, none for ""
+// (the pod's default container)
+func containerArgs(container string) []string {
+	if container == "" {
+		return nil
+	}
+	return []string{"-c", container}
+}
+
 // portForward shows port forwarding dialog
 func (a *App) portForward() {
 	a.mx.RLock()
@@ -2405,8 +3193,8 @@ func (a *App) portForward() {
 		return
 	}
 
-	ns := a.table.GetCell(row, 0).Text
-	name := a.table.GetCell(row, 1).Text
+	ns := a.tableCell(row, 0).Text
+	name := a.tableCell(row, 1).Text
 
 	// Create port forward dialog
 	form := tview.NewForm()
@@ -2420,8 +3208,7 @@ func (a *App) portForward() {
 		remotePort = text
 	})
 	form.AddButton("Forward", func() {
-		a.pages.RemovePage("port-forward")
-		a.SetFocus(a.table)
+		a.closeOverlay("port-forward")
 
 		if localPort == "" || remotePort == "" {
 			a.flashMsg("Both ports are required", true)
@@ -2431,33 +3218,10 @@ func (a *App) portForward() {
 		go a.startPortForward(ns, name, resource, localPort, remotePort)
 	})
 	form.AddButton("Cancel", func() {
-		a.pages.RemovePage("port-forward")
-		a.SetFocus(a.table)
+		a.closeOverlay("port-forward")
 	})
 
-	a.pages.AddPage("port-forward", centered(form, 50, 12), true, true)
-}
-
-// startPortForward starts port forwarding in background
-func (a *App) startPortForward(ns, name, resource, localPort, remotePort string) {
-	resourceType := "pod"
-	if resource == "services" || resource == "svc" {
-		resourceType = "svc"
-	}
-
-	target := fmt.Sprintf("%s/%s", resourceType, name)
-	portMap := fmt.Sprintf("%s:%s", localPort, remotePort)
-
-	a.flashMsg(fmt.Sprintf("Starting port forward %s -> %s:%s", localPort, name, remotePort), false)
-
-	cmd := exec.Command("kubectl", "port-forward", "-n", ns, target, portMap)
-	err := cmd.Start()
-	if err != nil {
-		a.flashMsg(fmt.Sprintf("Port forward failed: %v", err), true)
-		return
-	}
-
-	a.flashMsg(fmt.Sprintf("Port forward active: localhost:%s -> %s:%s (PID: %d)", localPort, name, remotePort, cmd.Process.Pid), false)
+	a.showCentered("port-forward", form, 50, 12)
 }
 
 // showContextSwitcher displays context selection dialog
@@ -2486,8 +3250,7 @@ func (a *App) showContextSwitcher() {
 
 	list.SetSelectedFunc(func(index int, mainText, secondaryText string, shortcut rune) {
 		selectedCtx := contexts[index]
-		a.pages.RemovePage("context-switcher")
-		a.SetFocus(a.table)
+		a.closeOverlay("context-switcher")
 
 		if selectedCtx == currentCtx {
 			return
@@ -2501,22 +3264,18 @@ func (a *App) showContextSwitcher() {
 				return
 			}
 
+			// Undo closures act on whatever cluster a.k8s points at now
+			a.undo.take()
 			a.flashMsg(fmt.Sprintf("Switched to context: %s", selectedCtx), false)
+			a.nsCounts.reset()
+			a.loadNamespaces()
+			a.detectMetrics()
 			a.updateHeader()
 			a.refresh()
 		}()
 	})
 
-	list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-		if event.Key() == tcell.KeyEsc {
-			a.pages.RemovePage("context-switcher")
-			a.SetFocus(a.table)
-			return nil
-		}
-		return event
-	})
-
-	a.pages.AddPage("context-switcher", centered(list, 60, min(len(contexts)+4, 20)), true, true)
+	a.showCentered("context-switcher", list, 60, min(len(contexts)+4, 20))
 }
 
 // showHealth displays system health status
@@ -2562,18 +3321,9 @@ func (a *App) showHealth() {
 
 	sb.WriteString("\n [gray]Press Esc to close[white]")
 
-	health.SetText(sb.String())
-
-	health.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-		if event.Key() == tcell.KeyEsc {
-			a.pages.RemovePage("health")
-			a.SetFocus(a.table)
-			return nil
-		}
-		return event
-	})
+	health.SetText(a.glyphs(sb.String()))
 
-	a.pages.AddPage("health", centered(health, 60, 18), true, true)
+	a.showCentered("health", health, 60, 18)
 }
 
 // showHelp displays help modal
@@ -2581,7 +3331,7 @@ func (a *App) showHelp() {
 	help := tview.NewTextView().
 		SetDynamicColors(true).
 		SetScrollable(true).
-		SetText(`
+		SetText(a.glyphs(`
  [yellow::b]k13s - Kubernetes AI Dashboard[white::-]
  [gray]k9s compatible keybindings with AI assistance[white]
 
@@ -2608,8 +3358,19 @@ func (a *App) showHelp() {
  ├──────────────────────────────────────────────────────────────────┤
  │  [yellow]d[white]        Describe           [yellow]y[white]        YAML view              │
  │  [yellow]e[white]        Edit ($EDITOR)     [yellow]Ctrl+D[white]   Delete                 │
+ │  [yellow]E[white]        Edit YAML in k13s and apply it (server-side)       │
  │  [yellow]r[white]        Refresh            [yellow]c[white]        Switch context         │
+ │  [yellow]Ctrl+R[white]   Refresh all (re-discover resources, namespaces)    │
+ │  [yellow]W[white]        Pause/resume auto-refresh                          │
+ │  [yellow]i[white]        Show/hide a LABELS column                          │
  │  [yellow]n[white]        Cycle namespace    [yellow]Space[white]    Multi-select           │
+ │  [yellow]V[white]        Compare the YAML of two selected rows side by side │
+ │  [yellow]X[white]        Remove finalizers  [yellow]L[white]        Label/Annotate         │
+ │  [yellow]T[white]        Node label/taint   [yellow]N[white]        Switch namespace       │
+ │  [yellow]C[white]        Cordon/Uncordon/Drain node                         │
+ │  [yellow]Ctrl+Z[white]   Undo the last cordon, scale or cronjob suspend     │
+ │  [yellow]b[white]        Benchmark a service over HTTP (p50/p90/p99, RPS)   │
+ │  [yellow]Y[white]        Copy name          [yellow]Ctrl+Y[white]   Copy YAML to clipboard │
  └──────────────────────────────────────────────────────────────────┘
 
  ┌──────────────────────────────────────────────────────────────────┐
@@ -2627,6 +3388,8 @@ func (a *App) showHelp() {
  │  [yellow]s[white]        Shell              [yellow]a[white]        Attach                 │
  │  [yellow]o[white]        Show node          [yellow]k/Ctrl+K[white] Kill (force delete)    │
  │  [yellow]Shift+F[white]  Port forward       [yellow]f[white]        Show port-forward      │
+ │  [yellow]Ctrl+S[white]   Save logs of the selected pods to ~/k13s-logs      │
+ │  [yellow]x[white]        Debug with an ephemeral container (image prompt)   │
  └──────────────────────────────────────────────────────────────────┘
 
  ┌──────────────────────────────────────────────────────────────────┐
@@ -2634,6 +3397,8 @@ func (a *App) showHelp() {
  ├──────────────────────────────────────────────────────────────────┤
  │  [yellow]S[white]        Scale              [yellow]R[white]        Restart/Rollout        │
  │  [yellow]z[white]        Show ReplicaSets   [yellow]Enter[white]    Show Pods              │
+ │  [yellow]U[white]        Rollback to a previous revision                    │
+ │  [yellow]z[white]        On a service: its Deployment/StatefulSet           │
  └──────────────────────────────────────────────────────────────────┘
 
  ┌──────────────────────────────────────────────────────────────────┐
@@ -2642,6 +3407,7 @@ func (a *App) showHelp() {
  │  [yellow]0-9[white]      Toggle container   [yellow]w[white]        Wrap toggle            │
  │  [yellow]t[white]        Toggle timestamps  [yellow]Ctrl+S[white]   Save to file           │
  │  [yellow]/[white]        Filter logs        [yellow]Esc[white]      Exit log view          │
+ │  [yellow]f[white]        Follow (stream)                                    │
  └──────────────────────────────────────────────────────────────────┘
 
  ┌──────────────────────────────────────────────────────────────────┐
@@ -2653,6 +3419,7 @@ func (a *App) showHelp() {
  │  [yellow]:deploy[white] [yellow]:dp[white]            List deployments                     │
  │  [yellow]:svc[white] [yellow]:services[white]         List services                        │
  │  [yellow]:ns kube-system[white]       Switch to namespace                     │
+ │  [yellow]:images[white] [yellow]:img[white]           Images by pod count ([yellow]o[white] to reverse)   │
  │  [yellow]:ctx[white] [yellow]:context[white]          Switch context                       │
  └──────────────────────────────────────────────────────────────────┘
 
@@ -2669,16 +3436,14 @@ func (a *App) showHelp() {
  └──────────────────────────────────────────────────────────────────┘
 
  [gray]Press Esc, q, or ? to close this help[white]
-`)
+`))
 	help.SetBorder(true).SetTitle(" Help ")
 
-	a.pages.AddPage("help", centered(help, 75, 55), true, true)
-	a.SetFocus(help)
+	a.showCentered("help", help, 75, 55)
 
 	help.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-		if event.Key() == tcell.KeyEsc || event.Rune() == 'q' || event.Rune() == '?' {
-			a.pages.RemovePage("help")
-			a.SetFocus(a.table)
+		if event.Rune() == 'q' || event.Rune() == '?' {
+			a.closeOverlay("help")
 			return nil
 		}
 		return event
@@ -2695,6 +3460,24 @@ func (a *App) Run() error {
 		}
 	}()
 
+	// Keep discovered API resources and the current view current while the
+	// app runs; the loops stop once Stop() ends the event loop
+	bgCtx, stopBackground := context.WithCancel(context.Background())
+	defer stopBackground()
+	go a.refreshAPIResourcesPeriodically(bgCtx)
+	go a.autoRefreshPeriodically(bgCtx)
+
+	// Serve pods, deployments and services from watch-backed caches and
+	// update their views as they change
+	if a.k8s != nil {
+		a.k8s.StartInformers(a.resourceChanged)
+		defer a.k8s.StopInformers()
+	}
+	go a.applyLiveUpdates(bgCtx)
+
+	// Close any port forwards still running on exit
+	defer a.portForwards.stopAll()
+
 	// Mark as running and trigger initial refresh after first draw
 	a.SetAfterDrawFunc(func(screen tcell.Screen) {
 		a.SetAfterDrawFunc(nil) // Only run once
@@ -2702,13 +3485,17 @@ func (a *App) Run() error {
 		go a.refresh()
 	})
 
+	if err := a.useMonochromeScreen(); err != nil {
+		return err
+	}
+
 	a.logger.Info("Starting k13s TUI")
 	return a.Application.Run()
 }
 
 // Helper functions
 
-func centered(p tview.Primitive, width, height int) tview.Primitive {
+func centered(p tview.Primitive, width, height int) *tview.Flex {
 	return tview.NewFlex().
 		AddItem(nil, 0, 1, false).
 		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
@@ -2740,6 +3527,7 @@ func (a *App) fetchConfigMaps(ctx context.Context, ns string) ([]string, [][]str
 	if err != nil {
 		return headers, nil, err
 	}
+	a.setListedLabels("configmaps", objectLabels(cms))
 	var rows [][]string
 	for _, cm := range cms {
 		rows = append(rows, []string{
@@ -2758,6 +3546,7 @@ func (a *App) fetchSecrets(ctx context.Context, ns string) ([]string, [][]string
 	if err != nil {
 		return headers, nil, err
 	}
+	a.setListedLabels("secrets", objectLabels(secrets))
 	var rows [][]string
 	for _, s := range secrets {
 		rows = append(rows, []string{
@@ -2777,6 +3566,7 @@ func (a *App) fetchPersistentVolumes(ctx context.Context) ([]string, [][]string,
 	if err != nil {
 		return headers, nil, err
 	}
+	a.setListedLabels("persistentvolumes", objectLabels(pvs))
 	var rows [][]string
 	for _, pv := range pvs {
 		capacity := ""
@@ -2805,6 +3595,7 @@ func (a *App) fetchPersistentVolumeClaims(ctx context.Context, ns string) ([]str
 	if err != nil {
 		return headers, nil, err
 	}
+	a.setListedLabels("persistentvolumeclaims", objectLabels(pvcs))
 	var rows [][]string
 	for _, pvc := range pvcs {
 		capacity := ""
@@ -2831,6 +3622,7 @@ func (a *App) fetchStorageClasses(ctx context.Context) ([]string, [][]string, er
 	if err != nil {
 		return headers, nil, err
 	}
+	a.setListedLabels("storageclasses", objectLabels(scs))
 	var rows [][]string
 	for _, sc := range scs {
 		reclaim := "<default>"
@@ -2858,6 +3650,7 @@ func (a *App) fetchReplicaSets(ctx context.Context, ns string) ([]string, [][]st
 	if err != nil {
 		return headers, nil, err
 	}
+	a.setListedLabels("replicasets", objectLabels(rss))
 	var rows [][]string
 	for _, rs := range rss {
 		desired := int32(0)
@@ -2882,6 +3675,7 @@ func (a *App) fetchDaemonSets(ctx context.Context, ns string) ([]string, [][]str
 	if err != nil {
 		return headers, nil, err
 	}
+	a.setListedLabels("daemonsets", objectLabels(dss))
 	var rows [][]string
 	for _, ds := range dss {
 		rows = append(rows, []string{
@@ -2902,6 +3696,7 @@ func (a *App) fetchStatefulSets(ctx context.Context, ns string) ([]string, [][]s
 	if err != nil {
 		return headers, nil, err
 	}
+	a.setListedLabels("statefulsets", objectLabels(stss))
 	var rows [][]string
 	for _, sts := range stss {
 		replicas := int32(0)
@@ -2924,6 +3719,7 @@ func (a *App) fetchJobs(ctx context.Context, ns string) ([]string, [][]string, e
 	if err != nil {
 		return headers, nil, err
 	}
+	a.setListedLabels("jobs", objectLabels(jobs))
 	var rows [][]string
 	for _, job := range jobs {
 		completions := int32(1)
@@ -2952,6 +3748,7 @@ func (a *App) fetchCronJobs(ctx context.Context, ns string) ([]string, [][]strin
 	if err != nil {
 		return headers, nil, err
 	}
+	a.setListedLabels("cronjobs", objectLabels(cjs))
 	var rows [][]string
 	for _, cj := range cjs {
 		suspend := "False"
@@ -2981,6 +3778,7 @@ func (a *App) fetchReplicationControllers(ctx context.Context, ns string) ([]str
 	if err != nil {
 		return headers, nil, err
 	}
+	a.setListedLabels("replicationcontrollers", objectLabels(rcs))
 	var rows [][]string
 	for _, rc := range rcs {
 		desired := int32(0)
@@ -3005,6 +3803,7 @@ func (a *App) fetchIngresses(ctx context.Context, ns string) ([]string, [][]stri
 	if err != nil {
 		return headers, nil, err
 	}
+	a.setListedLabels("ingresses", objectLabels(ings))
 	var rows [][]string
 	for _, ing := range ings {
 		class := "<none>"
@@ -3043,6 +3842,7 @@ func (a *App) fetchEndpoints(ctx context.Context, ns string) ([]string, [][]stri
 	if err != nil {
 		return headers, nil, err
 	}
+	a.setListedLabels("endpoints", objectLabels(eps))
 	var rows [][]string
 	for _, ep := range eps {
 		var addrs []string
@@ -3073,6 +3873,7 @@ func (a *App) fetchNetworkPolicies(ctx context.Context, ns string) ([]string, []
 	if err != nil {
 		return headers, nil, err
 	}
+	a.setListedLabels("networkpolicies", objectLabels(netpols))
 	var rows [][]string
 	for _, np := range netpols {
 		selector := "<all>"
@@ -3099,6 +3900,7 @@ func (a *App) fetchServiceAccounts(ctx context.Context, ns string) ([]string, []
 	if err != nil {
 		return headers, nil, err
 	}
+	a.setListedLabels("serviceaccounts", objectLabels(sas))
 	var rows [][]string
 	for _, sa := range sas {
 		rows = append(rows, []string{
@@ -3117,6 +3919,7 @@ func (a *App) fetchRoles(ctx context.Context, ns string) ([]string, [][]string,
 	if err != nil {
 		return headers, nil, err
 	}
+	a.setListedLabels("roles", objectLabels(roles))
 	var rows [][]string
 	for _, r := range roles {
 		rows = append(rows, []string{
@@ -3134,6 +3937,7 @@ func (a *App) fetchRoleBindings(ctx context.Context, ns string) ([]string, [][]s
 	if err != nil {
 		return headers, nil, err
 	}
+	a.setListedLabels("rolebindings", objectLabels(rbs))
 	var rows [][]string
 	for _, rb := range rbs {
 		roleRef := fmt.Sprintf("%s/%s", rb.RoleRef.Kind, rb.RoleRef.Name)
@@ -3153,6 +3957,7 @@ func (a *App) fetchClusterRoles(ctx context.Context) ([]string, [][]string, erro
 	if err != nil {
 		return headers, nil, err
 	}
+	a.setListedLabels("clusterroles", objectLabels(crs))
 	var rows [][]string
 	for _, cr := range crs {
 		rows = append(rows, []string{
@@ -3169,6 +3974,7 @@ func (a *App) fetchClusterRoleBindings(ctx context.Context) ([]string, [][]strin
 	if err != nil {
 		return headers, nil, err
 	}
+	a.setListedLabels("clusterrolebindings", objectLabels(crbs))
 	var rows [][]string
 	for _, crb := range crbs {
 		roleRef := fmt.Sprintf("%s/%s", crb.RoleRef.Kind, crb.RoleRef.Name)
@@ -3187,6 +3993,7 @@ func (a *App) fetchPodDisruptionBudgets(ctx context.Context, ns string) ([]strin
 	if err != nil {
 		return headers, nil, err
 	}
+	a.setListedLabels("poddisruptionbudgets", objectLabels(pdbs))
 	var rows [][]string
 	for _, pdb := range pdbs {
 		minAvail := "<none>"
@@ -3215,6 +4022,7 @@ func (a *App) fetchLimitRanges(ctx context.Context, ns string) ([]string, [][]st
 	if err != nil {
 		return headers, nil, err
 	}
+	a.setListedLabels("limitranges", objectLabels(lrs))
 	var rows [][]string
 	for _, lr := range lrs {
 		rows = append(rows, []string{
@@ -3232,6 +4040,7 @@ func (a *App) fetchResourceQuotas(ctx context.Context, ns string) ([]string, [][
 	if err != nil {
 		return headers, nil, err
 	}
+	a.setListedLabels("resourcequotas", objectLabels(rqs))
 	var rows [][]string
 	for _, rq := range rqs {
 		rows = append(rows, []string{
@@ -3249,6 +4058,7 @@ func (a *App) fetchHPAs(ctx context.Context, ns string) ([]string, [][]string, e
 	if err != nil {
 		return headers, nil, err
 	}
+	a.setListedLabels("horizontalpodautoscalers", objectLabels(hpas))
 	var rows [][]string
 	for _, hpa := range hpas {
 		ref := fmt.Sprintf("%s/%s", hpa.Spec.ScaleTargetRef.Kind, hpa.Spec.ScaleTargetRef.Name)
@@ -3276,6 +4086,7 @@ func (a *App) fetchCRDs(ctx context.Context) ([]string, [][]string, error) {
 	if err != nil {
 		return headers, nil, err
 	}
+	a.setListedLabels("customresourcedefinitions", objectLabels(crds))
 	var rows [][]string
 	for _, crd := range crds {
 		rows = append(rows, []string{
@@ -3287,8 +4098,46 @@ func (a *App) fetchCRDs(ctx context.Context) ([]string, [][]string, error) {
 }
 
 func (a *App) fetchGenericResource(ctx context.Context, resource, ns string) ([]string, [][]string, error) {
-	headers := []string{"NAMESPACE", "NAME", "AGE"}
-	return headers, nil, fmt.Errorf("resource type '%s' not yet implemented", resource)
+	headers := []string{"NAMESPACE", "NAME", "STATUS", "AGE"}
+	gvr, namespaced, ok := a.resolveGVR(resource)
+	if !ok {
+		return headers, nil, fmt.Errorf("unknown resource type '%s'", resource)
+	}
+	if !namespaced {
+		ns = ""
+	}
+
+	items, err := a.k8s.ListDynamicResource(ctx, gvr, ns)
+	if err != nil {
+		return headers, nil, err
+	}
+
+	var rows [][]string
+	labels := make(map[string]map[string]string, len(items))
+	for _, item := range items {
+		obj := &unstructured.Unstructured{Object: item}
+		labels[obj.GetNamespace()+"/"+obj.GetName()] = obj.GetLabels()
+		rows = append(rows, []string{
+			obj.GetNamespace(),
+			obj.GetName(),
+			genericStatus(obj),
+			formatAge(obj.GetCreationTimestamp().Time),
+		})
+	}
+	a.setListedLabels(resource, labels)
+	return headers, rows, nil
+}
+
+// genericStatus derives a STATUS value for an arbitrary object. Objects
+// waiting on finalizers are always reported as Terminating.
+func genericStatus(obj *unstructured.Unstructured) string {
+	if k8s.IsTerminating(obj) {
+		return "Terminating"
+	}
+	if phase, found, _ := unstructured.NestedString(obj.Object, "status", "phase"); found {
+		return phase
+	}
+	return ""
 }
 
 // Helper function for PV access modes
@@ -3311,9 +4160,11 @@ func accessModesToStrings(modes []corev1.PersistentVolumeAccessMode) []string {
 
 // Navigation history for back navigation
 type navHistory struct {
-	resource  string
-	namespace string
-	filter    string
+	resource      string
+	namespace     string
+	filter        string
+	labelSelector string
+	fieldSelector string
 }
 
 var navigationStack []navHistory
@@ -3329,20 +4180,27 @@ func (a *App) drillDown() {
 	resource := a.currentResource
 	ns := a.currentNamespace
 	filter := a.filterText
+	selector := a.labelSelector
+	fieldSelector := a.fieldSelector
 	a.mx.RUnlock()
 
+	// Images are not objects; there is nothing to drill into
+	if resource == "images" {
+		return
+	}
+
 	// Save current state to navigation stack
-	navigationStack = append(navigationStack, navHistory{resource, ns, filter})
+	navigationStack = append(navigationStack, navHistory{resource, ns, filter, selector, fieldSelector})
 
 	// Get selected item info
 	var selectedNs, selectedName string
 	switch resource {
 	case "nodes", "namespaces", "persistentvolumes", "storageclasses",
 		"clusterroles", "clusterrolebindings", "customresourcedefinitions":
-		selectedName = a.table.GetCell(row, 0).Text
+		selectedName = a.tableCell(row, 0).Text
 	default:
-		selectedNs = a.table.GetCell(row, 0).Text
-		selectedName = a.table.GetCell(row, 1).Text
+		selectedNs = a.tableCell(row, 0).Text
+		selectedName = a.tableCell(row, 1).Text
 	}
 
 	// Determine drill-down behavior based on resource type
@@ -3352,77 +4210,10 @@ func (a *App) drillDown() {
 		a.showLogs()
 		return
 
-	case "deployments", "deploy":
-		// Deployment -> Pods with label selector
-		a.mx.Lock()
-		a.currentResource = "pods"
-		a.currentNamespace = selectedNs
-		a.filterText = selectedName // Filter pods by deployment name
-		a.mx.Unlock()
-		go func() {
-			a.updateHeader()
-			a.refresh()
-		}()
-
-	case "services", "svc":
-		// Service -> Pods (show related pods)
-		a.mx.Lock()
-		a.currentResource = "pods"
-		a.currentNamespace = selectedNs
-		a.filterText = selectedName
-		a.mx.Unlock()
-		go func() {
-			a.updateHeader()
-			a.refresh()
-		}()
-
-	case "replicasets", "rs":
-		// ReplicaSet -> Pods
-		a.mx.Lock()
-		a.currentResource = "pods"
-		a.currentNamespace = selectedNs
-		a.filterText = selectedName
-		a.mx.Unlock()
-		go func() {
-			a.updateHeader()
-			a.refresh()
-		}()
-
-	case "statefulsets", "sts":
-		// StatefulSet -> Pods
-		a.mx.Lock()
-		a.currentResource = "pods"
-		a.currentNamespace = selectedNs
-		a.filterText = selectedName
-		a.mx.Unlock()
-		go func() {
-			a.updateHeader()
-			a.refresh()
-		}()
-
-	case "daemonsets", "ds":
-		// DaemonSet -> Pods
-		a.mx.Lock()
-		a.currentResource = "pods"
-		a.currentNamespace = selectedNs
-		a.filterText = selectedName
-		a.mx.Unlock()
-		go func() {
-			a.updateHeader()
-			a.refresh()
-		}()
-
-	case "jobs", "job":
-		// Job -> Pods
-		a.mx.Lock()
-		a.currentResource = "pods"
-		a.currentNamespace = selectedNs
-		a.filterText = selectedName
-		a.mx.Unlock()
-		go func() {
-			a.updateHeader()
-			a.refresh()
-		}()
+	case "deployments", "deploy", "services", "svc", "replicasets", "rs",
+		"statefulsets", "sts", "daemonsets", "ds", "jobs", "job":
+		// Workload or Service -> the pods its selector matches
+		go a.showSelectedPods(resource, selectedNs, selectedName)
 
 	case "cronjobs", "cj":
 		// CronJob -> Jobs
@@ -3430,6 +4221,8 @@ func (a *App) drillDown() {
 		a.currentResource = "jobs"
 		a.currentNamespace = selectedNs
 		a.filterText = selectedName
+		a.labelSelector = ""
+		a.fieldSelector = ""
 		a.mx.Unlock()
 		go func() {
 			a.updateHeader()
@@ -3437,23 +4230,31 @@ func (a *App) drillDown() {
 		}()
 
 	case "nodes", "no":
-		// Node -> Pods on that node
+		// Node -> Pods on that node, selected server-side by spec.nodeName
 		a.mx.Lock()
 		a.currentResource = "pods"
 		a.currentNamespace = "" // All namespaces
-		a.filterText = selectedName
+		a.filterText = ""
+		a.labelSelector = ""
+		a.fieldSelector = "spec.nodeName=" + selectedName
 		a.mx.Unlock()
 		go func() {
 			a.updateHeader()
 			a.refresh()
 		}()
 
+	case "customresourcedefinitions", "crd":
+		// CRD -> Its custom resources
+		a.showCRDInstances(selectedName)
+
 	case "namespaces", "ns":
 		// Namespace -> Switch to that namespace and show pods
 		a.mx.Lock()
 		a.currentResource = "pods"
 		a.currentNamespace = selectedName
 		a.filterText = ""
+		a.labelSelector = ""
+		a.fieldSelector = ""
 		a.mx.Unlock()
 		go func() {
 			a.updateHeader()
@@ -3481,6 +4282,8 @@ func (a *App) goBack() {
 	a.currentResource = prev.resource
 	a.currentNamespace = prev.namespace
 	a.filterText = prev.filter
+	a.labelSelector = prev.labelSelector
+	a.fieldSelector = prev.fieldSelector
 	a.mx.Unlock()
 
 	go func() {
@@ -3525,10 +4328,10 @@ func (a *App) showYAML() {
 	switch resource {
 	case "nodes", "no", "namespaces", "ns", "persistentvolumes", "storageclasses",
 		"clusterroles", "clusterrolebindings", "customresourcedefinitions":
-		name = a.table.GetCell(row, 0).Text
+		name = a.tableCell(row, 0).Text
 	default:
-		ns = a.table.GetCell(row, 0).Text
-		name = a.table.GetCell(row, 1).Text
+		ns = a.tableCell(row, 0).Text
+		name = a.tableCell(row, 1).Text
 	}
 
 	yamlView := tview.NewTextView().
@@ -3556,7 +4359,7 @@ func (a *App) showYAML() {
 		yaml, err := a.k8s.GetResourceYAML(ctx, ns, name, gvr)
 		a.QueueUpdateDraw(func() {
 			if err != nil {
-				yamlView.SetText(fmt.Sprintf("[red]Error: %v", err))
+				yamlView.SetText(resourceErrorText(err))
 			} else {
 				yamlView.SetText(yaml)
 			}
@@ -3589,14 +4392,14 @@ func (a *App) showLogsPrevious() {
 		return
 	}
 
-	ns := a.table.GetCell(row, 0).Text
-	name := a.table.GetCell(row, 1).Text
+	ns := a.tableCell(row, 0).Text
+	name := a.tableCell(row, 1).Text
 
 	logView := tview.NewTextView().
 		SetDynamicColors(true).
 		SetScrollable(true)
-	logView.SetBorder(true).
-		SetTitle(fmt.Sprintf(" Previous Logs: %s/%s (Press Esc to close) ", ns, name))
+	title := fmt.Sprintf(" Previous Logs: %s/%s (Ctrl+S: save, Esc: close) ", ns, name)
+	logView.SetBorder(true).SetTitle(title)
 
 	a.pages.AddPage("logs", logView, true, true)
 	a.SetFocus(logView)
@@ -3620,10 +4423,14 @@ func (a *App) showLogsPrevious() {
 	}()
 
 	logView.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-		if event.Key() == tcell.KeyEsc {
+		switch event.Key() {
+		case tcell.KeyEsc:
 			a.pages.RemovePage("logs")
 			a.SetFocus(a.table)
 			return nil
+		case tcell.KeyCtrlS:
+			a.saveLogView(logView, ns, name+"-previous", func() string { return title })
+			return nil
 		}
 		return event
 	})
@@ -3644,10 +4451,10 @@ func (a *App) editResource() {
 	switch resource {
 	case "nodes", "no", "namespaces", "ns", "persistentvolumes", "storageclasses",
 		"clusterroles", "clusterrolebindings", "customresourcedefinitions":
-		name = a.table.GetCell(row, 0).Text
+		name = a.tableCell(row, 0).Text
 	default:
-		ns = a.table.GetCell(row, 0).Text
-		name = a.table.GetCell(row, 1).Text
+		ns = a.tableCell(row, 0).Text
+		name = a.tableCell(row, 1).Text
 	}
 
 	// Suspend TUI and run kubectl edit
@@ -3684,16 +4491,20 @@ func (a *App) attachContainer() {
 		return
 	}
 
-	ns := a.table.GetCell(row, 0).Text
-	name := a.table.GetCell(row, 1).Text
+	ns := a.tableCell(row, 0).Text
+	name := a.tableCell(row, 1).Text
 
-	// Suspend TUI and run kubectl attach
-	a.Suspend(func() {
-		cmd := exec.Command("kubectl", "attach", "-it", "-n", ns, name)
-		cmd.Stdin = os.Stdin
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
-		cmd.Run()
+	a.pickContainer(ns, name, func(containers []string, idx int) {
+		args := append([]string{"attach", "-it", "-n", ns, name}, containerArgs(containers[idx])...)
+
+		// Suspend TUI and run kubectl attach
+		a.Suspend(func() {
+			cmd := exec.Command("kubectl", args...)
+			cmd.Stdin = os.Stdin
+			cmd.Stdout = os.Stdout
+			cmd.Stderr = os.Stderr
+			cmd.Run()
+		})
 	})
 }
 
@@ -3713,7 +4524,7 @@ func (a *App) useNamespace() {
 		return
 	}
 
-	nsName := a.table.GetCell(row, 0).Text
+	nsName := a.tableCell(row, 0).Text
 
 	a.mx.Lock()
 	a.currentNamespace = nsName
@@ -3744,8 +4555,8 @@ func (a *App) showNode() {
 		return
 	}
 
-	ns := a.table.GetCell(row, 0).Text
-	name := a.table.GetCell(row, 1).Text
+	ns := a.tableCell(row, 0).Text
+	name := a.tableCell(row, 1).Text
 
 	// Get pod to find node
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -3772,10 +4583,12 @@ func (a *App) showNode() {
 
 	// Save current state and navigate to nodes with filter
 	a.mx.Lock()
-	navigationStack = append(navigationStack, navHistory{resource, a.currentNamespace, a.filterText})
+	navigationStack = append(navigationStack, navHistory{resource, a.currentNamespace, a.filterText, a.labelSelector, a.fieldSelector})
 	a.currentResource = "nodes"
 	a.currentNamespace = ""
 	a.filterText = nodeName
+	a.labelSelector = ""
+	a.fieldSelector = ""
 	a.mx.Unlock()
 
 	go func() {
@@ -3800,15 +4613,14 @@ func (a *App) killPod() {
 		return
 	}
 
-	ns := a.table.GetCell(row, 0).Text
-	name := a.table.GetCell(row, 1).Text
+	ns := a.tableCell(row, 0).Text
+	name := a.tableCell(row, 1).Text
 
 	modal := tview.NewModal().
 		SetText(fmt.Sprintf("[red]Kill pod?[white]\n\n%s/%s\n\nThis will force delete the pod.", ns, name)).
 		AddButtons([]string{"Cancel", "Kill"}).
 		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
-			a.pages.RemovePage("kill-confirm")
-			a.SetFocus(a.table)
+			a.closeOverlay("kill-confirm")
 
 			if buttonLabel == "Kill" {
 				go func() {
@@ -3830,12 +4642,7 @@ func (a *App) killPod() {
 		})
 
 	modal.SetBackgroundColor(tcell.ColorDarkRed)
-	a.pages.AddPage("kill-confirm", modal, true, true)
-}
-
-// showBenchmark runs benchmark on service (k9s b key) - placeholder
-func (a *App) showBenchmark() {
-	a.flashMsg("Benchmark feature not yet implemented", true)
+	a.showOverlay("kill-confirm", modal)
 }
 
 // triggerCronJob manually triggers a cronjob (k9s t key)
@@ -3854,36 +4661,68 @@ func (a *App) triggerCronJob() {
 		return
 	}
 
-	ns := a.table.GetCell(row, 0).Text
-	name := a.table.GetCell(row, 1).Text
+	ns := a.tableCell(row, 0).Text
+	name := a.tableCell(row, 1).Text
 
-	modal := tview.NewModal().
-		SetText(fmt.Sprintf("Trigger CronJob?\n\n%s/%s\n\nThis will create a new job from this cronjob.", ns, name)).
-		AddButtons([]string{"Cancel", "Trigger"}).
-		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
-			a.pages.RemovePage("trigger-confirm")
-			a.SetFocus(a.table)
+	text := fmt.Sprintf("Trigger CronJob?\n\n%s/%s\n\nThis will create a new job from this cronjob.", ns, name)
+	a.confirmAction("trigger", "trigger-confirm", text, "Trigger", false, func() {
+		a.flashMsg(fmt.Sprintf("Triggering cronjob %s/%s...", ns, name), false)
 
-			if buttonLabel == "Trigger" {
-				go func() {
-					a.flashMsg(fmt.Sprintf("Triggering cronjob %s/%s...", ns, name), false)
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
 
-					// Use kubectl to create job from cronjob
-					jobName := fmt.Sprintf("%s-manual-%d", name, time.Now().Unix())
-					cmd := exec.Command("kubectl", "create", "job", jobName, "--from=cronjob/"+name, "-n", ns)
-					output, err := cmd.CombinedOutput()
-					if err != nil {
-						a.flashMsg(fmt.Sprintf("Trigger failed: %s", string(output)), true)
-						return
-					}
+		job, err := a.k8s.TriggerCronJob(ctx, ns, name)
+		if err != nil {
+			a.flashMsg(fmt.Sprintf("Trigger failed: %v", err), true)
+			return
+		}
 
-					a.flashMsg(fmt.Sprintf("Created job %s from cronjob %s", jobName, name), false)
-					a.refresh()
-				}()
-			}
-		})
+		a.flashMsg(fmt.Sprintf("Created job %s from cronjob %s", job.Name, name), false)
+		a.refresh()
+	})
+}
 
-	a.pages.AddPage("trigger-confirm", modal, true, true)
+// isCronJobView reports whether the table lists cronjobs
+func (a *App) isCronJobView() bool {
+	a.mx.RLock()
+	defer a.mx.RUnlock()
+	return a.currentResource == "cronjobs" || a.currentResource == "cj"
+}
+
+// toggleCronJobSuspend suspends the selected cronjob, or resumes it if it
+// is suspended (k9s s key on cronjobs)
+func (a *App) toggleCronJobSuspend() {
+	row, _ := a.table.GetSelection()
+	if row <= 0 {
+		return
+	}
+
+	ns := a.tableCell(row, 0).Text
+	name := a.tableCell(row, 1).Text
+	suspend := a.tableCell(row, 3).Text != "True"
+
+	go func() {
+		action, done, undo := "suspend", "Suspended", "resume"
+		if !suspend {
+			action, done, undo = "resume", "Resumed", "suspend"
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		if err := a.k8s.SetCronJobSuspended(ctx, ns, name, suspend); err != nil {
+			a.flashMsg(fmt.Sprintf("Failed to %s cronjob %s: %v", action, name, err), true)
+			return
+		}
+
+		resource := fmt.Sprintf("cronjobs/%s/%s", ns, name)
+		a.recordAudit(action, resource, "")
+		a.recordUndo(fmt.Sprintf("%s cronjob %s/%s", undo, ns, name), resource, "", func(ctx context.Context) error {
+			return a.k8s.SetCronJobSuspended(ctx, ns, name, !suspend)
+		})
+		a.flashMsg(fmt.Sprintf("%s cronjob %s (Ctrl+Z to undo)", done, name), false)
+		a.refresh()
+	}()
 }
 
 // showRelatedResource shows related resources (k9s z key)
@@ -3901,10 +4740,10 @@ func (a *App) showRelatedResource() {
 	switch resource {
 	case "nodes", "namespaces", "persistentvolumes", "storageclasses",
 		"clusterroles", "clusterrolebindings", "customresourcedefinitions":
-		name = a.table.GetCell(row, 0).Text
+		name = a.tableCell(row, 0).Text
 	default:
-		ns = a.table.GetCell(row, 0).Text
-		name = a.table.GetCell(row, 1).Text
+		ns = a.tableCell(row, 0).Text
+		name = a.tableCell(row, 1).Text
 	}
 
 	// Different behavior based on resource type
@@ -3912,16 +4751,22 @@ func (a *App) showRelatedResource() {
 	case "deployments", "deploy":
 		// Show ReplicaSets
 		a.mx.Lock()
-		navigationStack = append(navigationStack, navHistory{resource, a.currentNamespace, a.filterText})
+		navigationStack = append(navigationStack, navHistory{resource, a.currentNamespace, a.filterText, a.labelSelector, a.fieldSelector})
 		a.currentResource = "replicasets"
 		a.currentNamespace = ns
 		a.filterText = name
+		a.labelSelector = ""
+		a.fieldSelector = ""
 		a.mx.Unlock()
 		go func() {
 			a.updateHeader()
 			a.refresh()
 		}()
 
+	case "services", "svc":
+		// Show the Deployment/StatefulSet backing the service
+		go a.showServiceWorkloads(ns, name)
+
 	default:
 		a.flashMsg(fmt.Sprintf("No related resources for %s", resource), true)
 	}
@@ -3950,8 +4795,8 @@ func (a *App) scaleResource() {
 		return
 	}
 
-	ns := a.table.GetCell(row, 0).Text
-	name := a.table.GetCell(row, 1).Text
+	ns := a.tableCell(row, 0).Text
+	name := a.tableCell(row, 1).Text
 
 	// Create scale dialog
 	form := tview.NewForm()
@@ -3962,38 +4807,21 @@ func (a *App) scaleResource() {
 		replicas = text
 	})
 	form.AddButton("Scale", func() {
-		a.pages.RemovePage("scale-dialog")
-		a.SetFocus(a.table)
+		a.closeOverlay("scale-dialog")
 
-		go func() {
-			a.flashMsg(fmt.Sprintf("Scaling %s/%s to %s replicas...", ns, name, replicas), false)
-
-			resourceType := resource
-			if resourceType == "deploy" {
-				resourceType = "deployment"
-			} else if resourceType == "sts" {
-				resourceType = "statefulset"
-			} else if resourceType == "rs" {
-				resourceType = "replicaset"
-			}
-
-			cmd := exec.Command("kubectl", "scale", resourceType, name, "-n", ns, "--replicas="+replicas)
-			output, err := cmd.CombinedOutput()
-			if err != nil {
-				a.flashMsg(fmt.Sprintf("Scale failed: %s", string(output)), true)
-				return
-			}
+		count, err := strconv.ParseInt(strings.TrimSpace(replicas), 10, 32)
+		if err != nil || count < 0 {
+			a.flashMsg(fmt.Sprintf("Invalid replica count: %s", replicas), true)
+			return
+		}
 
-			a.flashMsg(fmt.Sprintf("Scaled %s/%s to %s replicas", ns, name, replicas), false)
-			a.refresh()
-		}()
+		go a.previewScale(resource, ns, name, int32(count))
 	})
 	form.AddButton("Cancel", func() {
-		a.pages.RemovePage("scale-dialog")
-		a.SetFocus(a.table)
+		a.closeOverlay("scale-dialog")
 	})
 
-	a.pages.AddPage("scale-dialog", centered(form, 50, 10), true, true)
+	a.showCentered("scale-dialog", form, 50, 10)
 }
 
 // restartResource restarts a deployment/statefulset (k9s Shift+R key)
@@ -4018,43 +4846,31 @@ func (a *App) restartResource() {
 		return
 	}
 
-	ns := a.table.GetCell(row, 0).Text
-	name := a.table.GetCell(row, 1).Text
+	ns := a.tableCell(row, 0).Text
+	name := a.tableCell(row, 1).Text
 
-	modal := tview.NewModal().
-		SetText(fmt.Sprintf("Restart %s?\n\n%s/%s\n\nThis will trigger a rolling restart.", resource, ns, name)).
-		AddButtons([]string{"Cancel", "Restart"}).
-		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
-			a.pages.RemovePage("restart-confirm")
-			a.SetFocus(a.table)
+	text := fmt.Sprintf("Restart %s?\n\n%s/%s\n\nThis will trigger a rolling restart.", resource, ns, name)
+	a.confirmAction("restart", "restart-confirm", text, "Restart", false, func() {
+		a.flashMsg(fmt.Sprintf("Restarting %s/%s...", ns, name), false)
 
-			if buttonLabel == "Restart" {
-				go func() {
-					a.flashMsg(fmt.Sprintf("Restarting %s/%s...", ns, name), false)
-
-					resourceType := resource
-					if resourceType == "deploy" {
-						resourceType = "deployment"
-					} else if resourceType == "sts" {
-						resourceType = "statefulset"
-					} else if resourceType == "ds" {
-						resourceType = "daemonset"
-					}
+		gvr, ok := a.k8s.GetGVR(resource)
+		if !ok {
+			a.flashMsg(fmt.Sprintf("Unknown resource type: %s", resource), true)
+			return
+		}
 
-					cmd := exec.Command("kubectl", "rollout", "restart", resourceType, name, "-n", ns)
-					output, err := cmd.CombinedOutput()
-					if err != nil {
-						a.flashMsg(fmt.Sprintf("Restart failed: %s", string(output)), true)
-						return
-					}
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
 
-					a.flashMsg(fmt.Sprintf("Restarted %s/%s", ns, name), false)
-					a.refresh()
-				}()
-			}
-		})
+		if err := a.k8s.RolloutRestart(ctx, gvr, ns, name); err != nil {
+			a.flashMsg(fmt.Sprintf("Restart failed: %v", err), true)
+			return
+		}
 
-	a.pages.AddPage("restart-confirm", modal, true, true)
+		a.flashMsg(fmt.Sprintf("Restarted %s/%s", ns, name), false)
+		a.refresh()
+		a.showRolloutProgress("Restart", gvr, ns, name)
+	})
 }
 
 // showDescribe shows describe output for selected resource (like kubectl describe)
@@ -4070,8 +4886,8 @@ func (a *App) showDescribe() {
 	a.mx.RUnlock()
 
 	// Get namespace and name from table
-	nsCell := a.table.GetCell(row, 0)
-	nameCell := a.table.GetCell(row, 1)
+	nsCell := a.tableCell(row, 0)
+	nameCell := a.tableCell(row, 1)
 	if nsCell == nil || nameCell == nil {
 		a.flashMsg("Cannot get resource info", true)
 		return
@@ -4109,7 +4925,7 @@ func (a *App) showDescribe() {
 		output, err := a.k8s.DescribeResource(ctx, resource, ns, name)
 		if err != nil {
 			a.QueueUpdateDraw(func() {
-				descView.SetText(fmt.Sprintf("[red]Error: %v[white]", err))
+				descView.SetText(resourceErrorText(err))
 			})
 			return
 		}
@@ -4216,7 +5032,7 @@ func (a *App) getSelectedResources() []string {
 		// No selection, return current row
 		row, _ := a.table.GetSelection()
 		if row > 0 {
-			cell := a.table.GetCell(row, 0)
+			cell := a.tableCell(row, 0)
 			if cell != nil {
 				name := strings.TrimSpace(tview.TranslateANSI(cell.Text))
 				// Handle namespace/name format
@@ -4235,13 +5051,13 @@ func (a *App) getSelectedResources() []string {
 
 	var resources []string
 	for row := range a.selectedRows {
-		cell := a.table.GetCell(row, 0)
+		cell := a.tableCell(row, 0)
 		if cell != nil {
 			// For namespaced resources, column 0 might be namespace, column 1 is name
 			name := strings.TrimSpace(tview.TranslateANSI(cell.Text))
 			// Check if there's a second column with name
 			if a.table.GetColumnCount() > 1 {
-				nameCell := a.table.GetCell(row, 1)
+				nameCell := a.tableCell(row, 1)
 				if nameCell != nil {
 					possibleName := strings.TrimSpace(tview.TranslateANSI(nameCell.Text))
 					// If first column looks like a namespace, use second column
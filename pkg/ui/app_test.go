@@ -1,10 +1,32 @@
 package ui
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/config"
+	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/k8s"
+	"github.com/rivo/tview"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	ktesting "k8s.io/client-go/testing"
 )
 
 func TestGetCompletions(t *testing.T) {
@@ -25,7 +47,7 @@ func TestGetCompletions(t *testing.T) {
 		{
 			name:     "match pods with po alias",
 			input:    "po",
-			expected: []string{"pods", "poddisruptionbudgets", "podsecuritypolicies"},
+			expected: []string{"pods", "poddisruptionbudgets"},
 		},
 		{
 			name:     "match deployments",
@@ -194,6 +216,7 @@ func TestStatusColor(t *testing.T) {
 		{"ContainerCreating", tcell.ColorYellow},
 		{"Warning", tcell.ColorYellow},
 		{"Updating", tcell.ColorYellow},
+		{"Terminating", tcell.ColorYellow},
 		{"Failed", tcell.ColorRed},
 		{"Error", tcell.ColorRed},
 		{"CrashLoopBackOff", tcell.ColorRed},
@@ -663,3 +686,1047 @@ func TestNumberKeyNamespaceSwitch(t *testing.T) {
 		})
 	}
 }
+
+func TestRunCommandTimeout(t *testing.T) {
+	app := &App{config: &config.Config{CommandTimeout: 1}}
+
+	_, err := app.runCommand("sleep", "5")
+	if err == nil {
+		t.Fatal("expected timeout error")
+	}
+	if !strings.Contains(err.Error(), "timed out after 1s") {
+		t.Errorf("expected timeout message, got %v", err)
+	}
+	if msg := commandError([]byte("partial output"), err); msg != err.Error() {
+		t.Errorf("commandError should prefer the timeout error, got %q", msg)
+	}
+}
+
+func TestRunCommandTimeoutCompound(t *testing.T) {
+	if _, err := exec.LookPath("bash"); err != nil {
+		t.Skip("bash not available")
+	}
+	app := &App{config: &config.Config{CommandTimeout: 1}}
+
+	// bash forks sleep, which holds the output pipe after bash is killed
+	start := time.Now()
+	output, err := app.runCommand("bash", "-c", "sleep 4; echo done")
+	if err == nil || !strings.Contains(err.Error(), "timed out after 1s") {
+		t.Fatalf("expected timeout error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 3*time.Second {
+		t.Errorf("runCommand returned after %v, want about 1s", elapsed)
+	}
+	if strings.Contains(string(output), "done") {
+		t.Error("the compound command ran to completion")
+	}
+}
+
+func TestFormatScalePreview(t *testing.T) {
+	text, danger := formatScalePreview("default", "web", &k8s.ScalePreview{
+		Current:     3,
+		Target:      0,
+		Terminating: []string{"web-a", "web-b", "web-c"},
+	})
+	if !danger {
+		t.Error("expected scaling to zero to be flagged as dangerous")
+	}
+	if !strings.Contains(text, "3 pod(s) will be terminated") || !strings.Contains(text, "web-b") {
+		t.Errorf("unexpected scale-down preview: %q", text)
+	}
+
+	text, danger = formatScalePreview("default", "web", &k8s.ScalePreview{Current: 1, Target: 2, Fits: false})
+	if danger {
+		t.Error("scale-up should not be flagged as dangerous")
+	}
+	if !strings.Contains(text, "may not fit") {
+		t.Errorf("expected capacity warning, got %q", text)
+	}
+}
+
+func TestFormatFindings(t *testing.T) {
+	if text := formatFindings(nil, nil); !strings.Contains(text, "No findings") {
+		t.Errorf("expected empty message, got %q", text)
+	}
+
+	text := formatFindings([]k8s.Finding{
+		{Check: k8s.CheckPDBMissing, Kind: "Deployment", Namespace: "default", Name: "worker", Message: "no PodDisruptionBudget selects its pods"},
+		{Check: k8s.CheckPDBBlocking, Kind: "PodDisruptionBudget", Namespace: "default", Name: "db-pdb", Message: "allows 0 disruptions"},
+	}, nil)
+	blocking := strings.Index(text, "blocking drains (1)")
+	missing := strings.Index(text, "without a PodDisruptionBudget (1)")
+	if blocking < 0 || missing < 0 || blocking > missing {
+		t.Errorf("expected blocking PDBs listed before uncovered workloads, got %q", text)
+	}
+	if !strings.Contains(text, "Deployment/default/worker") {
+		t.Errorf("expected workload reference, got %q", text)
+	}
+
+	// Findings from checks that ran are still shown when another check failed
+	text = formatFindings([]k8s.Finding{
+		{Check: k8s.CheckLimitMissing, Kind: "Deployment", Namespace: "default", Name: "api", Message: "no limit"},
+	}, fmt.Errorf("forbidden"))
+	if !strings.Contains(text, "Requests without limits (1)") || !strings.Contains(text, "Some checks failed: forbidden") {
+		t.Errorf("expected findings and the failed check, got %q", text)
+	}
+}
+
+func TestDrainTarget(t *testing.T) {
+	tests := []struct {
+		cmd  string
+		node string
+		ok   bool
+	}{
+		{"kubectl drain node-1 --ignore-daemonsets", "node-1", true},
+		{"kubectl drain --delete-emptydir-data=true node-2", "node-2", true},
+		{"drain node-3", "node-3", true},
+		{"kubectl cordon node-1", "", false},
+		{"kubectl drain", "", false},
+	}
+	for _, tt := range tests {
+		node, ok := drainTarget(tt.cmd)
+		if node != tt.node || ok != tt.ok {
+			t.Errorf("drainTarget(%q) = %q, %v; want %q, %v", tt.cmd, node, ok, tt.node, tt.ok)
+		}
+	}
+}
+
+func TestFormatDrainPreflight(t *testing.T) {
+	var findings []k8s.Finding
+	for i := 0; i < maxPreflightLines+2; i++ {
+		findings = append(findings, k8s.Finding{Kind: "Pod", Namespace: "default", Name: fmt.Sprintf("pod-%d", i), Message: "has no controller"})
+	}
+	text := formatDrainPreflight([]string{"node-1"}, findings, []string{"node-2: not found"})
+	if !strings.Contains(text, "Preflight failed:[white] node-2: not found") {
+		t.Errorf("expected preflight error listed first, got %q", text)
+	}
+	if !strings.Contains(text, "... and 3 more") {
+		t.Errorf("expected truncated finding list, got %q", text)
+	}
+}
+
+func TestFormatDrainConfirm(t *testing.T) {
+	text := formatDrainConfirm("node-1", 4, 0)
+	if !strings.Contains(text, "4 pod(s) will be evicted with each pod's own grace period") {
+		t.Errorf("unexpected confirmation text: %q", text)
+	}
+
+	app := &App{config: &config.Config{DrainGracePeriod: 30}}
+	text = formatDrainConfirm("node-1", 4, app.drainGracePeriod())
+	if !strings.Contains(text, "a 30s grace period") {
+		t.Errorf("expected configured grace period, got %q", text)
+	}
+}
+
+func TestDeploymentRevisions(t *testing.T) {
+	revisions := deploymentRevisions([]map[string]interface{}{
+		{"name": "web-1", "revision": "1", "ready": int32(0), "replicas": int32(0), "age": "2h"},
+		{"name": "web-3", "revision": "3", "ready": int32(2), "replicas": int32(3), "age": "5m"},
+		{"name": "web-2", "revision": "2", "ready": int32(0), "replicas": int32(0), "age": "1h"},
+		{"name": "orphan", "revision": "0"},
+	})
+	if len(revisions) != 3 {
+		t.Fatalf("expected 3 revisions, got %+v", revisions)
+	}
+	if revisions[0].Revision != 3 || revisions[0].Ready != 2 || revisions[0].Replicas != 3 || revisions[2].Name != "web-1" {
+		t.Errorf("expected revisions newest first, got %+v", revisions)
+	}
+}
+
+func TestTableTitle(t *testing.T) {
+	tests := []struct {
+		title tableTitle
+		want  string
+	}{
+		{tableTitle{Resource: "pods", Shown: 12, Total: 12}, " pods (12/12) "},
+		{tableTitle{Resource: "pods", Shown: 3, Total: 12, Filter: "web"}, " pods (3/12) [filter: web] "},
+		{tableTitle{Resource: "pods", Shown: 1, Total: 12, Filter: "^web-[0-9]", Regex: true}, " pods (1/12) [regex: ^web-[0-9[]] "},
+		{tableTitle{Resource: "nodes", Shown: 2, Total: 2, SortColumn: "AGE", SortDesc: true, LabelSelector: "role=worker"},
+			" nodes (2/2) [sort: AGE ↓] [labels: role=worker] "},
+		{tableTitle{Resource: "pods", Shown: 4, Total: 4, Freshness: "live: last change 15:04:05"}, " pods (4/4) [live: last change 15:04:05] "},
+	}
+	for _, tt := range tests {
+		if got := tt.title.String(); got != tt.want {
+			t.Errorf("tableTitle%+v = %q, want %q", tt.title, got, tt.want)
+		}
+	}
+}
+
+func TestFilterNamespaces(t *testing.T) {
+	namespaces := []string{"", "default", "kube-system", "kube-public", "monitoring"}
+
+	if got := filterNamespaces(namespaces, ""); len(got) != len(namespaces) {
+		t.Errorf("empty query should match everything, got %v", got)
+	}
+	if got := filterNamespaces(namespaces, "KUBE"); len(got) != 2 || got[0] != "kube-system" {
+		t.Errorf("expected case-insensitive match on kube-*, got %v", got)
+	}
+	if got := filterNamespaces(namespaces, "all"); len(got) != 1 || got[0] != "" {
+		t.Errorf("expected \"all\" to match the all-namespaces entry, got %v", got)
+	}
+}
+
+func TestLoadNamespaces(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}},
+	)
+	app := &App{
+		k8s:        &k8s.Client{Clientset: clientset},
+		namespaces: []string{""},
+		logger:     slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+
+	app.loadNamespaces()
+
+	want := []string{"", "default", "kube-system"}
+	if fmt.Sprint(app.namespaces) != fmt.Sprint(want) {
+		t.Errorf("expected namespace cache %v, got %v", want, app.namespaces)
+	}
+}
+
+func TestDiscoveryInterval(t *testing.T) {
+	app := &App{config: config.NewDefaultConfig()}
+	if got := app.discoveryInterval(); got != 5*time.Minute {
+		t.Errorf("expected default discovery interval of 5m, got %v", got)
+	}
+
+	app.config.Refresh.DiscoveryInterval = 0
+	if got := app.discoveryInterval(); got != 0 {
+		t.Errorf("expected discovery to be disabled, got %v", got)
+	}
+}
+
+func TestViewFreshness(t *testing.T) {
+	client := &k8s.Client{Clientset: fake.NewSimpleClientset()}
+	app := &App{config: config.NewDefaultConfig(), k8s: client}
+
+	if got := app.viewFreshness("pods", "", ""); got != "polled: every 2s" {
+		t.Errorf("expected polled pods without informers, got %q", got)
+	}
+
+	client.StartInformers(app.resourceChanged)
+	defer client.StopInformers()
+	for deadline := time.Now().Add(5 * time.Second); !client.IsWatched("pods"); {
+		if time.Now().After(deadline) {
+			t.Fatal("pod cache did not sync")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if got := app.viewFreshness("pods", "", ""); got != "live: no changes yet" {
+		t.Errorf("expected live pods, got %q", got)
+	}
+	app.resourceChanged("pods")
+	if got := app.viewFreshness("pods", "", ""); !strings.HasPrefix(got, "live: last change ") {
+		t.Errorf("expected the last change time, got %q", got)
+	}
+	if got := app.viewFreshness("pods", "", "spec.nodeName=node-1"); got != "polled: every 2s" {
+		t.Errorf("expected a node drill-down to be polled, got %q", got)
+	}
+
+	app.config.Refresh.Interval = 0
+	if got := app.viewFreshness("nodes", "", ""); got != "polled: on refresh" {
+		t.Errorf("expected nodes to be refreshed manually, got %q", got)
+	}
+}
+
+func TestAutoRefreshStatus(t *testing.T) {
+	app := &App{config: config.NewDefaultConfig()}
+	if got := app.autoRefreshInterval(); got != 2*time.Second {
+		t.Errorf("expected default auto-refresh interval of 2s, got %v", got)
+	}
+	if got := app.autoRefreshStatus(); !strings.Contains(got, "Auto:2s") {
+		t.Errorf("expected running status, got %q", got)
+	}
+
+	app.autoRefreshPaused = 1
+	if got := app.autoRefreshStatus(); !strings.Contains(got, "paused") {
+		t.Errorf("expected paused status, got %q", got)
+	}
+
+	app.config.Refresh.Interval = 0
+	if got := app.autoRefreshStatus(); got != "" {
+		t.Errorf("expected no status when disabled, got %q", got)
+	}
+}
+
+func TestAutoRefreshIdlePause(t *testing.T) {
+	app := &App{config: config.NewDefaultConfig()}
+	now := time.Now()
+	if app.userActive(now) {
+		t.Error("expected no activity before the first key press")
+	}
+
+	app.lastInput = now.Add(-time.Second).UnixNano()
+	if !app.userActive(now) || !app.autoRefreshHeld() {
+		t.Error("expected auto-refresh to wait right after a key press")
+	}
+	if app.userActive(now.Add(3 * time.Second)) {
+		t.Error("expected auto-refresh to resume after the idle pause")
+	}
+
+	app.config.Refresh.IdlePause = 0
+	if app.userActive(now) {
+		t.Error("expected no idle pause when disabled")
+	}
+}
+
+func TestResourceChanged(t *testing.T) {
+	app := &App{currentResource: "pods"}
+
+	app.resourceChanged("services")
+	if app.liveChanged != 0 {
+		t.Error("expected changes to other resources to be ignored")
+	}
+	app.resourceChanged("pods")
+	if app.liveChanged != 1 {
+		t.Error("expected a change to the current resource to be queued")
+	}
+}
+
+func TestFetchNodesUtilization(t *testing.T) {
+	clientset := fake.NewSimpleClientset(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}})
+	app := &App{k8s: &k8s.Client{Clientset: clientset}}
+
+	headers, rows, err := app.fetchNodes(context.Background())
+	if err != nil {
+		t.Fatalf("fetchNodes failed: %v", err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 node row, got %d", len(rows))
+	}
+	// No metrics client: the utilization columns are dropped rather than
+	// failing the view
+	for _, h := range headers {
+		if h == "CPU%" || h == "MEM%" {
+			t.Errorf("expected no %s column without metrics-server, got %v", h, headers)
+		}
+	}
+	if len(rows[0]) != len(headers) {
+		t.Errorf("row %v does not match headers %v", rows[0], headers)
+	}
+}
+
+func TestFetchNamespacesCounts(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shop"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "shop"}},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop"}},
+	)
+	app := &App{k8s: &k8s.Client{Clientset: clientset}}
+
+	headers, rows, err := app.fetchNamespaces(context.Background())
+	if err != nil {
+		t.Fatalf("fetchNamespaces failed: %v", err)
+	}
+	want := []string{"NAME", "STATUS", "PODS", "DEPLOYMENTS", "SERVICES", "AGE"}
+	if !reflect.DeepEqual(headers, want) {
+		t.Fatalf("headers = %v, want %v", headers, want)
+	}
+	if len(rows) != 1 || rows[0][2] != "1" || rows[0][3] != "0" || rows[0][4] != "1" {
+		t.Fatalf("unexpected rows %v", rows)
+	}
+
+	// Counts are reused until they expire
+	clientset.CoreV1().Pods("shop").Create(context.Background(),
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-2", Namespace: "shop"}}, metav1.CreateOptions{})
+	if _, rows, _ = app.fetchNamespaces(context.Background()); rows[0][2] != "1" {
+		t.Errorf("expected cached pod count 1, got %s", rows[0][2])
+	}
+	app.nsCounts.reset()
+	if _, rows, _ = app.fetchNamespaces(context.Background()); rows[0][2] != "2" {
+		t.Errorf("expected pod count 2 after reset, got %s", rows[0][2])
+	}
+}
+
+func TestUtilizationColor(t *testing.T) {
+	tests := []struct {
+		used, total int64
+		want        string
+		color       tcell.Color
+	}{
+		{350, 1000, "35%", tcell.ColorGreen},
+		{750, 1000, "75%", tcell.ColorYellow},
+		{950, 1000, "95%", tcell.ColorRed},
+		{100, 0, "<unknown>", tcell.ColorWhite},
+	}
+	app := &App{}
+	headers := []string{"NAME", "CPU%"}
+	for _, tt := range tests {
+		got := formatPercent(tt.used, tt.total)
+		if got != tt.want {
+			t.Errorf("formatPercent(%d, %d) = %q, want %q", tt.used, tt.total, got, tt.want)
+		}
+		if color := app.cellColor(headers, 1, got); color != tt.color {
+			t.Errorf("cellColor(%q) = %v, want %v", got, color, tt.color)
+		}
+	}
+}
+
+func TestFollowLogs(t *testing.T) {
+	screen := tcell.NewSimulationScreen("UTF-8")
+	if err := screen.Init(); err != nil {
+		t.Fatalf("failed to init screen: %v", err)
+	}
+	app := &App{
+		Application: tview.NewApplication().SetScreen(screen),
+		k8s:         &k8s.Client{Clientset: fake.NewSimpleClientset()},
+	}
+	go app.Application.Run()
+	defer app.Stop()
+
+	view := tview.NewTextView()
+	follow := &logFollow{}
+	ended := make(chan struct{})
+	app.followLogs(follow, view, "default", "web", []string{""}, func() { close(ended) })
+
+	select {
+	case <-ended:
+	case <-time.After(5 * time.Second):
+		t.Fatal("log stream did not end")
+	}
+	// The fake clientset serves a fixed body and closes the stream
+	if text := view.GetText(true); !strings.Contains(text, "fake logs") || !strings.Contains(text, "log stream ended") {
+		t.Errorf("unexpected log view content: %q", text)
+	}
+	if follow.running() {
+		t.Error("follow should stop once the stream ends")
+	}
+	if follow.stop() {
+		t.Error("stop after the stream ended should report nothing running")
+	}
+}
+
+func TestFollowLogsPrefixesContainers(t *testing.T) {
+	screen := tcell.NewSimulationScreen("UTF-8")
+	if err := screen.Init(); err != nil {
+		t.Fatalf("failed to init screen: %v", err)
+	}
+	app := &App{
+		Application: tview.NewApplication().SetScreen(screen),
+		k8s:         &k8s.Client{Clientset: fake.NewSimpleClientset()},
+	}
+	go app.Application.Run()
+	defer app.Stop()
+
+	view := tview.NewTextView().SetDynamicColors(true)
+	ended := make(chan struct{})
+	app.followLogs(&logFollow{}, view, "default", "web", []string{"app", "sidecar"}, func() { close(ended) })
+
+	select {
+	case <-ended:
+	case <-time.After(5 * time.Second):
+		t.Fatal("log streams did not end")
+	}
+	text := view.GetText(true)
+	for _, want := range []string{"[app] fake logs", "[sidecar] fake logs"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("expected %q in log view, got %q", want, text)
+		}
+	}
+}
+
+func TestPickContainerSingle(t *testing.T) {
+	screen := tcell.NewSimulationScreen("UTF-8")
+	if err := screen.Init(); err != nil {
+		t.Fatalf("failed to init screen: %v", err)
+	}
+	clientset := fake.NewSimpleClientset(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}},
+	})
+	app := &App{
+		Application: tview.NewApplication().SetScreen(screen),
+		k8s:         &k8s.Client{Clientset: clientset},
+	}
+	go app.Application.Run()
+	defer app.Stop()
+
+	chosen := make(chan string, 1)
+	app.pickContainer("default", "web", func(containers []string, idx int) {
+		chosen <- containers[idx]
+	})
+
+	select {
+	case c := <-chosen:
+		if c != "app" {
+			t.Errorf("expected the only container to be chosen, got %q", c)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("single-container pod should not ask for a container")
+	}
+}
+
+func TestPickContainerCached(t *testing.T) {
+	screen := tcell.NewSimulationScreen("UTF-8")
+	if err := screen.Init(); err != nil {
+		t.Fatalf("failed to init screen: %v", err)
+	}
+	clientset := fake.NewSimpleClientset(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{
+			{Name: "app"}, {Name: "sidecar"},
+		}},
+	})
+	app := &App{
+		Application: tview.NewApplication().SetScreen(screen),
+		k8s:         &k8s.Client{Clientset: clientset},
+		pages:       tview.NewPages(),
+		table:       tview.NewTable(),
+	}
+	app.Application.SetRoot(app.pages, true)
+	go app.Application.Run()
+	defer app.Stop()
+
+	// The pod list caches container names, so the picker needs no API call
+	if _, _, err := app.fetchPods(context.Background(), "default"); err != nil {
+		t.Fatalf("fetchPods failed: %v", err)
+	}
+	clientset.ClearActions()
+
+	app.pickContainer("default", "web", func(containers []string, idx int) {})
+
+	// pickContainer shows the picker from a goroutine; wait for it
+	shown := false
+	for deadline := time.Now().Add(5 * time.Second); !shown && time.Now().Before(deadline); {
+		time.Sleep(10 * time.Millisecond)
+		done := make(chan bool, 1)
+		app.QueueUpdate(func() { done <- app.pages.HasPage("container-picker") })
+		shown = <-done
+	}
+	if !shown {
+		t.Fatal("multi-container pod should ask for a container")
+	}
+
+	for _, action := range clientset.Actions() {
+		if action.GetVerb() == "get" {
+			t.Errorf("expected cached containers, got %s %s", action.GetVerb(), action.GetResource().Resource)
+		}
+	}
+}
+
+func TestFormatRecentChanges(t *testing.T) {
+	if text := formatRecentChanges(nil, nil); !strings.Contains(text, "No resources") {
+		t.Errorf("expected empty message, got %q", text)
+	}
+
+	text := formatRecentChanges([]k8s.RecentChange{
+		{Kind: "Deployment", Namespace: "shop", Name: "web", Time: time.Now().Add(-5 * time.Minute),
+			Manager: "kubectl-client-side-apply", Operation: "Update", Revision: "7"},
+		{Kind: "ConfigMap", Namespace: "shop", Name: "settings", Time: time.Now().Add(-48 * time.Hour), Operation: "Create"},
+	}, fmt.Errorf("secrets is forbidden"))
+
+	web := strings.Index(text, "shop/web")
+	settings := strings.Index(text, "shop/settings")
+	if web < 0 || settings < 0 || web > settings {
+		t.Errorf("expected both changes in order, got %q", text)
+	}
+	for _, want := range []string{"5m", "kubectl-client-side-apply (Update)", "7", "2d", "Create", "secrets is forbidden"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("expected %q in %q", want, text)
+		}
+	}
+}
+
+func TestSaveLogs(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	path, err := saveLogs("default", "web-1", "line 1\nline 2\n")
+	if err != nil {
+		t.Fatalf("saveLogs failed: %v", err)
+	}
+	if filepath.Dir(path) != filepath.Join(home, logsDir) || !strings.HasPrefix(filepath.Base(path), "default-web-1-") {
+		t.Errorf("unexpected log file path %q", path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || string(data) != "line 1\nline 2\n" {
+		t.Errorf("unexpected log file content %q (%v)", data, err)
+	}
+
+	// A file in place of the directory makes the save fail
+	t.Setenv("HOME", t.TempDir())
+	home2, _ := os.UserHomeDir()
+	if err := os.WriteFile(filepath.Join(home2, logsDir), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := saveLogs("default", "web-1", "x"); err == nil {
+		t.Error("expected an error when the log directory cannot be created")
+	}
+}
+
+func TestFetchGenericResource(t *testing.T) {
+	widget := &unstructured.Unstructured{}
+	widget.SetAPIVersion("example.com/v1")
+	widget.SetKind("Widget")
+	widget.SetNamespace("team-a")
+	widget.SetName("gear")
+	gvr := schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "widgets"}
+	dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{gvr: "WidgetList"}, widget)
+
+	app := &App{
+		k8s: &k8s.Client{Dynamic: dyn},
+		apiResources: []k8s.APIResource{
+			{Name: "widgets", ShortNames: []string{"wd"}, Kind: "Widget", Group: "example.com", Version: "v1", Namespaced: true},
+		},
+	}
+
+	for _, name := range []string{"widgets", "wd", "Widget"} {
+		if res, ok := app.lookupAPIResource(name); !ok || res.Name != "widgets" {
+			t.Errorf("lookupAPIResource(%q) = %+v, %v", name, res, ok)
+		}
+	}
+	if _, ok := app.lookupAPIResource("gadgets"); ok {
+		t.Error("expected unknown resource not to resolve")
+	}
+
+	_, rows, err := app.fetchGenericResource(context.Background(), "widgets", "team-a")
+	if err != nil {
+		t.Fatalf("fetchGenericResource failed: %v", err)
+	}
+	if len(rows) != 1 || rows[0][0] != "team-a" || rows[0][1] != "gear" {
+		t.Errorf("expected one widget row, got %v", rows)
+	}
+
+	if _, _, err := app.fetchGenericResource(context.Background(), "gadgets", ""); err == nil {
+		t.Error("expected an error for an undiscovered resource")
+	}
+}
+
+func TestConfirmAction(t *testing.T) {
+	cfg := config.NewDefaultConfig()
+	cfg.ConfirmActions = []string{"rollback"}
+	app := &App{Application: tview.NewApplication(), config: cfg, pages: tview.NewPages(), table: tview.NewTable()}
+
+	ran := make(chan string, 1)
+	app.confirmAction("restart", "restart-confirm", "Restart?", "Restart", false, func() { ran <- "restart" })
+	select {
+	case <-ran:
+	case <-time.After(time.Second):
+		t.Fatal("expected restart to run without confirmation")
+	}
+	if app.pages.HasPage("restart-confirm") {
+		t.Error("expected no confirmation for restart")
+	}
+
+	for _, action := range []string{"rollback", "delete"} {
+		app.confirmAction(action, action+"-confirm", "Sure?", "OK", false, func() { ran <- action })
+		if !app.pages.HasPage(action + "-confirm") {
+			t.Errorf("expected a confirmation for %s", action)
+		}
+	}
+	select {
+	case action := <-ran:
+		t.Errorf("expected %s to wait for confirmation", action)
+	default:
+	}
+}
+
+func TestDownloadPodLogs(t *testing.T) {
+	pod := func(name string, containers ...string) *corev1.Pod {
+		p := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+		for _, c := range containers {
+			p.Spec.Containers = append(p.Spec.Containers, corev1.Container{Name: c})
+		}
+		return p
+	}
+	client := &k8s.Client{Clientset: fake.NewSimpleClientset(pod("web-1", "app", "sidecar"), pod("web-2", "app"))}
+	dir := filepath.Join(t.TempDir(), "pods")
+
+	refs := []resourceRef{{"default", "web-1"}, {"default", "web-2"}, {"default", "gone"}}
+	var progressed int32
+	res, err := downloadPodLogs(context.Background(), client, refs, dir, func(done, total int) {
+		if total != len(refs) {
+			t.Errorf("expected progress out of %d pods, got %d", len(refs), total)
+		}
+		atomic.AddInt32(&progressed, 1)
+	})
+	if err != nil {
+		t.Fatalf("downloadPodLogs failed: %v", err)
+	}
+	if progressed != int32(len(refs)) {
+		t.Errorf("expected progress for each of %d pods, got %d", len(refs), progressed)
+	}
+	if res.Files != 3 {
+		t.Errorf("expected 3 log files, got %d", res.Files)
+	}
+	if len(res.Failures) != 1 || !strings.HasPrefix(res.Failures[0], "default/gone: ") {
+		t.Errorf("expected the missing pod to be reported, got %v", res.Failures)
+	}
+	// The fake clientset streams "fake logs" for every container
+	for _, name := range []string{"default-web-1-app.log", "default-web-1-sidecar.log", "default-web-2-app.log"} {
+		if data, err := os.ReadFile(filepath.Join(dir, name)); err != nil || string(data) != "fake logs" {
+			t.Errorf("expected the logs in %s, got %q: %v", name, data, err)
+		}
+	}
+}
+
+func TestPortForwardRegistry(t *testing.T) {
+	var r portForwardRegistry
+	newForward := func(name string, local int) *activePortForward {
+		return &activePortForward{namespace: "default", name: name, resource: "pods", localPort: local, remotePort: 80, stopCh: make(chan struct{})}
+	}
+
+	web := newForward("web", 8080)
+	api := newForward("api", 8081)
+	if err := r.add(api); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.add(web); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.add(newForward("other", 8080)); err == nil {
+		t.Error("expected a second forward on the same local port to be refused")
+	}
+	if got := r.list(); len(got) != 2 || got[0] != web || got[1] != api {
+		t.Errorf("expected forwards by local port, got %v", got)
+	}
+
+	r.stopAll()
+	if len(r.list()) != 0 {
+		t.Error("expected no forwards after stopAll")
+	}
+	for _, pf := range []*activePortForward{web, api} {
+		select {
+		case <-pf.stopCh:
+		default:
+			t.Errorf("expected %s to be stopped", pf.name)
+		}
+	}
+	web.stop() // stopping twice is harmless
+}
+
+func TestBundleName(t *testing.T) {
+	now := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+	if got := bundleName("deployments", "default", "web", now); got != "k13s-bundle-deployments-default-web-20240102-150405" {
+		t.Errorf("unexpected bundle name %q", got)
+	}
+	if got := bundleName("namespaces", "", "team-a", now); got != "k13s-bundle-namespaces-team-a-20240102-150405" {
+		t.Errorf("unexpected bundle name %q", got)
+	}
+}
+
+func TestFetchPodsWithLabelSelector(t *testing.T) {
+	pod := func(name, app string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{"app": app}}}
+	}
+	app := &App{
+		k8s:             &k8s.Client{Clientset: fake.NewSimpleClientset(pod("web-1", "web"), pod("web-admin-1", "web-admin"))},
+		currentResource: "pods",
+		labelSelector:   "app=web",
+	}
+
+	_, rows, err := app.fetchPods(context.Background(), "default")
+	if err != nil {
+		t.Fatalf("fetchPods failed: %v", err)
+	}
+	if len(rows) != 1 || rows[0][1] != "web-1" {
+		t.Errorf("expected only the pods matching the selector, got %v", rows)
+	}
+	if got := app.podSelector(); got != "app=web" {
+		t.Errorf("expected the selector in the pods view, got %q", got)
+	}
+
+	app.currentResource = "deployments"
+	if got := app.podSelector(); got != "" {
+		t.Errorf("expected no selector outside the pods view, got %q", got)
+	}
+}
+
+func TestASCIIMode(t *testing.T) {
+	cfg := config.NewDefaultConfig()
+	if asciiMode(cfg, "xterm-256color") {
+		t.Error("expected Unicode on xterm")
+	}
+	for _, term := range []string{"dumb", "vt100"} {
+		if !asciiMode(cfg, term) {
+			t.Errorf("expected ASCII on TERM=%s", term)
+		}
+	}
+	cfg.ASCIIMode = true
+	if !asciiMode(cfg, "xterm-256color") {
+		t.Error("expected ASCII when ascii_mode is set")
+	}
+
+	app := &App{}
+	if got := app.glyphs("│ ✓ ok │"); got != "│ ✓ ok │" {
+		t.Errorf("expected glyphs unchanged outside ASCII mode, got %q", got)
+	}
+	app.ascii = true
+	box := "┌──┐\n│ • ↑ │\n└──┘ ✓ ✗ ⚠ 🤖"
+	if got, want := app.glyphs(box), "+--+\n| * ^ |\n+--+ OK X ! AI"; got != want {
+		t.Errorf("glyphs(%q) = %q, want %q", box, got, want)
+	}
+
+	screen := tcell.NewSimulationScreen("")
+	if err := screen.Init(); err != nil {
+		t.Fatalf("failed to init screen: %v", err)
+	}
+	defer screen.Fini()
+	mono := monochromeScreen{screen}
+	mono.SetContent(0, 0, 'x', nil, tcell.StyleDefault.Foreground(tcell.ColorRed).Background(tcell.ColorBlue).Reverse(true))
+	_, _, style, _ := screen.GetContent(0, 0)
+	if fg, bg, attrs := style.Decompose(); fg != tcell.ColorDefault || bg != tcell.ColorDefault || attrs&tcell.AttrReverse == 0 {
+		t.Errorf("expected default colors with reverse kept, got fg=%v bg=%v attrs=%v", fg, bg, attrs)
+	}
+	if mono.Colors() != 0 {
+		t.Errorf("expected no colors, got %d", mono.Colors())
+	}
+}
+
+func TestFetchPodsWithFieldSelector(t *testing.T) {
+	clientset := fake.NewSimpleClientset(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default"},
+		Spec:       corev1.PodSpec{NodeName: "node-1"},
+	})
+	var listed string
+	clientset.PrependReactor("list", "pods", func(action ktesting.Action) (bool, runtime.Object, error) {
+		listed = action.(ktesting.ListAction).GetListRestrictions().Fields.String()
+		return false, nil, nil
+	})
+	app := &App{
+		k8s:             &k8s.Client{Clientset: clientset},
+		currentResource: "pods",
+		fieldSelector:   "spec.nodeName=node-1",
+	}
+
+	headers, rows, err := app.fetchPods(context.Background(), "")
+	if err != nil {
+		t.Fatalf("fetchPods failed: %v", err)
+	}
+	if listed != "spec.nodeName=node-1" {
+		t.Errorf("expected the node's pods to be listed server-side, got field selector %q", listed)
+	}
+	if headers[len(headers)-1] != "NODE" || len(rows) != 1 || rows[0][len(headers)-1] != "node-1" {
+		t.Errorf("expected a NODE column, got %v %v", headers, rows)
+	}
+	if got := app.podFieldSelector(); got != "spec.nodeName=node-1" {
+		t.Errorf("expected the field selector in the pods view, got %q", got)
+	}
+}
+
+func TestShowOverlayEsc(t *testing.T) {
+	app := &App{Application: tview.NewApplication(), pages: tview.NewPages(), table: tview.NewTable()}
+	app.pages.AddPage("main", app.table, true, true)
+	send := func(event *tcell.EventKey) {
+		app.pages.InputHandler()(event, func(p tview.Primitive) { app.SetFocus(p) })
+	}
+	press := func(key tcell.Key) { send(tcell.NewEventKey(key, 0, tcell.ModNone)) }
+
+	// A form with no Cancel handling of its own
+	form := tview.NewForm().AddInputField("Replicas:", "1", 10, nil, nil).AddButton("Scale", nil)
+	app.showCentered("scale-dialog", form, 50, 10)
+	if !form.HasFocus() {
+		t.Fatal("expected the form to be focused")
+	}
+	press(tcell.KeyTab)
+	if _, button := form.GetFocusedItemIndex(); button != 0 {
+		t.Errorf("expected Tab to move to the Scale button, got button %d", button)
+	}
+	press(tcell.KeyEsc)
+	if app.pages.HasPage("scale-dialog") || app.GetFocus() != app.table {
+		t.Error("expected Esc to close the form and focus the table")
+	}
+
+	// An overlay's own keys keep working
+	var keys []rune
+	view := tview.NewTextView()
+	view.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		keys = append(keys, event.Rune())
+		return nil
+	})
+	app.showCentered("findings", view, 100, 24)
+	send(tcell.NewEventKey(tcell.KeyRune, 'j', tcell.ModNone))
+	press(tcell.KeyEsc)
+	if string(keys) != "j" {
+		t.Errorf("expected the view to see j but not Esc, got %q", string(keys))
+	}
+	if app.pages.HasPage("findings") {
+		t.Error("expected Esc to close the view")
+	}
+
+	// The namespace switcher closes from its list as well as its filter
+	app.openNamespaceSwitcher([]string{"", "default"})
+	press(tcell.KeyTab)
+	press(tcell.KeyEsc)
+	if app.pages.HasPage("namespace-switcher") || app.GetFocus() != app.table {
+		t.Error("expected Esc to close the namespace switcher and focus the table")
+	}
+}
+
+func TestCommandsResolveToGVR(t *testing.T) {
+	client := &k8s.Client{}
+	for _, cmd := range commands {
+		if cmd.category != "resource" {
+			continue
+		}
+		for _, name := range []string{cmd.name, cmd.alias} {
+			t.Run(name, func(t *testing.T) {
+				gvr, ok := client.GetGVR(name)
+				if !ok {
+					t.Fatalf("no GVR for :%s", name)
+				}
+				if gvr.Resource != cmd.name || gvr.Version == "" {
+					t.Errorf("GetGVR(%q) = %v, want resource %s", name, gvr, cmd.name)
+				}
+			})
+		}
+	}
+}
+
+func TestTaskRegistry(t *testing.T) {
+	app := &App{}
+	if got := app.tasks.status(); got != "" {
+		t.Errorf("expected no status while idle, got %q", got)
+	}
+
+	drain := app.startTask("Draining node-1", "pods evicted")
+	bundle := app.startTask("Collecting diagnostics", "")
+	drain.progress(7, 20)
+	want := "[black:yellow] Draining node-1: 7/20 pods evicted | Collecting diagnostics... [-:-] "
+	if got := app.tasks.status(); got != want {
+		t.Errorf("status() = %q, want %q", got, want)
+	}
+
+	drain.finish()
+	bundle.finish()
+	if got := app.tasks.status(); got != "" {
+		t.Errorf("expected finished tasks to be cleared, got %q", got)
+	}
+}
+
+func TestUndoSlot(t *testing.T) {
+	app := &App{}
+	if app.undo.take() != nil {
+		t.Fatal("expected nothing to undo")
+	}
+
+	var reverted []string
+	app.recordUndo("uncordon node-1", "nodes/node-1", "uncordon", func(ctx context.Context) error {
+		reverted = append(reverted, "node-1")
+		return nil
+	})
+	app.recordUndo("scale default/web back to 3 replicas", "deployments/default/web", "scale", func(ctx context.Context) error {
+		reverted = append(reverted, "web")
+		return nil
+	})
+
+	action := app.undo.take()
+	if action == nil || action.description != "scale default/web back to 3 replicas" {
+		t.Fatalf("expected the last action to be undone, got %+v", action)
+	}
+	if err := action.revert(context.Background()); err != nil || len(reverted) != 1 || reverted[0] != "web" {
+		t.Errorf("unexpected revert: %v %v", reverted, err)
+	}
+	if app.undo.take() != nil {
+		t.Error("expected an action to be undone at most once")
+	}
+}
+
+func TestUndoLastChecks(t *testing.T) {
+	screen := tcell.NewSimulationScreen("UTF-8")
+	if err := screen.Init(); err != nil {
+		t.Fatalf("failed to init screen: %v", err)
+	}
+	app := &App{
+		Application: tview.NewApplication().SetScreen(screen),
+		config:      config.NewDefaultConfig(),
+		pages:       tview.NewPages(),
+		table:       tview.NewTable(),
+		flash:       tview.NewTextView(),
+	}
+	app.pages.AddPage("main", app.table, true, true)
+	app.Application.SetRoot(app.pages, true)
+	go app.Application.Run()
+	defer app.Stop()
+
+	// undo presses Ctrl+Z and reports whether a confirmation is shown
+	undo := func() bool {
+		asked := make(chan bool, 1)
+		app.QueueUpdate(func() {
+			app.undoLast()
+			asked <- app.pages.HasPage("undo-confirm")
+			app.closeOverlay("undo-confirm")
+		})
+		return <-asked
+	}
+	ran := make(chan string, 1)
+	revert := func(name string) func(ctx context.Context) error {
+		return func(ctx context.Context) error {
+			ran <- name
+			return errors.New("stop before refreshing")
+		}
+	}
+
+	// Done in another context: refused and forgotten
+	app.recordUndo("scale default/web back to 3 replicas", "deployments/default/web", "scale", revert("web"))
+	app.undo.last.kubeContext = "prod"
+	if undo() || app.undo.take() != nil {
+		t.Error("expected an action from another context not to be undone")
+	}
+
+	// Scaling back to 0 asks like scaling to 0 does
+	app.recordUndo("scale default/web back to 0 replicas", "deployments/default/web", "scale-to-0", revert("web"))
+	if !undo() {
+		t.Error("expected undoing to 0 replicas to ask for confirmation")
+	}
+
+	app.recordUndo("resume cronjob default/backup", "cronjobs/default/backup", "", revert("backup"))
+	if undo() {
+		t.Error("expected resuming a cronjob not to ask")
+	}
+	select {
+	case name := <-ran:
+		if name != "backup" {
+			t.Errorf("expected the cronjob to be resumed, got %s", name)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected an undo without confirmation to run")
+	}
+	select {
+	case name := <-ran:
+		t.Errorf("expected %s not to be undone", name)
+	default:
+	}
+}
+
+func TestColumnOrder(t *testing.T) {
+	headers := []string{"NAMESPACE", "NAME", "STATUS", "READY", "AGE"}
+	tests := []struct {
+		order []string
+		want  []int
+	}{
+		{nil, []int{0, 1, 2, 3, 4}},
+		{[]string{"STATUS"}, []int{2, 0, 1, 3, 4}},
+		{[]string{"name", " status ", "NAMESPACE"}, []int{1, 2, 0, 3, 4}},
+		{[]string{"UNKNOWN", "AGE", "AGE"}, []int{4, 0, 1, 2, 3}},
+	}
+	for _, tt := range tests {
+		if got := columnOrder(headers, tt.order); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("columnOrder(%v) = %v, want %v", tt.order, got, tt.want)
+		}
+	}
+
+	// Lookups by fetched column still find NAMESPACE and NAME once moved
+	app := &App{
+		table:  tview.NewTable(),
+		config: &config.Config{ColumnOrder: map[string][]string{"pods": {"STATUS", "NAME"}}},
+	}
+	display := app.displayColumns("pods", headers)
+	app.setTableHeaders(headers, display)
+	row := []string{"shop", "web-1", "Running", "1/1", "5m"}
+	for d, c := range display {
+		app.table.SetCell(1, d, tview.NewTableCell(row[c]))
+	}
+	if got := app.table.GetCell(0, 0).Text; got != "STATUS" {
+		t.Errorf("expected STATUS first, got %s", got)
+	}
+	if ns, name := app.tableCell(1, 0).Text, app.tableCell(1, 1).Text; ns != "shop" || name != "web-1" {
+		t.Errorf("expected shop/web-1, got %s/%s", ns, name)
+	}
+	if got := app.displayColumns("services", headers); fmt.Sprint(got) != "[0 1 2 3 4]" {
+		t.Errorf("expected other views to keep their order, got %v", got)
+	}
+}
@@ -0,0 +1,199 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/k8s"
+	"github.com/rivo/tview"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// applyHint is shown under the YAML editor until an apply fails
+const applyHint = "[gray]Ctrl+S apply (server-side)   Esc discard changes[white]"
+
+// editAndApply opens the selected resource's YAML in an editor inside the
+// TUI and applies it with server-side apply (Shift+E). Unlike e, it needs
+// no kubectl or $EDITOR.
+func (a *App) editAndApply() {
+	a.mx.RLock()
+	resource := a.currentResource
+	a.mx.RUnlock()
+
+	ns, name, ok := a.selectedResourceRef(resource)
+	if !ok {
+		return
+	}
+	gvr, _, ok := a.resolveGVR(resource)
+	if !ok {
+		a.flashMsg(fmt.Sprintf("Unknown resource type: %s", resource), true)
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		manifest, err := a.k8s.GetResourceYAMLForEdit(ctx, ns, name, gvr)
+		if err != nil {
+			a.flashMsg(fmt.Sprintf("Failed to load %s/%s: %v", resource, name, err), true)
+			return
+		}
+		a.QueueUpdateDraw(func() {
+			a.showApplyEditor(resource, ns, name, manifest)
+		})
+	}()
+}
+
+// showApplyEditor shows manifest in a text area. Ctrl+S applies it; if the
+// server rejects it, its error is shown below the editor, which stays open
+// for corrections.
+func (a *App) showApplyEditor(resource, ns, name, manifest string) {
+	editor := tview.NewTextArea()
+	editor.SetText(manifest, false)
+	editor.SetBorder(true).SetTitle(fmt.Sprintf(" Edit %s/%s ", resource, name))
+
+	status := tview.NewTextView().SetDynamicColors(true).SetWrap(true)
+	status.SetText(applyHint)
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(editor, 0, 1, true).
+		AddItem(status, 3, 0, false)
+
+	applying := false
+	layout.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() != tcell.KeyCtrlS {
+			return event
+		}
+		if applying {
+			return nil
+		}
+		applying = true
+		status.SetText("[yellow]Applying...[white]")
+		text := editor.GetText()
+
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			_, err := a.k8s.ApplyYAML(ctx, []byte(text))
+			a.QueueUpdateDraw(func() {
+				applying = false
+				if err != nil {
+					status.SetText(applyErrorText(resource, name, err))
+					return
+				}
+				a.closeOverlay("apply-editor")
+			})
+			if err != nil {
+				return
+			}
+
+			a.recordAudit("apply", fmt.Sprintf("%s/%s/%s", resource, ns, name), "server-side apply from the TUI editor")
+			a.flashMsg(fmt.Sprintf("Applied %s/%s", resource, name), false)
+			a.refresh()
+		}()
+		return nil
+	})
+
+	a.showOverlay("apply-editor", layout)
+}
+
+// applyErrorText explains a failed apply under the editor. A conflict means
+// the object changed since the editor loaded it; applying anyway would
+// revert that change, so the user is asked to reopen the latest version.
+func applyErrorText(resource, name string, err error) string {
+	if apierrors.IsConflict(err) {
+		return fmt.Sprintf("[red]Conflict:[white] %s/%s was modified since it was opened. Copy your changes, then Esc and reopen it to edit the latest version.\n%s",
+			resource, tview.Escape(name), applyHint)
+	}
+	return fmt.Sprintf("[red]Apply failed:[white] %s\n%s", tview.Escape(err.Error()), applyHint)
+}
+
+// applyFile applies every object in a YAML file (:apply <path>) and lists
+// the outcome per object in the AI panel
+func (a *App) applyFile(path string) {
+	if path == "" {
+		a.flashMsg("Usage: apply <path>", true)
+		return
+	}
+	if strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, path[2:])
+		}
+	}
+
+	go func() {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			a.flashMsg(fmt.Sprintf("Failed to read %s: %v", path, err), true)
+			return
+		}
+
+		// Like kubectl, objects without a namespace go to the current one,
+		// or the kubeconfig's while viewing all namespaces
+		a.mx.RLock()
+		namespace := a.currentNamespace
+		a.mx.RUnlock()
+		if namespace == "" {
+			namespace = a.k8s.GetCurrentNamespace()
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		defer cancel()
+		results, err := a.k8s.ApplyManifests(ctx, data, namespace)
+
+		text := formatApplyResults(path, results, err)
+		a.QueueUpdateDraw(func() {
+			a.aiPanel.SetText(a.glyphs(text))
+			a.aiPanel.ScrollToBeginning()
+		})
+
+		failed := 0
+		for _, r := range results {
+			if r.Err != nil {
+				failed++
+			}
+		}
+		a.recordAudit("apply", path, fmt.Sprintf("%d objects applied, %d failed", len(results)-failed, failed))
+		if err != nil || failed > 0 {
+			a.flashMsg(fmt.Sprintf("Applied %s with errors, see the AI panel", filepath.Base(path)), true)
+		} else {
+			a.flashMsg(fmt.Sprintf("Applied %d objects from %s", len(results), filepath.Base(path)), false)
+		}
+		a.refresh()
+	}()
+}
+
+// formatApplyResults lists one line per applied object, and the error that
+// stopped reading the file if there was one
+func formatApplyResults(path string, results []k8s.ApplyResult, err error) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "[yellow]Apply:[white] %s\n\n", tview.Escape(path))
+	for _, r := range results {
+		name := r.Name
+		if r.Namespace != "" {
+			name = r.Namespace + "/" + r.Name
+		}
+		if name == "" {
+			name = fmt.Sprintf("document %d", r.Document)
+		}
+		if r.Err != nil {
+			fmt.Fprintf(&sb, "[red]✗[white] %s %s: %s\n", r.Kind, tview.Escape(name), tview.Escape(r.Err.Error()))
+		} else {
+			fmt.Fprintf(&sb, "[green]✓[white] %s %s\n", r.Kind, tview.Escape(name))
+		}
+	}
+	if len(results) == 0 && err == nil {
+		sb.WriteString("[gray]No objects in the file[white]\n")
+	}
+	if err != nil {
+		fmt.Fprintf(&sb, "[red]Stopped:[white] %s\n", tview.Escape(err.Error()))
+	}
+	return sb.String()
+}
@@ -0,0 +1,201 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/k8s"
+	"github.com/rivo/tview"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	ktesting "k8s.io/client-go/testing"
+)
+
+func TestApplyEditorKeepsErrors(t *testing.T) {
+	screen := tcell.NewSimulationScreen("UTF-8")
+	if err := screen.Init(); err != nil {
+		t.Fatalf("failed to init screen: %v", err)
+	}
+	app := &App{
+		Application: tview.NewApplication().SetScreen(screen),
+		// Discovery knows no kinds, so every apply fails
+		k8s:   &k8s.Client{Clientset: fake.NewSimpleClientset()},
+		pages: tview.NewPages(),
+		table: tview.NewTable(),
+	}
+	app.pages.AddPage("main", app.table, true, true)
+	app.Application.SetRoot(app.pages, true)
+	go app.Application.Run()
+	defer app.Stop()
+
+	app.QueueUpdate(func() {
+		app.showApplyEditor("widgets", "shop", "w1", "apiVersion: example.com/v1\nkind: Widget\nmetadata:\n  name: w1\n  namespace: shop\n")
+		_, front := app.pages.GetFrontPage()
+		front.(*tview.Flex).GetInputCapture()(tcell.NewEventKey(tcell.KeyCtrlS, 0, tcell.ModCtrl))
+	})
+
+	var status string
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		done := make(chan string, 1)
+		app.QueueUpdate(func() {
+			name, front := app.pages.GetFrontPage()
+			if name != "apply-editor" {
+				done <- "closed"
+				return
+			}
+			done <- front.(*tview.Flex).GetItem(1).(*tview.TextView).GetText(true)
+		})
+		if status = <-done; strings.Contains(status, "Apply failed") || status == "closed" {
+			break
+		}
+	}
+	if !strings.Contains(status, "Apply failed") || !strings.Contains(status, "example.com/v1") {
+		t.Errorf("expected the editor to stay open with the apply error, got %q", status)
+	}
+}
+
+func TestEditAndApplyConflict(t *testing.T) {
+	screen := tcell.NewSimulationScreen("UTF-8")
+	if err := screen.Init(); err != nil {
+		t.Fatalf("failed to init screen: %v", err)
+	}
+
+	clientset := fake.NewSimpleClientset()
+	clientset.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{{
+		GroupVersion: "v1",
+		APIResources: []metav1.APIResource{{Name: "configmaps", Kind: "ConfigMap", Namespaced: true}},
+	}}
+	settings := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"name": "settings", "namespace": "shop", "resourceVersion": "42"},
+		"data":       map[string]interface{}{"mode": "fast"},
+	}}
+	dyn := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), settings)
+	// Like the API server, a server-side apply carrying a resourceVersion
+	// is refused unless it is the stored one
+	var stored atomic.Value
+	stored.Store("42")
+	dyn.PrependReactor("patch", "configmaps", func(action ktesting.Action) (bool, runtime.Object, error) {
+		obj := &unstructured.Unstructured{}
+		if err := obj.UnmarshalJSON(action.(ktesting.PatchAction).GetPatch()); err != nil {
+			return true, nil, err
+		}
+		if rv := obj.GetResourceVersion(); rv != "" && rv != stored.Load().(string) {
+			return true, nil, apierrors.NewConflict(schema.GroupResource{Resource: "configmaps"}, obj.GetName(), errors.New("the object has been modified"))
+		}
+		return true, obj, nil
+	})
+
+	app := &App{
+		Application:     tview.NewApplication().SetScreen(screen),
+		k8s:             &k8s.Client{Clientset: clientset, Dynamic: dyn},
+		pages:           tview.NewPages(),
+		table:           tview.NewTable(),
+		currentResource: "configmaps",
+	}
+	app.table.SetCell(0, 0, tview.NewTableCell("NAMESPACE"))
+	app.table.SetCell(0, 1, tview.NewTableCell("NAME"))
+	app.table.SetCell(1, 0, tview.NewTableCell("shop"))
+	app.table.SetCell(1, 1, tview.NewTableCell("settings"))
+	app.table.SetSelectable(true, false).Select(1, 0)
+	app.pages.AddPage("main", app.table, true, true)
+	app.Application.SetRoot(app.pages, true)
+	go app.Application.Run()
+	defer app.Stop()
+
+	// editorState returns the editor text and status line, or ok false
+	// while the editor is not open
+	editorState := func() (text, status string, ok bool) {
+		done := make(chan struct{})
+		app.QueueUpdate(func() {
+			defer close(done)
+			name, front := app.pages.GetFrontPage()
+			if name != "apply-editor" {
+				return
+			}
+			flex := front.(*tview.Flex)
+			text = flex.GetItem(0).(*tview.TextArea).GetText()
+			status = flex.GetItem(1).(*tview.TextView).GetText(true)
+			ok = true
+		})
+		<-done
+		return text, status, ok
+	}
+
+	app.editAndApply()
+	var text string
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		var ok bool
+		if text, _, ok = editorState(); ok {
+			break
+		}
+	}
+	if !strings.Contains(text, `resourceVersion: "42"`) {
+		t.Fatalf("expected the editor to load the resourceVersion, got:\n%s", text)
+	}
+
+	// Someone else updates the object while it is being edited
+	stored.Store("43")
+	app.QueueUpdate(func() {
+		_, front := app.pages.GetFrontPage()
+		front.(*tview.Flex).GetInputCapture()(tcell.NewEventKey(tcell.KeyCtrlS, 0, tcell.ModCtrl))
+	})
+
+	var status string
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		var ok bool
+		if _, status, ok = editorState(); !ok || strings.Contains(status, "Conflict") {
+			break
+		}
+	}
+	if !strings.Contains(status, "Conflict:") || !strings.Contains(status, "configmaps/settings") {
+		t.Errorf("expected the editor to stay open with the conflict, got %q", status)
+	}
+}
+
+func TestApplyErrorText(t *testing.T) {
+	conflict := apierrors.NewConflict(schema.GroupResource{Resource: "configmaps"}, "settings", errors.New("the object has been modified"))
+	if text := applyErrorText("configmaps", "settings", conflict); !strings.Contains(text, "Conflict:[white] configmaps/settings was modified") {
+		t.Errorf("expected a conflict to ask for a reload, got %q", text)
+	}
+	if text := applyErrorText("configmaps", "settings", errors.New("spec.replicas: invalid")); !strings.Contains(text, "Apply failed:[white] spec.replicas: invalid") {
+		t.Errorf("expected other errors to be shown as they are, got %q", text)
+	}
+}
+
+func TestFormatApplyResults(t *testing.T) {
+	results := []k8s.ApplyResult{
+		{Document: 1, Kind: "ConfigMap", Namespace: "shop", Name: "settings"},
+		{Document: 2, Kind: "Namespace", Name: "shop"},
+		{Document: 3, Kind: "Widget", Namespace: "shop", Name: "w", Err: errors.New("unknown kind Widget in v1")},
+	}
+	text := formatApplyResults("deploy.yaml", results, nil)
+	for _, want := range []string{
+		"[green]✓[white] ConfigMap shop/settings",
+		"[green]✓[white] Namespace shop\n",
+		"[red]✗[white] Widget shop/w: unknown kind Widget in v1",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("expected %q in:\n%s", want, text)
+		}
+	}
+
+	text = formatApplyResults("deploy.yaml", results[:1], errors.New("document 2: bad"))
+	if !strings.Contains(text, "Stopped:[white] document 2: bad") {
+		t.Errorf("expected the read error to be shown, got:\n%s", text)
+	}
+	if text := formatApplyResults("empty.yaml", nil, nil); !strings.Contains(text, "No objects") {
+		t.Errorf("expected an empty file to be reported, got:\n%s", text)
+	}
+}
@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"os"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/config"
+	"github.com/rivo/tview"
+)
+
+// asciiReplacer swaps the Unicode glyphs used in the help, header and flash
+// messages for ASCII. Box-drawing characters keep their width so the help
+// boxes stay aligned; "→", "✓" and "🤖" become wider, so they are kept out
+// of aligned text.
+var asciiReplacer = strings.NewReplacer(
+	"─", "-", "━", "=", "│", "|",
+	"┌", "+", "┐", "+", "└", "+", "┘", "+", "├", "+", "┤", "+",
+	"•", "*", "█", "#", "↑", "^", "↓", "v", "→", "->",
+	"✓", "OK", "✗", "X", "⚠", "!", "🤖", "AI",
+)
+
+// asciiMode reports whether the TUI should be drawn in ASCII: when
+// configured, or on terminals that can't show Unicode line drawing
+func asciiMode(cfg *config.Config, term string) bool {
+	if cfg != nil && cfg.ASCIIMode {
+		return true
+	}
+	return term == "dumb" || strings.HasPrefix(term, "vt")
+}
+
+// noColor reports whether colors are off, per https://no-color.org
+func noColor() bool {
+	return os.Getenv("NO_COLOR") != ""
+}
+
+// glyphs returns s with Unicode glyphs replaced in ASCII mode
+func (a *App) glyphs(s string) string {
+	if !a.ascii {
+		return s
+	}
+	return asciiReplacer.Replace(s)
+}
+
+// applyTerminalCompat switches borders to ASCII in ASCII mode, which also
+// turns color off (see monochromeScreen), and keeps the selected row visible
+// without color. Must run before Run creates the screen.
+func (a *App) applyTerminalCompat() {
+	if a.ascii {
+		tview.Borders.Horizontal = '-'
+		tview.Borders.Vertical = '|'
+		tview.Borders.TopLeft = '+'
+		tview.Borders.TopRight = '+'
+		tview.Borders.BottomLeft = '+'
+		tview.Borders.BottomRight = '+'
+		tview.Borders.HorizontalFocus = '='
+		tview.Borders.VerticalFocus = '|'
+		tview.Borders.TopLeftFocus = '+'
+		tview.Borders.TopRightFocus = '+'
+		tview.Borders.BottomLeftFocus = '+'
+		tview.Borders.BottomRightFocus = '+'
+	}
+
+	if a.ascii || noColor() {
+		// The default selection only differs in color
+		reverse := tcell.StyleDefault.Reverse(true)
+		a.table.SetSelectedStyle(reverse)
+		a.cmdDropdown.SetSelectedStyle(reverse)
+	}
+}
+
+// monochromeScreen draws every cell in the terminal's default colors,
+// keeping attributes such as reverse. ASCII mode uses it rather than setting
+// NO_COLOR, which would leak into kubectl and the other commands k13s runs.
+type monochromeScreen struct {
+	tcell.Screen
+}
+
+func (s monochromeScreen) SetContent(x, y int, primary rune, combining []rune, style tcell.Style) {
+	s.Screen.SetContent(x, y, primary, combining, style.Foreground(tcell.ColorDefault).Background(tcell.ColorDefault))
+}
+
+func (s monochromeScreen) Colors() int {
+	return 0
+}
+
+// useMonochromeScreen gives the application a monochromeScreen in ASCII
+// mode. Must run before the application runs.
+func (a *App) useMonochromeScreen() error {
+	if !a.ascii {
+		return nil
+	}
+	screen, err := tcell.NewScreen()
+	if err != nil {
+		return err
+	}
+	a.SetScreen(monochromeScreen{screen})
+	return nil
+}
@@ -0,0 +1,23 @@
+package ui
+
+import (
+	"os/user"
+
+	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/db"
+)
+
+// recordAudit writes a TUI action to the audit log. It is a no-op when
+// auditing is disabled because the database is never opened.
+func (a *App) recordAudit(action, resource, details string) {
+	username := "tui"
+	if u, err := user.Current(); err == nil {
+		username = u.Username
+	}
+
+	db.RecordAudit(db.AuditEntry{
+		User:     username,
+		Action:   action,
+		Resource: resource,
+		Details:  details,
+	})
+}
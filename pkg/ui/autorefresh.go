@@ -0,0 +1,182 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/config"
+	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/k8s"
+)
+
+// autoRefreshInterval returns how often the current view is refreshed in
+// the background, or 0 when disabled
+func (a *App) autoRefreshInterval() time.Duration {
+	rc := config.NewDefaultConfig().Refresh
+	if a.config != nil {
+		rc = a.config.Refresh
+	}
+	return time.Duration(rc.Interval * float64(time.Second))
+}
+
+// idlePause returns how long after a key press auto-refresh holds off, or 0
+// when it never does
+func (a *App) idlePause() time.Duration {
+	rc := config.NewDefaultConfig().Refresh
+	if a.config != nil {
+		rc = a.config.Refresh
+	}
+	return time.Duration(rc.IdlePause * float64(time.Second))
+}
+
+// userActive reports whether a key was pressed within the idle pause
+func (a *App) userActive(now time.Time) bool {
+	last := atomic.LoadInt64(&a.lastInput)
+	if last == 0 {
+		return false
+	}
+	return now.Sub(time.Unix(0, last)) < a.idlePause()
+}
+
+// autoRefreshHeld reports whether background refreshes should wait: while
+// paused with W, while the user is typing or navigating and while another
+// refresh is in flight
+func (a *App) autoRefreshHeld() bool {
+	return atomic.LoadInt32(&a.autoRefreshPaused) == 1 ||
+		atomic.LoadInt32(&a.inUpdate) == 1 ||
+		a.userActive(time.Now())
+}
+
+// autoRefreshPeriodically quietly refreshes the current view until ctx is
+// done. Ticks are skipped while held, and while a dialog covers the table or
+// the filter or command line is open.
+func (a *App) autoRefreshPeriodically(ctx context.Context) {
+	interval := a.autoRefreshInterval()
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if a.autoRefreshHeld() {
+				continue
+			}
+			a.autoRefresh()
+		}
+	}
+}
+
+// liveUpdateDelay batches watch events into at most one refresh per period
+const liveUpdateDelay = 500 * time.Millisecond
+
+// resourceChanged is called by the k8s informers when a watched resource
+// changes; changes to the resource on screen are applied by
+// applyLiveUpdates
+func (a *App) resourceChanged(resource string) {
+	a.mx.Lock()
+	if a.watchEvents == nil {
+		a.watchEvents = make(map[string]time.Time)
+	}
+	a.watchEvents[resource] = time.Now()
+	current := a.currentResource
+	a.mx.Unlock()
+
+	if current == resource {
+		atomic.StoreInt32(&a.liveChanged, 1)
+	}
+}
+
+// viewFreshness describes how current the rows of resource are for the
+// table title: live when a watch keeps them current, with the time of the
+// last change it saw, otherwise how often they are polled. Callers must
+// hold a.mx.
+func (a *App) viewFreshness(resource, labelSelector, fieldSelector string) string {
+	if a.k8s == nil {
+		return ""
+	}
+
+	if a.k8s.IsWatched(resource, k8s.WithLabelSelector(labelSelector), k8s.WithFieldSelector(fieldSelector)) {
+		if t, ok := a.watchEvents[resource]; ok {
+			return "live: last change " + t.Format("15:04:05")
+		}
+		return "live: no changes yet"
+	}
+	if interval := a.autoRefreshInterval(); interval > 0 {
+		return fmt.Sprintf("polled: every %s", interval)
+	}
+	return "polled: on refresh"
+}
+
+// applyLiveUpdates refreshes the current view from the informer cache after
+// watch events until ctx is done. Like auto-refresh it is paused with W and
+// waits while the user is typing; the changes are applied afterwards.
+func (a *App) applyLiveUpdates(ctx context.Context) {
+	ticker := time.NewTicker(liveUpdateDelay)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if a.autoRefreshHeld() {
+				continue
+			}
+			if atomic.CompareAndSwapInt32(&a.liveChanged, 1, 0) {
+				a.autoRefresh()
+			}
+		}
+	}
+}
+
+// autoRefresh refreshes the table in place if it is in front and the
+// filter or command line is closed; refreshView keeps the selected row
+func (a *App) autoRefresh() {
+	visible := false
+	a.QueueUpdate(func() {
+		front, _ := a.pages.GetFrontPage()
+		visible = front == "main" && a.GetFocus() != a.cmdInput
+	})
+	if !visible {
+		return
+	}
+
+	a.refreshView(true)
+}
+
+// toggleAutoRefresh pauses or resumes auto-refresh
+func (a *App) toggleAutoRefresh() {
+	if a.autoRefreshInterval() <= 0 {
+		a.flashMsg("Auto-refresh is disabled (refresh.interval is 0)", true)
+		return
+	}
+
+	if atomic.LoadInt32(&a.autoRefreshPaused) == 0 {
+		atomic.StoreInt32(&a.autoRefreshPaused, 1)
+		a.flashMsg("Auto-refresh paused", false)
+	} else {
+		atomic.StoreInt32(&a.autoRefreshPaused, 0)
+		a.flashMsg("Auto-refresh resumed", false)
+	}
+	a.QueueUpdateDraw(a.updateStatusBar)
+}
+
+// autoRefreshStatus is the status bar entry for auto-refresh
+func (a *App) autoRefreshStatus() string {
+	interval := a.autoRefreshInterval()
+	switch {
+	case interval <= 0:
+		return ""
+	case atomic.LoadInt32(&a.autoRefreshPaused) == 1:
+		return " [yellow]<W>[white]Auto:[red]paused[white]"
+	default:
+		return fmt.Sprintf(" [yellow]<W>[white]Auto:%s", interval)
+	}
+}
@@ -0,0 +1,299 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// benchmarkRequestTimeout bounds each request of a service benchmark
+const benchmarkRequestTimeout = 10 * time.Second
+
+// benchmarkProgressInterval is how often a running benchmark reports its
+// progress; reporting every request would slow the workers down with UI
+// updates and skew the measurements
+var benchmarkProgressInterval = 250 * time.Millisecond
+
+// benchmarkResult summarizes a benchmark run
+type benchmarkResult struct {
+	requests  int             // requests that finished, including failed ones
+	errors    int             // transport errors and 5xx responses
+	statuses  map[int]int     // responses by status code
+	latencies []time.Duration // of every response, sorted
+	elapsed   time.Duration
+	canceled  bool // stopped with Esc before all requests were sent
+}
+
+// percentile returns the latency p percent of responses were faster than
+// or equal to, 0 without responses
+func (r *benchmarkResult) percentile(p float64) time.Duration {
+	if len(r.latencies) == 0 {
+		return 0
+	}
+	idx := int(float64(len(r.latencies))*p/100+0.5) - 1
+	idx = max(0, min(idx, len(r.latencies)-1))
+	return r.latencies[idx]
+}
+
+// rps returns the finished requests per second
+func (r *benchmarkResult) rps() float64 {
+	if r.elapsed <= 0 {
+		return 0
+	}
+	return float64(r.requests) / r.elapsed.Seconds()
+}
+
+// errorRate returns the percentage of requests that failed
+func (r *benchmarkResult) errorRate() float64 {
+	if r.requests == 0 {
+		return 0
+	}
+	return float64(r.errors) * 100 / float64(r.requests)
+}
+
+// runBenchmark sends total GET requests to url from concurrency workers
+// and measures them. It stops early when ctx is canceled; requests cut off
+// by the cancellation are not counted. progress, if set, is called with
+// the number of finished requests every benchmarkProgressInterval and once
+// more at the end, from a goroutine of its own.
+func runBenchmark(ctx context.Context, client *http.Client, url string, concurrency, total int, progress func(done int)) *benchmarkResult {
+	concurrency = max(1, min(concurrency, total))
+	result := &benchmarkResult{statuses: make(map[int]int)}
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		next     int64
+		finished int64
+	)
+	stopProgress := make(chan struct{})
+	progressDone := make(chan struct{})
+	go func() {
+		defer close(progressDone)
+		if progress == nil {
+			return
+		}
+		ticker := time.NewTicker(benchmarkProgressInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stopProgress:
+				progress(int(atomic.LoadInt64(&finished)))
+				return
+			case <-ticker.C:
+				progress(int(atomic.LoadInt64(&finished)))
+			}
+		}
+	}()
+
+	start := time.Now()
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil && atomic.AddInt64(&next, 1) <= int64(total) {
+				status, latency, err := benchmarkRequest(ctx, client, url)
+				if ctx.Err() != nil {
+					return
+				}
+
+				mu.Lock()
+				result.requests++
+				if err != nil {
+					result.errors++
+				} else {
+					result.statuses[status]++
+					result.latencies = append(result.latencies, latency)
+					if status >= 500 {
+						result.errors++
+					}
+				}
+				mu.Unlock()
+				atomic.AddInt64(&finished, 1)
+			}
+		}()
+	}
+	wg.Wait()
+	result.elapsed = time.Since(start)
+
+	close(stopProgress)
+	<-progressDone
+
+	result.canceled = ctx.Err() != nil
+	sort.Slice(result.latencies, func(i, j int) bool { return result.latencies[i] < result.latencies[j] })
+	return result
+}
+
+// benchmarkRequest sends one GET request and reads the whole response
+func benchmarkRequest(ctx context.Context, client *http.Client, url string) (int, time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer resp.Body.Close()
+
+	if _, err := io.Copy(io.Discard, resp.Body); err != nil {
+		return 0, 0, err
+	}
+	return resp.StatusCode, time.Since(start), nil
+}
+
+// formatBenchmark renders a benchmark result for the result panel
+func formatBenchmark(url string, r *benchmarkResult) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "[cyan::b]GET %s[white::-]\n\n", tview.Escape(url))
+
+	requests := strconv.Itoa(r.requests)
+	if r.canceled {
+		requests += " [yellow](stopped)[white]"
+	}
+	fmt.Fprintf(&sb, "  Requests:      %s\n", requests)
+	fmt.Fprintf(&sb, "  Duration:      %s\n", r.elapsed.Round(time.Millisecond))
+	fmt.Fprintf(&sb, "  Requests/sec:  %.1f\n", r.rps())
+
+	errColor := "[green]"
+	if r.errors > 0 {
+		errColor = "[red]"
+	}
+	fmt.Fprintf(&sb, "  Error rate:    %s%.1f%% (%d)[white]\n", errColor, r.errorRate(), r.errors)
+
+	sb.WriteString("\n[yellow]Latency[white]\n")
+	for _, p := range []float64{50, 90, 99} {
+		fmt.Fprintf(&sb, "  p%-3.0f %s\n", p, r.percentile(p).Round(time.Microsecond))
+	}
+
+	if len(r.statuses) > 0 {
+		sb.WriteString("\n[yellow]Status codes[white]\n")
+		codes := make([]int, 0, len(r.statuses))
+		for code := range r.statuses {
+			codes = append(codes, code)
+		}
+		sort.Ints(codes)
+		for _, code := range codes {
+			fmt.Fprintf(&sb, "  %d  %d\n", code, r.statuses[code])
+		}
+	}
+	return sb.String()
+}
+
+// showBenchmark asks for the path, concurrency and number of requests of
+// an HTTP benchmark of the selected service (k9s b key)
+func (a *App) showBenchmark() {
+	a.mx.RLock()
+	resource := a.currentResource
+	a.mx.RUnlock()
+
+	if resource != "services" && resource != "svc" {
+		a.flashMsg("Benchmark only available for services", true)
+		return
+	}
+
+	ns, name, ok := a.selectedResourceRef(resource)
+	if !ok {
+		return
+	}
+
+	form := tview.NewForm()
+	form.SetBorder(true).SetTitle(fmt.Sprintf(" Benchmark: %s/%s ", ns, name))
+
+	path, port, concurrency, requests := "/", "", "10", "200"
+	form.AddInputField("Path:", path, 30, nil, func(text string) {
+		path = text
+	})
+	form.AddInputField("Port (empty = first):", port, 8, tview.InputFieldInteger, func(text string) {
+		port = text
+	})
+	form.AddInputField("Concurrency:", concurrency, 8, tview.InputFieldInteger, func(text string) {
+		concurrency = text
+	})
+	form.AddInputField("Requests:", requests, 8, tview.InputFieldInteger, func(text string) {
+		requests = text
+	})
+	form.AddButton("Run", func() {
+		c, errC := strconv.Atoi(concurrency)
+		n, errN := strconv.Atoi(requests)
+		if errC != nil || errN != nil || c < 1 || n < 1 {
+			a.flashMsg("Concurrency and requests must be positive numbers", true)
+			return
+		}
+		p := 0
+		if port != "" {
+			p, _ = strconv.Atoi(port)
+		}
+
+		a.closeOverlay("benchmark-form")
+		a.startBenchmark(ns, name, path, p, c, n)
+	})
+	form.AddButton("Cancel", func() {
+		a.closeOverlay("benchmark-form")
+	})
+
+	a.showCentered("benchmark-form", form, 55, 13)
+}
+
+// startBenchmark benchmarks the service's cluster IP and shows the result
+// in a panel. Esc closes the panel and stops a run still in progress.
+func (a *App) startBenchmark(ns, name, path string, port, concurrency, requests int) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	view := tview.NewTextView().SetDynamicColors(true)
+	view.SetBorder(true).SetTitle(fmt.Sprintf(" Benchmark: %s/%s (Esc to stop/close) ", ns, name))
+	view.SetText("Resolving the service address...")
+
+	box := centered(view, 70, 20)
+	a.showOverlay("benchmark", box)
+	closeOnEsc := box.GetInputCapture()
+	box.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			cancel()
+		}
+		return closeOnEsc(event)
+	})
+
+	go func() {
+		defer cancel()
+
+		addr, err := a.k8s.ServiceAddress(ctx, ns, name, port)
+		if err != nil {
+			a.QueueUpdateDraw(func() {
+				view.SetText(fmt.Sprintf("[red]Benchmark failed:[white] %s", tview.Escape(err.Error())))
+			})
+			return
+		}
+		if !strings.HasPrefix(path, "/") {
+			path = "/" + path
+		}
+		url := "http://" + addr + path
+
+		a.QueueUpdateDraw(func() {
+			view.SetText(fmt.Sprintf("Sending %d requests to %s, %d at a time...", requests, tview.Escape(url), concurrency))
+		})
+
+		task := a.startTask("Benchmarking "+name, "requests")
+		client := &http.Client{Timeout: benchmarkRequestTimeout}
+		result := runBenchmark(ctx, client, url, concurrency, requests, func(done int) {
+			task.progress(done, requests)
+		})
+		task.finish()
+
+		a.QueueUpdateDraw(func() {
+			view.SetText(a.glyphs(formatBenchmark(url, result)))
+		})
+	}()
+}
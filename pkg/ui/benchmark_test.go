@@ -0,0 +1,106 @@
+package ui
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunBenchmark(t *testing.T) {
+	var served int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt64(&served, 1)%4 == 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	var lastDone int64
+	result := runBenchmark(context.Background(), server.Client(), server.URL, 5, 40, func(done int) {
+		atomic.StoreInt64(&lastDone, int64(done))
+	})
+
+	if result.requests != 40 || result.canceled {
+		t.Fatalf("expected 40 finished requests, got %d (canceled %v)", result.requests, result.canceled)
+	}
+	if atomic.LoadInt64(&lastDone) != 40 {
+		t.Errorf("expected progress up to 40, got %d", lastDone)
+	}
+	if result.statuses[200] != 30 || result.statuses[503] != 10 || result.errors != 10 {
+		t.Errorf("expected 30 OK and 10 errors, got %v with %d errors", result.statuses, result.errors)
+	}
+	if rate := result.errorRate(); rate != 25 {
+		t.Errorf("expected a 25%% error rate, got %.1f", rate)
+	}
+	if p50, p99 := result.percentile(50), result.percentile(99); p50 <= 0 || p50 > p99 {
+		t.Errorf("expected 0 < p50 <= p99, got %s and %s", p50, p99)
+	}
+
+	text := formatBenchmark(server.URL, result)
+	for _, want := range []string{"Requests/sec", "p50", "p99", "503  10"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("expected %q in result panel, got %q", want, text)
+		}
+	}
+}
+
+func TestRunBenchmarkProgressThrottled(t *testing.T) {
+	prev := benchmarkProgressInterval
+	benchmarkProgressInterval = time.Hour
+	defer func() { benchmarkProgressInterval = prev }()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	// A slow progress callback must not hold up the requests
+	var calls []int
+	runBenchmark(context.Background(), server.Client(), server.URL, 4, 50, func(done int) {
+		calls = append(calls, done)
+		time.Sleep(10 * time.Millisecond)
+	})
+	if len(calls) != 1 || calls[0] != 50 {
+		t.Errorf("expected one final progress report of 50, got %v", calls)
+	}
+}
+
+func TestRunBenchmarkCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cancel()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	done := make(chan *benchmarkResult, 1)
+	go func() { done <- runBenchmark(ctx, server.Client(), server.URL, 2, 1000, nil) }()
+
+	select {
+	case result := <-done:
+		if !result.canceled || result.requests != 0 {
+			t.Errorf("expected a canceled run without counted requests, got %d (canceled %v)", result.requests, result.canceled)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("benchmark did not stop when canceled")
+	}
+}
+
+func TestBenchmarkPercentile(t *testing.T) {
+	r := &benchmarkResult{}
+	for i := 1; i <= 100; i++ {
+		r.latencies = append(r.latencies, time.Duration(i)*time.Millisecond)
+	}
+	for p, want := range map[float64]time.Duration{50: 50 * time.Millisecond, 90: 90 * time.Millisecond, 99: 99 * time.Millisecond} {
+		if got := r.percentile(p); got != want {
+			t.Errorf("p%.0f = %s, want %s", p, got, want)
+		}
+	}
+	if got := (&benchmarkResult{}).percentile(50); got != 0 {
+		t.Errorf("expected 0 without responses, got %s", got)
+	}
+}
@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// bundlesDir is the directory under the home directory diagnostics bundles
+// are saved to
+const bundlesDir = "k13s-bundles"
+
+// collectBundle saves a diagnostics bundle of the selected resource, or of
+// the current namespace when no row is selected
+func (a *App) collectBundle() {
+	a.mx.RLock()
+	resource := a.currentResource
+	currentNS := a.currentNamespace
+	a.mx.RUnlock()
+
+	ns, name, ok := a.selectedResourceRef(resource)
+	if !ok {
+		if currentNS == "" {
+			a.flashMsg("Select a resource or a namespace to bundle", true)
+			return
+		}
+		resource, ns, name = "namespaces", "", currentNS
+	}
+	go a.saveBundle(resource, ns, name)
+}
+
+// saveBundle writes the bundle to ~/k13s-bundles/<name>.tar.gz
+func (a *App) saveBundle(resource, ns, name string) {
+	base := bundleName(resource, ns, name, time.Now())
+	home, err := os.UserHomeDir()
+	if err != nil {
+		a.flashMsg(fmt.Sprintf("Failed to save bundle: %v", err), true)
+		return
+	}
+	dir := filepath.Join(home, bundlesDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		a.flashMsg(fmt.Sprintf("Failed to save bundle: %v", err), true)
+		return
+	}
+	path := filepath.Join(dir, base+".tar.gz")
+
+	a.flashMsg(fmt.Sprintf("Collecting diagnostics of %s/%s...", resource, name), false)
+	task := a.startTask(fmt.Sprintf("Collecting diagnostics of %s/%s", resource, name), "")
+	defer task.finish()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	f, err := os.Create(path)
+	if err != nil {
+		a.flashMsg(fmt.Sprintf("Failed to save bundle: %v", err), true)
+		return
+	}
+	err = a.k8s.WriteDiagnosticsBundle(ctx, f, base, resource, ns, name)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(path)
+		a.flashMsg(fmt.Sprintf("Failed to save bundle: %v", err), true)
+		return
+	}
+
+	a.recordAudit("diagnostics_bundle", fmt.Sprintf("%s/%s/%s", resource, ns, name), path)
+	a.flashMsg("Diagnostics bundle saved to "+path, false)
+}
+
+// bundleName names a bundle and its top-level directory, e.g.
+// k13s-bundle-deployments-default-web-20240102-150405
+func bundleName(resource, ns, name string, now time.Time) string {
+	parts := []string{"k13s-bundle", resource}
+	if ns != "" {
+		parts = append(parts, ns)
+	}
+	parts = append(parts, name, now.Format("20060102-150405"))
+	return strings.Join(parts, "-")
+}
@@ -0,0 +1,81 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/k8s"
+	"github.com/rivo/tview"
+)
+
+// maxRecentChanges bounds the recent changes view
+const maxRecentChanges = 100
+
+// showRecentChanges lists the most recently modified resources of the
+// current namespace, newest first (Esc to close).
+func (a *App) showRecentChanges() {
+	a.mx.RLock()
+	ns := a.currentNamespace
+	a.mx.RUnlock()
+
+	scope := ns
+	if scope == "" {
+		scope = "all namespaces"
+	}
+
+	view := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true)
+	view.SetBorder(true).SetTitle(fmt.Sprintf(" Recent changes: %s (Press Esc to close) ", scope))
+	view.SetText(" [yellow]Loading...[white]")
+
+	a.showCentered("changes", view, 110, 24)
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		changes, err := a.k8s.RecentChanges(ctx, ns)
+		text := formatRecentChanges(changes, err)
+		a.QueueUpdateDraw(func() {
+			view.SetText(text)
+		})
+	}()
+}
+
+// formatRecentChanges renders changes as a table: age, kind, object, who
+// changed it and the deployment revision. err comes from kinds that failed
+// to list.
+func formatRecentChanges(changes []k8s.RecentChange, err error) string {
+	if err != nil && len(changes) == 0 {
+		return fmt.Sprintf(" [red]Error: %v[white]", tview.Escape(err.Error()))
+	}
+	if len(changes) == 0 {
+		return " [gray]No resources found[white]"
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf(" [yellow::b]%-6s %-12s %-50s %-30s %s[white::-]\n", "AGE", "KIND", "NAME", "CHANGED BY", "REVISION"))
+	for i, c := range changes {
+		if i == maxRecentChanges {
+			sb.WriteString(fmt.Sprintf(" [gray]... and %d older[white]\n", len(changes)-maxRecentChanges))
+			break
+		}
+		name := c.Name
+		if c.Namespace != "" {
+			name = c.Namespace + "/" + c.Name
+		}
+		by := c.Operation
+		if c.Manager != "" {
+			by = c.Manager + " (" + c.Operation + ")"
+		}
+		sb.WriteString(tview.Escape(fmt.Sprintf(" %-6s %-12s %-50s %-30s %s", formatAge(c.Time), c.Kind, name, by, c.Revision)))
+		sb.WriteString("\n")
+	}
+	if err != nil {
+		sb.WriteString(fmt.Sprintf("\n [red]Some kinds could not be listed: %v[white]\n", tview.Escape(err.Error())))
+	}
+	return sb.String()
+}
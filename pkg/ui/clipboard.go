@@ -0,0 +1,116 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// errNoClipboard means none of the clipboard commands is installed
+var errNoClipboard = errors.New("no clipboard command found (install wl-copy, xclip or xsel)")
+
+// clipboardCommand picks the command that copies its stdin to the system
+// clipboard on goos: pbcopy on macOS, clip on Windows, and wl-copy (on
+// Wayland), xclip or xsel elsewhere
+func clipboardCommand(goos string, lookPath func(string) (string, error), getenv func(string) string) ([]string, error) {
+	var candidates [][]string
+	switch goos {
+	case "darwin":
+		candidates = [][]string{{"pbcopy"}}
+	case "windows":
+		candidates = [][]string{{"clip"}}
+	default:
+		if getenv("WAYLAND_DISPLAY") != "" {
+			candidates = append(candidates, []string{"wl-copy"})
+		}
+		candidates = append(candidates,
+			[]string{"xclip", "-selection", "clipboard"},
+			[]string{"xsel", "--clipboard", "--input"},
+		)
+	}
+
+	for _, c := range candidates {
+		if _, err := lookPath(c[0]); err == nil {
+			return c, nil
+		}
+	}
+	return nil, errNoClipboard
+}
+
+// copyToClipboard puts text on the system clipboard
+func copyToClipboard(text string) error {
+	args, err := clipboardCommand(runtime.GOOS, exec.LookPath, os.Getenv)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stdin = strings.NewReader(text)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%s: %v %s", args[0], err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// copySelectedName copies the name of the selected resource to the
+// clipboard (Shift+Y)
+func (a *App) copySelectedName() {
+	a.mx.RLock()
+	resource := a.currentResource
+	a.mx.RUnlock()
+
+	_, name, ok := a.selectedResourceRef(resource)
+	if !ok {
+		return
+	}
+
+	go func() {
+		if err := copyToClipboard(name); err != nil {
+			a.flashMsg(fmt.Sprintf("Copy failed: %v", err), true)
+			return
+		}
+		a.flashMsg(fmt.Sprintf("Copied %s to the clipboard", name), false)
+	}()
+}
+
+// copySelectedYAML copies the manifest of the selected resource to the
+// clipboard (Ctrl+Y)
+func (a *App) copySelectedYAML() {
+	a.mx.RLock()
+	resource := a.currentResource
+	a.mx.RUnlock()
+
+	ns, name, ok := a.selectedResourceRef(resource)
+	if !ok {
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		gvr, _, ok := a.resolveGVR(resource)
+		if !ok {
+			a.flashMsg(fmt.Sprintf("Unknown resource type: %s", resource), true)
+			return
+		}
+		manifest, err := a.k8s.GetResourceYAML(ctx, ns, name, gvr)
+		if err != nil {
+			a.flashMsg(fmt.Sprintf("Copy failed: %v", err), true)
+			return
+		}
+		if err := copyToClipboard(manifest); err != nil {
+			a.flashMsg(fmt.Sprintf("Copy failed: %v", err), true)
+			return
+		}
+		a.flashMsg(fmt.Sprintf("Copied the YAML of %s/%s to the clipboard", resource, name), false)
+	}()
+}
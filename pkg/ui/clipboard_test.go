@@ -0,0 +1,48 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestClipboardCommand(t *testing.T) {
+	installed := func(names ...string) func(string) (string, error) {
+		return func(name string) (string, error) {
+			for _, n := range names {
+				if n == name {
+					return "/usr/bin/" + name, nil
+				}
+			}
+			return "", errors.New("not found")
+		}
+	}
+	env := func(vars map[string]string) func(string) string {
+		return func(key string) string { return vars[key] }
+	}
+	x11 := env(nil)
+	wayland := env(map[string]string{"WAYLAND_DISPLAY": "wayland-0"})
+
+	tests := []struct {
+		goos   string
+		lookup func(string) (string, error)
+		getenv func(string) string
+		want   string
+	}{
+		{"darwin", installed("pbcopy"), x11, "pbcopy"},
+		{"windows", installed("clip"), x11, "clip"},
+		{"linux", installed("wl-copy", "xclip"), wayland, "wl-copy"},
+		{"linux", installed("wl-copy", "xclip"), x11, "xclip -selection clipboard"},
+		{"linux", installed("xsel"), wayland, "xsel --clipboard --input"},
+	}
+	for _, tt := range tests {
+		args, err := clipboardCommand(tt.goos, tt.lookup, tt.getenv)
+		if err != nil || strings.Join(args, " ") != tt.want {
+			t.Errorf("%s: got %v, %v, want %q", tt.goos, args, err, tt.want)
+		}
+	}
+
+	if _, err := clipboardCommand("linux", installed(), x11); !errors.Is(err, errNoClipboard) {
+		t.Errorf("expected errNoClipboard without a clipboard command, got %v", err)
+	}
+}
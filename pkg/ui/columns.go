@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// columnOrder returns the position in headers of each column to display,
+// left to right: the headers named in order first (case-insensitive;
+// unknown names are ignored), then the others in their usual order
+func columnOrder(headers, order []string) []int {
+	placed := make([]bool, len(headers))
+	display := make([]int, 0, len(headers))
+	for _, name := range order {
+		for i, h := range headers {
+			if !placed[i] && strings.EqualFold(h, strings.TrimSpace(name)) {
+				placed[i] = true
+				display = append(display, i)
+				break
+			}
+		}
+	}
+	for i := range headers {
+		if !placed[i] {
+			display = append(display, i)
+		}
+	}
+	return display
+}
+
+// displayColumns is the column order of resource from config.ColumnOrder
+func (a *App) displayColumns(resource string, headers []string) []int {
+	var order []string
+	if a.config != nil {
+		order = a.config.ColumnOrder[resource]
+	}
+	return columnOrder(headers, order)
+}
+
+// setTableHeaders writes the header row in display order. Each header cell
+// keeps the position of its column in the fetched headers, so tableCell
+// finds columns wherever the user moved them.
+func (a *App) setTableHeaders(headers []string, display []int) {
+	for d, c := range display {
+		a.table.SetCell(0, d, tview.NewTableCell(headers[c]).
+			SetTextColor(tcell.ColorYellow).
+			SetAttributes(tcell.AttrBold).
+			SetSelectable(false).
+			SetExpansion(1).
+			SetReference(c))
+	}
+}
+
+// tableCell returns the cell of the table in row for column col of the
+// fetched headers, e.g. 1 for NAME in namespaced views, whatever the
+// configured column order
+func (a *App) tableCell(row, col int) *tview.TableCell {
+	for d := 0; d < a.table.GetColumnCount(); d++ {
+		if c, ok := a.table.GetCell(0, d).GetReference().(int); ok && c == col {
+			return a.table.GetCell(row, d)
+		}
+	}
+	return a.table.GetCell(row, col)
+}
@@ -0,0 +1,54 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// defaultCommandTimeout is used when config.CommandTimeout is unset
+const defaultCommandTimeout = 30 * time.Second
+
+// commandWaitDelay bounds how long output is awaited after a command was
+// killed, in case something outside its process group holds the pipe
+var commandWaitDelay = 2 * time.Second
+
+// commandTimeout returns the timeout for non-interactive external commands
+func (a *App) commandTimeout() time.Duration {
+	if a.config != nil && a.config.CommandTimeout > 0 {
+		return time.Duration(a.config.CommandTimeout) * time.Second
+	}
+	return defaultCommandTimeout
+}
+
+// runCommand runs a non-interactive command with the configured timeout and
+// returns its combined output. On timeout the whole process group is killed,
+// so the children of e.g. bash -c "a; b" do not keep it running. A timeout
+// is reported as such instead of the generic "signal: killed" from the
+// process.
+func (a *App) runCommand(name string, args ...string) ([]byte, error) {
+	timeout := a.commandTimeout()
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, name, args...)
+	killProcessGroupOnCancel(cmd)
+	cmd.WaitDelay = commandWaitDelay
+	output, err := cmd.CombinedOutput()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return output, fmt.Errorf("%s %s timed out after %s", name, strings.Join(args, " "), timeout)
+	}
+	return output, err
+}
+
+// commandError picks the most useful message from a failed command: its
+// output when it printed something, the error otherwise (e.g. timeouts).
+func commandError(output []byte, err error) string {
+	if msg := strings.TrimSpace(string(output)); msg != "" && !strings.Contains(err.Error(), "timed out") {
+		return msg
+	}
+	return err.Error()
+}
@@ -0,0 +1,9 @@
+//go:build !unix
+
+package ui
+
+import "os/exec"
+
+// killProcessGroupOnCancel leaves cmd as is where process groups are not
+// available; WaitDelay still stops runCommand from waiting on its children
+func killProcessGroupOnCancel(cmd *exec.Cmd) {}
@@ -0,0 +1,136 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// compareSelected shows a side-by-side YAML diff of the two multi-selected
+// resources (Shift+V), e.g. a pod that works and one that doesn't
+func (a *App) compareSelected() {
+	a.mx.RLock()
+	resource := a.currentResource
+	a.mx.RUnlock()
+
+	refs := a.selectedResourceRefs(resource)
+	if len(refs) != 2 {
+		a.flashMsg(fmt.Sprintf("Select exactly two %s with Space to compare them", resource), true)
+		return
+	}
+	gvr, _, ok := a.resolveGVR(resource)
+	if !ok {
+		a.flashMsg(fmt.Sprintf("Unknown resource type: %s", resource), true)
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		var texts [2]string
+		for i, ref := range refs {
+			obj, err := a.k8s.GetResource(ctx, ref.namespace, ref.name, gvr)
+			if err != nil {
+				a.flashMsg(fmt.Sprintf("Failed to load %s: %v", ref.name, err), true)
+				return
+			}
+			if texts[i], err = comparableYAML(obj); err != nil {
+				a.flashMsg(fmt.Sprintf("Failed to render %s: %v", ref.name, err), true)
+				return
+			}
+		}
+
+		lines, exact := diffLines(strings.Split(texts[0], "\n"), strings.Split(texts[1], "\n"))
+		if !exact {
+			a.flashMsg("Too many differences for an exact diff; comparing line by line", true)
+		}
+		rows := sideBySide(lines)
+		a.QueueUpdateDraw(func() {
+			a.showComparison(resource, refName(refs[0]), refName(refs[1]), rows)
+		})
+	}()
+}
+
+// refName is ns/name, or name for cluster-scoped resources
+func refName(ref resourceRef) string {
+	if ref.namespace == "" {
+		return ref.name
+	}
+	return ref.namespace + "/" + ref.name
+}
+
+// showComparison shows diff rows in two columns that scroll together.
+// n and p jump to the next and previous difference.
+func (a *App) showComparison(resource, left, right string, rows []diffRow) {
+	table := tview.NewTable().SetFixed(1, 0).SetSelectable(true, false)
+
+	// Each side gets half the screen; longer lines are cut off
+	_, _, width, _ := a.pages.GetRect()
+	maxWidth := max(20, (width-4)/2)
+
+	for col, title := range []string{left, right} {
+		table.SetCell(0, col, tview.NewTableCell(tview.Escape(title)).
+			SetTextColor(tcell.ColorYellow).
+			SetAttributes(tcell.AttrBold).
+			SetSelectable(false).
+			SetExpansion(1))
+	}
+
+	differences := 0
+	for r, row := range rows {
+		leftColor, rightColor := tcell.ColorWhite, tcell.ColorWhite
+		switch row.op {
+		case diffChanged:
+			leftColor, rightColor = tcell.ColorYellow, tcell.ColorYellow
+		case diffRemoved:
+			leftColor = tcell.ColorRed
+		case diffAdded:
+			rightColor = tcell.ColorGreen
+		}
+		if row.op != diffSame {
+			differences++
+		}
+		table.SetCell(r+1, 0, diffCell(row.left, leftColor, maxWidth))
+		table.SetCell(r+1, 1, diffCell(row.right, rightColor, maxWidth))
+	}
+
+	title := fmt.Sprintf(" Compare %s: %d differing lines (n/p next/previous, Esc to close) ", resource, differences)
+	if differences == 0 {
+		title = fmt.Sprintf(" Compare %s: identical apart from uid, resourceVersion and timestamps (Esc to close) ", resource)
+	}
+	table.SetBorder(true).SetTitle(title)
+
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() != tcell.KeyRune || (event.Rune() != 'n' && event.Rune() != 'p') {
+			return event
+		}
+		step := 1
+		if event.Rune() == 'p' {
+			step = -1
+		}
+		current, _ := table.GetSelection()
+		for r := current + step; r >= 1 && r <= len(rows); r += step {
+			// Jump to the first row of each block of differences
+			if rows[r-1].op != diffSame && (r == 1 || rows[r-2].op == diffSame) {
+				table.Select(r, 0)
+				break
+			}
+		}
+		return nil
+	})
+
+	a.showOverlay("compare", table)
+}
+
+// diffCell is a cell of one side of a diff
+func diffCell(text string, color tcell.Color, maxWidth int) *tview.TableCell {
+	return tview.NewTableCell(tview.Escape(text)).
+		SetTextColor(color).
+		SetMaxWidth(maxWidth).
+		SetExpansion(1)
+}
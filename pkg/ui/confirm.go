@@ -0,0 +1,42 @@
+package ui
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/config"
+	"github.com/rivo/tview"
+)
+
+// confirmRequired reports whether action asks for confirmation before it
+// runs; see config.Config.ConfirmActions
+func (a *App) confirmRequired(action string) bool {
+	cfg := a.config
+	if cfg == nil {
+		cfg = config.NewDefaultConfig()
+	}
+	return cfg.RequiresConfirmation(action)
+}
+
+// confirmAction runs do in the background, after a Cancel/button modal
+// named page if action requires confirmation. Must be called from the UI
+// goroutine.
+func (a *App) confirmAction(action, page, text, button string, danger bool, do func()) {
+	if !a.confirmRequired(action) {
+		go do()
+		return
+	}
+
+	modal := tview.NewModal().
+		SetText(text).
+		AddButtons([]string{"Cancel", button}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			a.closeOverlay(page)
+
+			if buttonLabel == button {
+				go do()
+			}
+		})
+	if danger {
+		modal.SetBackgroundColor(tcell.ColorDarkRed)
+	}
+	a.showOverlay(page, modal)
+}
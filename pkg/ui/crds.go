@@ -0,0 +1,54 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/k8s"
+)
+
+// showCRDInstances switches from the CRD view to the instances of the named
+// CRD. drillDown has already saved the CRD view, so Esc returns to it.
+func (a *App) showCRDInstances(name string) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		crd, err := a.k8s.GetCRD(ctx, name)
+		if err == nil {
+			var res k8s.APIResource
+			if res, err = k8s.CRDAPIResource(crd); err == nil {
+				a.openAPIResource(res)
+				return
+			}
+		}
+		a.flashMsg(fmt.Sprintf("Failed to open %s: %v", name, err), true)
+		a.QueueUpdateDraw(a.goBack)
+	}()
+}
+
+// openAPIResource lists the instances of res, adding it to the discovered
+// resources when it is missing or discovered at another version, e.g. a CRD
+// installed since the last discovery. Resources of the same name in other
+// groups are kept; res goes first so that its name resolves to it.
+func (a *App) openAPIResource(res k8s.APIResource) {
+	a.mx.Lock()
+	resources := make([]k8s.APIResource, 0, len(a.apiResources)+1)
+	for _, r := range a.apiResources {
+		if r.Group == res.Group && r.Name == res.Name {
+			res.Verbs = r.Verbs
+			continue
+		}
+		resources = append(resources, r)
+	}
+	a.apiResources = append([]k8s.APIResource{res}, resources...)
+	a.currentResource = res.Name
+	a.filterText = ""
+	a.labelSelector = ""
+	a.fieldSelector = ""
+	a.mx.Unlock()
+
+	a.updateHeader()
+	a.refresh()
+}
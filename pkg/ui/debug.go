@@ -0,0 +1,86 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/k8s"
+	"github.com/rivo/tview"
+)
+
+// debugStartTimeout bounds how long a debug container may take to start,
+// including pulling its image
+const debugStartTimeout = 2 * time.Minute
+
+// debugPod asks for a debugger image and attaches to a new ephemeral
+// container in the selected pod, like kubectl debug -it (x key)
+func (a *App) debugPod() {
+	a.mx.RLock()
+	resource := a.currentResource
+	a.mx.RUnlock()
+
+	if resource != "pods" && resource != "po" {
+		a.flashMsg("Debug only available for pods", true)
+		return
+	}
+
+	ns, name, ok := a.selectedResourceRef(resource)
+	if !ok {
+		return
+	}
+
+	form := tview.NewForm()
+	form.SetBorder(true).SetTitle(fmt.Sprintf(" Debug: %s/%s ", ns, name))
+
+	image := k8s.DefaultDebugImage
+	form.AddInputField("Image:", image, 40, nil, func(text string) {
+		image = text
+	})
+	form.AddButton("Debug", func() {
+		a.closeOverlay("debug")
+		go a.startDebugContainer(ns, name, strings.TrimSpace(image))
+	})
+	form.AddButton("Cancel", func() {
+		a.closeOverlay("debug")
+	})
+
+	a.showCentered("debug", form, 60, 7)
+}
+
+// startDebugContainer adds the debug container, waits for it to run and
+// attaches to it with the TUI suspended
+func (a *App) startDebugContainer(ns, pod, image string) {
+	if image == "" {
+		image = k8s.DefaultDebugImage
+	}
+	a.flashMsg(fmt.Sprintf("Starting debug container (%s) in %s...", image, pod), false)
+
+	ctx, cancel := context.WithTimeout(context.Background(), debugStartTimeout)
+	defer cancel()
+
+	container, err := a.k8s.AddEphemeralContainer(ctx, ns, pod, image)
+	if err != nil {
+		a.flashMsg(fmt.Sprintf("Debug failed: %v", err), true)
+		return
+	}
+	a.recordAudit("debug", fmt.Sprintf("pods/%s/%s", ns, pod), fmt.Sprintf("container: %s, image: %s", container, image))
+
+	if err := a.k8s.WaitForEphemeralContainer(ctx, ns, pod, container); err != nil {
+		a.flashMsg(fmt.Sprintf("Debug failed: %v", err), true)
+		return
+	}
+
+	a.QueueUpdate(func() {
+		a.Suspend(func() {
+			cmd := exec.Command("kubectl", "attach", "-it", "-n", ns, pod, "-c", container)
+			cmd.Stdin = os.Stdin
+			cmd.Stdout = os.Stdout
+			cmd.Stderr = os.Stderr
+			cmd.Run()
+		})
+	})
+}
@@ -0,0 +1,240 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/k8s"
+	"github.com/rivo/tview"
+)
+
+// maxPreflightLines caps the findings listed in the drain override modal
+const maxPreflightLines = 10
+
+// drainTarget returns the node a "kubectl drain" command would drain
+func drainTarget(cmd string) (string, bool) {
+	parts := strings.Fields(cmd)
+	if len(parts) > 0 && parts[0] == "kubectl" {
+		parts = parts[1:]
+	}
+	if len(parts) < 2 || parts[0] != "drain" {
+		return "", false
+	}
+	for _, p := range parts[1:] {
+		if !strings.HasPrefix(p, "-") {
+			return p, true
+		}
+	}
+	return "", false
+}
+
+// preflightDrain runs the drain-safety preflight for nodes and calls proceed
+// when it finds nothing. Otherwise the findings are shown and proceed only
+// runs if the user explicitly overrides them. Must not be called from the UI
+// goroutine.
+func (a *App) preflightDrain(nodes []string, proceed func()) {
+	a.flashMsg(fmt.Sprintf("Running drain preflight for %s...", strings.Join(nodes, ", ")), false)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	var findings []k8s.Finding
+	var errs []string
+	for _, node := range nodes {
+		f, err := a.k8s.DrainPreflight(ctx, node)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", node, err))
+			continue
+		}
+		findings = append(findings, f...)
+	}
+
+	if len(findings) == 0 && len(errs) == 0 {
+		proceed()
+		return
+	}
+
+	text := formatDrainPreflight(nodes, findings, errs)
+	a.QueueUpdateDraw(func() {
+		modal := tview.NewModal().
+			SetText(text).
+			AddButtons([]string{"Cancel", "Drain Anyway"}).
+			SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+				a.closeOverlay("drain-preflight")
+				if buttonLabel == "Drain Anyway" {
+					a.recordAudit("drain_preflight_override", strings.Join(nodes, ","),
+						fmt.Sprintf("%d finding(s) overridden", len(findings)))
+					go proceed()
+				}
+			})
+		modal.SetBackgroundColor(tcell.ColorDarkRed)
+		a.showOverlay("drain-preflight", modal)
+	})
+}
+
+// formatDrainPreflight renders preflight findings for the override modal
+func formatDrainPreflight(nodes []string, findings []k8s.Finding, errs []string) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("[yellow]Drain preflight for %s[white]\n\n", strings.Join(nodes, ", ")))
+
+	var lines []string
+	for _, e := range errs {
+		lines = append(lines, "[red]Preflight failed:[white] "+tview.Escape(e))
+	}
+	for _, f := range findings {
+		lines = append(lines, tview.Escape(fmt.Sprintf("%s %s/%s %s", f.Kind, f.Namespace, f.Name, f.Message)))
+	}
+	if len(lines) > maxPreflightLines {
+		more := len(lines) - maxPreflightLines
+		lines = append(lines[:maxPreflightLines], fmt.Sprintf("... and %d more", more))
+	}
+	sb.WriteString(strings.Join(lines, "\n"))
+	sb.WriteString("\n\nDraining now may cause an outage. Proceed anyway?")
+	return sb.String()
+}
+
+// drainTimeout bounds cordoning a node and evicting its pods
+const drainTimeout = 2 * time.Minute
+
+// showNodeActions offers cordon, uncordon and drain for the selected node
+func (a *App) showNodeActions() {
+	a.mx.RLock()
+	resource := a.currentResource
+	a.mx.RUnlock()
+
+	if resource != "nodes" && resource != "no" {
+		a.flashMsg("Cordon/drain only available for nodes", true)
+		return
+	}
+
+	_, node, ok := a.selectedResourceRef(resource)
+	if !ok {
+		return
+	}
+
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("Node %s", node)).
+		AddButtons([]string{"Cordon", "Uncordon", "Drain", "Cancel"}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			a.closeOverlay("node-actions")
+			switch buttonLabel {
+			case "Cordon":
+				a.confirmAction("cordon", "cordon-confirm", fmt.Sprintf("Cordon node %s?\n\nNo new pods will be scheduled on it.", node),
+					"Cordon", false, func() { a.setNodeSchedulable(node, false) })
+			case "Uncordon":
+				a.confirmAction("uncordon", "cordon-confirm", fmt.Sprintf("Uncordon node %s?\n\nNew pods may be scheduled on it again.", node),
+					"Uncordon", false, func() { a.setNodeSchedulable(node, true) })
+			case "Drain":
+				go a.confirmDrain(node)
+			}
+		})
+	a.showOverlay("node-actions", modal)
+}
+
+// setNodeSchedulable cordons (schedulable=false) or uncordons a node
+func (a *App) setNodeSchedulable(node string, schedulable bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// Only a change of state can be undone
+	wasCordoned, stateErr := a.k8s.IsNodeCordoned(ctx, node)
+
+	action, done, undo := "cordon", "Cordoned", "uncordon"
+	var err error
+	if schedulable {
+		action, done, undo = "uncordon", "Uncordoned", "cordon"
+		err = a.k8s.UncordonNode(ctx, node)
+	} else {
+		err = a.k8s.CordonNode(ctx, node)
+	}
+	if err != nil {
+		a.flashMsg(fmt.Sprintf("Failed to %s %s: %v", action, node, err), true)
+		return
+	}
+
+	a.recordAudit(action, "nodes/"+node, "")
+	if stateErr == nil && wasCordoned == schedulable {
+		a.recordUndo(fmt.Sprintf("%s %s", undo, node), "nodes/"+node, undo, func(ctx context.Context) error {
+			if schedulable {
+				return a.k8s.CordonNode(ctx, node)
+			}
+			return a.k8s.UncordonNode(ctx, node)
+		})
+		a.flashMsg(fmt.Sprintf("%s %s (Ctrl+Z to undo)", done, node), false)
+	} else {
+		a.flashMsg(fmt.Sprintf("%s %s", done, node), false)
+	}
+	a.refresh()
+}
+
+// drainGracePeriod returns the configured eviction grace period; 0 keeps
+// each pod's own
+func (a *App) drainGracePeriod() int64 {
+	if a.config != nil && a.config.DrainGracePeriod > 0 {
+		return a.config.DrainGracePeriod
+	}
+	return 0
+}
+
+// confirmDrain asks for confirmation with the number of pods the drain
+// evicts, then runs the preflight and the drain. Must not be called from
+// the UI goroutine.
+func (a *App) confirmDrain(node string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	pods, err := a.k8s.DrainablePodsOnNode(ctx, node)
+	if err != nil {
+		a.flashMsg(fmt.Sprintf("Failed to list pods on %s: %v", node, err), true)
+		return
+	}
+
+	text := formatDrainConfirm(node, len(pods), a.drainGracePeriod())
+	a.QueueUpdateDraw(func() {
+		modal := tview.NewModal().
+			SetText(text).
+			AddButtons([]string{"Cancel", "Drain"}).
+			SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+				a.closeOverlay("drain-confirm")
+				if buttonLabel == "Drain" {
+					go a.preflightDrain([]string{node}, func() { a.drainNode(node) })
+				}
+			})
+		modal.SetBackgroundColor(tcell.ColorDarkRed)
+		a.showOverlay("drain-confirm", modal)
+	})
+}
+
+// formatDrainConfirm renders the drain confirmation text
+func formatDrainConfirm(node string, pods int, gracePeriod int64) string {
+	grace := "each pod's own grace period"
+	if gracePeriod > 0 {
+		grace = fmt.Sprintf("a %ds grace period", gracePeriod)
+	}
+	return fmt.Sprintf("Drain node %s?\n\nThe node is cordoned and %d pod(s) will be evicted with %s. DaemonSet and static pods are left in place.",
+		node, pods, grace)
+}
+
+// drainNode cordons node and evicts its pods
+func (a *App) drainNode(node string) {
+	a.flashMsg(fmt.Sprintf("Draining %s...", node), false)
+
+	ctx, cancel := context.WithTimeout(context.Background(), drainTimeout)
+	defer cancel()
+
+	task := a.startTask("Draining "+node, "pods evicted")
+	defer task.finish()
+
+	gracePeriod := a.drainGracePeriod()
+	if err := a.k8s.DrainNodeWithProgress(ctx, node, gracePeriod, false, task.progress); err != nil {
+		a.flashMsg(fmt.Sprintf("Failed to drain %s: %v", node, err), true)
+		return
+	}
+
+	a.recordAudit("drain", "nodes/"+node, fmt.Sprintf("grace period: %ds", gracePeriod))
+	a.flashMsg(fmt.Sprintf("Drained %s", node), false)
+	a.refresh()
+}
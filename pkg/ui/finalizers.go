@@ -0,0 +1,123 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// confirmRemoveFinalizers offers to strip the finalizers from the selected
+// object. Only objects that are already terminating are eligible.
+func (a *App) confirmRemoveFinalizers() {
+	a.mx.RLock()
+	resource := a.currentResource
+	a.mx.RUnlock()
+
+	ns, name, ok := a.selectedResourceRef(resource)
+	if !ok {
+		return
+	}
+
+	if resource == "namespaces" || resource == "ns" {
+		a.confirmFinalizeNamespace(name)
+		return
+	}
+
+	gvr, _, ok := a.resolveGVR(resource)
+	if !ok {
+		a.flashMsg(fmt.Sprintf("Unknown resource type: %s", resource), true)
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		finalizers, terminating, err := a.k8s.PendingFinalizers(ctx, gvr, ns, name)
+		if err != nil {
+			a.flashMsg(fmt.Sprintf("Failed to get finalizers: %v", err), true)
+			return
+		}
+		if !terminating {
+			a.flashMsg(fmt.Sprintf("%s/%s is not terminating; delete it first", resource, name), true)
+			return
+		}
+		if len(finalizers) == 0 {
+			a.flashMsg(fmt.Sprintf("%s/%s has no finalizers", resource, name), false)
+			return
+		}
+
+		text := fmt.Sprintf("[red]Remove finalizers from %s?[white]\n\n%s/%s\n\n%s\n\nThe controllers owning these finalizers will not get to clean up.",
+			resource, ns, name, strings.Join(finalizers, "\n"))
+		a.QueueUpdateDraw(func() {
+			a.confirmAction("finalizers", "finalizer-confirm", text, "Remove Finalizers", true, func() {
+				a.removeFinalizers(gvr, ns, name, resource)
+			})
+		})
+	}()
+}
+
+// removeFinalizers patches away the finalizers of a terminating object
+func (a *App) removeFinalizers(gvr schema.GroupVersionResource, ns, name, resource string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	a.flashMsg(fmt.Sprintf("Removing finalizers from %s/%s...", resource, name), false)
+
+	if err := a.k8s.RemoveFinalizers(ctx, gvr, ns, name); err != nil {
+		a.flashMsg(fmt.Sprintf("Remove finalizers failed: %v", err), true)
+		return
+	}
+
+	a.flashMsg(fmt.Sprintf("Removed finalizers from %s/%s", resource, name), false)
+	go a.refresh()
+}
+
+// confirmFinalizeNamespace asks the user to type the namespace name before
+// clearing its spec.finalizers through the /finalize subresource.
+func (a *App) confirmFinalizeNamespace(name string) {
+	form := tview.NewForm()
+	form.SetBorder(true).SetTitle(fmt.Sprintf(" Force Finalize Namespace: %s ", name))
+	form.SetBackgroundColor(tcell.ColorDarkRed)
+
+	var typed string
+	form.AddTextView("Warning:", "Resources left in the namespace may be orphaned in etcd.", 40, 2, true, false)
+	form.AddInputField("Type name to confirm:", "", 30, nil, func(text string) {
+		typed = text
+	})
+	form.AddButton("Force Finalize", func() {
+		if typed != name {
+			a.flashMsg("Namespace name does not match", true)
+			return
+		}
+		a.closeOverlay("finalize-namespace")
+		go a.finalizeNamespace(name)
+	})
+	form.AddButton("Cancel", func() {
+		a.closeOverlay("finalize-namespace")
+	})
+
+	a.showCentered("finalize-namespace", form, 60, 11)
+}
+
+// finalizeNamespace force-finalizes a terminating namespace and audits it
+func (a *App) finalizeNamespace(name string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	a.flashMsg(fmt.Sprintf("Finalizing namespace %s...", name), false)
+
+	if err := a.k8s.FinalizeNamespace(ctx, name); err != nil {
+		a.flashMsg(fmt.Sprintf("Finalize failed: %v", err), true)
+		return
+	}
+
+	a.recordAudit("namespace_finalize", "namespaces/"+name, "Cleared spec.finalizers via /finalize")
+	a.flashMsg(fmt.Sprintf("Namespace %s finalized", name), false)
+	go a.refresh()
+}
@@ -0,0 +1,119 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/k8s"
+	"github.com/rivo/tview"
+)
+
+// showFindings runs the cluster analyses for the current namespace and lists
+// the findings in a scrollable view (Esc to close).
+func (a *App) showFindings() {
+	a.showFindingsView("Findings", a.k8s.AnalyzeFindings)
+}
+
+// showUnused lists ConfigMaps, Secrets and PVCs nothing references
+func (a *App) showUnused() {
+	a.showFindingsView("Unused resources", a.k8s.AnalyzeOrphans)
+}
+
+func (a *App) showFindingsView(title string, analyze func(context.Context, string) ([]k8s.Finding, error)) {
+	a.mx.RLock()
+	ns := a.currentNamespace
+	a.mx.RUnlock()
+
+	scope := ns
+	if scope == "" {
+		scope = "all namespaces"
+	}
+
+	view := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true)
+	view.SetBorder(true).SetTitle(fmt.Sprintf(" %s: %s (Press Esc to close) ", title, scope))
+	view.SetText(" [yellow]Analyzing...[white]")
+
+	a.showCentered("findings", view, 100, 24)
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		findings, err := analyze(ctx, ns)
+		text := a.glyphs(formatFindings(findings, err))
+		a.QueueUpdateDraw(func() {
+			view.SetText(text)
+		})
+	}()
+}
+
+// formatFindings renders findings grouped by check. err comes from checks
+// that failed; it is shown below the findings of the checks that succeeded.
+func formatFindings(findings []k8s.Finding, err error) string {
+	if err != nil && len(findings) == 0 {
+		return fmt.Sprintf(" [red]Error: %v[white]", tview.Escape(err.Error()))
+	}
+	if len(findings) == 0 {
+		return " [green]✓[white] No findings"
+	}
+
+	titles := map[string]string{
+		k8s.CheckPrivilegedContainer:   "Privileged containers",
+		k8s.CheckHostNetwork:           "Workloads on the host network",
+		k8s.CheckRunAsRoot:             "Containers running as root",
+		k8s.CheckReadinessProbeMissing: "Containers without a readiness probe",
+		k8s.CheckLivenessProbeMissing:  "Containers without a liveness probe",
+		k8s.CheckRBACClusterAdmin:      "Bindings granting cluster-admin",
+		k8s.CheckRBACWildcard:          "Roles with wildcard verbs or resources",
+		k8s.CheckDefaultServiceAccount: "Workloads on the default ServiceAccount",
+		k8s.CheckDefaultSAAutomount:    "Default ServiceAccounts automounting tokens",
+		k8s.CheckTokenAutomount:        "Workloads with an API token mounted",
+		k8s.CheckPDBBlocking:           "PodDisruptionBudgets blocking drains",
+		k8s.CheckPDBMissing:            "Workloads without a PodDisruptionBudget",
+		k8s.CheckLimitBelowRequest:     "Limits below requests",
+		k8s.CheckLimitRatioHigh:        "Limits far above requests",
+		k8s.CheckLimitMissing:          "Requests without limits",
+		k8s.CheckUnusedConfigMap:       "Unused ConfigMaps",
+		k8s.CheckUnusedSecret:          "Unused Secrets",
+		k8s.CheckUnusedPVC:             "Unused PersistentVolumeClaims",
+	}
+	order := []string{
+		k8s.CheckPrivilegedContainer, k8s.CheckHostNetwork, k8s.CheckRunAsRoot,
+		k8s.CheckRBACClusterAdmin, k8s.CheckRBACWildcard,
+		k8s.CheckDefaultServiceAccount, k8s.CheckDefaultSAAutomount, k8s.CheckTokenAutomount,
+		k8s.CheckReadinessProbeMissing, k8s.CheckLivenessProbeMissing,
+		k8s.CheckPDBBlocking, k8s.CheckPDBMissing,
+		k8s.CheckLimitBelowRequest, k8s.CheckLimitRatioHigh, k8s.CheckLimitMissing,
+		k8s.CheckUnusedConfigMap, k8s.CheckUnusedSecret, k8s.CheckUnusedPVC,
+	}
+
+	var sb strings.Builder
+	for _, check := range order {
+		var lines []string
+		var severity string
+		for _, f := range findings {
+			if f.Check == check {
+				lines = append(lines, fmt.Sprintf("   %s/%s/%s: %s", f.Kind, f.Namespace, f.Name, f.Message))
+				severity = f.Severity
+			}
+		}
+		if len(lines) == 0 {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf(" [yellow::b]%s (%d)[white::-]", titles[check], len(lines)))
+		if severity != "" {
+			sb.WriteString(fmt.Sprintf(" [gray]%s[white]", severity))
+		}
+		sb.WriteString("\n")
+		sb.WriteString(tview.Escape(strings.Join(lines, "\n")))
+		sb.WriteString("\n\n")
+	}
+	if err != nil {
+		sb.WriteString(fmt.Sprintf(" [red]Some checks failed: %v[white]\n", tview.Escape(err.Error())))
+	}
+	return sb.String()
+}
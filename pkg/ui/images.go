@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/k8s"
+)
+
+// fetchImages lists the container images of the pods in ns, one row per
+// image with the number of pods running it. It is the TUI counterpart of
+// the image inventory of the comprehensive report.
+func (a *App) fetchImages(ctx context.Context, ns string) ([]string, [][]string, error) {
+	headers := []string{"IMAGE", "REGISTRY", "REPOSITORY", "TAG", "PODS"}
+	pods, err := a.k8s.ListPods(ctx, ns)
+	if err != nil {
+		return headers, nil, err
+	}
+
+	a.mx.RLock()
+	ascending := a.imagesAscending
+	a.mx.RUnlock()
+	return headers, imageRows(k8s.CountImages(pods), ascending), nil
+}
+
+// imageRows renders images most used first, or least used first when
+// ascending. Images with the same pod count keep their order by name.
+func imageRows(usage []k8s.ImageUsage, ascending bool) [][]string {
+	if ascending {
+		usage = append([]k8s.ImageUsage(nil), usage...)
+		sort.SliceStable(usage, func(i, j int) bool {
+			return usage[i].PodCount < usage[j].PodCount
+		})
+	}
+
+	rows := make([][]string, 0, len(usage))
+	for _, u := range usage {
+		tag := u.Ref.Tag
+		if u.Ref.Pinned() {
+			tag = u.Ref.Digest
+			if u.Ref.Tag != "" {
+				tag = u.Ref.Tag + "@" + u.Ref.Digest
+			}
+		}
+		rows = append(rows, []string{
+			u.Image,
+			u.Ref.Registry,
+			u.Ref.Repository,
+			tag,
+			fmt.Sprintf("%d", u.PodCount),
+		})
+	}
+	return rows
+}
+
+// isImagesView reports whether the table lists container images
+func (a *App) isImagesView() bool {
+	a.mx.RLock()
+	defer a.mx.RUnlock()
+	return a.currentResource == "images"
+}
+
+// toggleImageSort flips the images view between most and least used first
+func (a *App) toggleImageSort() {
+	a.mx.Lock()
+	a.imagesAscending = !a.imagesAscending
+	a.mx.Unlock()
+	go a.refresh()
+}
+
+// viewSort is the sort column and direction of resource for the table
+// title, or "" when rows keep the order of the API. Callers hold a.mx.
+func (a *App) viewSort(resource string) (string, bool) {
+	if resource == "images" {
+		return "PODS", !a.imagesAscending
+	}
+	return "", false
+}
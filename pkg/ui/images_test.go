@@ -0,0 +1,38 @@
+package ui
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/k8s"
+)
+
+func TestImageRows(t *testing.T) {
+	usage := []k8s.ImageUsage{
+		{Image: "envoy:1.30", Ref: k8s.ParseImageRef("envoy:1.30"), PodCount: 2},
+		{Image: "nginx:1.25", Ref: k8s.ParseImageRef("nginx:1.25"), PodCount: 2},
+		{Image: "registry:5000/app@sha256:abc", Ref: k8s.ParseImageRef("registry:5000/app@sha256:abc"), PodCount: 1},
+	}
+
+	rows := imageRows(usage, false)
+	want := [][]string{
+		{"envoy:1.30", "docker.io", "envoy", "1.30", "2"},
+		{"nginx:1.25", "docker.io", "nginx", "1.25", "2"},
+		{"registry:5000/app@sha256:abc", "registry:5000", "app", "sha256:abc", "1"},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("got %v, want %v", rows, want)
+	}
+
+	// Least used first; ties keep their order by name
+	var names []string
+	for _, row := range imageRows(usage, true) {
+		names = append(names, row[0])
+	}
+	if want := []string{"registry:5000/app@sha256:abc", "envoy:1.30", "nginx:1.25"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("ascending: got %v, want %v", names, want)
+	}
+	if usage[0].Image != "envoy:1.30" {
+		t.Errorf("sorting must not reorder the caller's slice, got %v", usage)
+	}
+}
@@ -0,0 +1,192 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/k8s"
+	"github.com/rivo/tview"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// showMetadataEditor prompts for label/annotation changes and applies them to
+// the selected (or multi-selected) resources.
+func (a *App) showMetadataEditor() {
+	a.mx.RLock()
+	resource := a.currentResource
+	a.mx.RUnlock()
+
+	refs := a.selectedResourceRefs(resource)
+	if len(refs) == 0 {
+		return
+	}
+
+	gvr, _, ok := a.resolveGVR(resource)
+	if !ok {
+		a.flashMsg(fmt.Sprintf("Unknown resource type: %s", resource), true)
+		return
+	}
+
+	title := fmt.Sprintf(" Label/Annotate: %s/%s ", resource, refs[0].name)
+	if len(refs) > 1 {
+		title = fmt.Sprintf(" Label/Annotate: %d %s ", len(refs), resource)
+	}
+
+	form := tview.NewForm()
+	form.SetBorder(true).SetTitle(title)
+
+	field := k8s.MetadataLabels
+	var changes string
+	form.AddDropDown("Field:", []string{"Labels", "Annotations"}, 0, func(option string, index int) {
+		if index == 1 {
+			field = k8s.MetadataAnnotations
+		} else {
+			field = k8s.MetadataLabels
+		}
+	})
+	form.AddInputField("Changes:", "", 40, nil, func(text string) {
+		changes = text
+	})
+	form.AddTextView("", "key=value to set, key- to remove", 40, 1, true, false)
+	form.AddButton("Apply", func() {
+		set, remove, err := k8s.ParseMetadataChanges(changes)
+		if err != nil {
+			a.flashMsg(err.Error(), true)
+			return
+		}
+
+		a.closeOverlay("metadata-editor")
+
+		go a.applyMetadataChanges(resource, gvr, refs, field, set, remove)
+	})
+	form.AddButton("Cancel", func() {
+		a.closeOverlay("metadata-editor")
+	})
+
+	a.showCentered("metadata-editor", form, 60, 13)
+}
+
+// applyMetadataChanges patches labels or annotations on each resource
+func (a *App) applyMetadataChanges(resource string, gvr schema.GroupVersionResource, refs []resourceRef, field k8s.MetadataField, set map[string]string, remove []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	var failed int
+	for _, ref := range refs {
+		if err := a.k8s.PatchMetadata(ctx, gvr, ref.namespace, ref.name, field, set, remove); err != nil {
+			a.logger.Warn("Metadata patch failed", "error", err, "resource", resource, "name", ref.name)
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		a.flashMsg(fmt.Sprintf("Updated %s on %d/%d %s (%d failed)", field, len(refs)-failed, len(refs), resource, failed), true)
+	} else {
+		a.flashMsg(fmt.Sprintf("Updated %s on %d %s", field, len(refs), resource), false)
+	}
+
+	if len(refs) > 1 {
+		a.clearSelections()
+	}
+	go a.refresh()
+}
+
+// labelsColumnWidth is the longest LABELS cell before it is truncated
+const labelsColumnWidth = 48
+
+// noLabelsResources are views whose rows are not single objects with a name
+var noLabelsResources = map[string]bool{
+	"events": true,
+	"images": true,
+}
+
+// formatLabels renders labels as sorted key=value pairs, truncated to max
+// characters
+func formatLabels(labels map[string]string, max int) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		pairs[i] = k + "=" + labels[k]
+	}
+	s := strings.Join(pairs, ",")
+	if max > 3 && len(s) > max {
+		s = s[:max-3] + "..."
+	}
+	return s
+}
+
+// objectLabels returns the labels of objs by "<ns>/<name>"
+func objectLabels[T any, PT interface {
+	*T
+	metav1.Object
+}](objs []T) map[string]map[string]string {
+	labels := make(map[string]map[string]string, len(objs))
+	for i := range objs {
+		obj := PT(&objs[i])
+		labels[obj.GetNamespace()+"/"+obj.GetName()] = obj.GetLabels()
+	}
+	return labels
+}
+
+// setListedLabels keeps the labels of the objects a fetcher just listed, so
+// the LABELS column needs no second LIST
+func (a *App) setListedLabels(resource string, labels map[string]map[string]string) {
+	a.mx.Lock()
+	a.labelsResource = resource
+	a.listedLabels = labels
+	a.mx.Unlock()
+}
+
+// withLabels appends a LABELS column to the rows of resource, looking each
+// row up by its namespace and name in the labels its fetcher listed. Views
+// that don't list objects are returned as they are.
+func (a *App) withLabels(resource string, headers []string, rows [][]string) ([]string, [][]string) {
+	if noLabelsResources[resource] {
+		return headers, rows
+	}
+	a.mx.RLock()
+	var labels map[string]map[string]string
+	if a.labelsResource == resource {
+		labels = a.listedLabels
+	}
+	a.mx.RUnlock()
+
+	namespaced := len(headers) > 0 && headers[0] == "NAMESPACE"
+	headers = append(append([]string(nil), headers...), "LABELS")
+	out := make([][]string, len(rows))
+	for i, row := range rows {
+		key := ""
+		switch {
+		case !namespaced && len(row) > 0:
+			key = "/" + row[0]
+		case len(row) > 1:
+			key = row[0] + "/" + row[1]
+		}
+		out[i] = append(append([]string(nil), row...), formatLabels(labels[key], labelsColumnWidth))
+	}
+	return headers, out
+}
+
+// toggleLabels shows or hides the LABELS column of the resource views
+func (a *App) toggleLabels() {
+	a.mx.Lock()
+	a.showLabels = !a.showLabels
+	shown := a.showLabels
+	a.mx.Unlock()
+
+	if shown {
+		a.flashMsg("Showing labels", false)
+	} else {
+		a.flashMsg("Hiding labels", false)
+	}
+	go a.refresh()
+}
@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func TestFormatLabels(t *testing.T) {
+	labels := map[string]string{"tier": "frontend", "app": "web"}
+	if got := formatLabels(labels, 48); got != "app=web,tier=frontend" {
+		t.Errorf("formatLabels = %q", got)
+	}
+	if got := formatLabels(labels, 10); got != "app=web..." {
+		t.Errorf("truncated formatLabels = %q", got)
+	}
+	if got := formatLabels(nil, 48); got != "" {
+		t.Errorf("formatLabels(nil) = %q", got)
+	}
+}
+
+func TestWithLabels(t *testing.T) {
+	widget := &unstructured.Unstructured{}
+	widget.SetAPIVersion("example.com/v1")
+	widget.SetKind("Widget")
+	widget.SetNamespace("team-a")
+	widget.SetName("gear")
+	widget.SetLabels(map[string]string{"app": "gear"})
+	gvr := schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "widgets"}
+	dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{gvr: "WidgetList"}, widget)
+
+	app := &App{
+		k8s: &k8s.Client{Dynamic: dyn},
+		apiResources: []k8s.APIResource{
+			{Name: "widgets", Kind: "Widget", Group: "example.com", Version: "v1", Namespaced: true},
+		},
+	}
+
+	// The labels come from the fetcher's list
+	headers, rows, err := app.fetchGenericResource(context.Background(), "widgets", "team-a")
+	if err != nil {
+		t.Fatalf("fetchGenericResource failed: %v", err)
+	}
+	rows = append(rows, []string{"team-a", "gone", "", "2d"})
+	headers, got := app.withLabels("widgets", headers, rows)
+	if headers[len(headers)-1] != "LABELS" {
+		t.Fatalf("headers = %v", headers)
+	}
+	if len(got) != 2 || got[0][4] != "app=gear" || got[1][4] != "" {
+		t.Errorf("rows = %v", got)
+	}
+	if len(rows[0]) != 4 {
+		t.Error("withLabels modified the fetched rows")
+	}
+
+	// Labels listed for another resource are not used
+	_, got = app.withLabels("gadgets", []string{"NAMESPACE", "NAME"}, [][]string{{"team-a", "gear"}})
+	if got[0][2] != "" {
+		t.Errorf("expected no labels from another resource's list, got %v", got)
+	}
+
+	nodes := []corev1.Node{{ObjectMeta: metav1.ObjectMeta{Name: "node-1", Labels: map[string]string{"zone": "a"}}}}
+	app.setListedLabels("nodes", objectLabels(nodes))
+	if _, got := app.withLabels("nodes", []string{"NAME", "STATUS"}, [][]string{{"node-1", "Ready"}}); got[0][2] != "zone=a" {
+		t.Errorf("expected the labels of a cluster-scoped object, got %v", got)
+	}
+
+	headers, _ = app.withLabels("images", []string{"IMAGE"}, nil)
+	if len(headers) != 1 {
+		t.Errorf("images view should get no LABELS column, got %v", headers)
+	}
+}
@@ -0,0 +1,163 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/k8s"
+)
+
+// logDownloadWorkers bounds the log fetches running at once
+const logDownloadWorkers = 4
+
+// logDownloadTimeout bounds the download of the logs of one container
+const logDownloadTimeout = 2 * time.Minute
+
+// logDownloadResult summarizes a bulk log download
+type logDownloadResult struct {
+	Files    int
+	Failures []string // "<ns>/<pod>[/<container>]: <error>", sorted
+}
+
+// downloadSelectedLogs saves the logs of the selected pods (or the current
+// one) to a new directory under ~/k13s-logs
+func (a *App) downloadSelectedLogs() {
+	a.mx.RLock()
+	resource := a.currentResource
+	a.mx.RUnlock()
+
+	if resource != "pods" && resource != "po" {
+		a.flashMsg("Log download only available for pods", true)
+		return
+	}
+
+	refs := a.selectedResourceRefs(resource)
+	if len(refs) == 0 {
+		return
+	}
+	go a.downloadLogs(refs)
+}
+
+// downloadLogs fetches the logs of every container of pods into
+// ~/k13s-logs/pods-<timestamp>/ and flashes a summary
+func (a *App) downloadLogs(pods []resourceRef) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		a.flashMsg(fmt.Sprintf("Failed to save logs: %v", err), true)
+		return
+	}
+	dir := filepath.Join(home, logsDir, "pods-"+time.Now().Format("20060102-150405"))
+
+	a.flashMsg(fmt.Sprintf("Downloading logs of %d pod(s)...", len(pods)), false)
+
+	task := a.startTask("Downloading logs", "pods")
+	defer task.finish()
+
+	res, err := downloadPodLogs(context.Background(), a.k8s, pods, dir, task.progress)
+	if err != nil {
+		a.flashMsg(fmt.Sprintf("Failed to save logs: %v", err), true)
+		return
+	}
+	if len(res.Failures) > 0 {
+		a.flashMsg(fmt.Sprintf("Saved %d log file(s) to %s; %d failed: %s",
+			res.Files, dir, len(res.Failures), strings.Join(res.Failures, "; ")), true)
+		return
+	}
+	a.flashMsg(fmt.Sprintf("Saved %d log file(s) of %d pod(s) to %s", res.Files, len(pods), dir), false)
+}
+
+// downloadPodLogs writes the logs of every container of pods to
+// dir/<ns>-<pod>-<container>.log, streaming up to logDownloadWorkers at once
+// with logDownloadTimeout for each. A pod or container that fails is
+// reported and doesn't stop the others. progress, if not nil, is called
+// with the pods done so far.
+func downloadPodLogs(ctx context.Context, client *k8s.Client, pods []resourceRef, dir string, progress func(done, total int)) (logDownloadResult, error) {
+	var res logDownloadResult
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return res, err
+	}
+
+	var mu sync.Mutex
+	fail := func(ref string, err error) {
+		mu.Lock()
+		res.Failures = append(res.Failures, fmt.Sprintf("%s: %v", ref, err))
+		mu.Unlock()
+	}
+
+	var wg sync.WaitGroup
+	var finished int
+	sem := make(chan struct{}, logDownloadWorkers)
+	for _, pod := range pods {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(pod resourceRef) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			defer func() {
+				if progress != nil {
+					mu.Lock()
+					finished++
+					done := finished
+					mu.Unlock()
+					progress(done, len(pods))
+				}
+			}()
+
+			ref := pod.namespace + "/" + pod.name
+			listCtx, cancel := context.WithTimeout(ctx, logDownloadTimeout)
+			containers, err := client.GetPodContainers(listCtx, pod.namespace, pod.name)
+			cancel()
+			if err != nil {
+				fail(ref, err)
+				return
+			}
+			for _, container := range containers {
+				path := filepath.Join(dir, fmt.Sprintf("%s-%s-%s.log", pod.namespace, pod.name, container))
+				if err := saveContainerLogs(ctx, client, pod, container, path); err != nil {
+					fail(ref+"/"+container, err)
+					continue
+				}
+				mu.Lock()
+				res.Files++
+				mu.Unlock()
+			}
+		}(pod)
+	}
+	wg.Wait()
+
+	sort.Strings(res.Failures)
+	return res, nil
+}
+
+// saveContainerLogs streams the logs of a container of pod into a new file
+// at path; on failure the partial file is removed
+func saveContainerLogs(ctx context.Context, client *k8s.Client, pod resourceRef, container, path string) error {
+	ctx, cancel := context.WithTimeout(ctx, logDownloadTimeout)
+	defer cancel()
+
+	logs, err := client.GetPodLogsStream(ctx, pod.namespace, pod.name, container, 0, false)
+	if err != nil {
+		return err
+	}
+	defer logs.Close()
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(f, logs)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(path)
+	}
+	return err
+}
@@ -0,0 +1,46 @@
+package ui
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// overlay is a primitive showOverlay can put on top of the main view, e.g.
+// a tview.Modal or the Flex returned by centered
+type overlay interface {
+	tview.Primitive
+	GetInputCapture() func(event *tcell.EventKey) *tcell.EventKey
+	SetInputCapture(capture func(event *tcell.EventKey) *tcell.EventKey) *tview.Box
+}
+
+// showOverlay shows p as page on top of the main view and focuses it. All
+// overlays close the same way: Esc removes the page and returns focus to
+// the table, before p's own key handling sees the key. Forms and modals
+// already move between their fields and buttons with Tab and Shift+Tab.
+func (a *App) showOverlay(page string, p overlay) {
+	capture := p.GetInputCapture()
+	p.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			a.closeOverlay(page)
+			return nil
+		}
+		if capture != nil {
+			return capture(event)
+		}
+		return event
+	})
+	a.pages.AddPage(page, p, true, true)
+	a.SetFocus(p)
+}
+
+// showCentered is showOverlay for p in a width x height box in the middle
+// of the screen
+func (a *App) showCentered(page string, p tview.Primitive, width, height int) {
+	a.showOverlay(page, centered(p, width, height))
+}
+
+// closeOverlay removes page and returns focus to the table
+func (a *App) closeOverlay(page string) {
+	a.pages.RemovePage(page)
+	a.SetFocus(a.table)
+}
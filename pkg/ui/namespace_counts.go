@@ -0,0 +1,71 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/k8s"
+)
+
+// namespaceCountsTTL is how long the object counts of the namespaces view
+// are reused; counting every namespace is slow on big clusters
+const namespaceCountsTTL = 30 * time.Second
+
+// namespaceCountCache holds the last object counts per namespace. The zero
+// value is ready to use.
+type namespaceCountCache struct {
+	mu      sync.Mutex
+	fetched time.Time
+	counts  map[string]k8s.NamespaceCounts
+}
+
+// get returns the cached counts if they are younger than namespaceCountsTTL
+// at now and cover every namespace
+func (c *namespaceCountCache) get(now time.Time, namespaces []string) (map[string]k8s.NamespaceCounts, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.counts == nil || now.Sub(c.fetched) > namespaceCountsTTL {
+		return nil, false
+	}
+	for _, ns := range namespaces {
+		if _, ok := c.counts[ns]; !ok {
+			return nil, false
+		}
+	}
+	return c.counts, true
+}
+
+// set replaces the cached counts, fetched at now
+func (c *namespaceCountCache) set(now time.Time, counts map[string]k8s.NamespaceCounts) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.fetched, c.counts = now, counts
+}
+
+// reset drops the cached counts, e.g. after a context switch
+func (c *namespaceCountCache) reset() {
+	c.set(time.Time{}, nil)
+}
+
+// namespaceCounts returns the object counts of namespaces, from the cache
+// while it is fresh
+func (a *App) namespaceCounts(ctx context.Context, namespaces []string) map[string]k8s.NamespaceCounts {
+	if counts, ok := a.nsCounts.get(time.Now(), namespaces); ok {
+		return counts
+	}
+	counts := a.k8s.CountNamespaceObjects(ctx, namespaces)
+	a.nsCounts.set(time.Now(), counts)
+	return counts
+}
+
+// formatCount renders an object count, "?" when it could not be listed
+func formatCount(n int) string {
+	if n < 0 {
+		return "?"
+	}
+	return fmt.Sprintf("%d", n)
+}
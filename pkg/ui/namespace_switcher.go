@@ -0,0 +1,156 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// setNamespaceCache replaces the namespaces used for cycling, numeric
+// selection and autocomplete. Index 0 is always "" (all namespaces).
+func (a *App) setNamespaceCache(names []string) {
+	cache := append([]string{""}, names...)
+	a.mx.Lock()
+	a.namespaces = cache
+	a.mx.Unlock()
+}
+
+// filterNamespaces returns the namespaces containing query (case-insensitive).
+// "" stands for all namespaces and matches "all".
+func filterNamespaces(namespaces []string, query string) []string {
+	query = strings.ToLower(strings.TrimSpace(query))
+	var result []string
+	for _, ns := range namespaces {
+		name := ns
+		if name == "" {
+			name = "all"
+		}
+		if strings.Contains(strings.ToLower(name), query) {
+			result = append(result, ns)
+		}
+	}
+	return result
+}
+
+// showNamespaceSwitcher opens a type-to-filter namespace picker. Namespaces
+// are listed on demand, so it works from any resource view.
+func (a *App) showNamespaceSwitcher() {
+	if a.k8s == nil {
+		a.flashMsg("K8s client not available", true)
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		nss, err := a.k8s.ListNamespaces(ctx)
+		if err != nil {
+			a.flashMsg(fmt.Sprintf("Failed to list namespaces: %v", err), true)
+			return
+		}
+		names := make([]string, 0, len(nss))
+		for _, n := range nss {
+			names = append(names, n.Name)
+		}
+		a.setNamespaceCache(names)
+
+		a.QueueUpdateDraw(func() {
+			a.openNamespaceSwitcher(append([]string{""}, names...))
+		})
+	}()
+}
+
+// openNamespaceSwitcher builds the switcher UI; must run on the UI goroutine
+func (a *App) openNamespaceSwitcher(namespaces []string) {
+	a.mx.RLock()
+	current := a.currentNamespace
+	a.mx.RUnlock()
+
+	input := tview.NewInputField().SetLabel(" Filter: ")
+	list := tview.NewList().ShowSecondaryText(false)
+
+	var shown []string
+	populate := func(query string) {
+		list.Clear()
+		shown = filterNamespaces(namespaces, query)
+		for _, ns := range shown {
+			label := ns
+			if label == "" {
+				label = "all"
+			}
+			prefix := "  "
+			if ns == current {
+				prefix = "* "
+			}
+			list.AddItem(prefix+label, "", 0, nil)
+		}
+	}
+	populate("")
+
+	selectNamespace := func(index int) {
+		if index < 0 || index >= len(shown) {
+			return
+		}
+		selected := shown[index]
+		a.closeOverlay("namespace-switcher")
+		if selected == current {
+			return
+		}
+
+		a.mx.Lock()
+		a.currentNamespace = selected
+		a.mx.Unlock()
+
+		label := selected
+		if label == "" {
+			label = "all"
+		}
+		go func() {
+			a.flashMsg(fmt.Sprintf("Switched to namespace: %s", label), false)
+			a.updateHeader()
+			a.refresh()
+		}()
+	}
+
+	input.SetChangedFunc(populate)
+	input.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEnter:
+			selectNamespace(list.GetCurrentItem())
+			return nil
+		case tcell.KeyDown, tcell.KeyTab:
+			a.SetFocus(list)
+			return nil
+		}
+		return event
+	})
+
+	list.SetSelectedFunc(func(index int, mainText, secondaryText string, shortcut rune) {
+		selectNamespace(index)
+	})
+	list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyUp:
+			if list.GetCurrentItem() == 0 {
+				a.SetFocus(input)
+				return nil
+			}
+		case tcell.KeyTab:
+			a.SetFocus(input)
+			return nil
+		}
+		return event
+	})
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(input, 1, 0, true).
+		AddItem(list, 0, 1, false)
+	layout.SetBorder(true).SetTitle(" Switch Namespace (type to filter, Enter to select, Esc to cancel) ")
+
+	a.showCentered("namespace-switcher", layout, 72, min(len(namespaces)+4, 20))
+}
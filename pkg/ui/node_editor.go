@@ -0,0 +1,119 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/k8s"
+	"github.com/rivo/tview"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// showNodeEditor opens a form to add or remove a label or taint on the selected node
+func (a *App) showNodeEditor() {
+	a.mx.RLock()
+	resource := a.currentResource
+	a.mx.RUnlock()
+
+	if resource != "nodes" && resource != "no" {
+		a.flashMsg("Label/taint editor only available for nodes", true)
+		return
+	}
+
+	_, node, ok := a.selectedResourceRef(resource)
+	if !ok {
+		return
+	}
+
+	form := tview.NewForm()
+	form.SetBorder(true).SetTitle(fmt.Sprintf(" Edit Node: %s ", node))
+
+	kind, action := "Taint", "Add"
+	var key, value string
+	effect := string(corev1.TaintEffectNoSchedule)
+
+	effects := make([]string, 0, len(k8s.TaintEffects)+1)
+	for _, e := range k8s.TaintEffects {
+		effects = append(effects, string(e))
+	}
+	effects = append(effects, "Any")
+
+	form.AddDropDown("Type:", []string{"Taint", "Label"}, 0, func(option string, index int) {
+		kind = option
+	})
+	form.AddDropDown("Action:", []string{"Add", "Remove"}, 0, func(option string, index int) {
+		action = option
+	})
+	form.AddInputField("Key:", "", 40, nil, func(text string) {
+		key = strings.TrimSpace(text)
+	})
+	form.AddInputField("Value:", "", 40, nil, func(text string) {
+		value = strings.TrimSpace(text)
+	})
+	form.AddDropDown("Effect:", effects, 0, func(option string, index int) {
+		effect = option
+	})
+	form.AddButton("Apply", func() {
+		if key == "" {
+			a.flashMsg("Key is required", true)
+			return
+		}
+		if kind == "Taint" && action == "Add" && effect == "Any" {
+			a.flashMsg("Choose an effect for the new taint", true)
+			return
+		}
+
+		a.closeOverlay("node-editor")
+
+		go a.applyNodeEdit(node, kind, action, key, value, effect)
+	})
+	form.AddButton("Cancel", func() {
+		a.closeOverlay("node-editor")
+	})
+
+	a.showCentered("node-editor", form, 60, 17)
+}
+
+// applyNodeEdit patches a node label or taint
+func (a *App) applyNodeEdit(node, kind, action, key, value, effect string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	var err error
+	switch kind {
+	case "Label":
+		gvr, _ := a.k8s.GetGVR("nodes")
+		if action == "Add" {
+			err = a.k8s.PatchMetadata(ctx, gvr, "", node, k8s.MetadataLabels, map[string]string{key: value}, nil)
+		} else {
+			err = a.k8s.PatchMetadata(ctx, gvr, "", node, k8s.MetadataLabels, nil, []string{key})
+		}
+	case "Taint":
+		var taintEffect corev1.TaintEffect
+		if effect != "Any" {
+			taintEffect = corev1.TaintEffect(effect)
+		}
+		if action == "Add" {
+			err = a.k8s.AddNodeTaint(ctx, node, corev1.Taint{Key: key, Value: value, Effect: taintEffect})
+		} else {
+			err = a.k8s.RemoveNodeTaint(ctx, node, key, taintEffect)
+		}
+	}
+
+	if err != nil {
+		a.flashMsg(fmt.Sprintf("%s %s failed: %v", action, strings.ToLower(kind), err), true)
+		return
+	}
+
+	a.flashMsg(fmt.Sprintf("%s %s %s on %s", actionPastTense(action), strings.ToLower(kind), key, node), false)
+	go a.refresh()
+}
+
+func actionPastTense(action string) string {
+	if action == "Remove" {
+		return "Removed"
+	}
+	return "Added"
+}
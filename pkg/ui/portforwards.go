@@ -0,0 +1,210 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// activePortForward is a tunnel started with Shift+F; closing stopCh ends it
+type activePortForward struct {
+	namespace  string
+	name       string // pod or service the user picked
+	resource   string // "pods" or "services"
+	pod        string // pod the tunnel goes to
+	localPort  int
+	remotePort int
+	started    time.Time
+	stopCh     chan struct{}
+	stopOnce   sync.Once
+}
+
+// key identifies a forward in the registry
+func (pf *activePortForward) key() string {
+	return fmt.Sprintf("%s/%s/%s/%d:%d", pf.resource, pf.namespace, pf.name, pf.localPort, pf.remotePort)
+}
+
+func (pf *activePortForward) stop() {
+	pf.stopOnce.Do(func() { close(pf.stopCh) })
+}
+
+// portForwardRegistry tracks the app's active port forwards. The zero value
+// is ready to use.
+type portForwardRegistry struct {
+	mu       sync.Mutex
+	forwards map[string]*activePortForward
+}
+
+// add registers pf unless the same forward, or another one on its local
+// port, is already active
+func (r *portForwardRegistry) add(pf *activePortForward) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.forwards == nil {
+		r.forwards = make(map[string]*activePortForward)
+	}
+	for _, other := range r.forwards {
+		if other.localPort == pf.localPort {
+			return fmt.Errorf("local port %d is already forwarded to %s/%s", pf.localPort, other.namespace, other.name)
+		}
+	}
+	r.forwards[pf.key()] = pf
+	return nil
+}
+
+// remove drops pf if it is still the registered forward for its key
+func (r *portForwardRegistry) remove(pf *activePortForward) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.forwards[pf.key()] == pf {
+		delete(r.forwards, pf.key())
+	}
+}
+
+// list returns the active forwards by local port
+func (r *portForwardRegistry) list() []*activePortForward {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	forwards := make([]*activePortForward, 0, len(r.forwards))
+	for _, pf := range r.forwards {
+		forwards = append(forwards, pf)
+	}
+	sort.Slice(forwards, func(i, j int) bool { return forwards[i].localPort < forwards[j].localPort })
+	return forwards
+}
+
+// stopAll ends every active forward
+func (r *portForwardRegistry) stopAll() {
+	for _, pf := range r.list() {
+		pf.stop()
+		r.remove(pf)
+	}
+}
+
+// startPortForward forwards localPort to remotePort of a pod, or of a pod
+// behind a service, in-process until it is stopped from the port-forward
+// view or the app exits
+func (a *App) startPortForward(ns, name, resource, localPort, remotePort string) {
+	local, err := strconv.Atoi(localPort)
+	if err != nil || local <= 0 || local > 65535 {
+		a.flashMsg(fmt.Sprintf("Invalid local port: %s", localPort), true)
+		return
+	}
+	remote, err := strconv.Atoi(remotePort)
+	if err != nil || remote <= 0 || remote > 65535 {
+		a.flashMsg(fmt.Sprintf("Invalid remote port: %s", remotePort), true)
+		return
+	}
+
+	pf := &activePortForward{
+		namespace:  ns,
+		name:       name,
+		resource:   "pods",
+		pod:        name,
+		localPort:  local,
+		remotePort: remote,
+		started:    time.Now(),
+		stopCh:     make(chan struct{}),
+	}
+	podPort := remote
+	if resource == "services" || resource == "svc" {
+		pf.resource = "services"
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		pf.pod, podPort, err = a.k8s.ResolveServicePort(ctx, ns, name, remote)
+		cancel()
+		if err != nil {
+			a.flashMsg(fmt.Sprintf("Port forward failed: %v", err), true)
+			return
+		}
+	}
+
+	if err := a.portForwards.add(pf); err != nil {
+		a.flashMsg(fmt.Sprintf("Port forward failed: %v", err), true)
+		return
+	}
+
+	a.flashMsg(fmt.Sprintf("Starting port forward %d -> %s:%d", local, name, remote), false)
+
+	readyCh := make(chan struct{})
+	errCh := make(chan error, 1)
+	go func() {
+		err := a.k8s.PortForward(context.Background(), ns, pf.pod, local, podPort, pf.stopCh, readyCh)
+		a.portForwards.remove(pf)
+		errCh <- err
+	}()
+
+	select {
+	case <-readyCh:
+		a.recordAudit("port_forward", fmt.Sprintf("%s/%s/%s", pf.resource, ns, name), fmt.Sprintf("localhost:%d -> %d", local, remote))
+		a.flashMsg(fmt.Sprintf("Port forward active: localhost:%d -> %s:%d (f to manage)", local, name, remote), false)
+	case err := <-errCh:
+		if err == nil {
+			err = fmt.Errorf("tunnel closed")
+		}
+		pf.stop()
+		a.flashMsg(fmt.Sprintf("Port forward failed: %v", err), true)
+		return
+	}
+
+	// Report tunnels that drop on their own, e.g. when the pod goes away
+	go func() {
+		err := <-errCh
+		select {
+		case <-pf.stopCh:
+		default:
+			pf.stop()
+			a.flashMsg(fmt.Sprintf("Port forward localhost:%d -> %s:%d ended: %v", local, name, remote, err), true)
+		}
+	}()
+}
+
+// showPortForwards lists the active port forwards; Ctrl+D or x stops the
+// selected one
+func (a *App) showPortForwards() {
+	forwards := a.portForwards.list()
+	if len(forwards) == 0 {
+		a.flashMsg("No active port forwards (Shift+F on a pod or service starts one)", false)
+		return
+	}
+
+	list := tview.NewList().ShowSecondaryText(true)
+	list.SetBorder(true).SetTitle(" Port forwards (Ctrl+D/x to stop, Esc to close) ")
+
+	for _, pf := range forwards {
+		main := fmt.Sprintf("localhost:%d -> %s/%s:%d", pf.localPort, pf.resource, pf.name, pf.remotePort)
+		secondary := fmt.Sprintf("  namespace %s  pod %s  up %s", pf.namespace, pf.pod, formatAge(pf.started))
+		list.AddItem(main, secondary, 0, nil)
+	}
+	list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case event.Key() == tcell.KeyCtrlD || event.Rune() == 'x':
+			idx := list.GetCurrentItem()
+			if idx < 0 || idx >= len(forwards) {
+				return nil
+			}
+			pf := forwards[idx]
+			pf.stop()
+			a.portForwards.remove(pf)
+			forwards = append(forwards[:idx], forwards[idx+1:]...)
+			list.RemoveItem(idx)
+			go a.flashMsg(fmt.Sprintf("Stopped port forward localhost:%d -> %s:%d", pf.localPort, pf.name, pf.remotePort), false)
+			if len(forwards) == 0 {
+				a.closeOverlay("port-forwards")
+			}
+			return nil
+		}
+		return event
+	})
+
+	a.showCentered("port-forwards", list, 80, min(2*len(forwards)+2, 22))
+}
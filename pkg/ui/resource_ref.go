@@ -0,0 +1,116 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/k8s"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// resourceRef identifies a single object shown in the table
+type resourceRef struct {
+	namespace string
+	name      string
+}
+
+// clusterScopedResources lists the resource views whose tables put NAME in
+// the first column because the objects have no namespace.
+var clusterScopedResources = map[string]bool{
+	"nodes":                     true,
+	"no":                        true,
+	"namespaces":                true,
+	"ns":                        true,
+	"persistentvolumes":         true,
+	"pv":                        true,
+	"storageclasses":            true,
+	"sc":                        true,
+	"clusterroles":              true,
+	"clusterrolebindings":       true,
+	"customresourcedefinitions": true,
+	"crd":                       true,
+}
+
+// resolveGVR maps a resource name to its GVR using the client's static table
+// first and the discovered API resources second. The second return value
+// reports whether the resource is namespaced.
+func (a *App) resolveGVR(resource string) (schema.GroupVersionResource, bool, bool) {
+	if gvr, ok := a.k8s.GetGVR(resource); ok {
+		return gvr, !clusterScopedResources[resource], true
+	}
+
+	if res, ok := a.lookupAPIResource(resource); ok {
+		gvr := schema.GroupVersionResource{Group: res.Group, Version: res.Version, Resource: res.Name}
+		return gvr, res.Namespaced, true
+	}
+	return schema.GroupVersionResource{}, false, false
+}
+
+// lookupAPIResource finds a discovered API resource, including CRDs, by
+// plural name, short name or lowercase kind.
+func (a *App) lookupAPIResource(name string) (k8s.APIResource, bool) {
+	a.mx.RLock()
+	apiResources := a.apiResources
+	a.mx.RUnlock()
+
+	name = strings.ToLower(name)
+	for _, res := range apiResources {
+		if res.Name == name || strings.ToLower(res.Kind) == name {
+			return res, true
+		}
+		for _, short := range res.ShortNames {
+			if short == name {
+				return res, true
+			}
+		}
+	}
+	return k8s.APIResource{}, false
+}
+
+// selectedResourceRef returns the namespace and name of the selected row.
+func (a *App) selectedResourceRef(resource string) (string, string, bool) {
+	row, _ := a.table.GetSelection()
+	if row <= 0 {
+		return "", "", false
+	}
+
+	if clusterScopedResources[resource] {
+		name := strings.TrimSpace(a.tableCell(row, 0).Text)
+		return "", name, name != ""
+	}
+	ns := strings.TrimSpace(a.tableCell(row, 0).Text)
+	name := strings.TrimSpace(a.tableCell(row, 1).Text)
+	return ns, name, name != ""
+}
+
+// selectedResourceRefs returns the multi-selected rows, or the current row
+// when nothing is selected.
+func (a *App) selectedResourceRefs(resource string) []resourceRef {
+	a.mx.RLock()
+	rows := make([]int, 0, len(a.selectedRows))
+	for row := range a.selectedRows {
+		rows = append(rows, row)
+	}
+	a.mx.RUnlock()
+
+	if len(rows) == 0 {
+		if ns, name, ok := a.selectedResourceRef(resource); ok {
+			return []resourceRef{{namespace: ns, name: name}}
+		}
+		return nil
+	}
+
+	var refs []resourceRef
+	for _, row := range rows {
+		var ref resourceRef
+		if clusterScopedResources[resource] {
+			ref.name = strings.TrimSpace(a.tableCell(row, 0).Text)
+		} else {
+			ref.namespace = strings.TrimSpace(a.tableCell(row, 0).Text)
+			ref.name = strings.TrimSpace(a.tableCell(row, 1).Text)
+		}
+		if ref.name != "" {
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
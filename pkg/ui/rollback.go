@@ -0,0 +1,132 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// deploymentRevision is one entry of a deployment's rollout history
+type deploymentRevision struct {
+	Revision int64
+	Name     string // ReplicaSet name
+	Age      string
+	Ready    int32
+	Replicas int32
+}
+
+// deploymentRevisions converts GetDeploymentReplicaSets output to revisions,
+// newest first. ReplicaSets without a revision are left out.
+func deploymentRevisions(replicaSets []map[string]interface{}) []deploymentRevision {
+	var revisions []deploymentRevision
+	for _, rs := range replicaSets {
+		revStr, _ := rs["revision"].(string)
+		rev, err := strconv.ParseInt(revStr, 10, 64)
+		if err != nil || rev <= 0 {
+			continue
+		}
+		r := deploymentRevision{Revision: rev}
+		r.Name, _ = rs["name"].(string)
+		r.Age, _ = rs["age"].(string)
+		r.Ready, _ = rs["ready"].(int32)
+		r.Replicas, _ = rs["replicas"].(int32)
+		revisions = append(revisions, r)
+	}
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Revision > revisions[j].Revision
+	})
+	return revisions
+}
+
+// showRollback lists the revisions of the selected deployment and rolls back
+// to the chosen one, like "kubectl rollout undo --to-revision".
+func (a *App) showRollback() {
+	a.mx.RLock()
+	resource := a.currentResource
+	a.mx.RUnlock()
+
+	if resource != "deployments" && resource != "deploy" {
+		a.flashMsg("Rollback only available for deployments", true)
+		return
+	}
+
+	ns, name, ok := a.selectedResourceRef(resource)
+	if !ok {
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		replicaSets, err := a.k8s.GetDeploymentReplicaSets(ctx, ns, name)
+		if err != nil {
+			a.flashMsg(fmt.Sprintf("Failed to get revisions of %s/%s: %v", ns, name, err), true)
+			return
+		}
+		revisions := deploymentRevisions(replicaSets)
+		if len(revisions) < 2 {
+			a.flashMsg(fmt.Sprintf("%s/%s has no previous revision to roll back to", ns, name), true)
+			return
+		}
+
+		a.QueueUpdateDraw(func() {
+			a.showRevisionList(ns, name, revisions)
+		})
+	}()
+}
+
+// showRevisionList opens the revision picker; the first revision is the
+// current one
+func (a *App) showRevisionList(ns, name string, revisions []deploymentRevision) {
+	list := tview.NewList().ShowSecondaryText(true)
+	list.SetBorder(true).SetTitle(fmt.Sprintf(" Roll back %s/%s (Enter to select, Esc to cancel) ", ns, name))
+
+	for i, r := range revisions {
+		main := fmt.Sprintf("Revision %d", r.Revision)
+		if i == 0 {
+			main += " [green](current)[white]"
+		}
+		secondary := fmt.Sprintf("  %s  ready %d/%d  age %s", r.Name, r.Ready, r.Replicas, r.Age)
+		revision := r.Revision
+		current := i == 0
+		list.AddItem(main, secondary, 0, func() {
+			if current {
+				a.flashMsg(fmt.Sprintf("%s/%s is already at revision %d", ns, name, revision), true)
+				return
+			}
+			a.closeOverlay("rollback")
+			a.confirmAction("rollback", "rollback-confirm", fmt.Sprintf("Roll back %s/%s to revision %d?", ns, name, revision),
+				"Roll Back", false, func() { a.rollbackDeployment(ns, name, revision) })
+		})
+	}
+	list.SetCurrentItem(1)
+	a.showCentered("rollback", list, 70, min(2*len(revisions)+2, 22))
+}
+
+// rollbackDeployment rolls a deployment back to revision and follows the
+// rollout
+func (a *App) rollbackDeployment(ns, name string, revision int64) {
+	a.flashMsg(fmt.Sprintf("Rolling back %s/%s to revision %d...", ns, name, revision), false)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := a.k8s.RollbackDeployment(ctx, ns, name, revision); err != nil {
+		a.flashMsg(fmt.Sprintf("Rollback failed: %v", err), true)
+		return
+	}
+
+	a.recordAudit("rollback", fmt.Sprintf("deployments/%s/%s", ns, name), fmt.Sprintf("to revision %d", revision))
+	a.flashMsg(fmt.Sprintf("Rolled back %s/%s to revision %d", ns, name, revision), false)
+	a.refresh()
+
+	gvr, ok := a.k8s.GetGVR("deployments")
+	if ok {
+		a.showRolloutProgress("Rollback", gvr, ns, name)
+	}
+}
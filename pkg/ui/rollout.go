@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/k8s"
+	"github.com/rivo/tview"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// rolloutWaitTimeout bounds how long the progress modal polls a workload
+const rolloutWaitTimeout = 5 * time.Minute
+
+// showRolloutProgress opens a modal that follows a workload until its
+// rollout converges, times out, or the user closes it (Esc/Enter).
+func (a *App) showRolloutProgress(action string, gvr schema.GroupVersionResource, ns, name string) {
+	ctx, cancel := context.WithTimeout(context.Background(), rolloutWaitTimeout)
+
+	view := tview.NewTextView().SetDynamicColors(true)
+	view.SetBorder(true).SetTitle(fmt.Sprintf(" %s: %s/%s (Esc to close) ", action, ns, name))
+	view.SetText("[yellow]Waiting for status...[white]")
+
+	a.QueueUpdateDraw(func() {
+		box := centered(view, 64, 10)
+		a.showOverlay("rollout-progress", box)
+		closeOnEsc := box.GetInputCapture()
+		box.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+			switch event.Key() {
+			case tcell.KeyEsc:
+				cancel()
+			case tcell.KeyEnter:
+				cancel()
+				a.closeOverlay("rollout-progress")
+				return nil
+			}
+			return closeOnEsc(event)
+		})
+	})
+
+	go func() {
+		defer cancel()
+
+		render := func(st k8s.RolloutStatus, footer string) {
+			var sb strings.Builder
+			sb.WriteString(fmt.Sprintf(" Desired:   %d\n", st.Desired))
+			sb.WriteString(fmt.Sprintf(" Updated:   %d\n", st.Updated))
+			sb.WriteString(fmt.Sprintf(" Ready:     %d\n", st.Ready))
+			sb.WriteString(fmt.Sprintf(" Available: %d\n\n", st.Available))
+			sb.WriteString(" " + footer)
+			text := sb.String()
+			a.QueueUpdateDraw(func() {
+				view.SetText(text)
+			})
+		}
+
+		st, err := a.k8s.WaitForRollout(ctx, gvr, ns, name, time.Second, func(st k8s.RolloutStatus) {
+			render(st, fmt.Sprintf("[yellow]%s...[white]", st.Message))
+		})
+
+		switch {
+		case err == nil:
+			render(st, "[green]"+st.Message+"[white]")
+			a.flashMsg(fmt.Sprintf("%s %s/%s: %s", action, ns, name, st.Message), false)
+		case errors.Is(err, context.Canceled):
+			return
+		case errors.Is(err, context.DeadlineExceeded):
+			render(st, fmt.Sprintf("[red]Timed out after %s: %s[white]", rolloutWaitTimeout, st.Message))
+			a.flashMsg(fmt.Sprintf("%s %s/%s did not converge in %s", action, ns, name, rolloutWaitTimeout), true)
+		default:
+			render(st, fmt.Sprintf("[red]Error: %v[white]", err))
+		}
+		go a.refresh()
+	}()
+}
@@ -0,0 +1,115 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/k8s"
+	"github.com/rivo/tview"
+)
+
+// previewScale shows the expected impact of a scale operation and asks for
+// confirmation before scaling. Scaling to 0 always asks; other counts scale
+// right away unless "scale" is in confirm_actions.
+func (a *App) previewScale(resource, ns, name string, replicas int32) {
+	if replicas > 0 && !a.confirmRequired("scale") {
+		a.doScale(resource, ns, name, replicas)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	a.flashMsg(fmt.Sprintf("Previewing scale of %s/%s...", ns, name), false)
+	preview, err := a.k8s.PreviewScale(ctx, resource, ns, name, replicas)
+
+	var text string
+	danger := false
+	if err != nil {
+		text = fmt.Sprintf("Scale %s/%s to %d replicas?\n\n[yellow]Preview unavailable: %v[white]", ns, name, replicas, err)
+	} else {
+		text, danger = formatScalePreview(ns, name, preview)
+	}
+
+	a.QueueUpdateDraw(func() {
+		modal := tview.NewModal().
+			SetText(a.glyphs(text)).
+			AddButtons([]string{"Cancel", "Scale"}).
+			SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+				a.closeOverlay("scale-preview")
+
+				if buttonLabel == "Scale" {
+					go a.doScale(resource, ns, name, replicas)
+				}
+			})
+		if danger {
+			modal.SetBackgroundColor(tcell.ColorDarkRed)
+		}
+		a.showOverlay("scale-preview", modal)
+	})
+}
+
+// formatScalePreview renders a ScalePreview for the confirmation modal. The
+// second return value is true when the change looks risky.
+func formatScalePreview(ns, name string, p *k8s.ScalePreview) (string, bool) {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Scale %s/%s: %d → %d replicas\n\n", ns, name, p.Current, p.Target))
+
+	switch {
+	case p.Target < p.Current:
+		sb.WriteString(fmt.Sprintf("[red]%d pod(s) will be terminated:[white]\n", p.Current-p.Target))
+		for _, pod := range p.Terminating {
+			sb.WriteString("  " + pod + "\n")
+		}
+		return sb.String(), p.Target == 0
+	case p.Target > p.Current:
+		sb.WriteString(fmt.Sprintf("Additional requests: CPU %s, memory %s\n", p.AdditionalCPU.String(), p.AdditionalMemory.String()))
+		sb.WriteString(fmt.Sprintf("Unrequested capacity: CPU %s, memory %s\n\n", p.FreeCPU.String(), p.FreeMemory.String()))
+		if p.Fits {
+			sb.WriteString("[green]New pods fit in the remaining cluster capacity[white]")
+		} else {
+			sb.WriteString("[yellow]New pods may not fit; some could stay Pending[white]")
+		}
+	default:
+		sb.WriteString("Replica count is unchanged")
+	}
+	return sb.String(), false
+}
+
+// doScale scales a workload and follows the rollout
+func (a *App) doScale(resource, ns, name string, replicas int32) {
+	a.flashMsg(fmt.Sprintf("Scaling %s/%s to %d replicas...", ns, name, replicas), false)
+
+	gvr, ok := a.k8s.GetGVR(resource)
+	if !ok {
+		a.flashMsg(fmt.Sprintf("Unknown resource type: %s", resource), true)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	previous, prevErr := a.k8s.GetReplicas(ctx, gvr, ns, name)
+
+	if err := a.k8s.ScaleResource(ctx, gvr, ns, name, replicas); err != nil {
+		a.flashMsg(fmt.Sprintf("Scale failed: %v", err), true)
+		return
+	}
+
+	if prevErr == nil && previous != replicas {
+		confirm := "scale"
+		if previous == 0 {
+			confirm = "scale-to-0"
+		}
+		a.recordUndo(fmt.Sprintf("scale %s/%s back to %d replicas", ns, name, previous), fmt.Sprintf("%s/%s/%s", gvr.Resource, ns, name), confirm,
+			func(ctx context.Context) error { return a.k8s.ScaleResource(ctx, gvr, ns, name, previous) })
+		a.flashMsg(fmt.Sprintf("Scaled %s/%s from %d to %d replicas (Ctrl+Z to undo)", ns, name, previous, replicas), false)
+	} else {
+		a.flashMsg(fmt.Sprintf("Scaled %s/%s to %d replicas", ns, name, replicas), false)
+	}
+	a.refresh()
+	a.showRolloutProgress("Scale", gvr, ns, name)
+}
@@ -0,0 +1,116 @@
+package ui
+
+// tableSelection is where the user was in the table before it was redrawn:
+// the object under the cursor, how far it was scrolled and which rows were
+// multi-selected, all by object identity rather than row index
+type tableSelection struct {
+	resource string
+	key      string
+	row      int
+	offset   int
+	selected map[string]bool
+}
+
+// rowIdentity returns the key that identifies the object of a fetched row
+// of resource across refreshes: the name of cluster-scoped objects and the
+// namespace and name of namespaced ones. Events have no name column and are
+// keyed by their object and reason.
+func rowIdentity(resource string, row []string) string {
+	switch {
+	case len(row) == 0:
+		return ""
+	case clusterScopedResources[resource] || resource == "images" || len(row) < 2:
+		return row[0]
+	case resource == "events" && len(row) > 3:
+		return row[0] + "/" + row[3] + "/" + row[2]
+	}
+	return row[0] + "/" + row[1]
+}
+
+// saveSelection remembers the selection of the table as it is now, unless
+// an earlier one is still waiting to be restored (e.g. taken before the
+// loading message replaced the rows). Call it on the UI goroutine.
+func (a *App) saveSelection() {
+	if a.savedSelection != nil {
+		return
+	}
+
+	row, _ := a.table.GetSelection()
+	offset, _ := a.table.GetOffset()
+	sel := &tableSelection{
+		resource: a.rowKeysResource,
+		row:      row,
+		offset:   offset,
+		selected: make(map[string]bool),
+	}
+	if row > 0 && row <= len(a.rowKeys) {
+		sel.key = a.rowKeys[row-1]
+	}
+
+	a.mx.RLock()
+	for r := range a.selectedRows {
+		if r > 0 && r <= len(a.rowKeys) {
+			sel.selected[a.rowKeys[r-1]] = true
+		}
+	}
+	a.mx.RUnlock()
+
+	a.savedSelection = sel
+}
+
+// restoreSelection moves the cursor back to the saved object after the rows
+// of resource were redrawn with keys, keeping it at the same height on
+// screen, and re-marks the multi-selected objects that are still listed.
+// Falls back to the first row when the object is gone or the view changed.
+// Call it on the UI goroutine.
+func (a *App) restoreSelection(resource string, keys []string) {
+	sel := a.savedSelection
+	a.savedSelection = nil
+	a.rowKeys = keys
+	a.rowKeysResource = resource
+
+	if sel != nil && sel.resource != resource {
+		sel = nil
+	}
+
+	rows := make(map[string]int, len(keys))
+	for i, key := range keys {
+		if _, dup := rows[key]; !dup {
+			rows[key] = i + 1
+		}
+	}
+
+	selected := make(map[int]bool)
+	if sel != nil {
+		for key := range sel.selected {
+			if r, ok := rows[key]; ok {
+				selected[r] = true
+			}
+		}
+	}
+	a.mx.Lock()
+	a.selectedRows = selected
+	a.mx.Unlock()
+	for r := range selected {
+		a.updateRowSelection(r)
+	}
+
+	if len(keys) == 0 {
+		return
+	}
+	row := 1
+	if sel != nil {
+		if r, ok := rows[sel.key]; ok {
+			row = r
+			offset := r - (sel.row - sel.offset)
+			if offset < 0 {
+				offset = 0
+			}
+			a.table.SetOffset(offset, 0)
+		}
+	}
+	if row == 1 {
+		a.table.SetOffset(0, 0)
+	}
+	a.table.Select(row, 0)
+}
@@ -0,0 +1,82 @@
+package ui
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rivo/tview"
+)
+
+// renderRows redraws the table with rows of pods the way refreshView does
+func renderRows(app *App, rows [][]string) {
+	app.saveSelection()
+	app.table.Clear()
+	app.table.SetCell(0, 0, tview.NewTableCell("NAMESPACE"))
+	app.table.SetCell(0, 1, tview.NewTableCell("NAME"))
+	keys := make([]string, len(rows))
+	for r, row := range rows {
+		for c, text := range row {
+			app.table.SetCell(r+1, c, tview.NewTableCell(text))
+		}
+		keys[r] = rowIdentity("pods", row)
+	}
+	app.restoreSelection("pods", keys)
+}
+
+func TestRestoreSelection(t *testing.T) {
+	app := &App{table: tview.NewTable().SetSelectable(true, false), selectedRows: make(map[int]bool)}
+
+	renderRows(app, [][]string{{"default", "a"}, {"default", "b"}, {"default", "c"}, {"kube-system", "d"}})
+	if row, _ := app.table.GetSelection(); row != 1 {
+		t.Fatalf("first render selected row %d, want 1", row)
+	}
+
+	app.table.Select(3, 0)
+	app.selectedRows[2] = true
+	app.selectedRows[4] = true
+
+	// b is gone and a new pod sorts first; c and d move
+	renderRows(app, [][]string{{"default", "0"}, {"default", "a"}, {"default", "c"}, {"kube-system", "d"}})
+	if row, _ := app.table.GetSelection(); row != 3 {
+		t.Errorf("selected row %d after refresh, want 3 (default/c)", row)
+	}
+	if want := map[int]bool{4: true}; !reflect.DeepEqual(app.selectedRows, want) {
+		t.Errorf("selectedRows = %v, want %v", app.selectedRows, want)
+	}
+
+	// The selected pod disappears
+	renderRows(app, [][]string{{"default", "0"}, {"default", "a"}})
+	if row, _ := app.table.GetSelection(); row != 1 {
+		t.Errorf("selected row %d after the pod went away, want 1", row)
+	}
+
+	// Another view starts at the top without selections
+	app.table.Select(2, 0)
+	app.selectedRows[2] = true
+	app.saveSelection()
+	app.restoreSelection("deployments", []string{"default/0", "default/a"})
+	if row, _ := app.table.GetSelection(); row != 1 {
+		t.Errorf("selected row %d in a new view, want 1", row)
+	}
+	if len(app.selectedRows) != 0 {
+		t.Errorf("selectedRows = %v in a new view, want none", app.selectedRows)
+	}
+}
+
+func TestRowIdentity(t *testing.T) {
+	tests := []struct {
+		resource string
+		row      []string
+		want     string
+	}{
+		{"pods", []string{"default", "web-1", "Running"}, "default/web-1"},
+		{"nodes", []string{"node-1", "Ready"}, "node-1"},
+		{"events", []string{"default", "Warning", "BackOff", "pod/web-1", "restarting"}, "default/pod/web-1/BackOff"},
+		{"pods", nil, ""},
+	}
+	for _, tt := range tests {
+		if got := rowIdentity(tt.resource, tt.row); got != tt.want {
+			t.Errorf("rowIdentity(%q, %v) = %q, want %q", tt.resource, tt.row, got, tt.want)
+		}
+	}
+}
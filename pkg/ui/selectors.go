@@ -0,0 +1,105 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/k8s"
+	"github.com/rivo/tview"
+)
+
+// showSelectedPods drills down from a workload or service to the pods its
+// label selector matches, listed server-side so pods of other objects with
+// overlapping names are left out. The navigation entry has already been
+// pushed by drillDown.
+func (a *App) showSelectedPods(resource, ns, name string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	selector, err := a.k8s.PodSelector(ctx, resource, ns, name)
+	if err != nil {
+		a.flashMsg(fmt.Sprintf("Cannot show pods of %s/%s: %v", resource, name, err), true)
+		a.QueueUpdateDraw(a.goBack)
+		return
+	}
+
+	a.mx.Lock()
+	a.currentResource = "pods"
+	a.currentNamespace = ns
+	a.filterText = ""
+	a.labelSelector = selector
+	a.fieldSelector = ""
+	a.mx.Unlock()
+
+	a.updateHeader()
+	a.refresh()
+}
+
+// podSelector returns the label selector applied to the current view, ""
+// when there is none. Callers must hold a.mx.
+func (a *App) podSelector() string {
+	if a.currentResource != "pods" {
+		return ""
+	}
+	return a.labelSelector
+}
+
+// podFieldSelector is podSelector for the field selector, e.g. the
+// spec.nodeName of a node drill-down. Callers must hold a.mx.
+func (a *App) podFieldSelector() string {
+	if a.currentResource != "pods" {
+		return ""
+	}
+	return a.fieldSelector
+}
+
+// showServiceWorkloads navigates from a service to the deployment or
+// statefulset whose pod template its selector matches, with a picker when
+// several do (k9s z key on services)
+func (a *App) showServiceWorkloads(ns, name string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	refs, err := a.k8s.ServiceWorkloads(ctx, ns, name)
+	if err != nil {
+		a.flashMsg(fmt.Sprintf("Cannot find workloads of service %s: %v", name, err), true)
+		return
+	}
+
+	switch len(refs) {
+	case 0:
+		a.flashMsg(fmt.Sprintf("No deployment or statefulset matches the selector of service %s", name), true)
+	case 1:
+		a.QueueUpdateDraw(func() { a.showWorkload(ns, refs[0]) })
+	default:
+		a.QueueUpdateDraw(func() {
+			list := tview.NewList().ShowSecondaryText(false)
+			list.SetBorder(true).SetTitle(fmt.Sprintf(" Workloads of %s/%s (Enter to select, Esc to cancel) ", ns, name))
+			for _, ref := range refs {
+				ref := ref
+				list.AddItem(fmt.Sprintf("%s/%s", ref.Resource, ref.Name), "", 0, func() {
+					a.closeOverlay("service-workloads")
+					a.showWorkload(ns, ref)
+				})
+			}
+			a.showCentered("service-workloads", list, 60, min(len(refs)+2, 20))
+		})
+	}
+}
+
+// showWorkload switches the table to the view of ref, filtered to its name
+func (a *App) showWorkload(ns string, ref k8s.WorkloadRef) {
+	a.mx.Lock()
+	navigationStack = append(navigationStack, navHistory{a.currentResource, a.currentNamespace, a.filterText, a.labelSelector, a.fieldSelector})
+	a.currentResource = ref.Resource
+	a.currentNamespace = ns
+	a.filterText = ref.Name
+	a.labelSelector = ""
+	a.fieldSelector = ""
+	a.mx.Unlock()
+	go func() {
+		a.updateHeader()
+		a.refresh()
+	}()
+}
@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rivo/tview"
+)
+
+// tableTitle describes what the main table is showing and every constraint
+// applied to it. It is the single source for the table's border title so
+// refresh and filtering always render it the same way.
+type tableTitle struct {
+	Resource string
+	Shown    int
+	Total    int
+
+	Filter string
+	Regex  bool
+
+	// Optional constraints, rendered only when set
+	SortColumn    string
+	SortDesc      bool
+	LabelSelector string
+	FieldSelector string
+
+	// Freshness says how current the rows are, e.g. "live: last change
+	// 15:04:05" for a watched view or "polled: every 2s"
+	Freshness string
+}
+
+// String renders the title, e.g. " pods (3/12) [regex: ^web] [sort: AGE ↓] "
+func (t tableTitle) String() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf(" %s (%d/%d)", t.Resource, t.Shown, t.Total))
+
+	if t.Filter != "" {
+		kind := "filter"
+		if t.Regex {
+			kind = "regex"
+		}
+		sb.WriteString(fmt.Sprintf(" [%s: %s]", kind, tview.Escape(t.Filter)))
+	}
+	if t.SortColumn != "" {
+		dir := "↑"
+		if t.SortDesc {
+			dir = "↓"
+		}
+		sb.WriteString(fmt.Sprintf(" [sort: %s %s]", t.SortColumn, dir))
+	}
+	if t.LabelSelector != "" {
+		sb.WriteString(fmt.Sprintf(" [labels: %s]", tview.Escape(t.LabelSelector)))
+	}
+	if t.FieldSelector != "" {
+		sb.WriteString(fmt.Sprintf(" [fields: %s]", tview.Escape(t.FieldSelector)))
+	}
+	if t.Freshness != "" {
+		sb.WriteString(fmt.Sprintf(" [%s]", tview.Escape(t.Freshness)))
+	}
+
+	sb.WriteString(" ")
+	return sb.String()
+}
@@ -0,0 +1,119 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/rivo/tview"
+)
+
+// backgroundTask is a long-running operation shown in the status bar from
+// start until finish, e.g. "Draining node-1: 7/20 pods evicted"
+type backgroundTask struct {
+	app   *App
+	id    int
+	label string
+	unit  string // what done and total count, e.g. "pods evicted"
+
+	mu    sync.Mutex
+	done  int
+	total int // 0 while unknown
+}
+
+// progress records that done of total units are complete
+func (t *backgroundTask) progress(done, total int) {
+	t.mu.Lock()
+	t.done, t.total = done, total
+	t.mu.Unlock()
+	t.app.redrawStatusBar()
+}
+
+// finish removes the task from the status bar
+func (t *backgroundTask) finish() {
+	t.app.tasks.remove(t)
+	t.app.redrawStatusBar()
+}
+
+// String renders the task for the status bar
+func (t *backgroundTask) String() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.total <= 0 {
+		return t.label + "..."
+	}
+	return fmt.Sprintf("%s: %d/%d %s", t.label, t.done, t.total, t.unit)
+}
+
+// taskRegistry tracks the app's running background tasks. The zero value
+// is ready to use.
+type taskRegistry struct {
+	mu     sync.Mutex
+	nextID int
+	tasks  map[int]*backgroundTask
+}
+
+func (r *taskRegistry) add(t *backgroundTask) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.tasks == nil {
+		r.tasks = make(map[int]*backgroundTask)
+	}
+	r.nextID++
+	t.id = r.nextID
+	r.tasks[t.id] = t
+}
+
+func (r *taskRegistry) remove(t *backgroundTask) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.tasks, t.id)
+}
+
+// list returns the running tasks, oldest first
+func (r *taskRegistry) list() []*backgroundTask {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tasks := make([]*backgroundTask, 0, len(r.tasks))
+	for _, t := range r.tasks {
+		tasks = append(tasks, t)
+	}
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].id < tasks[j].id })
+	return tasks
+}
+
+// status is the status bar entry for the running tasks, "" when idle
+func (r *taskRegistry) status() string {
+	tasks := r.list()
+	if len(tasks) == 0 {
+		return ""
+	}
+	parts := make([]string, len(tasks))
+	for i, t := range tasks {
+		parts[i] = tview.Escape(t.String())
+	}
+	return "[black:yellow] " + strings.Join(parts, " | ") + " [-:-] "
+}
+
+// startTask shows a background task in the status bar until its finish is
+// called. unit describes what its progress counts; tasks that never report
+// progress are shown as running.
+func (a *App) startTask(label, unit string) *backgroundTask {
+	t := &backgroundTask{app: a, label: label, unit: unit}
+	a.tasks.add(t)
+	a.redrawStatusBar()
+	return t
+}
+
+// redrawStatusBar updates the status bar from any goroutine
+func (a *App) redrawStatusBar() {
+	if atomic.LoadInt32(&a.running) == 1 {
+		a.QueueUpdateDraw(a.updateStatusBar)
+	}
+}
@@ -0,0 +1,151 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// transcriptsDir is the directory under the home directory AI transcripts
+// are exported to
+const transcriptsDir = "k13s-transcripts"
+
+// transcriptEntry is one step of an AI conversation
+type transcriptEntry struct {
+	Time   time.Time `json:"time"`
+	Kind   string    `json:"kind"`             // "question", "answer" or "command"
+	Text   string    `json:"text"`             // the question, answer or command line
+	Output string    `json:"output,omitempty"` // what a command printed
+	Error  bool      `json:"error,omitempty"`  // the command failed or was cancelled
+}
+
+// aiTranscript records the questions, answers and executed commands of
+// the session's AI conversation. The zero value is ready to use.
+type aiTranscript struct {
+	mu      sync.Mutex
+	entries []transcriptEntry
+}
+
+func (t *aiTranscript) add(kind, text, output string, isError bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.entries = append(t.entries, transcriptEntry{
+		Time:   time.Now(),
+		Kind:   kind,
+		Text:   text,
+		Output: output,
+		Error:  isError,
+	})
+}
+
+// list returns a copy of the entries, oldest first
+func (t *aiTranscript) list() []transcriptEntry {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return append([]transcriptEntry(nil), t.entries...)
+}
+
+// recordAICommand adds a command the AI assistant ran to the transcript and
+// the audit log
+func (a *App) recordAICommand(command, output string, isError bool) {
+	a.transcript.add("command", command, output, isError)
+
+	details := command
+	if isError {
+		details += " (failed)"
+	}
+	a.recordAudit("ai_command", "", details)
+}
+
+// formatTranscriptMarkdown renders a transcript as a Markdown document
+func formatTranscriptMarkdown(entries []transcriptEntry, exported time.Time) string {
+	var sb strings.Builder
+	sb.WriteString("# k13s AI Transcript\n\n")
+	fmt.Fprintf(&sb, "Exported %s", exported.Format(time.RFC3339))
+	if len(entries) > 0 {
+		fmt.Fprintf(&sb, ", conversation started %s", entries[0].Time.Format(time.RFC3339))
+	}
+	sb.WriteString("\n")
+
+	for _, e := range entries {
+		ts := e.Time.Format("15:04:05")
+		switch e.Kind {
+		case "question":
+			fmt.Fprintf(&sb, "\n## %s Question\n\n%s\n", ts, e.Text)
+		case "answer":
+			fmt.Fprintf(&sb, "\n### %s Answer\n\n%s\n", ts, e.Text)
+		case "command":
+			status := "ran"
+			if e.Error {
+				status = "failed"
+			}
+			fmt.Fprintf(&sb, "\n### %s Command (%s)\n\n```\n$ %s\n", ts, status, e.Text)
+			if output := strings.TrimRight(e.Output, "\n"); output != "" {
+				sb.WriteString(output + "\n")
+			}
+			sb.WriteString("```\n")
+		}
+	}
+	return sb.String()
+}
+
+// writeTranscript saves entries to dir as Markdown ("md") or JSON ("json")
+// and returns the file's path
+func writeTranscript(dir, format string, entries []transcriptEntry, now time.Time) (string, error) {
+	var data []byte
+	switch format {
+	case "md", "markdown":
+		format = "md"
+		data = []byte(formatTranscriptMarkdown(entries, now))
+	case "json":
+		var err error
+		data, err = json.MarshalIndent(struct {
+			Exported time.Time         `json:"exported"`
+			Entries  []transcriptEntry `json:"entries"`
+		}{now, entries}, "", "  ")
+		if err != nil {
+			return "", err
+		}
+	default:
+		return "", fmt.Errorf("unknown transcript format %q (use md or json)", format)
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, fmt.Sprintf("ai-transcript-%s.%s", now.Format("20060102-150405"), format))
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// exportTranscript saves the AI conversation of this session to
+// ~/k13s-transcripts as Markdown or JSON (:transcript [md|json])
+func (a *App) exportTranscript(format string) {
+	entries := a.transcript.list()
+	if len(entries) == 0 {
+		a.flashMsg("No AI conversation to export yet", true)
+		return
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		a.flashMsg(fmt.Sprintf("Failed to export transcript: %v", err), true)
+		return
+	}
+	path, err := writeTranscript(filepath.Join(home, transcriptsDir), format, entries, time.Now())
+	if err != nil {
+		a.flashMsg(fmt.Sprintf("Failed to export transcript: %v", err), true)
+		return
+	}
+
+	a.recordAudit("ai_transcript", "", path)
+	a.flashMsg("AI transcript saved to "+path, false)
+}
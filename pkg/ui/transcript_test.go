@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteTranscript(t *testing.T) {
+	var transcript aiTranscript
+	transcript.add("question", "Why is web crashing?", "", false)
+	transcript.add("command", "kubectl -n shop logs web-1", "panic: missing DATABASE_URL\n", false)
+	transcript.add("command", "kubectl -n shop delete pod web-1", "Cancelled by user", true)
+	transcript.add("answer", "The DATABASE_URL variable is not set.", "", false)
+	entries := transcript.list()
+
+	dir := t.TempDir()
+	now := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	path, err := writeTranscript(dir, "md", entries, now)
+	if err != nil {
+		t.Fatalf("writeTranscript(md) failed: %v", err)
+	}
+	if !strings.HasSuffix(path, "ai-transcript-20240102-150405.md") {
+		t.Errorf("unexpected path %s", path)
+	}
+	data, _ := os.ReadFile(path)
+	md := string(data)
+	for _, want := range []string{
+		"Question\n\nWhy is web crashing?",
+		"Command (ran)\n\n```\n$ kubectl -n shop logs web-1\npanic: missing DATABASE_URL\n```",
+		"Command (failed)",
+		"Answer\n\nThe DATABASE_URL variable is not set.",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("expected %q in markdown transcript, got:\n%s", want, md)
+		}
+	}
+	if strings.Index(md, "Question") > strings.Index(md, "Answer") {
+		t.Error("expected entries in conversation order")
+	}
+
+	path, err = writeTranscript(dir, "json", entries, now)
+	if err != nil {
+		t.Fatalf("writeTranscript(json) failed: %v", err)
+	}
+	data, _ = os.ReadFile(path)
+	var doc struct {
+		Entries []transcriptEntry `json:"entries"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("invalid JSON transcript: %v", err)
+	}
+	if len(doc.Entries) != 4 || doc.Entries[1].Output != "panic: missing DATABASE_URL\n" || !doc.Entries[2].Error {
+		t.Errorf("unexpected JSON entries %+v", doc.Entries)
+	}
+
+	if _, err := writeTranscript(dir, "pdf", entries, now); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// undoAction reverses the last reversible action, e.g. uncordons a node
+// the user just cordoned
+type undoAction struct {
+	description string // what undoing does, e.g. "uncordon node-1"
+	resource    string // for the audit log, e.g. "nodes/node-1"
+	confirm     string // confirm_actions name of the revert, "" if none
+	kubeContext string // context the action was done in
+	revert      func(ctx context.Context) error
+}
+
+// undoSlot holds the last reversible action. The zero value is ready to
+// use.
+type undoSlot struct {
+	mu   sync.Mutex
+	last *undoAction
+}
+
+// set replaces the last reversible action
+func (u *undoSlot) set(action *undoAction) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.last = action
+}
+
+// take returns the last reversible action, nil if there is none, and
+// forgets it so it is undone at most once
+func (u *undoSlot) take() *undoAction {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	action := u.last
+	u.last = nil
+	return action
+}
+
+// recordUndo remembers how to reverse an action that just succeeded; Ctrl+Z
+// runs revert, asking first if confirm (e.g. "scale-to-0") requires it like
+// the same action done directly would. Only the last action can be undone,
+// and only in the context it was done in.
+func (a *App) recordUndo(description, resource, confirm string, revert func(ctx context.Context) error) {
+	a.undo.set(&undoAction{
+		description: description,
+		resource:    resource,
+		confirm:     confirm,
+		kubeContext: a.kubeContext(),
+		revert:      revert,
+	})
+}
+
+// kubeContext names the current kubeconfig context, "" if unknown
+func (a *App) kubeContext() string {
+	if a.k8s == nil {
+		return ""
+	}
+	name, _ := a.k8s.GetCurrentContext()
+	return name
+}
+
+// undoLast reverses the last reversible action (Ctrl+Z). Must be called
+// from the UI goroutine.
+func (a *App) undoLast() {
+	action := a.undo.take()
+	if action == nil {
+		go a.flashMsg("Nothing to undo (cordon, scale and cronjob suspend can be undone)", false)
+		return
+	}
+	if current := a.kubeContext(); current != action.kubeContext {
+		go a.flashMsg(fmt.Sprintf("Not undoing %s: it was done in context %s, now %s", action.description, action.kubeContext, current), true)
+		return
+	}
+
+	text := fmt.Sprintf("Undo: %s?", action.description)
+	a.confirmAction(action.confirm, "undo-confirm", text, "Undo", action.confirm == "scale-to-0", func() {
+		a.flashMsg(fmt.Sprintf("Undo: %s...", action.description), false)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		if err := action.revert(ctx); err != nil {
+			a.flashMsg(fmt.Sprintf("Undo failed: %s: %v", action.description, err), true)
+			return
+		}
+		a.recordAudit("undo", action.resource, action.description)
+		a.flashMsg(fmt.Sprintf("Undone: %s", action.description), false)
+		a.refresh()
+	})
+}
@@ -0,0 +1,171 @@
+package ui
+
+import (
+	"strings"
+
+	"gopkg.in/yaml.v2"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// diffOp is how a line differs between two texts
+type diffOp int
+
+const (
+	diffSame diffOp = iota
+	diffRemoved
+	diffAdded
+	diffChanged // a removed line shown next to the line that replaced it
+)
+
+// diffLine is a line of a unified diff
+type diffLine struct {
+	op   diffOp
+	text string
+}
+
+// diffRow is a row of a side-by-side diff; a side is empty where the
+// other side has a line the first lacks
+type diffRow struct {
+	op          diffOp
+	left, right string
+}
+
+// maxDiffCells caps the longest common subsequence table of diffLines,
+// which takes a word per cell: about 32 MB
+const maxDiffCells = 1 << 22
+
+// diffLines returns the shortest edit from a to b as removed, added and
+// unchanged lines, using the longest common subsequence. When the texts
+// differ in too many lines for the table it compares them line by line
+// instead and reports false.
+func diffLines(a, b []string) ([]diffLine, bool) {
+	// Common prefix and suffix keep the table small for similar texts
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	ma, mb := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	if (len(ma)+1)*(len(mb)+1) > maxDiffCells {
+		return lineByLine(a, b), false
+	}
+
+	// lcs[i][j] is the LCS length of ma[i:] and mb[j:]
+	lcs := make([][]int, len(ma)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(mb)+1)
+	}
+	for i := len(ma) - 1; i >= 0; i-- {
+		for j := len(mb) - 1; j >= 0; j-- {
+			if ma[i] == mb[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	lines := make([]diffLine, 0, len(a)+len(b))
+	for _, l := range a[:prefix] {
+		lines = append(lines, diffLine{diffSame, l})
+	}
+	i, j := 0, 0
+	for i < len(ma) || j < len(mb) {
+		switch {
+		case i < len(ma) && j < len(mb) && ma[i] == mb[j]:
+			lines = append(lines, diffLine{diffSame, ma[i]})
+			i++
+			j++
+		case i < len(ma) && (j == len(mb) || lcs[i+1][j] >= lcs[i][j+1]):
+			// Removals first, so sideBySide pairs them with what replaced them
+			lines = append(lines, diffLine{diffRemoved, ma[i]})
+			i++
+		default:
+			lines = append(lines, diffLine{diffAdded, mb[j]})
+			j++
+		}
+	}
+	for _, l := range a[len(a)-suffix:] {
+		lines = append(lines, diffLine{diffSame, l})
+	}
+	return lines, true
+}
+
+// lineByLine compares the lines of a and b at the same positions, so a
+// line added or removed shows everything after it as changed
+func lineByLine(a, b []string) []diffLine {
+	lines := make([]diffLine, 0, len(a)+len(b))
+	for k := 0; k < max(len(a), len(b)); k++ {
+		switch {
+		case k < len(a) && k < len(b) && a[k] == b[k]:
+			lines = append(lines, diffLine{diffSame, a[k]})
+		case k < len(a) && k < len(b):
+			lines = append(lines, diffLine{diffRemoved, a[k]}, diffLine{diffAdded, b[k]})
+		case k < len(a):
+			lines = append(lines, diffLine{diffRemoved, a[k]})
+		default:
+			lines = append(lines, diffLine{diffAdded, b[k]})
+		}
+	}
+	return lines
+}
+
+// sideBySide lays out a diff in two columns. A run of removed lines is
+// paired with the added lines that follow it, as changed rows.
+func sideBySide(lines []diffLine) []diffRow {
+	var rows []diffRow
+	for i := 0; i < len(lines); {
+		if lines[i].op == diffSame {
+			rows = append(rows, diffRow{diffSame, lines[i].text, lines[i].text})
+			i++
+			continue
+		}
+
+		var removed, added []string
+		for ; i < len(lines) && lines[i].op == diffRemoved; i++ {
+			removed = append(removed, lines[i].text)
+		}
+		for ; i < len(lines) && lines[i].op == diffAdded; i++ {
+			added = append(added, lines[i].text)
+		}
+		for k := 0; k < max(len(removed), len(added)); k++ {
+			switch {
+			case k < len(removed) && k < len(added):
+				rows = append(rows, diffRow{diffChanged, removed[k], added[k]})
+			case k < len(removed):
+				rows = append(rows, diffRow{diffRemoved, removed[k], ""})
+			default:
+				rows = append(rows, diffRow{diffAdded, "", added[k]})
+			}
+		}
+	}
+	return rows
+}
+
+// volatileFields differ between any two objects and hide the differences
+// that matter when comparing them
+var volatileFields = [][]string{
+	{"metadata", "managedFields"},
+	{"metadata", "uid"},
+	{"metadata", "resourceVersion"},
+	{"metadata", "creationTimestamp"},
+	{"metadata", "generation"},
+}
+
+// comparableYAML renders obj as YAML for a comparison, without the fields
+// that are unique to every object
+func comparableYAML(obj *unstructured.Unstructured) (string, error) {
+	obj = obj.DeepCopy()
+	for _, field := range volatileFields {
+		unstructured.RemoveNestedField(obj.Object, field...)
+	}
+
+	data, err := yaml.Marshal(obj.Object)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\n"), nil
+}
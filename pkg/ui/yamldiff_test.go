@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestSideBySideDiff(t *testing.T) {
+	left := strings.Split("a\nb\nc\nd\ne", "\n")
+	right := strings.Split("a\nB\nc\ne\nf", "\n")
+
+	lines, exact := diffLines(left, right)
+	if !exact {
+		t.Fatal("expected an exact diff of short texts")
+	}
+	rows := sideBySide(lines)
+	want := []diffRow{
+		{diffSame, "a", "a"},
+		{diffChanged, "b", "B"},
+		{diffSame, "c", "c"},
+		{diffRemoved, "d", ""},
+		{diffSame, "e", "e"},
+		{diffAdded, "", "f"},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("expected %d rows, got %+v", len(want), rows)
+	}
+	for i := range want {
+		if rows[i] != want[i] {
+			t.Errorf("row %d: got %+v, want %+v", i, rows[i], want[i])
+		}
+	}
+
+	same, _ := diffLines(left, left)
+	for _, row := range sideBySide(same) {
+		if row.op != diffSame {
+			t.Errorf("expected identical texts to have no differences, got %+v", row)
+		}
+	}
+}
+
+func TestDiffLinesTooLarge(t *testing.T) {
+	// Texts that differ in every line need a table over the cap
+	n := 3000
+	left, right := make([]string, n), make([]string, n+1)
+	for i := 0; i < n; i++ {
+		left[i] = fmt.Sprintf("left-%d", i)
+		right[i] = fmt.Sprintf("right-%d", i)
+	}
+	right[n] = "extra"
+	left[1], right[1] = "same", "same"
+
+	lines, exact := diffLines(left, right)
+	if exact {
+		t.Fatal("expected the line-by-line fallback")
+	}
+	rows := sideBySide(lines)
+	if len(rows) != n+1 {
+		t.Fatalf("expected %d rows, got %d", n+1, len(rows))
+	}
+	if rows[0] != (diffRow{diffChanged, "left-0", "right-0"}) || rows[1] != (diffRow{diffSame, "same", "same"}) ||
+		rows[n] != (diffRow{diffAdded, "", "extra"}) {
+		t.Errorf("unexpected rows: %+v %+v %+v", rows[0], rows[1], rows[n])
+	}
+}
+
+func TestComparableYAML(t *testing.T) {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata": map[string]interface{}{
+			"name":              "web-1",
+			"uid":               "1234",
+			"resourceVersion":   "99",
+			"creationTimestamp": "2024-01-02T15:04:05Z",
+			"managedFields":     []interface{}{map[string]interface{}{"manager": "kubelet"}},
+		},
+		"spec": map[string]interface{}{"nodeName": "node-1"},
+	}}
+
+	text, err := comparableYAML(obj)
+	if err != nil {
+		t.Fatalf("comparableYAML failed: %v", err)
+	}
+	for _, gone := range []string{"uid", "resourceVersion", "creationTimestamp", "managedFields"} {
+		if strings.Contains(text, gone) {
+			t.Errorf("expected %s to be dropped, got:\n%s", gone, text)
+		}
+	}
+	if !strings.Contains(text, "name: web-1") || !strings.Contains(text, "nodeName: node-1") {
+		t.Errorf("expected the rest of the object, got:\n%s", text)
+	}
+	if _, ok := obj.Object["metadata"].(map[string]interface{})["uid"]; !ok {
+		t.Error("expected the original object to be left alone")
+	}
+}
@@ -0,0 +1,169 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/db"
+	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/k8s"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// ActionRequest is the body of POST /api/actions/{scale,restart,delete}
+type ActionRequest struct {
+	Resource  string `json:"resource"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Replicas  *int32 `json:"replicas,omitempty"` // scale only
+}
+
+// ActionResponse reports the outcome of an action and the object's status
+// right after it
+type ActionResponse struct {
+	Action    string             `json:"action"`
+	Resource  string             `json:"resource"`
+	Namespace string             `json:"namespace,omitempty"`
+	Name      string             `json:"name"`
+	Status    string             `json:"status,omitempty"`
+	Rollout   *k8s.RolloutStatus `json:"rollout,omitempty"`
+	Error     string             `json:"error,omitempty"`
+	Hint      string             `json:"hint,omitempty"`
+	Timestamp time.Time          `json:"timestamp"`
+}
+
+// scalableResources and restartableResources list what each action accepts
+var (
+	scalableResources    = map[string]bool{"deployments": true, "statefulsets": true, "replicasets": true}
+	restartableResources = map[string]bool{"deployments": true, "statefulsets": true, "daemonsets": true}
+)
+
+// handleResourceAction runs a mutating action on one object. It must be
+// wrapped with AdminMiddleware; every attempt is recorded in the audit log.
+func (s *Server) handleResourceAction(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	action := strings.TrimPrefix(r.URL.Path, "/api/actions/")
+	if action != "scale" && action != "restart" && action != "delete" {
+		http.Error(w, fmt.Sprintf("Unknown action: %s", action), http.StatusNotFound)
+		return
+	}
+
+	var req ActionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.Resource == "" || req.Name == "" {
+		http.Error(w, "resource and name are required", http.StatusBadRequest)
+		return
+	}
+
+	gvr, ok := s.k8sClient.GetGVR(req.Resource)
+	if !ok {
+		http.Error(w, fmt.Sprintf("Unknown resource type: %s", req.Resource), http.StatusNotFound)
+		return
+	}
+
+	switch action {
+	case "scale":
+		if !scalableResources[gvr.Resource] {
+			http.Error(w, fmt.Sprintf("%s cannot be scaled", gvr.Resource), http.StatusBadRequest)
+			return
+		}
+		if req.Replicas == nil || *req.Replicas < 0 {
+			http.Error(w, "replicas must be a non-negative number", http.StatusBadRequest)
+			return
+		}
+	case "restart":
+		if !restartableResources[gvr.Resource] {
+			http.Error(w, fmt.Sprintf("%s cannot be restarted", gvr.Resource), http.StatusBadRequest)
+			return
+		}
+	}
+
+	details := fmt.Sprintf("namespace=%s name=%s", req.Namespace, req.Name)
+	if action == "scale" {
+		details += fmt.Sprintf(" replicas=%d", *req.Replicas)
+	}
+
+	var err error
+	switch action {
+	case "scale":
+		err = s.k8sClient.ScaleResource(r.Context(), gvr, req.Namespace, req.Name, *req.Replicas)
+	case "restart":
+		err = s.k8sClient.RolloutRestart(r.Context(), gvr, req.Namespace, req.Name)
+	case "delete":
+		err = s.k8sClient.DeleteResource(r.Context(), gvr, req.Namespace, req.Name)
+	}
+
+	if err != nil {
+		details += fmt.Sprintf(" error=%v", err)
+	}
+	username := r.Header.Get("X-Username")
+	if username == "" {
+		username = "anonymous"
+	}
+	db.RecordAudit(db.AuditEntry{
+		User:     username,
+		Action:   action,
+		Resource: gvr.Resource,
+		Details:  details,
+	})
+
+	resp := ActionResponse{
+		Action:    action,
+		Resource:  gvr.Resource,
+		Namespace: req.Namespace,
+		Name:      req.Name,
+		Timestamp: time.Now(),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err != nil {
+		ce := k8s.ClassifyError(err, gvr.Resource, req.Namespace)
+		resp.Error = ce.Message
+		resp.Hint = ce.Suggestion
+		w.WriteHeader(actionErrorStatus(err))
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+
+	// Report the status right after the action
+	if action == "delete" {
+		obj, getErr := s.k8sClient.GetResource(r.Context(), req.Namespace, req.Name, gvr)
+		switch {
+		case apierrors.IsNotFound(getErr):
+			resp.Status = "Deleted"
+		case getErr == nil && k8s.IsTerminating(obj):
+			resp.Status = "Terminating"
+		default:
+			resp.Status = "Delete requested"
+		}
+	} else if st, stErr := s.k8sClient.GetRolloutStatus(r.Context(), gvr, req.Namespace, req.Name); stErr == nil {
+		resp.Status = st.Message
+		resp.Rollout = &st
+	}
+
+	json.NewEncoder(w).Encode(resp)
+}
+
+// actionErrorStatus returns the HTTP status of a failed action: the API
+// server's own status, e.g. 403, 404 or 409, or 502 Bad Gateway when it sent
+// none. A 401 from the API server rejects our cluster credentials, not the
+// caller's session, so it is reported as 502 as well.
+func actionErrorStatus(err error) int {
+	var status apierrors.APIStatus
+	if errors.As(err, &status) {
+		if code := int(status.Status().Code); code >= 400 && code != http.StatusUnauthorized {
+			return code
+		}
+	}
+	return http.StatusBadGateway
+}
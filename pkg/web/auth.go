@@ -9,10 +9,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"os"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/config"
+	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/db"
 	authv1 "k8s.io/api/authentication/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
@@ -24,10 +28,10 @@ type User struct {
 	ID           string    `json:"id"`
 	Username     string    `json:"username"`
 	PasswordHash string    `json:"-"`
-	Role         string    `json:"role"` // admin, user, viewer
+	Role         string    `json:"role"` // viewer, operator or admin, see roles.go
 	Email        string    `json:"email,omitempty"`
 	DisplayName  string    `json:"display_name,omitempty"`
-	Source       string    `json:"source"` // local, ldap
+	Source       string    `json:"source"` // local, ldap, oidc
 	CreatedAt    time.Time `json:"created_at"`
 	LastLogin    time.Time `json:"last_login,omitempty"`
 }
@@ -38,7 +42,7 @@ type Session struct {
 	UserID    string    `json:"user_id"`
 	Username  string    `json:"username"`
 	Role      string    `json:"role"`
-	Source    string    `json:"source"` // local, ldap
+	Source    string    `json:"source"` // local, ldap, k8s-token, oidc
 	CreatedAt time.Time `json:"created_at"`
 	ExpiresAt time.Time `json:"expires_at"`
 }
@@ -47,11 +51,12 @@ type Session struct {
 type AuthManager struct {
 	users          map[string]*User    // username -> User
 	sessions       map[string]*Session // session ID -> Session
-	tokenSessions  map[string]*Session // K8s token -> Session (cached)
+	tokenSessions  map[string]*Session // K8s or OIDC token -> Session (cached)
 	mu             sync.RWMutex
 	config         *AuthConfig
 	ldapProvider   *LDAPProvider
 	tokenValidator *K8sTokenValidator
+	oidcProvider   *OIDCProvider
 }
 
 // AuthConfig holds authentication configuration
@@ -61,8 +66,10 @@ type AuthConfig struct {
 	DefaultAdmin    string        `yaml:"default_admin" json:"default_admin"`
 	DefaultPassword string        `yaml:"default_password" json:"-"`
 	LDAP            *LDAPConfig   `yaml:"ldap" json:"ldap"`
-	// AuthMode: "token" (K8s RBAC token - default), "local" (username/password), "ldap"
-	AuthMode string `yaml:"auth_mode" json:"auth_mode"`
+	// AuthMode: "token" (K8s RBAC token - default), "local" (username/password), "ldap",
+	// "oidc" (OIDC ID tokens as bearer tokens, plus local users)
+	AuthMode string             `yaml:"auth_mode" json:"auth_mode"`
+	OIDC     *config.OIDCConfig `yaml:"oidc" json:"oidc"`
 }
 
 // K8sTokenValidator validates Kubernetes service account tokens
@@ -119,6 +126,19 @@ type TokenReview struct {
 	Groups        []string `json:"groups"`
 }
 
+// InsecureDefaultPassword is the default admin password of earlier
+// versions. The web server refuses it unless started with
+// -insecure-default-admin.
+const InsecureDefaultPassword = "admin123"
+
+// shippedDefaultPasswords are the passwords earlier releases shipped in
+// their Docker images, compose file and Kubernetes manifest; like
+// InsecureDefaultPassword they are refused without -insecure-default-admin
+var shippedDefaultPasswords = map[string]bool{
+	InsecureDefaultPassword: true,
+	"admin":                 true,
+}
+
 // NewAuthManager creates a new authentication manager
 func NewAuthManager(cfg *AuthConfig) *AuthManager {
 	am := &AuthManager{
@@ -150,17 +170,39 @@ func NewAuthManager(cfg *AuthConfig) *AuthManager {
 		am.ldapProvider = NewLDAPProvider(cfg.LDAP)
 	}
 
-	// Create default admin user only for local auth mode
-	if cfg.Enabled && cfg.AuthMode == "local" {
+	// Initialize the OIDC provider. An unreachable issuer is not fatal:
+	// its keys are fetched again when the first token comes in.
+	if cfg.AuthMode == config.AuthModeOIDC {
+		if cfg.OIDC == nil {
+			fmt.Printf("  OIDC: Not configured\n")
+		} else if provider, err := NewOIDCProvider(cfg.OIDC); err != nil {
+			fmt.Printf("  OIDC: %v\n", err)
+		} else {
+			am.oidcProvider = provider
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			if err := provider.Discover(ctx); err != nil {
+				fmt.Printf("  OIDC: Issuer unreachable, retrying on first token (%v)\n", err)
+			} else {
+				fmt.Printf("  OIDC: Ready (%s)\n", cfg.OIDC.IssuerURL)
+			}
+			cancel()
+		}
+	}
+
+	// Create default admin user for local auth, which OIDC mode keeps
+	if cfg.Enabled && (cfg.AuthMode == config.AuthModeLocal || cfg.AuthMode == config.AuthModeOIDC) {
 		adminUser := cfg.DefaultAdmin
 		if adminUser == "" {
 			adminUser = "admin"
 		}
 		adminPass := cfg.DefaultPassword
 		if adminPass == "" {
-			adminPass = "admin123" // Default password - should be changed
+			// Shown once; users live in memory, so every start gets a new one
+			adminPass = generateSessionID()[:20]
+			fmt.Fprintf(os.Stderr, "  Generated password for %q: %s\n", adminUser, adminPass)
+			fmt.Fprintf(os.Stderr, "  Set K13S_ADMIN_PASSWORD to choose your own.\n")
 		}
-		am.createLocalUser(adminUser, adminPass, "admin")
+		am.createLocalUser(adminUser, adminPass, RoleAdmin)
 	}
 
 	return am
@@ -202,8 +244,13 @@ func generateSessionID() string {
 	return base64.URLEncoding.EncodeToString(b)
 }
 
-// CreateUser creates a new local user
+// CreateUser creates a new local user. An empty role is DefaultRole.
 func (am *AuthManager) CreateUser(username, password, role string) error {
+	role, err := NormalizeRole(role)
+	if err != nil {
+		return err
+	}
+
 	am.mu.Lock()
 	defer am.mu.Unlock()
 
@@ -434,11 +481,40 @@ func (am *AuthManager) DeleteUser(username string) error {
 	am.mu.Lock()
 	defer am.mu.Unlock()
 
-	if _, exists := am.users[username]; !exists {
+	user, exists := am.users[username]
+	if !exists {
 		return fmt.Errorf("user not found")
 	}
 
 	delete(am.users, username)
+	for id, session := range am.sessions {
+		if session.UserID == user.ID {
+			delete(am.sessions, id)
+		}
+	}
+	return nil
+}
+
+// SetUserRole changes the role of a user, including in their open sessions
+func (am *AuthManager) SetUserRole(username, role string) error {
+	role, err := NormalizeRole(role)
+	if err != nil {
+		return err
+	}
+
+	am.mu.Lock()
+	defer am.mu.Unlock()
+
+	user, exists := am.users[username]
+	if !exists {
+		return fmt.Errorf("user not found")
+	}
+	user.Role = role
+	for _, session := range am.sessions {
+		if session.UserID == user.ID {
+			session.Role = role
+		}
+	}
 	return nil
 }
 
@@ -467,6 +543,20 @@ func (am *AuthManager) AuthMiddleware(next http.HandlerFunc) http.HandlerFunc {
 			}
 		}
 
+		// In OIDC mode a bearer JWT is an ID token of the issuer
+		if am.config.AuthMode == config.AuthModeOIDC && looksLikeJWT(token) {
+			session, err := am.ValidateOIDCToken(r.Context(), token)
+			if err != nil {
+				http.Error(w, "Unauthorized: "+err.Error(), http.StatusUnauthorized)
+				return
+			}
+			r.Header.Set("X-User-ID", session.UserID)
+			r.Header.Set("X-Username", session.Username)
+			r.Header.Set("X-User-Role", session.Role)
+			next(w, r)
+			return
+		}
+
 		// For token auth mode, try K8s token validation first
 		if am.config.AuthMode == "token" && token != "" {
 			session, err := am.ValidateK8sToken(r.Context(), token)
@@ -505,6 +595,12 @@ func (am *AuthManager) AuthMiddleware(next http.HandlerFunc) http.HandlerFunc {
 	}
 }
 
+// AdminMiddleware wraps handlers to require an authenticated admin. With
+// auth disabled every caller is treated as admin, as in HandleCurrentUser.
+func (am *AuthManager) AdminMiddleware(next http.HandlerFunc) http.HandlerFunc {
+	return am.RequireRole(RoleAdmin)(next)
+}
+
 // ValidateK8sToken validates a Kubernetes service account token
 func (am *AuthManager) ValidateK8sToken(ctx context.Context, token string) (*Session, error) {
 	// Check cache first
@@ -528,14 +624,14 @@ func (am *AuthManager) ValidateK8sToken(ctx context.Context, token string) (*Ses
 	}
 
 	// Determine role from groups
-	role := "viewer"
+	role := DefaultRole
 	for _, group := range review.Groups {
 		if strings.Contains(group, "admin") || strings.Contains(group, "cluster-admin") {
-			role = "admin"
+			role = RoleAdmin
 			break
 		}
 		if strings.Contains(group, "edit") || strings.Contains(group, "developer") {
-			role = "user"
+			role = RoleOperator
 		}
 	}
 
@@ -558,11 +654,58 @@ func (am *AuthManager) ValidateK8sToken(ctx context.Context, token string) (*Ses
 	return session, nil
 }
 
+// ValidateOIDCToken validates an OIDC ID token and returns its session,
+// which is cached until the token or the session duration expires
+func (am *AuthManager) ValidateOIDCToken(ctx context.Context, token string) (*Session, error) {
+	am.mu.RLock()
+	if session, exists := am.tokenSessions[token]; exists {
+		if time.Now().Before(session.ExpiresAt) {
+			am.mu.RUnlock()
+			return session, nil
+		}
+	}
+	am.mu.RUnlock()
+
+	if am.oidcProvider == nil {
+		return nil, fmt.Errorf("OIDC is not configured")
+	}
+
+	review, expiry, err := am.oidcProvider.ValidateToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(am.config.SessionDuration)
+	if expiry.Before(expiresAt) {
+		expiresAt = expiry
+	}
+	session := &Session{
+		ID:        generateSessionID(),
+		UserID:    review.UID,
+		Username:  review.Username,
+		Role:      am.oidcProvider.Role(review.Groups),
+		Source:    "oidc",
+		CreatedAt: time.Now(),
+		ExpiresAt: expiresAt,
+	}
+
+	am.mu.Lock()
+	for t, s := range am.tokenSessions {
+		if time.Now().After(s.ExpiresAt) {
+			delete(am.tokenSessions, t)
+		}
+	}
+	am.tokenSessions[token] = session
+	am.mu.Unlock()
+
+	return session, nil
+}
+
 // LoginRequest represents a login request
 type LoginRequest struct {
 	Username string `json:"username,omitempty"`
 	Password string `json:"password,omitempty"`
-	Token    string `json:"token,omitempty"` // K8s service account token
+	Token    string `json:"token,omitempty"` // K8s service account token, or OIDC ID token in oidc mode
 }
 
 // LoginResponse represents a login response
@@ -590,8 +733,32 @@ func (am *AuthManager) HandleLogin(w http.ResponseWriter, r *http.Request) {
 	var session *Session
 	var err error
 
-	// Handle token-based login (K8s RBAC)
-	if req.Token != "" {
+	// Handle OIDC ID token login, which trades the token for a session cookie
+	if req.Token != "" && am.config.AuthMode == config.AuthModeOIDC {
+		oidcSession, err := am.ValidateOIDCToken(r.Context(), req.Token)
+		if err != nil {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(map[string]string{
+				"error": "Invalid OIDC token: " + err.Error(),
+			})
+			return
+		}
+		// The cookie names a session of its own, not the cached token
+		session = &Session{
+			ID:        generateSessionID(),
+			UserID:    oidcSession.UserID,
+			Username:  oidcSession.Username,
+			Role:      oidcSession.Role,
+			Source:    oidcSession.Source,
+			CreatedAt: time.Now(),
+			ExpiresAt: oidcSession.ExpiresAt,
+		}
+		am.mu.Lock()
+		am.sessions[session.ID] = session
+		am.mu.Unlock()
+	} else if req.Token != "" {
+		// Handle token-based login (K8s RBAC)
 		session, err = am.ValidateK8sToken(r.Context(), req.Token)
 		if err != nil {
 			w.Header().Set("Content-Type", "application/json")
@@ -682,6 +849,85 @@ func (am *AuthManager) HandleCurrentUser(w http.ResponseWriter, r *http.Request)
 	})
 }
 
+// UserRequest creates a user or changes a user's role on /api/auth/users
+type UserRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password,omitempty"`
+	Role     string `json:"role"`
+}
+
+// HandleUsers lets admins manage users: GET lists them, POST creates a
+// local user, PUT changes a role and DELETE ?username= removes a user.
+// Admins cannot change their own role or delete themselves, so at least
+// one admin remains.
+func (am *AuthManager) HandleUsers(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	current := r.Header.Get("X-Username")
+
+	switch r.Method {
+	case http.MethodGet:
+		users := am.GetUsers()
+		sort.Slice(users, func(i, j int) bool { return users[i].Username < users[j].Username })
+		json.NewEncoder(w).Encode(users)
+		return
+
+	case http.MethodPost, http.MethodPut:
+		var req UserRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Username == "" {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+
+		var err error
+		if r.Method == http.MethodPost {
+			if req.Password == "" {
+				http.Error(w, "Password is required", http.StatusBadRequest)
+				return
+			}
+			err = am.CreateUser(req.Username, req.Password, req.Role)
+		} else {
+			if req.Username == current {
+				http.Error(w, "You cannot change your own role", http.StatusBadRequest)
+				return
+			}
+			err = am.SetUserRole(req.Username, req.Role)
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		db.RecordAudit(db.AuditEntry{
+			User:     current,
+			Action:   "manage_user",
+			Resource: "user/" + req.Username,
+			Details:  fmt.Sprintf("%s role=%s", r.Method, req.Role),
+		})
+
+	case http.MethodDelete:
+		username := r.URL.Query().Get("username")
+		if username == "" || username == current {
+			http.Error(w, "Invalid username", http.StatusBadRequest)
+			return
+		}
+		if err := am.DeleteUser(username); err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		db.RecordAudit(db.AuditEntry{
+			User:     current,
+			Action:   "manage_user",
+			Resource: "user/" + username,
+			Details:  "DELETE",
+		})
+
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+}
+
 // HandleLDAPStatus returns LDAP configuration status
 func (am *AuthManager) HandleLDAPStatus(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
@@ -7,6 +7,8 @@ import (
 	"net/http/httptest"
 	"testing"
 	"time"
+
+	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/config"
 )
 
 func TestNewAuthManager(t *testing.T) {
@@ -36,6 +38,52 @@ func TestNewAuthManager(t *testing.T) {
 	}
 }
 
+func TestNewAuthManager_GeneratedPassword(t *testing.T) {
+	am := NewAuthManager(&AuthConfig{
+		Enabled:         true,
+		SessionDuration: time.Hour,
+		AuthMode:        "local",
+	})
+
+	if _, err := am.Authenticate("admin", InsecureDefaultPassword); err == nil {
+		t.Error("default admin accepted the well-known password without it being configured")
+	}
+	if am.users["admin"].PasswordHash == hashPassword("") {
+		t.Error("default admin got an empty password")
+	}
+}
+
+func TestDefaultAdminPassword(t *testing.T) {
+	tests := []struct {
+		env      string
+		oldEnv   string
+		insecure bool
+		want     string
+		wantErr  bool
+	}{
+		{env: "", want: ""},
+		{oldEnv: "old-pass", want: "old-pass"},
+		{env: "s3cret-pass", oldEnv: "old-pass", want: "s3cret-pass"},
+		{oldEnv: InsecureDefaultPassword, wantErr: true},
+		{env: "admin", wantErr: true},
+		{oldEnv: "admin", wantErr: true},
+		{oldEnv: "admin", insecure: true, want: "admin"},
+		{env: "s3cret-pass", want: "s3cret-pass"},
+		{env: InsecureDefaultPassword, wantErr: true},
+		{env: InsecureDefaultPassword, insecure: true, want: InsecureDefaultPassword},
+		{env: "", insecure: true, want: InsecureDefaultPassword},
+		{env: "s3cret-pass", insecure: true, want: "s3cret-pass"},
+	}
+	for _, tt := range tests {
+		t.Setenv("K13S_ADMIN_PASSWORD", tt.env)
+		t.Setenv("K13S_PASSWORD", tt.oldEnv)
+		got, err := defaultAdminPassword(&config.AuthConfig{InsecureDefaultAdmin: tt.insecure})
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("env %q, old env %q, insecure %v: got %q, %v; want %q, error %v", tt.env, tt.oldEnv, tt.insecure, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
 func TestNewAuthManager_TokenMode(t *testing.T) {
 	// Test with "token" auth mode (no default admin user, K8s token auth)
 	am := NewAuthManager(&AuthConfig{
@@ -473,3 +521,134 @@ func TestAuthManager_HandleLogin_WithToken(t *testing.T) {
 		t.Errorf("HandleLogin() with token status = %d, want %d (unauthorized because no K8s cluster)", w.Code, http.StatusUnauthorized)
 	}
 }
+
+func TestNormalizeRole(t *testing.T) {
+	tests := map[string]string{
+		"":         RoleViewer,
+		"viewer":   RoleViewer,
+		"Operator": RoleOperator,
+		"user":     RoleOperator,
+		"admin":    RoleAdmin,
+	}
+	for in, want := range tests {
+		if got, err := NormalizeRole(in); err != nil || got != want {
+			t.Errorf("NormalizeRole(%q) = %q, %v, want %q", in, got, err, want)
+		}
+	}
+	if _, err := NormalizeRole("root"); err == nil {
+		t.Error("expected an error for an unknown role")
+	}
+}
+
+func TestRequireRole(t *testing.T) {
+	am := NewAuthManager(&AuthConfig{
+		Enabled:         true,
+		SessionDuration: time.Hour,
+		AuthMode:        "local",
+		DefaultAdmin:    "admin",
+		DefaultPassword: "admin",
+	})
+	am.CreateUser("viewer", "viewer", "")
+	am.CreateUser("operator", "operator", RoleOperator)
+
+	sessions := map[string]*Session{}
+	for _, name := range []string{"viewer", "operator", "admin"} {
+		session, err := am.Authenticate(name, name)
+		if err != nil {
+			t.Fatalf("login %s: %v", name, err)
+		}
+		sessions[name] = session
+	}
+	if sessions["viewer"].Role != DefaultRole {
+		t.Errorf("user created without a role got %q, want %q", sessions["viewer"].Role, DefaultRole)
+	}
+
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	status := func(handler http.HandlerFunc, method, user string) int {
+		req := httptest.NewRequest(method, "/api/test", nil)
+		req.Header.Set("Authorization", "Bearer "+sessions[user].ID)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	tests := []struct {
+		required string
+		user     string
+		want     int
+	}{
+		{RoleViewer, "viewer", http.StatusOK},
+		{RoleOperator, "viewer", http.StatusForbidden},
+		{RoleOperator, "operator", http.StatusOK},
+		{RoleAdmin, "operator", http.StatusForbidden},
+		{RoleAdmin, "admin", http.StatusOK},
+	}
+	for _, tt := range tests {
+		if got := status(am.RequireRole(tt.required)(ok), http.MethodGet, tt.user); got != tt.want {
+			t.Errorf("RequireRole(%s) for %s = %d, want %d", tt.required, tt.user, got, tt.want)
+		}
+	}
+
+	writes := am.RequireRoleToWrite(RoleAdmin)(ok)
+	if got := status(writes, http.MethodGet, "viewer"); got != http.StatusOK {
+		t.Errorf("viewer GET = %d, want 200", got)
+	}
+	if got := status(writes, http.MethodPut, "operator"); got != http.StatusForbidden {
+		t.Errorf("operator PUT = %d, want 403", got)
+	}
+}
+
+func TestAuthManager_HandleUsers(t *testing.T) {
+	am := NewAuthManager(&AuthConfig{
+		Enabled:         true,
+		SessionDuration: time.Hour,
+		AuthMode:        "local",
+		DefaultAdmin:    "admin",
+		DefaultPassword: "admin",
+	})
+	admin, _ := am.Authenticate("admin", "admin")
+	handler := am.AdminMiddleware(am.HandleUsers)
+
+	do := func(method, url, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, url, bytes.NewBufferString(body))
+		req.Header.Set("Authorization", "Bearer "+admin.ID)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := do(http.MethodPost, "/api/auth/users", `{"username":"alice","password":"secret"}`); w.Code != http.StatusOK {
+		t.Fatalf("create user: %d %s", w.Code, w.Body.String())
+	}
+	if w := do(http.MethodPost, "/api/auth/users", `{"username":"bob","password":"secret","role":"root"}`); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an unknown role, got %d", w.Code)
+	}
+
+	alice, err := am.Authenticate("alice", "secret")
+	if err != nil || alice.Role != RoleViewer {
+		t.Fatalf("alice should be a viewer: %+v %v", alice, err)
+	}
+
+	if w := do(http.MethodPut, "/api/auth/users", `{"username":"alice","role":"operator"}`); w.Code != http.StatusOK {
+		t.Fatalf("set role: %d %s", w.Code, w.Body.String())
+	}
+	if session, _ := am.ValidateSession(alice.ID); session.Role != RoleOperator {
+		t.Errorf("open session kept role %q", session.Role)
+	}
+	if w := do(http.MethodPut, "/api/auth/users", `{"username":"admin","role":"viewer"}`); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for changing one's own role, got %d", w.Code)
+	}
+
+	var users []User
+	json.Unmarshal(do(http.MethodGet, "/api/auth/users", "").Body.Bytes(), &users)
+	if len(users) != 2 || users[0].Username != "admin" || users[1].Role != RoleOperator {
+		t.Errorf("unexpected users: %+v", users)
+	}
+
+	if w := do(http.MethodDelete, "/api/auth/users?username=alice", ""); w.Code != http.StatusOK {
+		t.Fatalf("delete user: %d %s", w.Code, w.Body.String())
+	}
+	if _, err := am.ValidateSession(alice.ID); err == nil {
+		t.Error("sessions of a deleted user should end")
+	}
+}
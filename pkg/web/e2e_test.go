@@ -1,17 +1,31 @@
 package web
 
 import (
+	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
+	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/ai"
 	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/config"
+	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/db"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
 	"k8s.io/client-go/kubernetes/fake"
 
 	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/k8s"
@@ -238,6 +252,147 @@ func TestE2E_HealthEndpoint(t *testing.T) {
 	}
 }
 
+// E2E Test: Liveness and readiness probes
+func TestE2E_ProbeEndpoints(t *testing.T) {
+	server, _ := setupTestServer(t)
+
+	w := httptest.NewRecorder()
+	server.handleHealthz(w, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("healthz: expected 200, got %d", w.Code)
+	}
+
+	prevDB := db.DB
+	defer func() { db.DB = prevDB }()
+
+	db.DB = nil
+	w = httptest.NewRecorder()
+	server.handleReadyz(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if w.Code != http.StatusServiceUnavailable || !strings.Contains(w.Body.String(), "database") {
+		t.Errorf("readyz without database: expected 503, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if err := db.Init(filepath.Join(t.TempDir(), "audit.db")); err != nil {
+		t.Fatalf("db.Init failed: %v", err)
+	}
+	defer db.Close()
+
+	w = httptest.NewRecorder()
+	server.handleReadyz(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("readyz: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	server.k8sClient = nil
+	w = httptest.NewRecorder()
+	server.handleReadyz(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if w.Code != http.StatusServiceUnavailable || !strings.Contains(w.Body.String(), "k8s") {
+		t.Errorf("readyz without k8s client: expected 503, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+// startTestServer runs server.Start in the background and waits until it
+// has registered its http.Server; the returned channel yields Start's error
+func startTestServer(t *testing.T, server *Server) <-chan error {
+	t.Helper()
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.Start()
+	}()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		server.serverMu.Lock()
+		started := server.server != nil
+		server.serverMu.Unlock()
+		if started {
+			return errCh
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("server did not start")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// E2E Test: Stop shuts the HTTP server down and Start returns cleanly
+func TestE2E_GracefulStop(t *testing.T) {
+	server, _ := setupTestServer(t)
+	server.port = 0
+	server.cfg.BindAddress = "127.0.0.1"
+
+	errCh := startTestServer(t, server)
+
+	if err := server.Stop(); err != nil {
+		t.Fatalf("Stop failed: %v", err)
+	}
+	select {
+	case err := <-errCh:
+		if err != nil {
+			t.Errorf("Start returned %v after Stop, expected nil", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Start did not return after Stop")
+	}
+}
+
+// E2E Test: Stop ends open SSE streams instead of waiting out shutdownTimeout
+func TestE2E_StopEndsStreams(t *testing.T) {
+	server, authManager := setupTestServer(t)
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.port = l.Addr().(*net.TCPAddr).Port
+	l.Close()
+	server.cfg.BindAddress = "127.0.0.1"
+
+	errCh := startTestServer(t, server)
+
+	session, err := authManager.Authenticate("admin", "admin123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("http://127.0.0.1:%d/api/k8s/watch/pods?namespace=default", server.port), nil)
+	req.AddCookie(&http.Cookie{Name: "k13s_session", Value: session.ID})
+
+	var resp *http.Response
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		resp, err = http.DefaultClient.Do(req)
+		if err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("watch request: %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("watch: expected 200, got %d", resp.StatusCode)
+	}
+	if _, err := bufio.NewReader(resp.Body).ReadString('\n'); err != nil {
+		t.Fatalf("reading stream: %v", err)
+	}
+
+	start := time.Now()
+	if err := server.Stop(); err != nil {
+		t.Fatalf("Stop with an open stream: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > shutdownTimeout/2 {
+		t.Errorf("Stop took %v with an open stream", elapsed)
+	}
+	select {
+	case err := <-errCh:
+		if err != nil {
+			t.Errorf("Start returned %v after Stop, expected nil", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Start did not return after Stop")
+	}
+}
+
 // E2E Test: Settings endpoint
 func TestE2E_SettingsEndpoint(t *testing.T) {
 	server, authManager := setupTestServer(t)
@@ -281,8 +436,9 @@ func TestE2E_UserManagement(t *testing.T) {
 		t.Fatalf("failed to login with new user: %v", err)
 	}
 
-	if session.Role != "user" {
-		t.Errorf("expected role 'user', got %s", session.Role)
+	// "user" is the operator role of earlier versions
+	if session.Role != RoleOperator {
+		t.Errorf("expected role 'operator', got %s", session.Role)
 	}
 
 	// Change password
@@ -452,6 +608,366 @@ func TestE2E_ReportsComprehensive(t *testing.T) {
 	}
 }
 
+// E2E Test: Namespace-scoped comprehensive report
+func TestE2E_ReportsNamespaceScoped(t *testing.T) {
+	server, authManager := setupTestServer(t)
+
+	session, _ := authManager.Authenticate("admin", "admin123")
+
+	_, err := server.k8sClient.Clientset.CoreV1().Pods("other").Create(context.Background(), &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "other-pod", Namespace: "other"},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("failed to create pod: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/reports?namespace=default", nil)
+	req.Header.Set("Authorization", "Bearer "+session.ID)
+	w := httptest.NewRecorder()
+	authManager.AuthMiddleware(http.HandlerFunc(server.reportGenerator.HandleReports)).ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var report ComprehensiveReport
+	if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if report.Namespace != "default" {
+		t.Errorf("expected namespace default, got %q", report.Namespace)
+	}
+	if len(report.Nodes) != report.NodeSummary.Total || report.NodeSummary.Total == 0 {
+		t.Errorf("expected cluster-wide node data in a namespace-scoped report, got %d of %d nodes", len(report.Nodes), report.NodeSummary.Total)
+	}
+	for _, nc := range report.CostEstimate.Namespaces {
+		if nc.Namespace != "default" {
+			t.Errorf("unexpected namespace %s in the cost estimate", nc.Namespace)
+		}
+	}
+	if len(report.Namespaces) != 1 || report.Namespaces[0].Name != "default" {
+		t.Errorf("expected only the default namespace, got %+v", report.Namespaces)
+	}
+	for _, pod := range report.Pods {
+		if pod.Namespace != "default" {
+			t.Errorf("unexpected pod %s/%s in namespace-scoped report", pod.Namespace, pod.Name)
+		}
+	}
+	if report.Workloads.TotalPods != 1 {
+		t.Errorf("expected 1 pod, got %d", report.Workloads.TotalPods)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/reports?namespace=Bad_NS", nil)
+	req.Header.Set("Authorization", "Bearer "+session.ID)
+	w = httptest.NewRecorder()
+	authManager.AuthMiddleware(http.HandlerFunc(server.reportGenerator.HandleReports)).ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an invalid namespace, got %d", w.Code)
+	}
+}
+
+// E2E Test: Report image list keeps registry ports out of the tag
+func TestE2E_ReportsImageRegistryPort(t *testing.T) {
+	server, _ := setupTestServer(t)
+
+	_, err := server.k8sClient.Clientset.CoreV1().Pods("default").Create(context.Background(), &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "private-image", Namespace: "default"},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "myregistry:5000/app:1.2"}}},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("failed to create pod: %v", err)
+	}
+
+	report, err := server.reportGenerator.GenerateComprehensiveReport(context.Background(), "test", "default")
+	if err != nil {
+		t.Fatalf("GenerateComprehensiveReport failed: %v", err)
+	}
+	for _, img := range report.Images {
+		if img.Image != "myregistry:5000/app:1.2" {
+			continue
+		}
+		if img.Registry != "myregistry:5000" || img.Repository != "app" || img.Tag != "1.2" {
+			t.Errorf("unexpected parse of %s: %+v", img.Image, img)
+		}
+		return
+	}
+	t.Errorf("image not found in report: %+v", report.Images)
+}
+
+// E2E Test: Inventory report counts every listable resource kind
+func TestE2E_ReportsInventory(t *testing.T) {
+	server, _ := setupTestServer(t)
+
+	server.k8sClient.Clientset.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{
+		{GroupVersion: "v1", APIResources: []metav1.APIResource{
+			{Name: "configmaps", Kind: "ConfigMap", Namespaced: true, Verbs: []string{"list"}},
+		}},
+	}
+	configmaps := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	cm := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"name": "settings", "namespace": "default"},
+	}}
+	server.k8sClient.Dynamic = dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{configmaps: "ConfigMapList"}, cm)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/reports?type=inventory", nil)
+	w := httptest.NewRecorder()
+	server.reportGenerator.HandleReports(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if !strings.Contains(w.Header().Get("Content-Disposition"), "k13s-inventory-") {
+		t.Errorf("expected inventory attachment, got %q", w.Header().Get("Content-Disposition"))
+	}
+	var inv k8s.Inventory
+	if err := json.Unmarshal(w.Body.Bytes(), &inv); err != nil {
+		t.Fatalf("failed to parse inventory: %v", err)
+	}
+	if len(inv.Resources) != 1 || inv.Resources[0].Count != 1 || inv.Resources[0].Namespaces["default"] != 1 {
+		t.Errorf("unexpected inventory: %+v", inv)
+	}
+}
+
+// E2E Test: Security report contains only the security posture
+func TestE2E_ReportsSecurity(t *testing.T) {
+	server, _ := setupTestServer(t)
+
+	privileged := true
+	_, err := server.k8sClient.Clientset.AppsV1().Deployments("default").Create(context.Background(), &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default"},
+		Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "agent", SecurityContext: &corev1.SecurityContext{Privileged: &privileged}}},
+		}}},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("failed to create deployment: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/reports?type=security&namespace=default", nil)
+	w := httptest.NewRecorder()
+	server.reportGenerator.HandleReports(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var report SecurityReport
+	if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+		t.Fatalf("failed to parse security report: %v", err)
+	}
+	if report.Namespace != "default" || report.FindingSummary.Critical != 1 {
+		t.Errorf("expected one critical finding, got %+v", report.FindingSummary)
+	}
+	if len(report.Findings) == 0 || report.Findings[0].Check != k8s.CheckPrivilegedContainer {
+		t.Errorf("expected privileged container finding first, got %+v", report.Findings)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/reports?type=security&format=md", nil)
+	w = httptest.NewRecorder()
+	server.reportGenerator.HandleReports(w, req)
+	if !strings.Contains(w.Header().Get("Content-Disposition"), "k13s-security-") || !strings.Contains(w.Body.String(), "# K13s Cluster Security Report") {
+		t.Errorf("expected Markdown security report attachment, got %q", w.Header().Get("Content-Disposition"))
+	}
+}
+
+// E2E Test: Report AI analysis is streamed as server-sent events
+func TestE2E_ReportAIStream(t *testing.T) {
+	server, authManager := setupTestServer(t)
+	session, _ := authManager.Authenticate("admin", "admin123")
+
+	do := func(method, url, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, url, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+session.ID)
+		w := httptest.NewRecorder()
+		authManager.AuthMiddleware(http.HandlerFunc(server.reportGenerator.HandleReportAIStream)).ServeHTTP(w, req)
+		return w
+	}
+	const report = `{"namespace":"shop","health_score":42}`
+
+	if w := do(http.MethodPost, "/api/reports/ai", report); w.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected 503 without an AI client, got %d", w.Code)
+	}
+
+	var prompts []string
+	mockAIServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		prompts = append(prompts, string(body))
+		w.Header().Set("Content-Type", "text/event-stream")
+		flusher, _ := w.(http.Flusher)
+		for _, chunk := range []string{"Cluster is healthy.", "\\nNo action needed."} {
+			w.Write([]byte(`data: {"id":"test","choices":[{"delta":{"content":"` + chunk + `"}}]}` + "\n\n"))
+			flusher.Flush()
+		}
+		w.Write([]byte("data: [DONE]\n\n"))
+		flusher.Flush()
+	}))
+	defer mockAIServer.Close()
+
+	aiClient, err := ai.NewClient(&config.LLMConfig{
+		Provider: "openai",
+		Model:    "gpt-4",
+		Endpoint: mockAIServer.URL,
+		APIKey:   "test-key",
+	})
+	if err != nil {
+		t.Fatalf("failed to create AI client: %v", err)
+	}
+	server.aiClient = aiClient
+
+	w := do(http.MethodPost, "/api/reports/ai?ai_words=200&ai_focus=security", report)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("expected SSE content type, got %q", ct)
+	}
+	body := w.Body.String()
+	if !strings.Contains(body, "data: Cluster is healthy.\n\n") ||
+		!strings.Contains(body, `data: \nNo action needed.`) ||
+		!strings.HasSuffix(body, "data: [DONE]\n\n") {
+		t.Errorf("unexpected stream body: %q", body)
+	}
+	// The posted report is analysed, not a freshly generated one
+	if len(prompts) != 1 || !strings.Contains(prompts[0], "shop") || !strings.Contains(prompts[0], "42") {
+		t.Errorf("expected the posted report in the prompt, got %q", prompts)
+	}
+
+	if w := do(http.MethodPost, "/api/reports/ai?ai_focus=bogus", report); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an invalid focus, got %d", w.Code)
+	}
+	if w := do(http.MethodPost, "/api/reports/ai", "not json"); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an invalid body, got %d", w.Code)
+	}
+	if w := do(http.MethodGet, "/api/reports/ai", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 without a report id, got %d", w.Code)
+	}
+	if w := do(http.MethodGet, "/api/reports/ai?id=999", ""); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for an unknown report, got %d", w.Code)
+	}
+}
+
+// E2E Test: Report diff compares two posted snapshots
+func TestE2E_ReportDiff(t *testing.T) {
+	server, authManager := setupTestServer(t)
+	session, _ := authManager.Authenticate("admin", "admin123")
+	handler := authManager.AuthMiddleware(server.reportGenerator.HandleReportDiff)
+
+	post := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/reports/diff", strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+session.ID)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	w := post(`{"before":{"health_score":90,"deployments":[{"name":"web","namespace":"default","ready":"2/2"}]},` +
+		`"after":{"health_score":80,"deployments":[{"name":"web","namespace":"default","ready":"1/2"}]}}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var diff ReportDiff
+	if err := json.Unmarshal(w.Body.Bytes(), &diff); err != nil {
+		t.Fatalf("failed to parse diff: %v", err)
+	}
+	if diff.HealthScoreDelta != -10 || len(diff.ReplicaChanges) != 1 {
+		t.Errorf("unexpected diff: %+v", diff)
+	}
+
+	if w := post(`{"before":{}}`); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 without an after report, got %d", w.Code)
+	}
+}
+
+// E2E Test: Generated reports are stored and can be diffed by id
+func TestE2E_ReportHistory(t *testing.T) {
+	server, authManager := setupTestServer(t)
+	session, _ := authManager.Authenticate("admin", "admin123")
+
+	get := func(handler http.HandlerFunc, url string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, url, nil)
+		req.Header.Set("Authorization", "Bearer "+session.ID)
+		w := httptest.NewRecorder()
+		authManager.AuthMiddleware(handler).ServeHTTP(w, req)
+		return w
+	}
+	history := server.reportGenerator.HandleReportHistory
+
+	prevDB := db.DB
+	defer func() { db.DB = prevDB }()
+
+	db.DB = nil
+	if w := get(history, "/api/reports/history"); w.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected 503 without a database, got %d", w.Code)
+	}
+
+	if err := db.Init(filepath.Join(t.TempDir(), "audit.db")); err != nil {
+		t.Fatalf("db.Init failed: %v", err)
+	}
+	defer db.Close()
+
+	for i := 0; i < 2; i++ {
+		if w := get(server.reportGenerator.HandleReports, "/api/reports"); w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+	}
+
+	w := get(history, "/api/reports/history")
+	var records []db.ReportRecord
+	if err := json.Unmarshal(w.Body.Bytes(), &records); err != nil {
+		t.Fatalf("failed to parse history: %v: %s", err, w.Body.String())
+	}
+	if len(records) != 2 || records[0].ID <= records[1].ID || records[0].User != "admin" {
+		t.Fatalf("unexpected history: %+v", records)
+	}
+
+	w = get(server.reportGenerator.HandleReportDiff,
+		fmt.Sprintf("/api/reports/diff?from=%d&to=%d", records[1].ID, records[0].ID))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var diff ReportDiff
+	if err := json.Unmarshal(w.Body.Bytes(), &diff); err != nil {
+		t.Fatalf("failed to parse diff: %v", err)
+	}
+	if diff.PodDelta != 0 || diff.From.After(diff.To) {
+		t.Errorf("unexpected diff: %+v", diff)
+	}
+
+	if w := get(server.reportGenerator.HandleReportDiff, "/api/reports/diff?from=999&to=1"); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for an unknown report, got %d", w.Code)
+	}
+	if w := get(history, fmt.Sprintf("/api/reports/history?id=%d", records[0].ID)); w.Code != http.StatusOK {
+		t.Errorf("expected 200 for a stored report, got %d", w.Code)
+	}
+
+	// Viewers only see the reports they generated
+	authManager.createLocalUser("bob", "bob-password", RoleViewer)
+	bob, _ := authManager.Authenticate("bob", "bob-password")
+	getAsBob := func(handler http.HandlerFunc, url string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, url, nil)
+		req.Header.Set("Authorization", "Bearer "+bob.ID)
+		w := httptest.NewRecorder()
+		authManager.AuthMiddleware(handler).ServeHTTP(w, req)
+		return w
+	}
+	if w := getAsBob(history, fmt.Sprintf("/api/reports/history?id=%d", records[0].ID)); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for another user's report, got %d", w.Code)
+	}
+	if w := getAsBob(server.reportGenerator.HandleReportDiff,
+		fmt.Sprintf("/api/reports/diff?from=%d&to=%d", records[1].ID, records[0].ID)); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 diffing another user's reports, got %d", w.Code)
+	}
+	getAsBob(server.reportGenerator.HandleReports, "/api/reports")
+	w = getAsBob(history, "/api/reports/history")
+	records = nil
+	json.Unmarshal(w.Body.Bytes(), &records)
+	if len(records) != 1 || records[0].User != "bob" {
+		t.Errorf("expected only bob's report, got %+v", records)
+	}
+}
+
 // E2E Test: Chat endpoint without AI client
 func TestE2E_ChatWithoutAI(t *testing.T) {
 	server, authManager := setupTestServer(t)
@@ -591,3 +1107,241 @@ func TestE2E_SessionExpiration(t *testing.T) {
 		t.Error("session should be expired")
 	}
 }
+
+// E2E Test: Single object YAML/describe/JSON endpoint with secret redaction
+func TestE2E_K8sObjectEndpoint(t *testing.T) {
+	server, authManager := setupTestServer(t)
+
+	secret := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"metadata":   map[string]interface{}{"name": "db", "namespace": "default"},
+		"data":       map[string]interface{}{"password": "aHVudGVyMg=="},
+	}}
+	server.k8sClient = &k8s.Client{
+		Clientset: fake.NewSimpleClientset(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"},
+			Data:       map[string][]byte{"password": []byte("hunter2")},
+		}),
+		Dynamic: dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+			map[schema.GroupVersionResource]string{{Version: "v1", Resource: "secrets"}: "SecretList"}, secret),
+	}
+
+	session, err := authManager.Authenticate("admin", "admin123")
+	if err != nil {
+		t.Fatalf("authentication failed: %v", err)
+	}
+
+	get := func(url string) (*httptest.ResponseRecorder, K8sObjectResponse) {
+		req := httptest.NewRequest(http.MethodGet, url, nil)
+		req.Header.Set("Authorization", "Bearer "+session.ID)
+		w := httptest.NewRecorder()
+		authManager.AuthMiddleware(http.HandlerFunc(server.handleK8sResource)).ServeHTTP(w, req)
+
+		var resp K8sObjectResponse
+		if w.Code == http.StatusOK {
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("failed to parse response: %v", err)
+			}
+		}
+		return w, resp
+	}
+
+	for _, output := range []string{"yaml", "describe", "json"} {
+		w, resp := get("/api/k8s/secrets/default/db?output=" + output)
+		if w.Code != http.StatusOK || resp.Error != "" {
+			t.Fatalf("output=%s: status %d, error %q", output, w.Code, resp.Error)
+		}
+		if strings.Contains(w.Body.String(), "aHVudGVyMg==") || strings.Contains(w.Body.String(), "hunter2") {
+			t.Errorf("output=%s leaked secret data: %s", output, w.Body.String())
+		}
+	}
+
+	_, resp := get("/api/k8s/secrets/default/db?output=json")
+	if data, _, _ := unstructured.NestedString(resp.Object, "data", "password"); data != k8s.RedactedValue {
+		t.Errorf("expected redacted password in JSON output, got %q", data)
+	}
+
+	if w, _ := get("/api/k8s/secrets/default/db?output=xml"); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for unsupported output, got %d", w.Code)
+	}
+	if w, _ := get("/api/k8s/widgets/default/db"); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for unknown resource, got %d", w.Code)
+	}
+}
+
+// E2E Test: Pod log streaming over SSE
+func TestE2E_PodLogsStream(t *testing.T) {
+	server, authManager := setupTestServer(t)
+
+	session, err := authManager.Authenticate("admin", "admin123")
+	if err != nil {
+		t.Fatalf("authentication failed: %v", err)
+	}
+
+	get := func(url string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, url, nil)
+		req.Header.Set("Authorization", "Bearer "+session.ID)
+		w := httptest.NewRecorder()
+		authManager.AuthMiddleware(http.HandlerFunc(server.handlePodLogs)).ServeHTTP(w, req)
+		return w
+	}
+
+	w := get("/api/k8s/logs?namespace=default&pod=test-pod-1&tail=10")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("expected SSE content type, got %q", ct)
+	}
+	body := w.Body.String()
+	if !strings.Contains(body, "data: fake logs\n\n") || !strings.HasSuffix(body, "data: [DONE]\n\n") {
+		t.Errorf("unexpected stream body: %q", body)
+	}
+
+	if w := get("/api/k8s/logs?namespace=default"); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 without pod, got %d", w.Code)
+	}
+	if w := get("/api/k8s/logs?pod=test-pod-1&tail=abc"); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for invalid tail, got %d", w.Code)
+	}
+}
+
+// E2E Test: Mutating resource actions require admin and report status
+func TestE2E_ResourceActions(t *testing.T) {
+	server, authManager := setupTestServer(t)
+
+	deploymentsGVR := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	deployment := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]interface{}{"name": "web", "namespace": "default", "generation": int64(1)},
+		"spec":       map[string]interface{}{"replicas": int64(1)},
+	}}
+	server.k8sClient.Dynamic = dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{deploymentsGVR: "DeploymentList"}, deployment)
+
+	if err := authManager.CreateUser("viewer", "viewer-pass", "viewer"); err != nil {
+		t.Fatalf("failed to create viewer: %v", err)
+	}
+	admin, _ := authManager.Authenticate("admin", "admin123")
+	viewer, _ := authManager.Authenticate("viewer", "viewer-pass")
+
+	post := func(session *Session, action, body string) (*httptest.ResponseRecorder, ActionResponse) {
+		req := httptest.NewRequest(http.MethodPost, "/api/actions/"+action, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+session.ID)
+		w := httptest.NewRecorder()
+		authManager.AdminMiddleware(server.handleResourceAction).ServeHTTP(w, req)
+
+		var resp ActionResponse
+		if w.Header().Get("Content-Type") == "application/json" {
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("failed to parse response: %v", err)
+			}
+		}
+		return w, resp
+	}
+
+	body := `{"resource":"deploy","namespace":"default","name":"web","replicas":3}`
+	if w, _ := post(viewer, "scale", body); w.Code != http.StatusForbidden {
+		t.Errorf("expected 403 for non-admin, got %d", w.Code)
+	}
+
+	w, resp := post(admin, "scale", body)
+	if w.Code != http.StatusOK || resp.Error != "" {
+		t.Fatalf("scale failed: %d %s %s", w.Code, resp.Error, w.Body.String())
+	}
+	if resp.Rollout == nil || resp.Rollout.Desired != 3 {
+		t.Errorf("expected rollout status with 3 desired replicas, got %+v", resp.Rollout)
+	}
+
+	if w, _ := post(admin, "restart", `{"resource":"services","namespace":"default","name":"web"}`); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 when restarting a service, got %d", w.Code)
+	}
+	if w, _ := post(admin, "scale", `{"resource":"deployments","namespace":"default","name":"web"}`); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 when scaling without replicas, got %d", w.Code)
+	}
+
+	w, resp = post(admin, "delete", `{"resource":"deployments","namespace":"default","name":"web"}`)
+	if w.Code != http.StatusOK || resp.Status != "Deleted" {
+		t.Errorf("expected deployment to be deleted, got %d %+v", w.Code, resp)
+	}
+
+	w, resp = post(admin, "restart", `{"resource":"deployments","namespace":"default","name":"web"}`)
+	if w.Code != http.StatusNotFound || resp.Error == "" {
+		t.Errorf("expected 404 with an error when restarting a deleted deployment, got %d %+v", w.Code, resp)
+	}
+}
+
+// E2E Test: resource changes are streamed with their resource version as id
+func TestE2E_K8sWatch(t *testing.T) {
+	server, authManager := setupTestServer(t)
+	session, _ := authManager.Authenticate("admin", "admin123")
+
+	prevHeartbeat := watchHeartbeat
+	watchHeartbeat = 50 * time.Millisecond
+	defer func() { watchHeartbeat = prevHeartbeat }()
+
+	ts := httptest.NewServer(authManager.AuthMiddleware(server.handleK8sWatch))
+	defer ts.Close()
+
+	get := func(ctx context.Context, path string) *http.Response {
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL+path, nil)
+		req.Header.Set("Authorization", "Bearer "+session.ID)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		return resp
+	}
+
+	if resp := get(context.Background(), "/api/k8s/watch/widgets"); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected 404 for an unwatchable resource, got %d", resp.StatusCode)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	resp := get(ctx, "/api/k8s/watch/pods?namespace=default")
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("expected text/event-stream, got %q", ct)
+	}
+
+	reader := bufio.NewReader(resp.Body)
+	readUntil := func(prefix string) string {
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				t.Fatalf("stream ended before %q: %v", prefix, err)
+			}
+			if strings.HasPrefix(line, prefix) {
+				return strings.TrimSpace(strings.TrimPrefix(line, prefix))
+			}
+		}
+	}
+
+	readUntil("retry: ")
+	readUntil(": heartbeat")
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "watched", Namespace: "default", ResourceVersion: "42"},
+		Status:     corev1.PodStatus{Phase: corev1.PodPending},
+	}
+	if _, err := server.k8sClient.Clientset.CoreV1().Pods("default").Create(ctx, pod, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("create pod: %v", err)
+	}
+
+	if id := readUntil("id: "); id != "42" {
+		t.Errorf("expected id 42, got %q", id)
+	}
+	if event := readUntil("event: "); event != "ADDED" {
+		t.Errorf("expected an ADDED event, got %q", event)
+	}
+	var ev WatchEvent
+	if err := json.Unmarshal([]byte(readUntil("data: ")), &ev); err != nil {
+		t.Fatalf("failed to parse event: %v", err)
+	}
+	if ev.Item["name"] != "watched" || ev.Item["status"] != "Pending" || ev.ResourceVersion != "42" {
+		t.Errorf("unexpected event: %+v", ev)
+	}
+}
@@ -25,7 +25,7 @@ type LDAPConfig struct {
 	GroupSearchBase  string   `yaml:"group_search_base" json:"group_search_base"`
 	GroupSearchFilter string  `yaml:"group_search_filter" json:"group_search_filter"` // e.g., "(member=%s)"
 	AdminGroups      []string `yaml:"admin_groups" json:"admin_groups"`               // Groups that grant admin role
-	UserGroups       []string `yaml:"user_groups" json:"user_groups"`                 // Groups that grant user role
+	UserGroups       []string `yaml:"user_groups" json:"user_groups"`                 // Groups that grant operator role
 	ViewerGroups     []string `yaml:"viewer_groups" json:"viewer_groups"`             // Groups that grant viewer role
 	UsernameAttr     string   `yaml:"username_attr" json:"username_attr"`             // e.g., "uid" or "sAMAccountName"
 	EmailAttr        string   `yaml:"email_attr" json:"email_attr"`                   // e.g., "mail"
@@ -248,26 +248,26 @@ func (p *LDAPProvider) determineRole(groups []string) string {
 	// Check admin groups first (highest priority)
 	for _, adminGroup := range p.config.AdminGroups {
 		if groupSet[strings.ToLower(adminGroup)] {
-			return "admin"
+			return RoleAdmin
 		}
 	}
 
 	// Check user groups
 	for _, userGroup := range p.config.UserGroups {
 		if groupSet[strings.ToLower(userGroup)] {
-			return "user"
+			return RoleOperator
 		}
 	}
 
 	// Check viewer groups
 	for _, viewerGroup := range p.config.ViewerGroups {
 		if groupSet[strings.ToLower(viewerGroup)] {
-			return "viewer"
+			return RoleViewer
 		}
 	}
 
-	// Default to viewer if no specific group matched but user authenticated
-	return "viewer"
+	// Default role if no specific group matched but user authenticated
+	return DefaultRole
 }
 
 // TestConnection tests the LDAP connection
@@ -154,10 +154,10 @@ func TestLDAPProvider_DetermineRole(t *testing.T) {
 	}{
 		{"admin group", []string{"k8s-admins", "other"}, "admin"},
 		{"admin group case insensitive", []string{"K8S-ADMINS"}, "admin"},
-		{"user group", []string{"developers", "other"}, "user"},
+		{"user group", []string{"developers", "other"}, "operator"},
 		{"viewer group", []string{"readonly"}, "viewer"},
 		{"admin takes priority", []string{"k8s-admins", "developers", "readonly"}, "admin"},
-		{"user takes priority over viewer", []string{"developers", "readonly"}, "user"},
+		{"user takes priority over viewer", []string{"developers", "readonly"}, "operator"},
 		{"no matching group defaults to viewer", []string{"unknown-group"}, "viewer"},
 		{"empty groups defaults to viewer", []string{}, "viewer"},
 	}
@@ -0,0 +1,188 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/config"
+)
+
+// oidcRefreshInterval is the least time between attempts to discover an
+// issuer that could not be reached, so tokens cannot make the server
+// hammer it
+var oidcRefreshInterval = time.Minute
+
+// oidcHTTPClient fetches the issuer's discovery document and keys
+var oidcHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+// OIDCProvider validates ID tokens of an OpenID Connect issuer with go-oidc.
+// It discovers the issuer lazily: when the issuer is down at startup,
+// tokens are refused until it can be reached, while local users can still
+// sign in.
+type OIDCProvider struct {
+	config *config.OIDCConfig
+
+	mu        sync.Mutex
+	verifier  *oidc.IDTokenVerifier
+	lastFetch time.Time
+}
+
+// NewOIDCProvider creates a provider for cfg. Call Discover to fetch the
+// issuer's discovery document up front.
+func NewOIDCProvider(cfg *config.OIDCConfig) (*OIDCProvider, error) {
+	if cfg.IssuerURL == "" || cfg.ClientID == "" {
+		return nil, fmt.Errorf("OIDC needs an issuer_url and a client_id")
+	}
+	return &OIDCProvider{config: cfg}, nil
+}
+
+// Discover fetches the issuer's discovery document and sets up the token
+// verifier; the signing keys are fetched, and refetched on rotation, by
+// go-oidc
+func (p *OIDCProvider) Discover(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.discoverLocked(ctx)
+}
+
+func (p *OIDCProvider) discoverLocked(ctx context.Context) error {
+	p.lastFetch = time.Now()
+
+	provider, err := oidc.NewProvider(oidc.ClientContext(ctx, oidcHTTPClient), p.config.IssuerURL)
+	if err != nil {
+		return fmt.Errorf("OIDC discovery failed: %w", err)
+	}
+	var doc struct {
+		JWKSURI string `json:"jwks_uri"`
+	}
+	if err := provider.Claims(&doc); err != nil {
+		return fmt.Errorf("OIDC discovery failed: %w", err)
+	}
+	if u, err := url.Parse(doc.JWKSURI); err != nil || u.Scheme != "https" {
+		return fmt.Errorf("OIDC discovery returned jwks_uri %q, which is not https", doc.JWKSURI)
+	}
+	p.verifier = provider.Verifier(&oidc.Config{ClientID: p.config.ClientID})
+	return nil
+}
+
+// tokenVerifier returns the verifier, discovering the issuer first if that
+// has not succeeded yet
+func (p *OIDCProvider) tokenVerifier(ctx context.Context) (*oidc.IDTokenVerifier, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.verifier != nil {
+		return p.verifier, nil
+	}
+	if time.Since(p.lastFetch) < oidcRefreshInterval {
+		return nil, fmt.Errorf("OIDC issuer %s is unreachable", p.config.IssuerURL)
+	}
+	if err := p.discoverLocked(ctx); err != nil {
+		return nil, err
+	}
+	return p.verifier, nil
+}
+
+// ValidateToken checks the signature, issuer, audience, authorized party
+// and lifetime of a raw ID token and returns the user it names, with the
+// token's expiry
+func (p *OIDCProvider) ValidateToken(ctx context.Context, raw string) (*TokenReview, time.Time, error) {
+	verifier, err := p.tokenVerifier(ctx)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	token, err := verifier.Verify(ctx, raw)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	var claims map[string]interface{}
+	if err := token.Claims(&claims); err != nil {
+		return nil, time.Time{}, fmt.Errorf("malformed token claims: %w", err)
+	}
+	// go-oidc leaves the authorized party to the client: a token for
+	// several audiences must name us as azp, and one that names another
+	// client is not ours
+	azp, _ := claims["azp"].(string)
+	if (len(token.Audience) > 1 || azp != "") && azp != p.config.ClientID {
+		return nil, time.Time{}, fmt.Errorf("token authorized for %q, not %q", azp, p.config.ClientID)
+	}
+
+	username := p.username(claims)
+	if username == "" {
+		return nil, time.Time{}, fmt.Errorf("token has no username claim")
+	}
+	groupsClaim := p.config.GroupsClaim
+	if groupsClaim == "" {
+		groupsClaim = "groups"
+	}
+	return &TokenReview{
+		Authenticated: true,
+		Username:      username,
+		UID:           token.Subject,
+		Groups:        stringList(claims[groupsClaim]),
+	}, token.Expiry, nil
+}
+
+// username returns the configured username claim, or else the first of
+// preferred_username, email and sub that is set
+func (p *OIDCProvider) username(claims map[string]interface{}) string {
+	names := []string{"preferred_username", "email", "sub"}
+	if p.config.UsernameClaim != "" {
+		names = []string{p.config.UsernameClaim}
+	}
+	for _, name := range names {
+		if s, ok := claims[name].(string); ok && s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+// Role returns the role of a user in groups: admin for AdminGroups,
+// operator for OperatorGroups and DefaultRole otherwise
+func (p *OIDCProvider) Role(groups []string) string {
+	groupSet := make(map[string]bool)
+	for _, g := range groups {
+		groupSet[strings.ToLower(g)] = true
+	}
+	for _, g := range p.config.AdminGroups {
+		if groupSet[strings.ToLower(g)] {
+			return RoleAdmin
+		}
+	}
+	for _, g := range p.config.OperatorGroups {
+		if groupSet[strings.ToLower(g)] {
+			return RoleOperator
+		}
+	}
+	return DefaultRole
+}
+
+// looksLikeJWT tells a compact JWT from a session ID
+func looksLikeJWT(token string) bool {
+	return strings.Count(token, ".") == 2
+}
+
+// stringList returns a claim that is a string or a list of strings as a list
+func stringList(claim interface{}) []string {
+	switch v := claim.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		list := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				list = append(list, s)
+			}
+		}
+		return list
+	}
+	return nil
+}
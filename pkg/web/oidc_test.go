@@ -0,0 +1,272 @@
+package web
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/config"
+)
+
+// testIssuer serves the discovery document and keys of an OIDC issuer over
+// TLS and signs ID tokens; while down it answers 503. It offers an RSA key
+// (k1) and a P-384 key (k2), and advertises RS256 and ES256.
+type testIssuer struct {
+	*httptest.Server
+	key   *rsa.PrivateKey
+	ecKey *ecdsa.PrivateKey
+	down  atomic.Bool
+
+	// jwksURI overrides the advertised jwks_uri when set
+	jwksURI string
+}
+
+func newTestIssuer(t *testing.T) *testIssuer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	iss := &testIssuer{key: key, ecKey: ecKey}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		jwksURI := iss.jwksURI
+		if jwksURI == "" {
+			jwksURI = iss.URL + "/keys"
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"issuer":                                iss.URL,
+			"jwks_uri":                              jwksURI,
+			"id_token_signing_alg_values_supported": []string{"RS256", "ES256"},
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		b64 := base64.RawURLEncoding
+		json.NewEncoder(w).Encode(map[string]interface{}{"keys": []map[string]string{{
+			"kty": "RSA", "kid": "k1", "use": "sig",
+			"n": b64.EncodeToString(key.N.Bytes()),
+			"e": b64.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}, {
+			"kty": "EC", "kid": "k2", "use": "sig", "crv": "P-384",
+			"x": b64.EncodeToString(ecKey.X.FillBytes(make([]byte, 48))),
+			"y": b64.EncodeToString(ecKey.Y.FillBytes(make([]byte, 48))),
+		}}})
+	})
+	iss.Server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if iss.down.Load() {
+			http.Error(w, "down", http.StatusServiceUnavailable)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(iss.Close)
+
+	client := oidcHTTPClient
+	oidcHTTPClient = iss.Client()
+	t.Cleanup(func() { oidcHTTPClient = client })
+	return iss
+}
+
+// token signs claims with RS256, filling in a valid issuer, audience and
+// expiry unless given
+func (iss *testIssuer) token(t *testing.T, claims map[string]interface{}) string {
+	signed := iss.signingInput(map[string]string{"alg": "RS256", "kid": "k1", "typ": "JWT"}, claims)
+	digest := sha256.Sum256([]byte(signed))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, iss.key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+// es256TokenWithP384 signs a token as ES256 (SHA-256) with the P-384 key,
+// a curve ES256 does not allow
+func (iss *testIssuer) es256TokenWithP384(t *testing.T) string {
+	signed := iss.signingInput(map[string]string{"alg": "ES256", "kid": "k2", "typ": "JWT"}, nil)
+	digest := sha256.Sum256([]byte(signed))
+	r, s, err := ecdsa.Sign(rand.Reader, iss.ecKey, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig := append(r.FillBytes(make([]byte, 48)), s.FillBytes(make([]byte, 48))...)
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+// signingInput encodes header and claims, completed like token does
+func (iss *testIssuer) signingInput(header map[string]string, claims map[string]interface{}) string {
+	full := map[string]interface{}{
+		"iss": iss.URL,
+		"aud": "k13s",
+		"sub": "u-1",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}
+	for k, v := range claims {
+		full[k] = v
+	}
+	h, _ := json.Marshal(header)
+	payload, _ := json.Marshal(full)
+	return base64.RawURLEncoding.EncodeToString(h) + "." + base64.RawURLEncoding.EncodeToString(payload)
+}
+
+func newOIDCAuthManager(iss *testIssuer) *AuthManager {
+	return NewAuthManager(&AuthConfig{
+		Enabled:         true,
+		AuthMode:        config.AuthModeOIDC,
+		SessionDuration: time.Hour,
+		DefaultAdmin:    "admin",
+		DefaultPassword: "admin",
+		OIDC: &config.OIDCConfig{
+			IssuerURL:      iss.URL,
+			ClientID:       "k13s",
+			AdminGroups:    []string{"platform"},
+			OperatorGroups: []string{"developers"},
+		},
+	})
+}
+
+func TestOIDCProvider_ValidateToken(t *testing.T) {
+	iss := newTestIssuer(t)
+	p, err := NewOIDCProvider(&config.OIDCConfig{IssuerURL: iss.URL, ClientID: "k13s"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	review, expiry, err := p.ValidateToken(t.Context(), iss.token(t, map[string]interface{}{
+		"preferred_username": "alice",
+		"groups":             []string{"developers"},
+	}))
+	if err != nil {
+		t.Fatalf("valid token: %v", err)
+	}
+	if review.Username != "alice" || review.UID != "u-1" || len(review.Groups) != 1 || review.Groups[0] != "developers" {
+		t.Errorf("review = %+v", review)
+	}
+	if time.Until(expiry) < 50*time.Minute {
+		t.Errorf("expiry = %v", expiry)
+	}
+
+	if _, _, err := p.ValidateToken(t.Context(), iss.token(t, map[string]interface{}{
+		"aud": []string{"k13s", "other"}, "azp": "k13s",
+	})); err != nil {
+		t.Errorf("token for several audiences authorized for us: %v", err)
+	}
+
+	valid := iss.token(t, nil)
+	tampered := valid[:strings.LastIndex(valid, ".")] + ".c2lnbmF0dXJl"
+	unsigned := iss.signingInput(map[string]string{"alg": "none"}, nil) + "."
+	tests := map[string]string{
+		"wrong audience":                iss.token(t, map[string]interface{}{"aud": []string{"other"}}),
+		"several audiences without azp": iss.token(t, map[string]interface{}{"aud": []string{"k13s", "other"}}),
+		"authorized for another client": iss.token(t, map[string]interface{}{"azp": "other"}),
+		"ES256 with a P-384 key":        iss.es256TokenWithP384(t),
+		"unsigned":                      unsigned,
+		"wrong issuer":                  iss.token(t, map[string]interface{}{"iss": "https://evil.example"}),
+		"expired":                       iss.token(t, map[string]interface{}{"exp": time.Now().Add(-time.Hour).Unix()}),
+		"not yet valid":                 iss.token(t, map[string]interface{}{"nbf": time.Now().Add(time.Hour).Unix()}),
+		"bad signature":                 tampered,
+		"malformed":                     "not.a-token",
+	}
+	for name, token := range tests {
+		if _, _, err := p.ValidateToken(t.Context(), token); err == nil {
+			t.Errorf("%s: token accepted", name)
+		}
+	}
+}
+
+func TestOIDCProvider_PlainHTTPKeys(t *testing.T) {
+	iss := newTestIssuer(t)
+	iss.jwksURI = "http://" + strings.TrimPrefix(iss.URL, "https://") + "/keys"
+
+	p, err := NewOIDCProvider(&config.OIDCConfig{IssuerURL: iss.URL, ClientID: "k13s"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Discover(t.Context()); err == nil || !strings.Contains(err.Error(), "not https") {
+		t.Errorf("expected a plain-http jwks_uri to be refused, got %v", err)
+	}
+}
+
+func TestOIDCProvider_UnreachableAtStartup(t *testing.T) {
+	iss := newTestIssuer(t)
+	iss.down.Store(true)
+
+	interval := oidcRefreshInterval
+	oidcRefreshInterval = 0
+	defer func() { oidcRefreshInterval = interval }()
+
+	am := newOIDCAuthManager(iss)
+	if am.oidcProvider == nil {
+		t.Fatal("OIDC provider not created while the issuer is down")
+	}
+	token := iss.token(t, map[string]interface{}{"email": "bob@example.com"})
+	if _, err := am.ValidateOIDCToken(t.Context(), token); err == nil {
+		t.Fatal("token accepted while the issuer is down")
+	}
+	if _, err := am.Authenticate("admin", "admin"); err != nil {
+		t.Errorf("local login while the issuer is down: %v", err)
+	}
+
+	iss.down.Store(false)
+	session, err := am.ValidateOIDCToken(t.Context(), token)
+	if err != nil {
+		t.Fatalf("token refused after the issuer came back: %v", err)
+	}
+	if session.Username != "bob@example.com" || session.Role != DefaultRole {
+		t.Errorf("session = %+v", session)
+	}
+}
+
+func TestAuthMiddleware_OIDC(t *testing.T) {
+	iss := newTestIssuer(t)
+	am := newOIDCAuthManager(iss)
+
+	var gotUser, gotRole string
+	handler := am.AuthMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		gotUser, gotRole = r.Header.Get("X-Username"), r.Header.Get("X-User-Role")
+	})
+	serve := func(req *http.Request) int {
+		w := httptest.NewRecorder()
+		handler(w, req)
+		return w.Code
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/test", nil)
+	req.Header.Set("Authorization", "Bearer "+iss.token(t, map[string]interface{}{
+		"preferred_username": "carol",
+		"groups":             []string{"Platform"},
+	}))
+	if code := serve(req); code != http.StatusOK || gotUser != "carol" || gotRole != RoleAdmin {
+		t.Errorf("OIDC token: status %d, user %q, role %q", code, gotUser, gotRole)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/test", nil)
+	req.Header.Set("Authorization", "Bearer "+iss.token(t, map[string]interface{}{"aud": "other"}))
+	if code := serve(req); code != http.StatusUnauthorized {
+		t.Errorf("token for another client: status %d, want 401", code)
+	}
+
+	session, err := am.Authenticate("admin", "admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	req = httptest.NewRequest(http.MethodGet, "/api/test", nil)
+	req.AddCookie(&http.Cookie{Name: "k13s_session", Value: session.ID})
+	if code := serve(req); code != http.StatusOK || gotUser != "admin" {
+		t.Errorf("local session: status %d, user %q", code, gotUser)
+	}
+}
@@ -0,0 +1,91 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// errNoPDFConverter means none of the HTML to PDF converters is installed
+var errNoPDFConverter = errors.New("no PDF converter found: install wkhtmltopdf or Chromium/Google Chrome on the server, or download the HTML report and print it to PDF")
+
+// pdfTimeout bounds one conversion; a large report renders in seconds
+const pdfTimeout = 60 * time.Second
+
+// pdfWaitDelay is how long a killed converter's children may keep its output
+// pipes open before they are closed anyway; Chrome's helpers outlive it
+var pdfWaitDelay = 5 * time.Second
+
+// pdfConverter renders an HTML file to a PDF file with an external command
+type pdfConverter struct {
+	command string
+	args    func(in, out string) []string
+}
+
+// chromeNeedsNoSandbox is set when running as root, where Chrome refuses to
+// start with its sandbox; everyone else keeps it
+var chromeNeedsNoSandbox = os.Geteuid() == 0
+
+// headlessChrome prints a page to PDF with Chrome or Chromium, with
+// JavaScript off since reports are static
+func headlessChrome(in, out string) []string {
+	args := []string{"--headless", "--disable-gpu", "--blink-settings=scriptEnabled=false", "--no-pdf-header-footer"}
+	if chromeNeedsNoSandbox {
+		args = append(args, "--no-sandbox")
+	}
+	return append(args, "--print-to-pdf="+out, "file://"+in)
+}
+
+// pdfConverters are tried in order; wkhtmltopdf starts faster than Chrome.
+// The report may quote cluster data, so wkhtmltopdf runs without JavaScript
+// and may read no local file but the report itself.
+var pdfConverters = []pdfConverter{
+	{"wkhtmltopdf", func(in, out string) []string {
+		return []string{"--quiet", "--encoding", "utf-8", "--disable-javascript",
+			"--disable-local-file-access", "--allow", filepath.Dir(in), in, out}
+	}},
+	{"chromium", headlessChrome},
+	{"chromium-browser", headlessChrome},
+	{"google-chrome", headlessChrome},
+	{"google-chrome-stable", headlessChrome},
+}
+
+// findPDFConverter returns the first installed converter
+func findPDFConverter(lookPath func(string) (string, error)) (pdfConverter, error) {
+	for _, c := range pdfConverters {
+		if path, err := lookPath(c.command); err == nil {
+			return pdfConverter{command: path, args: c.args}, nil
+		}
+	}
+	return pdfConverter{}, errNoPDFConverter
+}
+
+// htmlToPDF renders an HTML document to PDF with converter
+func htmlToPDF(ctx context.Context, converter pdfConverter, html string) ([]byte, error) {
+	dir, err := os.MkdirTemp("", "k13s-report-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	in := filepath.Join(dir, "report.html")
+	out := filepath.Join(dir, "report.pdf")
+	if err := os.WriteFile(in, []byte(html), 0600); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, pdfTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, converter.command, converter.args(in, out)...)
+	cmd.WaitDelay = pdfWaitDelay
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("%s: %v %s", filepath.Base(converter.command), err, strings.TrimSpace(string(output)))
+	}
+	return os.ReadFile(out)
+}
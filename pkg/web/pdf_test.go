@@ -0,0 +1,101 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestFindPDFConverter(t *testing.T) {
+	installed := func(names ...string) func(string) (string, error) {
+		return func(name string) (string, error) {
+			for _, n := range names {
+				if n == name {
+					return "/usr/bin/" + name, nil
+				}
+			}
+			return "", exec.ErrNotFound
+		}
+	}
+
+	if _, err := findPDFConverter(installed()); !errors.Is(err, errNoPDFConverter) {
+		t.Errorf("expected errNoPDFConverter, got %v", err)
+	}
+
+	c, err := findPDFConverter(installed("google-chrome", "wkhtmltopdf"))
+	if err != nil || c.command != "/usr/bin/wkhtmltopdf" {
+		t.Errorf("expected wkhtmltopdf to be preferred, got %q (%v)", c.command, err)
+	}
+
+	c, err = findPDFConverter(installed("chromium"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	args := c.args("/tmp/in.html", "/tmp/out.pdf")
+	if args[len(args)-1] != "file:///tmp/in.html" || args[len(args)-2] != "--print-to-pdf=/tmp/out.pdf" {
+		t.Errorf("unexpected chromium arguments %v", args)
+	}
+	if slices.Contains(args, "--no-sandbox") != chromeNeedsNoSandbox {
+		t.Errorf("chromium arguments %v: --no-sandbox should only be passed as root", args)
+	}
+
+	c, _ = findPDFConverter(installed("wkhtmltopdf"))
+	args = c.args("/tmp/k13s-report-1/report.html", "/tmp/k13s-report-1/report.pdf")
+	for _, want := range []string{"--disable-javascript", "--disable-local-file-access"} {
+		if !slices.Contains(args, want) {
+			t.Errorf("wkhtmltopdf arguments %v lack %s", args, want)
+		}
+	}
+}
+
+func TestHTMLToPDF(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+	// A converter that copies its input stands in for a real one
+	copier := pdfConverter{command: "sh", args: func(in, out string) []string {
+		return []string{"-c", `cp "$0" "$1"`, in, out}
+	}}
+	data, err := htmlToPDF(context.Background(), copier, "<html>report</html>")
+	if err != nil {
+		t.Fatalf("htmlToPDF failed: %v", err)
+	}
+	if string(data) != "<html>report</html>" {
+		t.Errorf("expected the converter output, got %q", data)
+	}
+
+	failing := pdfConverter{command: "sh", args: func(in, out string) []string {
+		return []string{"-c", "echo broken >&2; exit 1"}
+	}}
+	if _, err := htmlToPDF(context.Background(), failing, "<html></html>"); err == nil {
+		t.Error("expected a failing converter to return an error")
+	}
+}
+
+func TestHTMLToPDF_KilledConverterChildren(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+	delay := pdfWaitDelay
+	pdfWaitDelay = 100 * time.Millisecond
+	defer func() { pdfWaitDelay = delay }()
+
+	// The child keeps the output pipe open after sh is killed, like
+	// Chrome's helper processes
+	hanging := pdfConverter{command: "sh", args: func(in, out string) []string {
+		return []string{"-c", "sleep 5; echo done"}
+	}}
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if _, err := htmlToPDF(ctx, hanging, "<html></html>"); err == nil {
+		t.Error("expected a timed out converter to return an error")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("htmlToPDF returned after %v, want it to stop soon after the timeout", elapsed)
+	}
+}
@@ -0,0 +1,156 @@
+package web
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/config"
+	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// PricingModel prices the compute requested by workloads for cost estimates
+type PricingModel interface {
+	Provider() string
+	CPUHourly() float64      // USD per vCPU-hour
+	MemoryGBHourly() float64 // USD per GB-hour
+}
+
+// listPricing is a provider's on-demand list price per unit of a general
+// purpose instance, derived from its vCPU and memory split
+type listPricing struct {
+	provider string
+	cpu      float64
+	memoryGB float64
+}
+
+func (p listPricing) Provider() string        { return p.provider }
+func (p listPricing) CPUHourly() float64      { return p.cpu }
+func (p listPricing) MemoryGBHourly() float64 { return p.memoryGB }
+
+// pricingModels are the selectable providers; onprem has no list price and
+// is priced only by the rates in the config
+var pricingModels = map[string]listPricing{
+	"aws":    {"aws", 0.04, 0.004},
+	"gcp":    {"gcp", 0.031611, 0.004237},
+	"azure":  {"azure", 0.0496, 0.0062},
+	"onprem": {"onprem", 0, 0},
+}
+
+// defaultPricingProvider is used when the config names no known provider
+const defaultPricingProvider = "aws"
+
+// NewPricingModel returns the pricing of the configured provider, with the
+// configured CPU and memory rates taking precedence over its list prices
+func NewPricingModel(cfg config.PricingConfig) PricingModel {
+	model, ok := pricingModels[strings.ToLower(cfg.Provider)]
+	if !ok {
+		model = pricingModels[defaultPricingProvider]
+	}
+	if cfg.CPUHourly > 0 {
+		model.cpu = cfg.CPUHourly
+	}
+	if cfg.MemoryGBHourly > 0 {
+		model.memoryGB = cfg.MemoryGBHourly
+	}
+	return model
+}
+
+// CostEstimate is the monthly price of the CPU and memory requested by the
+// running pods of a report
+type CostEstimate struct {
+	Provider       string  `json:"provider"`
+	CPUHourly      float64 `json:"cpu_hourly"`
+	MemoryGBHourly float64 `json:"memory_gb_hourly"`
+	CPUCores       float64 `json:"cpu_cores"`
+	MemoryGB       float64 `json:"memory_gb"`
+	MonthlyCPU     float64 `json:"monthly_cpu"`
+	MonthlyMemory  float64 `json:"monthly_memory"`
+	MonthlyTotal   float64 `json:"monthly_total"`
+	// Unrequested counts the containers without CPU or memory requests,
+	// which the estimate cannot price
+	Unrequested int `json:"unrequested"`
+	// Namespaces breaks the total down, most expensive first
+	Namespaces []NamespaceCost `json:"namespaces"`
+}
+
+// NamespaceCost is the share of a cost estimate requested in one namespace
+type NamespaceCost struct {
+	Namespace    string  `json:"namespace"`
+	CPUCores     float64 `json:"cpu_cores"`
+	MemoryGB     float64 `json:"memory_gb"`
+	MonthlyTotal float64 `json:"monthly_total"`
+}
+
+// Priced reports whether the estimate has rates to price with; onprem
+// without configured rates has none
+func (c CostEstimate) Priced() bool {
+	return c.CPUHourly > 0 || c.MemoryGBHourly > 0
+}
+
+// generateFinOpsAnalysis prices the CPU and memory requests of pods with
+// model. Finished pods hold no resources and are skipped.
+func generateFinOpsAnalysis(pods []corev1.Pod, model PricingModel) CostEstimate {
+	est := CostEstimate{
+		Provider:       model.Provider(),
+		CPUHourly:      model.CPUHourly(),
+		MemoryGBHourly: model.MemoryGBHourly(),
+	}
+
+	type requests struct{ milliCPU, memBytes int64 }
+	var total requests
+	byNamespace := make(map[string]*requests)
+	for _, pod := range pods {
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		ns := byNamespace[pod.Namespace]
+		if ns == nil {
+			ns = &requests{}
+			byNamespace[pod.Namespace] = ns
+		}
+		for _, c := range pod.Spec.Containers {
+			cpu, hasCPU := c.Resources.Requests[corev1.ResourceCPU]
+			mem, hasMem := c.Resources.Requests[corev1.ResourceMemory]
+			if !hasCPU || !hasMem {
+				est.Unrequested++
+			}
+			if hasCPU {
+				ns.milliCPU += cpu.MilliValue()
+			}
+			if hasMem {
+				ns.memBytes += mem.Value()
+			}
+		}
+	}
+
+	monthly := func(cpuCores, memoryGB float64) (float64, float64) {
+		return cpuCores * est.CPUHourly * k8s.HoursPerMonth, memoryGB * est.MemoryGBHourly * k8s.HoursPerMonth
+	}
+	for name, r := range byNamespace {
+		nc := NamespaceCost{
+			Namespace: name,
+			CPUCores:  float64(r.milliCPU) / 1000,
+			MemoryGB:  float64(r.memBytes) / (1 << 30),
+		}
+		cpu, mem := monthly(nc.CPUCores, nc.MemoryGB)
+		nc.MonthlyTotal = cpu + mem
+		est.Namespaces = append(est.Namespaces, nc)
+
+		total.milliCPU += r.milliCPU
+		total.memBytes += r.memBytes
+	}
+	sort.Slice(est.Namespaces, func(i, j int) bool {
+		a, b := est.Namespaces[i], est.Namespaces[j]
+		if a.MonthlyTotal != b.MonthlyTotal {
+			return a.MonthlyTotal > b.MonthlyTotal
+		}
+		return a.Namespace < b.Namespace
+	})
+
+	est.CPUCores = float64(total.milliCPU) / 1000
+	est.MemoryGB = float64(total.memBytes) / (1 << 30)
+	est.MonthlyCPU, est.MonthlyMemory = monthly(est.CPUCores, est.MemoryGB)
+	est.MonthlyTotal = est.MonthlyCPU + est.MonthlyMemory
+	return est
+}
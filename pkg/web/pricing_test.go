@@ -0,0 +1,76 @@
+package web
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/config"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewPricingModel(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      config.PricingConfig
+		provider string
+		cpu, mem float64
+	}{
+		{"default", config.PricingConfig{}, "aws", 0.04, 0.004},
+		{"gcp", config.PricingConfig{Provider: "GCP"}, "gcp", 0.031611, 0.004237},
+		{"unknown falls back", config.PricingConfig{Provider: "ibm"}, "aws", 0.04, 0.004},
+		{"override", config.PricingConfig{Provider: "azure", CPUHourly: 0.05}, "azure", 0.05, 0.0062},
+		{"onprem", config.PricingConfig{Provider: "onprem", CPUHourly: 0.01, MemoryGBHourly: 0.001}, "onprem", 0.01, 0.001},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewPricingModel(tt.cfg)
+			if m.Provider() != tt.provider || m.CPUHourly() != tt.cpu || m.MemoryGBHourly() != tt.mem {
+				t.Errorf("got %s %v/%v, want %s %v/%v", m.Provider(), m.CPUHourly(), m.MemoryGBHourly(), tt.provider, tt.cpu, tt.mem)
+			}
+		})
+	}
+}
+
+func TestGenerateFinOpsAnalysis(t *testing.T) {
+	container := func(cpu, mem string) corev1.Container {
+		requests := corev1.ResourceList{}
+		if cpu != "" {
+			requests[corev1.ResourceCPU] = resource.MustParse(cpu)
+		}
+		if mem != "" {
+			requests[corev1.ResourceMemory] = resource.MustParse(mem)
+		}
+		return corev1.Container{Resources: corev1.ResourceRequirements{Requests: requests}}
+	}
+	pod := func(ns string, phase corev1.PodPhase, containers ...corev1.Container) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: ns},
+			Spec:       corev1.PodSpec{Containers: containers},
+			Status:     corev1.PodStatus{Phase: phase},
+		}
+	}
+	pods := []corev1.Pod{
+		pod("shop", corev1.PodRunning, container("1500m", "2Gi"), container("500m", "")),
+		pod("tools", corev1.PodPending, container("", "1Gi")),
+		pod("shop", corev1.PodSucceeded, container("4", "8Gi")),
+	}
+
+	est := generateFinOpsAnalysis(pods, NewPricingModel(config.PricingConfig{Provider: "aws"}))
+	if est.Provider != "aws" || est.CPUCores != 2 || est.MemoryGB != 3 || est.Unrequested != 2 {
+		t.Fatalf("unexpected estimate: %+v", est)
+	}
+	want := (2*0.04 + 3*0.004) * 730
+	if math.Abs(est.MonthlyTotal-want) > 1e-9 {
+		t.Errorf("monthly total = %v, want %v", est.MonthlyTotal, want)
+	}
+
+	if len(est.Namespaces) != 2 || est.Namespaces[0].Namespace != "shop" || est.Namespaces[0].CPUCores != 2 || est.Namespaces[1].MemoryGB != 1 {
+		t.Errorf("unexpected namespace costs: %+v", est.Namespaces)
+	}
+
+	if generateFinOpsAnalysis(pods, NewPricingModel(config.PricingConfig{Provider: "onprem"})).Priced() {
+		t.Error("onprem without configured rates should not be priced")
+	}
+}
@@ -0,0 +1,231 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/db"
+	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ReportDiff is what changed between two report snapshots
+type ReportDiff struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+
+	HealthScoreDelta float64 `json:"health_score_delta"`
+	PodDelta         int     `json:"pod_delta"`
+	RunningPodDelta  int     `json:"running_pod_delta"`
+	FailedPodDelta   int     `json:"failed_pod_delta"`
+	NodeDelta        int     `json:"node_delta"`
+
+	AddedDeployments   []string        `json:"added_deployments,omitempty"` // namespace/name
+	RemovedDeployments []string        `json:"removed_deployments,omitempty"`
+	ReplicaChanges     []ReplicaChange `json:"replica_changes,omitempty"`
+	AddedServices      []string        `json:"added_services,omitempty"`
+	RemovedServices    []string        `json:"removed_services,omitempty"`
+	AddedImages        []string        `json:"added_images,omitempty"`
+	RemovedImages      []string        `json:"removed_images,omitempty"`
+
+	NewWarnings      []EventInfo   `json:"new_warnings,omitempty"`
+	NewFindings      []k8s.Finding `json:"new_findings,omitempty"`
+	ResolvedFindings []k8s.Finding `json:"resolved_findings,omitempty"`
+
+	// LoadBalancerCostDelta is the monthly cost change from LoadBalancer
+	// services at the configured price
+	LoadBalancerCostDelta float64 `json:"load_balancer_cost_delta"`
+	// ComputeCostDelta is the change of the monthly cost estimate of the
+	// requested CPU and memory
+	ComputeCostDelta float64 `json:"compute_cost_delta"`
+}
+
+// ReplicaChange is a deployment whose ready replicas changed
+type ReplicaChange struct {
+	Deployment string `json:"deployment"` // namespace/name
+	Before     string `json:"before"`     // ready/desired
+	After      string `json:"after"`
+}
+
+// DiffReports compares two snapshots; lbHourly prices LoadBalancer services
+// for the cost delta. Lists are sorted for stable output.
+func DiffReports(before, after *ComprehensiveReport, lbHourly float64) ReportDiff {
+	d := ReportDiff{
+		From:             before.GeneratedAt,
+		To:               after.GeneratedAt,
+		HealthScoreDelta: after.HealthScore - before.HealthScore,
+		PodDelta:         after.Workloads.TotalPods - before.Workloads.TotalPods,
+		RunningPodDelta:  after.Workloads.RunningPods - before.Workloads.RunningPods,
+		FailedPodDelta:   after.Workloads.FailedPods - before.Workloads.FailedPods,
+		NodeDelta:        after.NodeSummary.Total - before.NodeSummary.Total,
+		ComputeCostDelta: after.CostEstimate.MonthlyTotal - before.CostEstimate.MonthlyTotal,
+	}
+
+	oldDeps := make(map[string]DeploymentInfo, len(before.Deployments))
+	for _, dep := range before.Deployments {
+		oldDeps[dep.Namespace+"/"+dep.Name] = dep
+	}
+	newDeps := make(map[string]bool, len(after.Deployments))
+	for _, dep := range after.Deployments {
+		key := dep.Namespace + "/" + dep.Name
+		newDeps[key] = true
+		old, ok := oldDeps[key]
+		switch {
+		case !ok:
+			d.AddedDeployments = append(d.AddedDeployments, key)
+		case old.Ready != dep.Ready:
+			d.ReplicaChanges = append(d.ReplicaChanges, ReplicaChange{Deployment: key, Before: old.Ready, After: dep.Ready})
+		}
+	}
+	for key := range oldDeps {
+		if !newDeps[key] {
+			d.RemovedDeployments = append(d.RemovedDeployments, key)
+		}
+	}
+
+	serviceKeys := func(r *ComprehensiveReport) map[string]bool {
+		keys := make(map[string]bool, len(r.Services))
+		for _, svc := range r.Services {
+			keys[svc.Namespace+"/"+svc.Name] = true
+		}
+		return keys
+	}
+	d.AddedServices, d.RemovedServices = diffKeys(serviceKeys(before), serviceKeys(after))
+
+	imageKeys := func(r *ComprehensiveReport) map[string]bool {
+		keys := make(map[string]bool, len(r.Images))
+		for _, img := range r.Images {
+			keys[img.Image] = true
+		}
+		return keys
+	}
+	d.AddedImages, d.RemovedImages = diffKeys(imageKeys(before), imageKeys(after))
+
+	oldWarnings := make(map[string]bool, len(before.Events))
+	for _, e := range before.Events {
+		oldWarnings[e.Reason+"/"+e.Object] = true
+	}
+	for _, e := range after.Events {
+		if !oldWarnings[e.Reason+"/"+e.Object] {
+			d.NewWarnings = append(d.NewWarnings, e)
+		}
+	}
+
+	findingKey := func(f k8s.Finding) string {
+		return f.Check + "/" + f.Kind + "/" + f.Namespace + "/" + f.Name
+	}
+	oldFindings := make(map[string]bool, len(before.Findings))
+	for _, f := range before.Findings {
+		oldFindings[findingKey(f)] = true
+	}
+	newFindings := make(map[string]bool, len(after.Findings))
+	for _, f := range after.Findings {
+		newFindings[findingKey(f)] = true
+		if !oldFindings[findingKey(f)] {
+			d.NewFindings = append(d.NewFindings, f)
+		}
+	}
+	for _, f := range before.Findings {
+		if !newFindings[findingKey(f)] {
+			d.ResolvedFindings = append(d.ResolvedFindings, f)
+		}
+	}
+
+	lbDelta := countLoadBalancers(after) - countLoadBalancers(before)
+	d.LoadBalancerCostDelta = float64(lbDelta) * lbHourly * k8s.HoursPerMonth
+
+	sort.Strings(d.AddedDeployments)
+	sort.Strings(d.RemovedDeployments)
+	sort.Slice(d.ReplicaChanges, func(i, j int) bool {
+		return d.ReplicaChanges[i].Deployment < d.ReplicaChanges[j].Deployment
+	})
+	return d
+}
+
+// diffKeys returns the sorted keys only in after and only in before
+func diffKeys(before, after map[string]bool) (added, removed []string) {
+	for k := range after {
+		if !before[k] {
+			added = append(added, k)
+		}
+	}
+	for k := range before {
+		if !after[k] {
+			removed = append(removed, k)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
+
+func countLoadBalancers(r *ComprehensiveReport) int {
+	n := 0
+	for _, svc := range r.Services {
+		if svc.Type == string(corev1.ServiceTypeLoadBalancer) {
+			n++
+		}
+	}
+	return n
+}
+
+// ReportDiffRequest holds the two snapshots to compare, e.g. two saved
+// JSON reports
+type ReportDiffRequest struct {
+	Before *ComprehensiveReport `json:"before"`
+	After  *ComprehensiveReport `json:"after"`
+}
+
+// HandleReportDiff compares two report snapshots: two stored reports with
+// GET ?from=<id>&to=<id> (ids from /api/reports/history), or two posted
+// JSON reports
+func (rg *ReportGenerator) HandleReportDiff(w http.ResponseWriter, r *http.Request) {
+	var req ReportDiffRequest
+	switch r.Method {
+	case http.MethodGet:
+		from, to := r.URL.Query().Get("from"), r.URL.Query().Get("to")
+		if from == "" || to == "" {
+			http.Error(w, "Both from and to report ids are required", http.StatusBadRequest)
+			return
+		}
+		var err error
+		if req.Before, err = rg.loadReport(r, from); err != nil {
+			writeReportLoadError(w, err)
+			return
+		}
+		if req.After, err = rg.loadReport(r, to); err != nil {
+			writeReportLoadError(w, err)
+			return
+		}
+	case http.MethodPost:
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		if req.Before == nil || req.After == nil {
+			http.Error(w, "Both before and after reports are required", http.StatusBadRequest)
+			return
+		}
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	username := r.Header.Get("X-Username")
+	if username == "" {
+		username = "anonymous"
+	}
+	diff := DiffReports(req.Before, req.After, rg.server.cfg.Pricing.LoadBalancerHourly)
+	db.RecordAudit(db.AuditEntry{
+		User:     username,
+		Action:   "diff_reports",
+		Resource: "reports",
+		Details:  fmt.Sprintf("From: %s, To: %s", diff.From.Format(time.RFC3339), diff.To.Format(time.RFC3339)),
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(diff)
+}
@@ -0,0 +1,126 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/db"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// defaultHistoryLimit is how many stored reports /api/reports/history lists
+// unless limit is given
+const defaultHistoryLimit = 50
+
+// saveReport stores a snapshot of report for the history; a failure only
+// costs the snapshot
+func (rg *ReportGenerator) saveReport(report *ComprehensiveReport) {
+	data, err := json.Marshal(report)
+	if err != nil {
+		return
+	}
+	db.SaveReport(db.ReportRecord{
+		GeneratedAt: report.GeneratedAt,
+		User:        report.GeneratedBy,
+		Namespace:   report.Namespace,
+		HealthScore: report.HealthScore,
+	}, data)
+}
+
+// reportReader returns the user whose stored reports r may read, or "" when
+// r may read them all: admins may, and so may everyone with auth disabled
+func (rg *ReportGenerator) reportReader(r *http.Request) string {
+	if !rg.server.authManager.config.Enabled || RoleAllows(r.Header.Get("X-User-Role"), RoleAdmin) {
+		return ""
+	}
+	if user := r.Header.Get("X-Username"); user != "" {
+		return user
+	}
+	return "anonymous"
+}
+
+// loadReport reads the stored report with the id given as a query value.
+// Reports of other users are not found unless r may read them all.
+func (rg *ReportGenerator) loadReport(r *http.Request, id string) (*ComprehensiveReport, error) {
+	n, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid report id %q", id)
+	}
+	rec, data, err := db.GetReport(n)
+	if err != nil {
+		return nil, err
+	}
+	if reader := rg.reportReader(r); reader != "" && rec.User != reader {
+		return nil, db.ErrReportNotFound
+	}
+	var report ComprehensiveReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// HandleReportHistory lists the stored reports, newest first: cluster-wide
+// ones, or those of ?namespace=. ?limit=N caps the list; ?id=N returns that
+// report as JSON instead. Users other than admins only see the reports they
+// generated.
+func (rg *ReportGenerator) HandleReportHistory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if db.DB == nil {
+		http.Error(w, "Report history needs the audit database", http.StatusServiceUnavailable)
+		return
+	}
+
+	q := r.URL.Query()
+	if id := q.Get("id"); id != "" {
+		report, err := rg.loadReport(r, id)
+		if err != nil {
+			writeReportLoadError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(report)
+		return
+	}
+
+	namespace := q.Get("namespace")
+	if namespace != "" && len(validation.IsDNS1123Label(namespace)) > 0 {
+		http.Error(w, "Invalid namespace", http.StatusBadRequest)
+		return
+	}
+	limit := defaultHistoryLimit
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			http.Error(w, fmt.Sprintf("invalid limit %q: must be a positive number", v), http.StatusBadRequest)
+			return
+		}
+		limit = n
+	}
+
+	records, err := db.ListReports(namespace, rg.reportReader(r), limit)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if records == nil {
+		records = []db.ReportRecord{}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(records)
+}
+
+// writeReportLoadError answers a failed loadReport
+func writeReportLoadError(w http.ResponseWriter, err error) {
+	if errors.Is(err, db.ErrReportNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	http.Error(w, err.Error(), http.StatusBadRequest)
+}
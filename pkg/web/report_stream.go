@@ -0,0 +1,97 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/config"
+	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/db"
+)
+
+// StreamAIAnalysis is GenerateAIAnalysis with the analysis passed to
+// callback as it is generated
+func (rg *ReportGenerator) StreamAIAnalysis(ctx context.Context, report *ComprehensiveReport, opts config.ReportAI, callback func(string)) error {
+	if rg.server.aiClient == nil || !rg.server.aiClient.IsReady() {
+		return fmt.Errorf("AI client not available")
+	}
+	return rg.server.aiClient.Ask(ctx, buildAIAnalysisPrompt(report, opts), callback)
+}
+
+// HandleReportAIStream streams the AI analysis of a report as server-sent
+// events, so the report preview can show it while it is being written: of a
+// stored report with GET ?id=<id> (ids from /api/reports/history), or of a
+// posted JSON report, such as the preview's. It takes the ai_words and
+// ai_focus parameters of /api/reports and uses the same protocol as
+// /api/chat/stream: newlines are escaped, errors are sent as "[ERROR] ..."
+// and the stream ends with [DONE].
+func (rg *ReportGenerator) HandleReportAIStream(w http.ResponseWriter, r *http.Request) {
+	var report *ComprehensiveReport
+	switch r.Method {
+	case http.MethodGet:
+		id := r.URL.Query().Get("id")
+		if id == "" {
+			http.Error(w, "A report id is required", http.StatusBadRequest)
+			return
+		}
+		var err error
+		if report, err = rg.loadReport(r, id); err != nil {
+			writeReportLoadError(w, err)
+			return
+		}
+	case http.MethodPost:
+		if err := json.NewDecoder(r.Body).Decode(&report); err != nil || report == nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	username := r.Header.Get("X-Username")
+	if username == "" {
+		username = "anonymous"
+	}
+	aiOpts, err := aiOptionsFromQuery(r.URL.Query(), rg.server.cfg.ReportAI)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if rg.server.aiClient == nil || !rg.server.aiClient.IsReady() {
+		http.Error(w, "AI client not configured", http.StatusServiceUnavailable)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	scope := "cluster"
+	if report.Namespace != "" {
+		scope = "namespace/" + report.Namespace
+	}
+	db.RecordAudit(db.AuditEntry{
+		User:     username,
+		Action:   "stream_report_analysis",
+		Resource: scope,
+		Details:  fmt.Sprintf("Words: %d, Focus: %s", aiOpts.MaxWords, strings.Join(aiOpts.Focus, ",")),
+	})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	sse := &SSEWriter{w: w, flusher: flusher}
+	err = rg.StreamAIAnalysis(r.Context(), report, aiOpts, func(text string) {
+		sse.Write(strings.ReplaceAll(text, "\n", "\\n"))
+	})
+	if err != nil {
+		sse.Write(fmt.Sprintf("[ERROR] %s", err.Error()))
+	}
+	sse.Write("[DONE]")
+}
@@ -1,24 +1,35 @@
 package web
 
 import (
+	"archive/zip"
 	"bytes"
 	"context"
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"html"
+	"io"
 	"net/http"
+	"net/url"
+	"os/exec"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/config"
 	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/db"
+	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/k8s"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 // ComprehensiveReport contains all cluster information for export
 type ComprehensiveReport struct {
 	GeneratedAt   time.Time              `json:"generated_at"`
 	GeneratedBy   string                 `json:"generated_by"`
+	Namespace     string                 `json:"namespace,omitempty"` // set for namespace-scoped reports
 	ClusterInfo   ClusterInfo            `json:"cluster_info"`
 	NodeSummary   NodeSummary            `json:"node_summary"`
 	Nodes         []NodeInfo             `json:"nodes"`
@@ -30,9 +41,16 @@ type ComprehensiveReport struct {
 	Services      []ServiceInfo          `json:"services"`
 	SecurityInfo  SecurityInfo           `json:"security_info"`
 	Images        []ImageInfo            `json:"images"`
+	Registries    []RegistryInfo         `json:"registries"`
 	Events        []EventInfo            `json:"events"`
+	Findings      []k8s.Finding          `json:"findings"` // ordered by severity
+	FindingSummary k8s.FindingSummary    `json:"finding_summary"`
+	CostEstimate  CostEstimate           `json:"cost_estimate"`
 	AIAnalysis    string                 `json:"ai_analysis,omitempty"`
 	HealthScore   float64                `json:"health_score"`
+	// Errors lists the analyses that failed, e.g. for lack of RBAC; their
+	// findings are missing
+	Errors []string `json:"errors,omitempty"`
 }
 
 type ClusterInfo struct {
@@ -131,13 +149,55 @@ type SecurityInfo struct {
 	PrivilegedPods       int             `json:"privileged_pods"`
 	HostNetworkPods      int             `json:"host_network_pods"`
 	RootContainers       int             `json:"root_containers"`
+	DefaultSAPods        int             `json:"default_service_account_pods"` // pods running as the default service account
+	TokenMountedPods     int             `json:"token_mounted_pods"`           // pods with an API token mounted
+	MutableTagImages     int             `json:"mutable_tag_images"`           // images referenced by "latest" or no tag
+}
+
+// countPod adds the privileged, root and host network containers of a pod
+// and its service account usage
+func (si *SecurityInfo) countPod(pod corev1.Pod) {
+	for _, c := range pod.Spec.Containers {
+		if c.SecurityContext != nil {
+			if c.SecurityContext.Privileged != nil && *c.SecurityContext.Privileged {
+				si.PrivilegedPods++
+			}
+			if c.SecurityContext.RunAsUser != nil && *c.SecurityContext.RunAsUser == 0 {
+				si.RootContainers++
+			}
+		}
+	}
+	if pod.Spec.HostNetwork {
+		si.HostNetworkPods++
+	}
+	if k8s.PodServiceAccount(pod.Spec) == k8s.DefaultServiceAccount {
+		si.DefaultSAPods++
+	}
+	if k8s.HasServiceAccountToken(pod) {
+		si.TokenMountedPods++
+	}
 }
 
 type ImageInfo struct {
 	Image      string `json:"image"`
+	Registry   string `json:"registry"`
 	Repository string `json:"repository"`
 	Tag        string `json:"tag"`
+	Digest     string `json:"digest,omitempty"`
+	Pinned     bool   `json:"pinned"`
+	MutableTag bool   `json:"mutable_tag"` // tag is "latest" or missing and no digest is pinned
 	PodCount   int    `json:"pod_count"`
+	SizeBytes  int64  `json:"size_bytes,omitempty"` // from node image lists; 0 when unknown
+}
+
+// RegistryInfo summarizes the images pulled from one registry host
+type RegistryInfo struct {
+	Registry     string `json:"registry"`
+	Repositories int    `json:"repositories"` // distinct repositories
+	Images       int    `json:"images"`       // distinct image references (tags/digests)
+	Unpinned     int    `json:"unpinned"`     // images referenced without a digest
+	PodCount     int    `json:"pod_count"`
+	SizeBytes    int64  `json:"size_bytes,omitempty"`
 }
 
 type EventInfo struct {
@@ -160,11 +220,15 @@ func NewReportGenerator(server *Server) *ReportGenerator {
 	return &ReportGenerator{server: server}
 }
 
-// GenerateComprehensiveReport gathers all cluster data
-func (rg *ReportGenerator) GenerateComprehensiveReport(ctx context.Context, username string) (*ComprehensiveReport, error) {
+// GenerateComprehensiveReport gathers all cluster data. A non-empty namespace
+// scopes the workloads, events, findings and costs to that namespace. Nodes
+// stay cluster-wide and are left out when the user may not list them, so a
+// scoped report only needs namespace-level permissions.
+func (rg *ReportGenerator) GenerateComprehensiveReport(ctx context.Context, username, namespace string) (*ComprehensiveReport, error) {
 	report := &ComprehensiveReport{
 		GeneratedAt: time.Now(),
 		GeneratedBy: username,
+		Namespace:   namespace,
 	}
 
 	// Get nodes
@@ -222,7 +286,12 @@ func (rg *ReportGenerator) GenerateComprehensiveReport(ctx context.Context, user
 	}
 
 	// Get namespaces
-	namespaces, err := rg.server.k8sClient.ListNamespaces(ctx)
+	var namespaces []corev1.Namespace
+	if namespace == "" {
+		namespaces, err = rg.server.k8sClient.ListNamespaces(ctx)
+	} else {
+		namespaces, err = []corev1.Namespace{rg.scopedNamespace(ctx, namespace)}, nil
+	}
 	if err == nil {
 		report.NamespaceSummary.Total = len(namespaces)
 		for _, ns := range namespaces {
@@ -251,11 +320,13 @@ func (rg *ReportGenerator) GenerateComprehensiveReport(ctx context.Context, user
 	}
 
 	// Gather workload data
-	imageCount := make(map[string]int)
+	var allPods []corev1.Pod
+	var allServices []corev1.Service
 
 	for _, ns := range namespaces {
 		// Pods
 		pods, _ := rg.server.k8sClient.ListPods(ctx, ns.Name)
+		allPods = append(allPods, pods...)
 		for _, pod := range pods {
 			report.Workloads.TotalPods++
 
@@ -278,23 +349,10 @@ func (rg *ReportGenerator) GenerateComprehensiveReport(ctx context.Context, user
 			var images []string
 			for _, c := range pod.Spec.Containers {
 				images = append(images, c.Image)
-				imageCount[c.Image]++
 			}
 
 			// Security checks
-			for _, c := range pod.Spec.Containers {
-				if c.SecurityContext != nil {
-					if c.SecurityContext.Privileged != nil && *c.SecurityContext.Privileged {
-						report.SecurityInfo.PrivilegedPods++
-					}
-					if c.SecurityContext.RunAsUser != nil && *c.SecurityContext.RunAsUser == 0 {
-						report.SecurityInfo.RootContainers++
-					}
-				}
-			}
-			if pod.Spec.HostNetwork {
-				report.SecurityInfo.HostNetworkPods++
-			}
+			report.SecurityInfo.countPod(pod)
 
 			ready := 0
 			total := len(pod.Status.ContainerStatuses)
@@ -351,6 +409,7 @@ func (rg *ReportGenerator) GenerateComprehensiveReport(ctx context.Context, user
 
 		// Services
 		svcs, _ := rg.server.k8sClient.ListServices(ctx, ns.Name)
+		allServices = append(allServices, svcs...)
 		for _, svc := range svcs {
 			report.Workloads.TotalServices++
 
@@ -394,30 +453,44 @@ func (rg *ReportGenerator) GenerateComprehensiveReport(ctx context.Context, user
 		report.SecurityInfo.Secrets += len(secrets)
 	}
 
-	// Build image list
-	for image, count := range imageCount {
-		parts := strings.Split(image, ":")
-		repo := parts[0]
-		tag := "latest"
-		if len(parts) > 1 {
-			tag = parts[1]
+	// Build image list; sizes come from the image lists nodes report
+	imageSizes := make(map[string]int64)
+	for _, node := range nodes {
+		for _, img := range node.Status.Images {
+			for _, name := range img.Names {
+				imageSizes[name] = img.SizeBytes
+			}
+		}
+	}
+	// Images are ordered by pod count
+	imageUsage := k8s.CountImages(allPods)
+	for _, usage := range imageUsage {
+		ref, count := usage.Ref, usage.PodCount
+		size, ok := imageSizes[usage.Image]
+		if !ok {
+			size = imageSizes[ref.FullName()]
 		}
 
 		report.Images = append(report.Images, ImageInfo{
-			Image:      image,
-			Repository: repo,
-			Tag:        tag,
+			Image:      usage.Image,
+			Registry:   ref.Registry,
+			Repository: ref.Repository,
+			Tag:        ref.Tag,
+			Digest:     ref.Digest,
+			Pinned:     ref.Pinned(),
+			MutableTag: ref.MutableTag(),
 			PodCount:   count,
+			SizeBytes:  size,
 		})
+		if ref.MutableTag() {
+			report.SecurityInfo.MutableTagImages++
+		}
 	}
 
-	// Sort images by pod count
-	sort.Slice(report.Images, func(i, j int) bool {
-		return report.Images[i].PodCount > report.Images[j].PodCount
-	})
+	report.Registries = summarizeRegistries(report.Images)
 
 	// Get events (warnings only, last 50)
-	events, _ := rg.server.k8sClient.ListEvents(ctx, "")
+	events, _ := rg.server.k8sClient.ListEvents(ctx, namespace)
 	warningEvents := []EventInfo{}
 	for _, event := range events {
 		if event.Type == "Warning" {
@@ -438,6 +511,19 @@ func (rg *ReportGenerator) GenerateComprehensiveReport(ctx context.Context, user
 	}
 	report.Events = warningEvents
 
+	// Cluster analyses (PDB coverage, request/limit ratios); a failed check
+	// only drops its own findings
+	findings, err := rg.server.k8sClient.AnalyzeFindings(ctx, namespace)
+	if err != nil {
+		report.Errors = append(report.Errors, err.Error())
+	}
+	report.Findings = append(report.Findings, findings...)
+	report.Findings = append(report.Findings, k8s.CheckLoadBalancerConsolidation(allServices, rg.server.cfg.Pricing.LoadBalancerHourly)...)
+	report.Findings = append(report.Findings, k8s.CheckMutableImageTags(imageUsage)...)
+	report.Findings = k8s.ClassifyFindings(report.Findings)
+	report.FindingSummary = k8s.SummarizeFindings(report.Findings)
+	report.CostEstimate = generateFinOpsAnalysis(allPods, NewPricingModel(rg.server.cfg.Pricing))
+
 	// Calculate health score
 	report.HealthScore = calculateHealthScore(
 		report.NodeSummary.Ready, report.NodeSummary.Total,
@@ -453,14 +539,56 @@ func (rg *ReportGenerator) GenerateComprehensiveReport(ctx context.Context, user
 	return report, nil
 }
 
-// GenerateAIAnalysis uses LLM to analyze the cluster state
-func (rg *ReportGenerator) GenerateAIAnalysis(ctx context.Context, report *ComprehensiveReport) (string, error) {
+// scopedNamespace fetches the namespace a report is scoped to. Users allowed
+// to read a namespace's workloads may not be allowed to get the namespace
+// itself, so a failed lookup falls back to a stub with just the name.
+func (rg *ReportGenerator) scopedNamespace(ctx context.Context, name string) corev1.Namespace {
+	ns, err := rg.server.k8sClient.Clientset.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}, Status: corev1.NamespaceStatus{Phase: corev1.NamespaceActive}}
+	}
+	return *ns
+}
+
+// GenerateAIAnalysis uses LLM to analyze the cluster state; opts sets the
+// length and the topics to emphasize
+func (rg *ReportGenerator) GenerateAIAnalysis(ctx context.Context, report *ComprehensiveReport, opts config.ReportAI) (string, error) {
 	if rg.server.aiClient == nil || !rg.server.aiClient.IsReady() {
 		return "", fmt.Errorf("AI client not available")
 	}
 
-	// Build summary for AI
-	prompt := fmt.Sprintf(`You are a Kubernetes expert. Analyze this cluster state and provide a brief professional report (max 500 words).
+	analysis, err := rg.server.aiClient.AskNonStreaming(ctx, buildAIAnalysisPrompt(report, opts))
+	if err != nil {
+		return "", err
+	}
+
+	return analysis, nil
+}
+
+// aiFocusSections are the sections the analysis asks for per focus, and the
+// finding category they draw on
+var aiFocusSections = map[string]struct {
+	section  string
+	category string
+}{
+	"security":    {"Security posture: privileged or root containers, RBAC and service account risks", k8s.CategorySecurity},
+	"reliability": {"Reliability risks: failing pods, probes, PodDisruptionBudgets and resource limits", k8s.CategoryReliability},
+	"finops":      {"Cost optimization: unused or oversized resources and LoadBalancer consolidation", k8s.CategoryCost},
+}
+
+// maxAIFocusFindings caps the findings listed per focus topic in the prompt
+const maxAIFocusFindings = 10
+
+// buildAIAnalysisPrompt builds the report analysis prompt. Without a focus
+// the analysis is balanced; each focus adds its own section and its top
+// findings to the prompt.
+func buildAIAnalysisPrompt(report *ComprehensiveReport, opts config.ReportAI) string {
+	maxWords := opts.MaxWords
+	if maxWords <= 0 {
+		maxWords = 500
+	}
+
+	prompt := fmt.Sprintf(`You are a Kubernetes expert. Analyze this cluster state and provide a brief professional report (max %d words).
 
 Cluster Summary:
 - Nodes: %d total, %d ready, %d not ready
@@ -468,40 +596,147 @@ Cluster Summary:
 - Deployments: %d total, %d healthy
 - Services: %d
 - Health Score: %.1f%%
+- Requested Compute: %.1f CPU cores, %.1f GB memory, about $%.2f/month at %s prices
 
 Security Concerns:
 - Privileged Pods: %d
 - Host Network Pods: %d
 - Root Containers: %d
 
+Findings: %d critical, %d high, %d medium, %d low
+
 Warning Events: %d
 
 Top Images Used:
 %s
-
-Please provide:
-1. Overall cluster health assessment
-2. Key issues or concerns (if any)
-3. Recommendations for improvement
-4. Security observations
-
-Be concise and actionable.`,
+`,
+		maxWords,
 		report.NodeSummary.Total, report.NodeSummary.Ready, report.NodeSummary.NotReady,
 		report.Workloads.TotalPods, report.Workloads.RunningPods, report.Workloads.PendingPods, report.Workloads.FailedPods,
 		report.Workloads.TotalDeployments, report.Workloads.HealthyDeploys,
 		report.Workloads.TotalServices,
 		report.HealthScore,
+		report.CostEstimate.CPUCores, report.CostEstimate.MemoryGB, report.CostEstimate.MonthlyTotal, report.CostEstimate.Provider,
 		report.SecurityInfo.PrivilegedPods, report.SecurityInfo.HostNetworkPods, report.SecurityInfo.RootContainers,
+		report.FindingSummary.Critical, report.FindingSummary.High, report.FindingSummary.Medium, report.FindingSummary.Low,
 		len(report.Events),
 		formatTopImages(report.Images, 5),
 	)
 
-	analysis, err := rg.server.aiClient.AskNonStreaming(ctx, prompt)
-	if err != nil {
-		return "", err
+	sections := []string{"Overall cluster health assessment", "Key issues or concerns (if any)"}
+	if len(opts.Focus) == 0 {
+		sections = append(sections, "Recommendations for improvement", "Security observations")
+	} else {
+		var topics []string
+		for _, focus := range opts.Focus {
+			fs, ok := aiFocusSections[focus]
+			if !ok {
+				continue
+			}
+			topics = append(topics, focus)
+			sections = append(sections, fs.section)
+
+			var lines []string
+			for _, f := range report.Findings {
+				if f.Category == fs.category && len(lines) < maxAIFocusFindings {
+					lines = append(lines, fmt.Sprintf("- [%s] %s %s/%s: %s", f.Severity, f.Kind, f.Namespace, f.Name, f.Message))
+				}
+			}
+			if len(lines) > 0 {
+				prompt += fmt.Sprintf("\nTop %s findings:\n%s\n", focus, strings.Join(lines, "\n"))
+			}
+		}
+		sections = append(sections, "Prioritized recommendations")
+		prompt += fmt.Sprintf("\nFocus on %s; keep other topics to a sentence or two.\n", strings.Join(topics, " and "))
 	}
 
-	return analysis, nil
+	prompt += "\nPlease provide:\n"
+	for i, section := range sections {
+		prompt += fmt.Sprintf("%d. %s\n", i+1, section)
+	}
+	prompt += "\nBe concise and actionable."
+
+	if report.Namespace != "" {
+		prompt += fmt.Sprintf("\n\nNote: this report covers only the %q namespace; node figures are cluster-wide.", report.Namespace)
+	}
+	return prompt
+}
+
+// aiOptionsFromQuery overrides the configured analysis options with ai_words
+// and ai_focus (comma-separated security, reliability, finops)
+func aiOptionsFromQuery(q url.Values, opts config.ReportAI) (config.ReportAI, error) {
+	if v := q.Get("ai_words"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 50 || n > 5000 {
+			return opts, fmt.Errorf("invalid ai_words %q: must be between 50 and 5000", v)
+		}
+		opts.MaxWords = n
+	}
+	if v := q.Get("ai_focus"); v != "" {
+		opts.Focus = nil
+		for _, focus := range strings.Split(v, ",") {
+			focus = strings.TrimSpace(focus)
+			if _, ok := aiFocusSections[focus]; !ok {
+				return opts, fmt.Errorf("invalid ai_focus %q: must be %s", focus, strings.Join(config.ReportAIFocuses, ", "))
+			}
+			opts.Focus = append(opts.Focus, focus)
+		}
+	}
+	return opts, nil
+}
+
+// summarizeRegistries groups images by registry host, busiest first
+func summarizeRegistries(images []ImageInfo) []RegistryInfo {
+	byRegistry := make(map[string]*RegistryInfo)
+	repos := make(map[string]map[string]bool)
+	var order []string
+	for _, img := range images {
+		r, ok := byRegistry[img.Registry]
+		if !ok {
+			r = &RegistryInfo{Registry: img.Registry}
+			byRegistry[img.Registry] = r
+			repos[img.Registry] = make(map[string]bool)
+			order = append(order, img.Registry)
+		}
+		repos[img.Registry][img.Repository] = true
+		r.Images++
+		r.PodCount += img.PodCount
+		r.SizeBytes += img.SizeBytes
+		if !img.Pinned {
+			r.Unpinned++
+		}
+	}
+
+	result := make([]RegistryInfo, 0, len(order))
+	for _, name := range order {
+		r := byRegistry[name]
+		r.Repositories = len(repos[name])
+		result = append(result, *r)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].PodCount != result[j].PodCount {
+			return result[i].PodCount > result[j].PodCount
+		}
+		return result[i].Registry < result[j].Registry
+	})
+	return result
+}
+
+// formatBytes renders a byte count with binary units; 0 is shown as "-"
+func formatBytes(n int64) string {
+	if n <= 0 {
+		return "-"
+	}
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
 }
 
 func formatTopImages(images []ImageInfo, limit int) string {
@@ -533,53 +768,129 @@ func calculateHealthScore(healthyNodes, totalNodes, runningPods, totalPods int)
 	return nodeScore + podScore
 }
 
-// ExportToCSV generates CSV format report
-func (rg *ReportGenerator) ExportToCSV(report *ComprehensiveReport) ([]byte, error) {
-	var buf bytes.Buffer
-	writer := csv.NewWriter(&buf)
+// reportCSS styles the HTML reports
+const reportCSS = `body { font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; margin: 40px; color: #333; }
+h1 { color: #1a1b26; border-bottom: 3px solid #7aa2f7; padding-bottom: 10px; }
+h2 { color: #24283b; margin-top: 30px; border-bottom: 1px solid #ddd; padding-bottom: 5px; }
+table { width: 100%; border-collapse: collapse; margin: 15px 0; font-size: 12px; }
+th, td { padding: 8px 12px; text-align: left; border: 1px solid #ddd; }
+th { background: #24283b; color: white; }
+tr:nth-child(even) { background: #f5f5f5; }
+.metric-card { display: inline-block; background: #f0f0f0; padding: 15px 25px; margin: 10px; border-radius: 8px; text-align: center; }
+.metric-value { font-size: 28px; font-weight: bold; color: #7aa2f7; }
+.metric-label { font-size: 12px; color: #666; margin-top: 5px; }
+.health-score { font-size: 48px; font-weight: bold; color: #9ece6a; }
+.health-score.warning { color: #e0af68; }
+.health-score.critical { color: #f7768e; }
+.status-running { color: #9ece6a; font-weight: bold; }
+.status-pending { color: #e0af68; font-weight: bold; }
+.status-failed { color: #f7768e; font-weight: bold; }
+.ai-analysis { background: #f8f9fa; border-left: 4px solid #7aa2f7; padding: 20px; margin: 20px 0; white-space: pre-wrap; }
+.mutable-tag td { background: #fff3cd; }
+.warning { background: #fff3cd; border-left: 4px solid #e0af68; padding: 10px 15px; margin: 10px 0; }
+.footer { margin-top: 40px; text-align: center; color: #999; font-size: 11px; }
+@media print { body { margin: 20px; } }
+`
+
+// severityClass colors a finding severity in the HTML report
+func severityClass(severity string) string {
+	switch severity {
+	case k8s.SeverityCritical, k8s.SeverityHigh:
+		return "status-failed"
+	case k8s.SeverityMedium:
+		return "status-pending"
+	}
+	return ""
+}
 
-	// Write header section
-	writer.Write([]string{"K13s Cluster Report"})
-	writer.Write([]string{"Generated At:", report.GeneratedAt.Format(time.RFC3339)})
-	writer.Write([]string{"Generated By:", report.GeneratedBy})
-	writer.Write([]string{"Health Score:", fmt.Sprintf("%.1f%%", report.HealthScore)})
-	writer.Write([]string{""})
-
-	// Cluster Summary
-	writer.Write([]string{"=== CLUSTER SUMMARY ==="})
-	writer.Write([]string{"Metric", "Value"})
-	writer.Write([]string{"Total Nodes", fmt.Sprintf("%d", report.NodeSummary.Total)})
-	writer.Write([]string{"Ready Nodes", fmt.Sprintf("%d", report.NodeSummary.Ready)})
-	writer.Write([]string{"Total Pods", fmt.Sprintf("%d", report.Workloads.TotalPods)})
-	writer.Write([]string{"Running Pods", fmt.Sprintf("%d", report.Workloads.RunningPods)})
-	writer.Write([]string{"Pending Pods", fmt.Sprintf("%d", report.Workloads.PendingPods)})
-	writer.Write([]string{"Failed Pods", fmt.Sprintf("%d", report.Workloads.FailedPods)})
-	writer.Write([]string{"Total Deployments", fmt.Sprintf("%d", report.Workloads.TotalDeployments)})
-	writer.Write([]string{"Healthy Deployments", fmt.Sprintf("%d", report.Workloads.HealthyDeploys)})
-	writer.Write([]string{"Total Services", fmt.Sprintf("%d", report.Workloads.TotalServices)})
-	writer.Write([]string{""})
+// utf8BOM makes Excel read CSV files as UTF-8
+const utf8BOM = "\ufeff"
 
-	// Nodes
-	writer.Write([]string{"=== NODES ==="})
-	writer.Write([]string{"Name", "Status", "Roles", "Version", "CPU", "Memory", "IP"})
-	for _, node := range report.Nodes {
-		writer.Write([]string{
-			node.Name,
-			node.Status,
-			strings.Join(node.Roles, ","),
-			node.KubeletVersion,
-			node.CPUCapacity,
-			node.MemoryCapacity,
-			node.InternalIP,
-		})
+// csvSection is one table of the CSV report; File names it in a zip export
+type csvSection struct {
+	Title string // "" for the report header
+	File  string
+	Rows  [][]string
+}
+
+// costRows lists a cost estimate as metric and value pairs for the exports
+func costRows(est CostEstimate) [][]string {
+	return [][]string{
+		{"Pricing Provider", est.Provider},
+		{"CPU per vCPU-hour", fmt.Sprintf("$%.4f", est.CPUHourly)},
+		{"Memory per GB-hour", fmt.Sprintf("$%.4f", est.MemoryGBHourly)},
+		{"Requested CPU", fmt.Sprintf("%.2f cores", est.CPUCores)},
+		{"Requested Memory", fmt.Sprintf("%.2f GB", est.MemoryGB)},
+		{"Monthly CPU", fmt.Sprintf("$%.2f", est.MonthlyCPU)},
+		{"Monthly Memory", fmt.Sprintf("$%.2f", est.MonthlyMemory)},
+		{"Monthly Total", fmt.Sprintf("$%.2f", est.MonthlyTotal)},
+		{"Containers without Requests", fmt.Sprintf("%d", est.Unrequested)},
 	}
-	writer.Write([]string{""})
+}
 
-	// Namespaces
-	writer.Write([]string{"=== NAMESPACES ==="})
-	writer.Write([]string{"Name", "Status", "Pods", "Deployments", "Services"})
-	for _, ns := range report.Namespaces {
-		writer.Write([]string{
+// namespaceCostRow lists the namespace, requests and monthly cost of nc
+func namespaceCostRow(nc NamespaceCost) []string {
+	return []string{
+		nc.Namespace,
+		fmt.Sprintf("%.2f cores", nc.CPUCores),
+		fmt.Sprintf("%.2f GB", nc.MemoryGB),
+		fmt.Sprintf("$%.2f", nc.MonthlyTotal),
+	}
+}
+
+// csvSections lays out the report as CSV tables, truncated to limits
+func csvSections(report *ComprehensiveReport, limits config.ReportLimits) []csvSection {
+	var sections []csvSection
+	add := func(title, file string, rows ...[]string) *csvSection {
+		sections = append(sections, csvSection{Title: title, File: file, Rows: rows})
+		return &sections[len(sections)-1]
+	}
+	truncated := func(sec *csvSection, n, total int, what string) {
+		if n < total {
+			sec.Rows = append(sec.Rows, []string{fmt.Sprintf("Showing first %d of %d %s", n, total, what)})
+		}
+	}
+
+	header := add("", "report.csv",
+		[]string{"K13s Cluster Report"},
+		[]string{"Generated At:", report.GeneratedAt.Format(time.RFC3339)},
+		[]string{"Generated By:", report.GeneratedBy})
+	if report.Namespace != "" {
+		header.Rows = append(header.Rows, []string{"Namespace:", report.Namespace})
+	}
+	header.Rows = append(header.Rows, []string{"Health Score:", fmt.Sprintf("%.1f%%", report.HealthScore)})
+
+	add("CLUSTER SUMMARY", "summary.csv",
+		[]string{"Metric", "Value"},
+		[]string{"Total Nodes", fmt.Sprintf("%d", report.NodeSummary.Total)},
+		[]string{"Ready Nodes", fmt.Sprintf("%d", report.NodeSummary.Ready)},
+		[]string{"Total Pods", fmt.Sprintf("%d", report.Workloads.TotalPods)},
+		[]string{"Running Pods", fmt.Sprintf("%d", report.Workloads.RunningPods)},
+		[]string{"Pending Pods", fmt.Sprintf("%d", report.Workloads.PendingPods)},
+		[]string{"Failed Pods", fmt.Sprintf("%d", report.Workloads.FailedPods)},
+		[]string{"Total Deployments", fmt.Sprintf("%d", report.Workloads.TotalDeployments)},
+		[]string{"Healthy Deployments", fmt.Sprintf("%d", report.Workloads.HealthyDeploys)},
+		[]string{"Total Services", fmt.Sprintf("%d", report.Workloads.TotalServices)})
+
+	if report.Namespace == "" || len(report.Nodes) > 0 {
+		sec := add("NODES", "nodes.csv", []string{"Name", "Status", "Roles", "Version", "CPU", "Memory", "IP"})
+		for _, node := range report.Nodes {
+			sec.Rows = append(sec.Rows, []string{
+				node.Name,
+				node.Status,
+				strings.Join(node.Roles, ","),
+				node.KubeletVersion,
+				node.CPUCapacity,
+				node.MemoryCapacity,
+				node.InternalIP,
+			})
+		}
+	}
+
+	sec := add("NAMESPACES", "namespaces.csv", []string{"Name", "Status", "Pods", "Deployments", "Services"})
+	nsShown := rowLimit(len(report.Namespaces), limits.Namespaces)
+	for _, ns := range report.Namespaces[:nsShown] {
+		sec.Rows = append(sec.Rows, []string{
 			ns.Name,
 			ns.Status,
 			fmt.Sprintf("%d", ns.PodCount),
@@ -587,13 +898,12 @@ func (rg *ReportGenerator) ExportToCSV(report *ComprehensiveReport) ([]byte, err
 			fmt.Sprintf("%d", ns.ServiceCount),
 		})
 	}
-	writer.Write([]string{""})
+	truncated(sec, nsShown, len(report.Namespaces), "namespaces")
 
-	// Pods
-	writer.Write([]string{"=== PODS ==="})
-	writer.Write([]string{"Name", "Namespace", "Status", "Ready", "Restarts", "Node", "IP", "Age"})
-	for _, pod := range report.Pods {
-		writer.Write([]string{
+	sec = add("PODS", "pods.csv", []string{"Name", "Namespace", "Status", "Ready", "Restarts", "Node", "IP", "Age"})
+	podsShown := rowLimit(len(report.Pods), limits.Pods)
+	for _, pod := range report.Pods[:podsShown] {
+		sec.Rows = append(sec.Rows, []string{
 			pod.Name,
 			pod.Namespace,
 			pod.Status,
@@ -604,13 +914,11 @@ func (rg *ReportGenerator) ExportToCSV(report *ComprehensiveReport) ([]byte, err
 			pod.Age,
 		})
 	}
-	writer.Write([]string{""})
+	truncated(sec, podsShown, len(report.Pods), "pods")
 
-	// Deployments
-	writer.Write([]string{"=== DEPLOYMENTS ==="})
-	writer.Write([]string{"Name", "Namespace", "Ready", "Up-to-date", "Available", "Strategy", "Age"})
+	sec = add("DEPLOYMENTS", "deployments.csv", []string{"Name", "Namespace", "Ready", "Up-to-date", "Available", "Strategy", "Age"})
 	for _, dep := range report.Deployments {
-		writer.Write([]string{
+		sec.Rows = append(sec.Rows, []string{
 			dep.Name,
 			dep.Namespace,
 			dep.Ready,
@@ -620,13 +928,10 @@ func (rg *ReportGenerator) ExportToCSV(report *ComprehensiveReport) ([]byte, err
 			dep.Age,
 		})
 	}
-	writer.Write([]string{""})
 
-	// Services
-	writer.Write([]string{"=== SERVICES ==="})
-	writer.Write([]string{"Name", "Namespace", "Type", "ClusterIP", "ExternalIP", "Ports", "Age"})
+	sec = add("SERVICES", "services.csv", []string{"Name", "Namespace", "Type", "ClusterIP", "ExternalIP", "Ports", "Age"})
 	for _, svc := range report.Services {
-		writer.Write([]string{
+		sec.Rows = append(sec.Rows, []string{
 			svc.Name,
 			svc.Namespace,
 			svc.Type,
@@ -636,40 +941,66 @@ func (rg *ReportGenerator) ExportToCSV(report *ComprehensiveReport) ([]byte, err
 			svc.Age,
 		})
 	}
-	writer.Write([]string{""})
 
-	// Images
-	writer.Write([]string{"=== CONTAINER IMAGES ==="})
-	writer.Write([]string{"Image", "Repository", "Tag", "Pod Count"})
-	for _, img := range report.Images {
-		writer.Write([]string{
+	sec = add("CONTAINER IMAGES", "images.csv", []string{"Image", "Registry", "Repository", "Tag", "Digest Pinned", "Mutable Tag", "Size", "Pod Count"})
+	imagesShown := rowLimit(len(report.Images), limits.Images)
+	for _, img := range report.Images[:imagesShown] {
+		sec.Rows = append(sec.Rows, []string{
 			img.Image,
+			img.Registry,
 			img.Repository,
 			img.Tag,
+			fmt.Sprintf("%v", img.Pinned),
+			fmt.Sprintf("%v", img.MutableTag),
+			formatBytes(img.SizeBytes),
 			fmt.Sprintf("%d", img.PodCount),
 		})
 	}
-	writer.Write([]string{""})
+	truncated(sec, imagesShown, len(report.Images), "images")
+
+	sec = add("IMAGE REGISTRIES", "registries.csv", []string{"Registry", "Repositories", "Images", "Unpinned", "Size", "Pod Count"})
+	for _, r := range report.Registries {
+		sec.Rows = append(sec.Rows, []string{
+			r.Registry,
+			fmt.Sprintf("%d", r.Repositories),
+			fmt.Sprintf("%d", r.Images),
+			fmt.Sprintf("%d", r.Unpinned),
+			formatBytes(r.SizeBytes),
+			fmt.Sprintf("%d", r.PodCount),
+		})
+	}
 
-	// Security
-	writer.Write([]string{"=== SECURITY SUMMARY ==="})
-	writer.Write([]string{"Metric", "Value"})
-	writer.Write([]string{"Secrets Count", fmt.Sprintf("%d", report.SecurityInfo.Secrets)})
-	writer.Write([]string{"Privileged Pods", fmt.Sprintf("%d", report.SecurityInfo.PrivilegedPods)})
-	writer.Write([]string{"Host Network Pods", fmt.Sprintf("%d", report.SecurityInfo.HostNetworkPods)})
-	writer.Write([]string{"Root Containers", fmt.Sprintf("%d", report.SecurityInfo.RootContainers)})
-	writer.Write([]string{""})
+	add("SECURITY SUMMARY", "security.csv",
+		[]string{"Metric", "Value"},
+		[]string{"Secrets Count", fmt.Sprintf("%d", report.SecurityInfo.Secrets)},
+		[]string{"Privileged Pods", fmt.Sprintf("%d", report.SecurityInfo.PrivilegedPods)},
+		[]string{"Host Network Pods", fmt.Sprintf("%d", report.SecurityInfo.HostNetworkPods)},
+		[]string{"Root Containers", fmt.Sprintf("%d", report.SecurityInfo.RootContainers)},
+		[]string{"Pods on Default ServiceAccount", fmt.Sprintf("%d", report.SecurityInfo.DefaultSAPods)},
+		[]string{"Pods with API Token", fmt.Sprintf("%d", report.SecurityInfo.TokenMountedPods)},
+		[]string{"Images with Mutable Tags", fmt.Sprintf("%d", report.SecurityInfo.MutableTagImages)})
+
+	sec = add("COST ESTIMATE", "cost.csv", []string{"Metric", "Value"})
+	sec.Rows = append(sec.Rows, costRows(report.CostEstimate)...)
+
+	if costs := report.CostEstimate.Namespaces; len(costs) > 0 {
+		sec = add("COST BY NAMESPACE", "cost-namespaces.csv", []string{"Namespace", "CPU", "Memory", "Monthly"})
+		costsShown := rowLimit(len(costs), limits.Namespaces)
+		for _, nc := range costs[:costsShown] {
+			sec.Rows = append(sec.Rows, namespaceCostRow(nc))
+		}
+		truncated(sec, costsShown, len(costs), "namespaces")
+	}
 
-	// Warning Events
 	if len(report.Events) > 0 {
-		writer.Write([]string{"=== WARNING EVENTS ==="})
-		writer.Write([]string{"Type", "Reason", "Object", "Message", "Count", "Last Seen"})
-		for _, event := range report.Events {
+		sec = add("WARNING EVENTS", "events.csv", []string{"Type", "Reason", "Object", "Message", "Count", "Last Seen"})
+		eventsShown := rowLimit(len(report.Events), limits.Events)
+		for _, event := range report.Events[:eventsShown] {
 			msg := event.Message
 			if len(msg) > 100 {
 				msg = msg[:100] + "..."
 			}
-			writer.Write([]string{
+			sec.Rows = append(sec.Rows, []string{
 				event.Type,
 				event.Reason,
 				event.Object,
@@ -678,16 +1009,43 @@ func (rg *ReportGenerator) ExportToCSV(report *ComprehensiveReport) ([]byte, err
 				event.LastSeen,
 			})
 		}
-		writer.Write([]string{""})
+		truncated(sec, eventsShown, len(report.Events), "warning events")
+	}
+
+	if len(report.Findings) > 0 {
+		sec = add("FINDINGS", "findings.csv", []string{"Severity", "Category", "Check", "Kind", "Namespace", "Name", "Message", "Remediation"})
+		for _, f := range report.Findings {
+			sec.Rows = append(sec.Rows, []string{f.Severity, f.Category, f.Check, f.Kind, f.Namespace, f.Name, f.Message, f.Remediation})
+		}
 	}
 
-	// AI Analysis
 	if report.AIAnalysis != "" {
-		writer.Write([]string{"=== AI ANALYSIS ==="})
 		// Split analysis into lines for CSV
-		lines := strings.Split(report.AIAnalysis, "\n")
-		for _, line := range lines {
-			writer.Write([]string{line})
+		sec = add("AI ANALYSIS", "ai-analysis.csv")
+		for _, line := range strings.Split(report.AIAnalysis, "\n") {
+			sec.Rows = append(sec.Rows, []string{line})
+		}
+	}
+
+	return sections
+}
+
+// ExportToCSV generates CSV format report, truncating sections to limits.
+// Sections follow each other under "=== TITLE ===" rows; with bom set the
+// file starts with a UTF-8 byte order mark for Excel.
+func (rg *ReportGenerator) ExportToCSV(report *ComprehensiveReport, limits config.ReportLimits, bom bool) ([]byte, error) {
+	var buf bytes.Buffer
+	if bom {
+		buf.WriteString(utf8BOM)
+	}
+	writer := csv.NewWriter(&buf)
+	for _, sec := range csvSections(report, limits) {
+		if sec.Title != "" {
+			writer.Write([]string{"=== " + sec.Title + " ==="})
+		}
+		writer.WriteAll(sec.Rows)
+		if sec.Title != "AI ANALYSIS" {
+			writer.Write([]string{""})
 		}
 	}
 
@@ -695,8 +1053,35 @@ func (rg *ReportGenerator) ExportToCSV(report *ComprehensiveReport) ([]byte, err
 	return buf.Bytes(), writer.Error()
 }
 
-// ExportToHTML generates HTML format for PDF conversion
-func (rg *ReportGenerator) ExportToHTML(report *ComprehensiveReport) string {
+// ExportToCSVZip generates a zip with one CSV file per report section, so
+// each opens as a clean table in a spreadsheet
+func (rg *ReportGenerator) ExportToCSVZip(report *ComprehensiveReport, limits config.ReportLimits, bom bool) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, sec := range csvSections(report, limits) {
+		f, err := zw.Create(sec.File)
+		if err != nil {
+			return nil, err
+		}
+		if bom {
+			if _, err := io.WriteString(f, utf8BOM); err != nil {
+				return nil, err
+			}
+		}
+		writer := csv.NewWriter(f)
+		if err := writer.WriteAll(sec.Rows); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ExportToHTML generates HTML format for PDF conversion, truncating sections
+// to limits
+func (rg *ReportGenerator) ExportToHTML(report *ComprehensiveReport, limits config.ReportLimits) string {
 	var sb strings.Builder
 
 	sb.WriteString(`<!DOCTYPE html>
@@ -705,35 +1090,22 @@ func (rg *ReportGenerator) ExportToHTML(report *ComprehensiveReport) string {
 <meta charset="UTF-8">
 <title>K13s Cluster Report</title>
 <style>
-body { font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; margin: 40px; color: #333; }
-h1 { color: #1a1b26; border-bottom: 3px solid #7aa2f7; padding-bottom: 10px; }
-h2 { color: #24283b; margin-top: 30px; border-bottom: 1px solid #ddd; padding-bottom: 5px; }
-table { width: 100%; border-collapse: collapse; margin: 15px 0; font-size: 12px; }
-th, td { padding: 8px 12px; text-align: left; border: 1px solid #ddd; }
-th { background: #24283b; color: white; }
-tr:nth-child(even) { background: #f5f5f5; }
-.metric-card { display: inline-block; background: #f0f0f0; padding: 15px 25px; margin: 10px; border-radius: 8px; text-align: center; }
-.metric-value { font-size: 28px; font-weight: bold; color: #7aa2f7; }
-.metric-label { font-size: 12px; color: #666; margin-top: 5px; }
-.health-score { font-size: 48px; font-weight: bold; color: #9ece6a; }
-.health-score.warning { color: #e0af68; }
-.health-score.critical { color: #f7768e; }
-.status-running { color: #9ece6a; font-weight: bold; }
-.status-pending { color: #e0af68; font-weight: bold; }
-.status-failed { color: #f7768e; font-weight: bold; }
-.ai-analysis { background: #f8f9fa; border-left: 4px solid #7aa2f7; padding: 20px; margin: 20px 0; white-space: pre-wrap; }
-.warning { background: #fff3cd; border-left: 4px solid #e0af68; padding: 10px 15px; margin: 10px 0; }
-.footer { margin-top: 40px; text-align: center; color: #999; font-size: 11px; }
-@media print { body { margin: 20px; } }
-</style>
+` + reportCSS + `</style>
 </head>
 <body>
 `)
 
 	// Header
-	sb.WriteString(fmt.Sprintf(`<h1>🚀 K13s Cluster Report</h1>
+	if report.Namespace != "" {
+		sb.WriteString(fmt.Sprintf(`<h1>🚀 K13s Namespace Report: %s</h1>
 <p><strong>Generated:</strong> %s | <strong>By:</strong> %s</p>
-`, report.GeneratedAt.Format("2006-01-02 15:04:05"), report.GeneratedBy))
+<p><em>Scoped to the %s namespace: workloads, events, findings and costs cover only this namespace; nodes are cluster-wide.</em></p>
+`, html.EscapeString(report.Namespace), report.GeneratedAt.Format("2006-01-02 15:04:05"), html.EscapeString(report.GeneratedBy), html.EscapeString(report.Namespace)))
+	} else {
+		sb.WriteString(fmt.Sprintf(`<h1>🚀 K13s Cluster Report</h1>
+<p><strong>Generated:</strong> %s | <strong>By:</strong> %s</p>
+`, report.GeneratedAt.Format("2006-01-02 15:04:05"), html.EscapeString(report.GeneratedBy)))
+	}
 
 	// Health Score
 	healthClass := ""
@@ -749,8 +1121,10 @@ tr:nth-child(even) { background: #f5f5f5; }
 
 	// Summary Cards
 	sb.WriteString(`<div style="text-align: center;">`)
-	sb.WriteString(fmt.Sprintf(`<div class="metric-card"><div class="metric-value">%d</div><div class="metric-label">Nodes (%d Ready)</div></div>`,
-		report.NodeSummary.Total, report.NodeSummary.Ready))
+	if report.Namespace == "" || len(report.Nodes) > 0 {
+		sb.WriteString(fmt.Sprintf(`<div class="metric-card"><div class="metric-value">%d</div><div class="metric-label">Nodes (%d Ready)</div></div>`,
+			report.NodeSummary.Total, report.NodeSummary.Ready))
+	}
 	sb.WriteString(fmt.Sprintf(`<div class="metric-card"><div class="metric-value">%d</div><div class="metric-label">Pods (%d Running)</div></div>`,
 		report.Workloads.TotalPods, report.Workloads.RunningPods))
 	sb.WriteString(fmt.Sprintf(`<div class="metric-card"><div class="metric-value">%d</div><div class="metric-label">Deployments</div></div>`,
@@ -764,41 +1138,50 @@ tr:nth-child(even) { background: #f5f5f5; }
 	// AI Analysis (if available)
 	if report.AIAnalysis != "" {
 		sb.WriteString(`<h2>🤖 AI Analysis</h2>`)
-		sb.WriteString(fmt.Sprintf(`<div class="ai-analysis">%s</div>`, report.AIAnalysis))
+		sb.WriteString(fmt.Sprintf(`<div class="ai-analysis">%s</div>`, html.EscapeString(report.AIAnalysis)))
 	}
 
 	// Nodes
-	sb.WriteString(`<h2>📦 Nodes</h2>`)
-	sb.WriteString(`<table><tr><th>Name</th><th>Status</th><th>Roles</th><th>Version</th><th>CPU</th><th>Memory</th><th>IP</th></tr>`)
-	for _, node := range report.Nodes {
-		statusClass := "status-running"
-		if node.Status != "Ready" {
-			statusClass = "status-failed"
+	if report.Namespace == "" || len(report.Nodes) > 0 {
+		if report.Namespace != "" {
+			sb.WriteString(`<h2>📦 Nodes (cluster-wide)</h2>`)
+		} else {
+			sb.WriteString(`<h2>📦 Nodes</h2>`)
+		}
+		sb.WriteString(`<table><tr><th>Name</th><th>Status</th><th>Roles</th><th>Version</th><th>CPU</th><th>Memory</th><th>IP</th></tr>`)
+		for _, node := range report.Nodes {
+			statusClass := "status-running"
+			if node.Status != "Ready" {
+				statusClass = "status-failed"
+			}
+			sb.WriteString(fmt.Sprintf(`<tr><td>%s</td><td class="%s">%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td></tr>`,
+				html.EscapeString(node.Name), statusClass, html.EscapeString(node.Status), html.EscapeString(strings.Join(node.Roles, ", ")), html.EscapeString(node.KubeletVersion),
+				html.EscapeString(node.CPUCapacity), html.EscapeString(node.MemoryCapacity), html.EscapeString(node.InternalIP)))
 		}
-		sb.WriteString(fmt.Sprintf(`<tr><td>%s</td><td class="%s">%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td></tr>`,
-			node.Name, statusClass, node.Status, strings.Join(node.Roles, ", "), node.KubeletVersion, node.CPUCapacity, node.MemoryCapacity, node.InternalIP))
+		sb.WriteString(`</table>`)
 	}
-	sb.WriteString(`</table>`)
 
 	// Namespaces
 	sb.WriteString(`<h2>📁 Namespaces</h2>`)
+	nsShown := rowLimit(len(report.Namespaces), limits.Namespaces)
+	if nsShown < len(report.Namespaces) {
+		sb.WriteString(fmt.Sprintf(`<p><em>Showing first %d of %d namespaces</em></p>`, nsShown, len(report.Namespaces)))
+	}
 	sb.WriteString(`<table><tr><th>Name</th><th>Status</th><th>Pods</th><th>Deployments</th><th>Services</th></tr>`)
-	for _, ns := range report.Namespaces {
+	for _, ns := range report.Namespaces[:nsShown] {
 		sb.WriteString(fmt.Sprintf(`<tr><td>%s</td><td>%s</td><td>%d</td><td>%d</td><td>%d</td></tr>`,
-			ns.Name, ns.Status, ns.PodCount, ns.DeployCount, ns.ServiceCount))
+			html.EscapeString(ns.Name), html.EscapeString(ns.Status), ns.PodCount, ns.DeployCount, ns.ServiceCount))
 	}
 	sb.WriteString(`</table>`)
 
-	// Pods (limit to first 50 for readability)
+	// Pods
 	sb.WriteString(`<h2>🔸 Pods</h2>`)
-	if len(report.Pods) > 50 {
-		sb.WriteString(fmt.Sprintf(`<p><em>Showing first 50 of %d pods</em></p>`, len(report.Pods)))
+	podsShown := rowLimit(len(report.Pods), limits.Pods)
+	if podsShown < len(report.Pods) {
+		sb.WriteString(fmt.Sprintf(`<p><em>Showing first %d of %d pods</em></p>`, podsShown, len(report.Pods)))
 	}
 	sb.WriteString(`<table><tr><th>Name</th><th>Namespace</th><th>Status</th><th>Ready</th><th>Restarts</th><th>Node</th><th>Age</th></tr>`)
-	for i, pod := range report.Pods {
-		if i >= 50 {
-			break
-		}
+	for _, pod := range report.Pods[:podsShown] {
 		statusClass := "status-running"
 		switch pod.Status {
 		case "Pending":
@@ -807,7 +1190,8 @@ tr:nth-child(even) { background: #f5f5f5; }
 			statusClass = "status-failed"
 		}
 		sb.WriteString(fmt.Sprintf(`<tr><td>%s</td><td>%s</td><td class="%s">%s</td><td>%s</td><td>%d</td><td>%s</td><td>%s</td></tr>`,
-			pod.Name, pod.Namespace, statusClass, pod.Status, pod.Ready, pod.Restarts, pod.Node, pod.Age))
+			html.EscapeString(pod.Name), html.EscapeString(pod.Namespace), statusClass, html.EscapeString(pod.Status), html.EscapeString(pod.Ready), pod.Restarts,
+			html.EscapeString(pod.Node), html.EscapeString(pod.Age)))
 	}
 	sb.WriteString(`</table>`)
 
@@ -816,7 +1200,8 @@ tr:nth-child(even) { background: #f5f5f5; }
 	sb.WriteString(`<table><tr><th>Name</th><th>Namespace</th><th>Ready</th><th>Up-to-date</th><th>Available</th><th>Strategy</th><th>Age</th></tr>`)
 	for _, dep := range report.Deployments {
 		sb.WriteString(fmt.Sprintf(`<tr><td>%s</td><td>%s</td><td>%s</td><td>%d</td><td>%d</td><td>%s</td><td>%s</td></tr>`,
-			dep.Name, dep.Namespace, dep.Ready, dep.UpToDate, dep.Available, dep.Strategy, dep.Age))
+			html.EscapeString(dep.Name), html.EscapeString(dep.Namespace), html.EscapeString(dep.Ready), dep.UpToDate, dep.Available,
+			html.EscapeString(dep.Strategy), html.EscapeString(dep.Age)))
 	}
 	sb.WriteString(`</table>`)
 
@@ -825,20 +1210,37 @@ tr:nth-child(even) { background: #f5f5f5; }
 	sb.WriteString(`<table><tr><th>Name</th><th>Namespace</th><th>Type</th><th>ClusterIP</th><th>ExternalIP</th><th>Ports</th></tr>`)
 	for _, svc := range report.Services {
 		sb.WriteString(fmt.Sprintf(`<tr><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td></tr>`,
-			svc.Name, svc.Namespace, svc.Type, svc.ClusterIP, svc.ExternalIP, svc.Ports))
+			html.EscapeString(svc.Name), html.EscapeString(svc.Namespace), html.EscapeString(svc.Type), html.EscapeString(svc.ClusterIP),
+			html.EscapeString(svc.ExternalIP), html.EscapeString(svc.Ports)))
 	}
 	sb.WriteString(`</table>`)
 
 	// Images
 	sb.WriteString(`<h2>🐳 Container Images</h2>`)
-	sb.WriteString(`<table><tr><th>Image</th><th>Tag</th><th>Pod Count</th></tr>`)
-	for i, img := range report.Images {
-		if i >= 20 {
-			sb.WriteString(fmt.Sprintf(`<tr><td colspan="3"><em>... and %d more images</em></td></tr>`, len(report.Images)-20))
-			break
+	sb.WriteString(`<table><tr><th>Registry</th><th>Repositories</th><th>Images</th><th>Without Digest</th><th>Size</th><th>Pod Count</th></tr>`)
+	for _, r := range report.Registries {
+		sb.WriteString(fmt.Sprintf(`<tr><td>%s</td><td>%d</td><td>%d</td><td>%d</td><td>%s</td><td>%d</td></tr>`,
+			html.EscapeString(r.Registry), r.Repositories, r.Images, r.Unpinned, formatBytes(r.SizeBytes), r.PodCount))
+	}
+	sb.WriteString(`</table>`)
+	sb.WriteString(`<table><tr><th>Image</th><th>Tag</th><th>Digest</th><th>Size</th><th>Pod Count</th></tr>`)
+	imagesShown := rowLimit(len(report.Images), limits.Images)
+	for _, img := range report.Images[:imagesShown] {
+		digest := `<span class="status-pending">not pinned</span>`
+		if img.Pinned {
+			digest = html.EscapeString(img.Digest)
+		}
+		// Highlight images whose tag can change under running pods
+		if img.MutableTag {
+			sb.WriteString(`<tr class="mutable-tag">`)
+		} else {
+			sb.WriteString(`<tr>`)
 		}
-		sb.WriteString(fmt.Sprintf(`<tr><td>%s</td><td>%s</td><td>%d</td></tr>`,
-			img.Repository, img.Tag, img.PodCount))
+		sb.WriteString(fmt.Sprintf(`<td>%s/%s</td><td>%s</td><td>%s</td><td>%s</td><td>%d</td></tr>`,
+			html.EscapeString(img.Registry), html.EscapeString(img.Repository), html.EscapeString(img.Tag), digest, formatBytes(img.SizeBytes), img.PodCount))
+	}
+	if imagesShown < len(report.Images) {
+		sb.WriteString(fmt.Sprintf(`<tr><td colspan="5"><em>... and %d more images</em></td></tr>`, len(report.Images)-imagesShown))
 	}
 	sb.WriteString(`</table>`)
 
@@ -847,31 +1249,86 @@ tr:nth-child(even) { background: #f5f5f5; }
 	if report.SecurityInfo.PrivilegedPods > 0 || report.SecurityInfo.HostNetworkPods > 0 || report.SecurityInfo.RootContainers > 0 {
 		sb.WriteString(`<div class="warning">⚠️ Security concerns detected - review privileged and root containers</div>`)
 	}
+	if report.SecurityInfo.MutableTagImages > 0 {
+		sb.WriteString(fmt.Sprintf(`<div class="warning">⚠️ %d images use the latest tag or no tag - pin them to a version or digest so rollouts and restarts run the tested image</div>`,
+			report.SecurityInfo.MutableTagImages))
+	}
 	sb.WriteString(`<table><tr><th>Metric</th><th>Count</th></tr>`)
 	sb.WriteString(fmt.Sprintf(`<tr><td>Secrets</td><td>%d</td></tr>`, report.SecurityInfo.Secrets))
 	sb.WriteString(fmt.Sprintf(`<tr><td>Privileged Pods</td><td>%d</td></tr>`, report.SecurityInfo.PrivilegedPods))
 	sb.WriteString(fmt.Sprintf(`<tr><td>Host Network Pods</td><td>%d</td></tr>`, report.SecurityInfo.HostNetworkPods))
 	sb.WriteString(fmt.Sprintf(`<tr><td>Root Containers</td><td>%d</td></tr>`, report.SecurityInfo.RootContainers))
+	sb.WriteString(fmt.Sprintf(`<tr><td>Pods on Default ServiceAccount</td><td>%d</td></tr>`, report.SecurityInfo.DefaultSAPods))
+	sb.WriteString(fmt.Sprintf(`<tr><td>Pods with API Token</td><td>%d</td></tr>`, report.SecurityInfo.TokenMountedPods))
+	sb.WriteString(fmt.Sprintf(`<tr><td>Images with Mutable Tags</td><td>%d</td></tr>`, report.SecurityInfo.MutableTagImages))
+	sb.WriteString(`</table>`)
+
+	// Cost Estimate
+	sb.WriteString(`<h2>💰 Cost Estimate</h2>`)
+	if !report.CostEstimate.Priced() {
+		sb.WriteString(`<div class="warning">⚠️ No compute prices for this provider - set pricing.cpu_hourly and pricing.memory_gb_hourly in config.yaml</div>`)
+	}
+	sb.WriteString(`<table><tr><th>Metric</th><th>Value</th></tr>`)
+	for _, r := range costRows(report.CostEstimate) {
+		sb.WriteString(fmt.Sprintf(`<tr><td>%s</td><td>%s</td></tr>`, html.EscapeString(r[0]), html.EscapeString(r[1])))
+	}
 	sb.WriteString(`</table>`)
+	if costs := report.CostEstimate.Namespaces; len(costs) > 0 {
+		sb.WriteString(`<h3>Cost by Namespace</h3>`)
+		sb.WriteString(`<table><tr><th>Namespace</th><th>CPU</th><th>Memory</th><th>Monthly</th></tr>`)
+		costsShown := rowLimit(len(costs), limits.Namespaces)
+		for _, nc := range costs[:costsShown] {
+			r := namespaceCostRow(nc)
+			sb.WriteString(fmt.Sprintf(`<tr><td>%s</td><td>%s</td><td>%s</td><td>%s</td></tr>`,
+				html.EscapeString(r[0]), html.EscapeString(r[1]), html.EscapeString(r[2]), html.EscapeString(r[3])))
+		}
+		sb.WriteString(`</table>`)
+		if costsShown < len(costs) {
+			sb.WriteString(fmt.Sprintf(`<p><em>Showing first %d of %d namespaces</em></p>`, costsShown, len(costs)))
+		}
+	}
 
 	// Warning Events
 	if len(report.Events) > 0 {
 		sb.WriteString(`<h2>⚠️ Warning Events</h2>`)
 		sb.WriteString(`<table><tr><th>Reason</th><th>Object</th><th>Message</th><th>Count</th></tr>`)
-		for i, event := range report.Events {
-			if i >= 20 {
-				break
-			}
+		eventsShown := rowLimit(len(report.Events), limits.Events)
+		for _, event := range report.Events[:eventsShown] {
 			msg := event.Message
 			if len(msg) > 80 {
 				msg = msg[:80] + "..."
 			}
 			sb.WriteString(fmt.Sprintf(`<tr><td>%s</td><td>%s</td><td>%s</td><td>%d</td></tr>`,
-				event.Reason, event.Object, msg, event.Count))
+				html.EscapeString(event.Reason), html.EscapeString(event.Object), html.EscapeString(msg), event.Count))
+		}
+		if eventsShown < len(report.Events) {
+			sb.WriteString(fmt.Sprintf(`<tr><td colspan="4"><em>... and %d more events</em></td></tr>`, len(report.Events)-eventsShown))
 		}
 		sb.WriteString(`</table>`)
 	}
 
+	// Findings
+	if len(report.Findings) > 0 {
+		sum := report.FindingSummary
+		sb.WriteString(`<h2>🔎 Findings</h2>`)
+		sb.WriteString(fmt.Sprintf(`<p><strong>%d findings:</strong> %d critical, %d high, %d medium, %d low</p>`,
+			sum.Total, sum.Critical, sum.High, sum.Medium, sum.Low))
+		sb.WriteString(`<table><tr><th>Severity</th><th>Category</th><th>Check</th><th>Kind</th><th>Namespace</th><th>Name</th><th>Message</th><th>Remediation</th></tr>`)
+		for _, f := range report.Findings {
+			sb.WriteString(fmt.Sprintf(`<tr><td class="%s">%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td></tr>`,
+				severityClass(f.Severity), html.EscapeString(f.Severity), html.EscapeString(f.Category), html.EscapeString(f.Check), html.EscapeString(f.Kind),
+				html.EscapeString(f.Namespace), html.EscapeString(f.Name), html.EscapeString(f.Message), html.EscapeString(f.Remediation)))
+		}
+		sb.WriteString(`</table>`)
+	}
+	if len(report.Errors) > 0 {
+		sb.WriteString(`<div class="warning">Some checks could not run:<ul>`)
+		for _, e := range report.Errors {
+			sb.WriteString(`<li>` + html.EscapeString(e) + `</li>`)
+		}
+		sb.WriteString(`</ul></div>`)
+	}
+
 	// Footer
 	sb.WriteString(`<div class="footer">Generated by k13s - AI-Powered Kubernetes Dashboard</div>`)
 	sb.WriteString(`</body></html>`)
@@ -879,6 +1336,250 @@ tr:nth-child(even) { background: #f5f5f5; }
 	return sb.String()
 }
 
+// ExportToMarkdown generates a Markdown report for tickets, wikis and PR
+// descriptions, truncating sections to limits
+func (rg *ReportGenerator) ExportToMarkdown(report *ComprehensiveReport, limits config.ReportLimits) string {
+	var sb strings.Builder
+	table := func(headers ...string) {
+		sb.WriteString("| " + strings.Join(headers, " | ") + " |\n")
+		sb.WriteString(strings.Repeat("|---", len(headers)) + "|\n")
+	}
+	row := func(cells ...string) {
+		for i, c := range cells {
+			cells[i] = mdCell(c)
+		}
+		sb.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+	truncated := func(n, total int, what string) {
+		if n < total {
+			sb.WriteString(fmt.Sprintf("\n_Showing first %d of %d %s_\n", n, total, what))
+		}
+	}
+
+	if report.Namespace != "" {
+		sb.WriteString(fmt.Sprintf("# K13s Namespace Report: %s\n\n", report.Namespace))
+		sb.WriteString(fmt.Sprintf("_Scoped to the %s namespace: workloads, events, findings and costs cover only this namespace; nodes are cluster-wide._\n\n", report.Namespace))
+	} else {
+		sb.WriteString("# K13s Cluster Report\n\n")
+	}
+	sb.WriteString(fmt.Sprintf("**Generated:** %s | **By:** %s | **Health Score:** %.0f%%\n",
+		report.GeneratedAt.Format("2006-01-02 15:04:05"), report.GeneratedBy, report.HealthScore))
+
+	// Summary
+	sb.WriteString("\n## Summary\n\n")
+	table("Metric", "Value")
+	if report.Namespace == "" || len(report.Nodes) > 0 {
+		row("Nodes", fmt.Sprintf("%d (%d Ready)", report.NodeSummary.Total, report.NodeSummary.Ready))
+	}
+	row("Pods", fmt.Sprintf("%d (%d Running, %d Pending, %d Failed)", report.Workloads.TotalPods,
+		report.Workloads.RunningPods, report.Workloads.PendingPods, report.Workloads.FailedPods))
+	row("Deployments", fmt.Sprintf("%d (%d Healthy)", report.Workloads.TotalDeployments, report.Workloads.HealthyDeploys))
+	row("Services", fmt.Sprintf("%d", report.Workloads.TotalServices))
+	row("Namespaces", fmt.Sprintf("%d", report.NamespaceSummary.Total))
+
+	// AI Analysis is already Markdown
+	if report.AIAnalysis != "" {
+		sb.WriteString("\n## AI Analysis\n\n")
+		sb.WriteString(strings.TrimSpace(report.AIAnalysis) + "\n")
+	}
+
+	if len(report.Nodes) > 0 {
+		if report.Namespace != "" {
+			sb.WriteString("\n## Nodes (cluster-wide)\n\n")
+		} else {
+			sb.WriteString("\n## Nodes\n\n")
+		}
+		table("Name", "Status", "Roles", "Version", "CPU", "Memory", "IP")
+		for _, node := range report.Nodes {
+			row(node.Name, node.Status, strings.Join(node.Roles, ", "), node.KubeletVersion, node.CPUCapacity, node.MemoryCapacity, node.InternalIP)
+		}
+	}
+
+	if len(report.Namespaces) > 0 {
+		sb.WriteString("\n## Namespaces\n\n")
+		table("Name", "Status", "Pods", "Deployments", "Services")
+		nsShown := rowLimit(len(report.Namespaces), limits.Namespaces)
+		for _, ns := range report.Namespaces[:nsShown] {
+			row(ns.Name, ns.Status, fmt.Sprintf("%d", ns.PodCount), fmt.Sprintf("%d", ns.DeployCount), fmt.Sprintf("%d", ns.ServiceCount))
+		}
+		truncated(nsShown, len(report.Namespaces), "namespaces")
+	}
+
+	if len(report.Pods) > 0 {
+		sb.WriteString("\n## Pods\n\n")
+		table("Name", "Namespace", "Status", "Ready", "Restarts", "Node", "Age")
+		podsShown := rowLimit(len(report.Pods), limits.Pods)
+		for _, pod := range report.Pods[:podsShown] {
+			row(pod.Name, pod.Namespace, pod.Status, pod.Ready, fmt.Sprintf("%d", pod.Restarts), pod.Node, pod.Age)
+		}
+		truncated(podsShown, len(report.Pods), "pods")
+	}
+
+	if len(report.Deployments) > 0 {
+		sb.WriteString("\n## Deployments\n\n")
+		table("Name", "Namespace", "Ready", "Up-to-date", "Available", "Strategy", "Age")
+		for _, dep := range report.Deployments {
+			row(dep.Name, dep.Namespace, dep.Ready, fmt.Sprintf("%d", dep.UpToDate), fmt.Sprintf("%d", dep.Available), dep.Strategy, dep.Age)
+		}
+	}
+
+	if len(report.Services) > 0 {
+		sb.WriteString("\n## Services\n\n")
+		table("Name", "Namespace", "Type", "ClusterIP", "ExternalIP", "Ports")
+		for _, svc := range report.Services {
+			row(svc.Name, svc.Namespace, svc.Type, svc.ClusterIP, svc.ExternalIP, svc.Ports)
+		}
+	}
+
+	if len(report.Images) > 0 {
+		sb.WriteString("\n## Container Images\n\n")
+		table("Registry", "Repositories", "Images", "Without Digest", "Size", "Pod Count")
+		for _, r := range report.Registries {
+			row(r.Registry, fmt.Sprintf("%d", r.Repositories), fmt.Sprintf("%d", r.Images), fmt.Sprintf("%d", r.Unpinned), formatBytes(r.SizeBytes), fmt.Sprintf("%d", r.PodCount))
+		}
+		sb.WriteString("\n")
+		table("Image", "Tag", "Digest", "Size", "Pod Count")
+		imagesShown := rowLimit(len(report.Images), limits.Images)
+		for _, img := range report.Images[:imagesShown] {
+			digest := "not pinned"
+			if img.Pinned {
+				digest = img.Digest
+			}
+			row(img.Registry+"/"+img.Repository, img.Tag, digest, formatBytes(img.SizeBytes), fmt.Sprintf("%d", img.PodCount))
+		}
+		truncated(imagesShown, len(report.Images), "images")
+	}
+
+	sb.WriteString("\n## Security Summary\n\n")
+	table("Metric", "Count")
+	row("Secrets", fmt.Sprintf("%d", report.SecurityInfo.Secrets))
+	row("Privileged Pods", fmt.Sprintf("%d", report.SecurityInfo.PrivilegedPods))
+	row("Host Network Pods", fmt.Sprintf("%d", report.SecurityInfo.HostNetworkPods))
+	row("Root Containers", fmt.Sprintf("%d", report.SecurityInfo.RootContainers))
+	row("Pods on Default ServiceAccount", fmt.Sprintf("%d", report.SecurityInfo.DefaultSAPods))
+	row("Pods with API Token", fmt.Sprintf("%d", report.SecurityInfo.TokenMountedPods))
+	row("Images with Mutable Tags", fmt.Sprintf("%d", report.SecurityInfo.MutableTagImages))
+	if report.SecurityInfo.MutableTagImages > 0 {
+		sb.WriteString(fmt.Sprintf("\n> **Warning:** %d images use the latest tag or no tag. Pin them to a version or digest so rollouts and restarts run the tested image.\n",
+			report.SecurityInfo.MutableTagImages))
+	}
+
+	sb.WriteString("\n## Cost Estimate\n\n")
+	table("Metric", "Value")
+	for _, r := range costRows(report.CostEstimate) {
+		row(r[0], r[1])
+	}
+	if costs := report.CostEstimate.Namespaces; len(costs) > 0 {
+		sb.WriteString("\n### Cost by Namespace\n\n")
+		table("Namespace", "CPU", "Memory", "Monthly")
+		costsShown := rowLimit(len(costs), limits.Namespaces)
+		for _, nc := range costs[:costsShown] {
+			row(namespaceCostRow(nc)...)
+		}
+		truncated(costsShown, len(costs), "namespaces")
+	}
+	if !report.CostEstimate.Priced() {
+		sb.WriteString("\n> **Note:** no compute prices for this provider. Set `pricing.cpu_hourly` and `pricing.memory_gb_hourly` in config.yaml.\n")
+	}
+
+	if len(report.Events) > 0 {
+		sb.WriteString("\n## Warning Events\n\n")
+		table("Reason", "Object", "Message", "Count")
+		eventsShown := rowLimit(len(report.Events), limits.Events)
+		for _, event := range report.Events[:eventsShown] {
+			row(event.Reason, event.Object, event.Message, fmt.Sprintf("%d", event.Count))
+		}
+		truncated(eventsShown, len(report.Events), "warning events")
+	}
+
+	if len(report.Findings) > 0 {
+		sum := report.FindingSummary
+		sb.WriteString("\n## Findings\n\n")
+		sb.WriteString(fmt.Sprintf("%d findings: %d critical, %d high, %d medium, %d low\n\n",
+			sum.Total, sum.Critical, sum.High, sum.Medium, sum.Low))
+		table("Severity", "Category", "Check", "Kind", "Namespace", "Name", "Message", "Remediation")
+		for _, f := range report.Findings {
+			row(f.Severity, f.Category, f.Check, f.Kind, f.Namespace, f.Name, f.Message, f.Remediation)
+		}
+	}
+	if len(report.Errors) > 0 {
+		sb.WriteString("\n## Incomplete Checks\n\n")
+		for _, e := range report.Errors {
+			sb.WriteString("- " + mdCell(e) + "\n")
+		}
+	}
+
+	sb.WriteString("\n---\n_Generated by k13s - AI-Powered Kubernetes Dashboard_\n")
+	return sb.String()
+}
+
+// mdCell keeps a value on one line of a Markdown table
+func mdCell(s string) string {
+	s = strings.ReplaceAll(s, "|", "\\|")
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// handleInventory serves the resource inventory report: object counts of
+// every listable kind, CRDs included, for tracking changes over time
+func (rg *ReportGenerator) handleInventory(w http.ResponseWriter, r *http.Request, username, namespace string) {
+	inv, err := rg.server.k8sClient.BuildInventory(r.Context(), namespace)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	scope := "cluster"
+	filename := "k13s-inventory-"
+	if namespace != "" {
+		scope = "namespace/" + namespace
+		filename += namespace + "-"
+	}
+	filename += inv.GeneratedAt.Format("20060102-150405")
+	db.RecordAudit(db.AuditEntry{
+		User:     username,
+		Action:   "generate_inventory",
+		Resource: scope,
+		Details:  fmt.Sprintf("Kinds: %d, Objects: %d, Errors: %d", len(inv.Resources), inv.Total, len(inv.Errors)),
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s.json", filename))
+	json.NewEncoder(w).Encode(inv)
+}
+
+// rowLimit returns how many of total rows to show under limit; 0 shows all
+func rowLimit(total, limit int) int {
+	if limit > 0 && limit < total {
+		return limit
+	}
+	return total
+}
+
+// reportLimitsFromQuery overrides limits with limit_pods, limit_images,
+// limit_events and limit_namespaces; full=true lifts every limit.
+func reportLimitsFromQuery(q url.Values, limits config.ReportLimits) (config.ReportLimits, error) {
+	if q.Get("full") == "true" {
+		return config.ReportLimits{}, nil
+	}
+	for param, field := range map[string]*int{
+		"limit_pods":       &limits.Pods,
+		"limit_images":     &limits.Images,
+		"limit_events":     &limits.Events,
+		"limit_namespaces": &limits.Namespaces,
+	} {
+		v := q.Get(param)
+		if v == "" {
+			continue
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return limits, fmt.Errorf("invalid %s: must be a non-negative number", param)
+		}
+		*field = n
+	}
+	return limits, nil
+}
+
 // HandleReports handles report-related API requests
 func (rg *ReportGenerator) HandleReports(w http.ResponseWriter, r *http.Request) {
 	username := r.Header.Get("X-Username")
@@ -886,13 +1587,53 @@ func (rg *ReportGenerator) HandleReports(w http.ResponseWriter, r *http.Request)
 		username = "anonymous"
 	}
 
-	format := r.URL.Query().Get("format") // json, csv, html
+	format := r.URL.Query().Get("format") // json, csv, html, md, pdf
 	includeAI := r.URL.Query().Get("ai") == "true"
+	namespace := r.URL.Query().Get("namespace") // empty = cluster-wide
+	if namespace != "" && len(validation.IsDNS1123Label(namespace)) > 0 {
+		http.Error(w, "Invalid namespace", http.StatusBadRequest)
+		return
+	}
+
+	// HTML, PDF and Markdown default to the configured limits for readability;
+	// CSV is complete unless limits are asked for
+	var limits config.ReportLimits
+	if format == "html" || format == "md" || format == "pdf" {
+		limits = rg.server.cfg.ReportLimits
+	}
+	limits, err := reportLimitsFromQuery(r.URL.Query(), limits)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	aiOpts, err := aiOptionsFromQuery(r.URL.Query(), rg.server.cfg.ReportAI)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 
 	switch r.Method {
 	case http.MethodGet:
+		switch r.URL.Query().Get("type") {
+		case "inventory":
+			rg.handleInventory(w, r, username, namespace)
+			return
+		case "security":
+			rg.handleSecurityReport(w, r, username, namespace, format)
+			return
+		}
+
+		// PDF needs a converter on the server; say so before gathering data
+		var pdf pdfConverter
+		if format == "pdf" {
+			if pdf, err = findPDFConverter(exec.LookPath); err != nil {
+				http.Error(w, err.Error(), http.StatusNotImplemented)
+				return
+			}
+		}
+
 		// Generate comprehensive report
-		report, err := rg.GenerateComprehensiveReport(r.Context(), username)
+		report, err := rg.GenerateComprehensiveReport(r.Context(), username, namespace)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -900,38 +1641,80 @@ func (rg *ReportGenerator) HandleReports(w http.ResponseWriter, r *http.Request)
 
 		// Add AI analysis if requested
 		if includeAI {
-			analysis, err := rg.GenerateAIAnalysis(r.Context(), report)
+			analysis, err := rg.GenerateAIAnalysis(r.Context(), report, aiOpts)
 			if err == nil {
 				report.AIAnalysis = analysis
 			}
 		}
 
+		// Keep a snapshot for the history and trend diffs
+		rg.saveReport(report)
+
 		// Record audit
+		scope := "cluster"
+		if namespace != "" {
+			scope = "namespace/" + namespace
+		}
 		db.RecordAudit(db.AuditEntry{
 			User:     username,
 			Action:   "generate_report",
-			Resource: "cluster",
+			Resource: scope,
 			Details:  fmt.Sprintf("Format: %s, AI: %v", format, includeAI),
 		})
 
+		filename := "k13s-report-"
+		if namespace != "" {
+			filename += namespace + "-"
+		}
+		filename += time.Now().Format("20060102-150405")
+
 		// Return in requested format
 		switch format {
 		case "csv":
-			csvData, err := rg.ExportToCSV(report)
+			// Excel needs the BOM to read UTF-8; bom=false leaves it out
+			bom := r.URL.Query().Get("bom") != "false"
+			if r.URL.Query().Get("split") == "true" {
+				zipData, err := rg.ExportToCSVZip(report, limits, bom)
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusInternalServerError)
+					return
+				}
+				w.Header().Set("Content-Type", "application/zip")
+				w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s.zip", filename))
+				w.Write(zipData)
+				return
+			}
+
+			csvData, err := rg.ExportToCSV(report, limits, bom)
 			if err != nil {
 				http.Error(w, err.Error(), http.StatusInternalServerError)
 				return
 			}
 			w.Header().Set("Content-Type", "text/csv; charset=utf-8")
-			w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=k13s-report-%s.csv", time.Now().Format("20060102-150405")))
+			w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s.csv", filename))
 			w.Write(csvData)
 
 		case "html":
-			htmlData := rg.ExportToHTML(report)
+			htmlData := rg.ExportToHTML(report, limits)
 			w.Header().Set("Content-Type", "text/html; charset=utf-8")
-			w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=k13s-report-%s.html", time.Now().Format("20060102-150405")))
+			w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s.html", filename))
 			w.Write([]byte(htmlData))
 
+		case "pdf":
+			pdfData, err := htmlToPDF(r.Context(), pdf, rg.ExportToHTML(report, limits))
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("Content-Type", "application/pdf")
+			w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s.pdf", filename))
+			w.Write(pdfData)
+
+		case "md":
+			w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+			w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s.md", filename))
+			w.Write([]byte(rg.ExportToMarkdown(report, limits)))
+
 		default: // json
 			w.Header().Set("Content-Type", "application/json")
 			json.NewEncoder(w).Encode(report)
@@ -1,7 +1,16 @@
 package web
 
 import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
 	"testing"
+
+	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/config"
+	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/k8s"
 )
 
 func TestCalculateHealthScore(t *testing.T) {
@@ -68,3 +77,410 @@ func TestNewReportGenerator(t *testing.T) {
 		t.Error("expected server to be nil")
 	}
 }
+
+func TestSummarizeRegistries(t *testing.T) {
+	images := []ImageInfo{
+		{Image: "nginx:1.25", Registry: "docker.io", Repository: "nginx", Tag: "1.25", PodCount: 4, SizeBytes: 100},
+		{Image: "nginx:1.24", Registry: "docker.io", Repository: "nginx", Tag: "1.24", PodCount: 1},
+		{Image: "registry.local:5000/app@sha256:abc", Registry: "registry.local:5000", Repository: "app", Digest: "sha256:abc", Pinned: true, PodCount: 2},
+		{Image: "registry.local:5000/worker:2", Registry: "registry.local:5000", Repository: "worker", Tag: "2", PodCount: 1},
+	}
+
+	got := summarizeRegistries(images)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 registries, got %+v", got)
+	}
+	want := []RegistryInfo{
+		{Registry: "docker.io", Repositories: 1, Images: 2, Unpinned: 2, PodCount: 5, SizeBytes: 100},
+		{Registry: "registry.local:5000", Repositories: 2, Images: 2, Unpinned: 1, PodCount: 3},
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("registry %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := map[int64]string{0: "-", 512: "512 B", 2048: "2.0 KiB", 157286400: "150.0 MiB"}
+	for in, want := range tests {
+		if got := formatBytes(in); got != want {
+			t.Errorf("formatBytes(%d) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestReportLimitsFromQuery(t *testing.T) {
+	base := config.ReportLimits{Pods: 50, Images: 20, Events: 20}
+
+	got, err := reportLimitsFromQuery(url.Values{"limit_pods": {"200"}, "limit_images": {"0"}}, base)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := config.ReportLimits{Pods: 200, Images: 0, Events: 20}
+	if got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	if got, _ := reportLimitsFromQuery(url.Values{"full": {"true"}}, base); got != (config.ReportLimits{}) {
+		t.Errorf("full=true should lift all limits, got %+v", got)
+	}
+
+	for _, v := range []string{"-1", "many"} {
+		if _, err := reportLimitsFromQuery(url.Values{"limit_events": {v}}, base); err == nil {
+			t.Errorf("limit_events=%s should be rejected", v)
+		}
+	}
+}
+
+func TestAIOptionsFromQuery(t *testing.T) {
+	base := config.ReportAI{MaxWords: 500}
+
+	got, err := aiOptionsFromQuery(url.Values{"ai_words": {"150"}, "ai_focus": {"security, finops"}}, base)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.MaxWords != 150 || len(got.Focus) != 2 || got.Focus[0] != "security" || got.Focus[1] != "finops" {
+		t.Errorf("unexpected options: %+v", got)
+	}
+
+	for _, q := range []url.Values{{"ai_words": {"10"}}, {"ai_words": {"lots"}}, {"ai_focus": {"marketing"}}} {
+		if _, err := aiOptionsFromQuery(q, base); err == nil {
+			t.Errorf("%v should be rejected", q)
+		}
+	}
+}
+
+func TestBuildAIAnalysisPrompt(t *testing.T) {
+	report := &ComprehensiveReport{
+		Findings: k8s.ClassifyFindings([]k8s.Finding{
+			{Check: k8s.CheckPrivilegedContainer, Kind: "Deployment", Namespace: "shop", Name: "agent", Message: "runs privileged"},
+			{Check: k8s.CheckUnusedPVC, Kind: "PersistentVolumeClaim", Namespace: "shop", Name: "old-data", Message: "not mounted"},
+		}),
+	}
+
+	balanced := buildAIAnalysisPrompt(report, config.ReportAI{})
+	if !strings.Contains(balanced, "(max 500 words)") || !strings.Contains(balanced, "4. Security observations") {
+		t.Errorf("unexpected balanced prompt:\n%s", balanced)
+	}
+
+	focused := buildAIAnalysisPrompt(report, config.ReportAI{MaxWords: 150, Focus: []string{"finops"}})
+	for _, want := range []string{
+		"(max 150 words)",
+		"Top finops findings:\n- [medium] PersistentVolumeClaim shop/old-data: not mounted",
+		"3. Cost optimization",
+		"Focus on finops",
+	} {
+		if !strings.Contains(focused, want) {
+			t.Errorf("focused prompt missing %q:\n%s", want, focused)
+		}
+	}
+	if strings.Contains(focused, "agent") || strings.Contains(focused, "Security observations") {
+		t.Errorf("finops prompt should leave out security details:\n%s", focused)
+	}
+}
+
+func TestExportLimits(t *testing.T) {
+	rg := &ReportGenerator{}
+	report := &ComprehensiveReport{}
+	for i := 0; i < 200; i++ {
+		report.Pods = append(report.Pods, PodInfo{Name: fmt.Sprintf("pod-%03d", i), Namespace: "default"})
+	}
+
+	limited := rg.ExportToHTML(report, config.ReportLimits{Pods: 50})
+	if !strings.Contains(limited, "Showing first 50 of 200 pods") || strings.Contains(limited, "pod-050") {
+		t.Error("HTML report should show the first 50 pods and say so")
+	}
+	if full := rg.ExportToHTML(report, config.ReportLimits{}); !strings.Contains(full, "pod-199") {
+		t.Error("HTML report without limits should show every pod")
+	}
+
+	csvData, err := rg.ExportToCSV(report, config.ReportLimits{Pods: 10}, false)
+	if err != nil {
+		t.Fatalf("ExportToCSV failed: %v", err)
+	}
+	if !strings.Contains(string(csvData), "Showing first 10 of 200 pods") || strings.Contains(string(csvData), "pod-010") {
+		t.Error("CSV report should show the first 10 pods and say so")
+	}
+}
+
+func TestExportToMarkdown(t *testing.T) {
+	rg := &ReportGenerator{}
+	report := &ComprehensiveReport{
+		GeneratedBy: "test-user",
+		Namespace:   "shop",
+		AIAnalysis:  "## Risks\n- none",
+		Pods: []PodInfo{
+			{Name: "web-1", Namespace: "shop", Status: "Running"},
+			{Name: "web-2", Namespace: "shop", Status: "Running"},
+		},
+		Events: []EventInfo{{Reason: "BackOff", Object: "Pod/web-1", Message: "a | b\nc", Count: 3}},
+		Errors: []string{"rbac analysis: forbidden"},
+	}
+
+	md := rg.ExportToMarkdown(report, config.ReportLimits{Pods: 1})
+	for _, want := range []string{
+		"# K13s Namespace Report: shop",
+		"## Incomplete Checks\n\n- rbac analysis: forbidden",
+		"| Name | Namespace | Status | Ready | Restarts | Node | Age |",
+		"| web-1 | shop | Running |",
+		"_Showing first 1 of 2 pods_",
+		"## Risks\n- none",
+		"| BackOff | Pod/web-1 | a \\| b c | 3 |",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown report missing %q:\n%s", want, md)
+		}
+	}
+	if strings.Contains(md, "web-2") || strings.Contains(md, "## Nodes") {
+		t.Error("markdown report should respect limits and skip nodes for namespace reports")
+	}
+
+	// Nodes the user may list stay in, marked as cluster-wide
+	report.Nodes = []NodeInfo{{Name: "node-1", Status: "Ready"}}
+	report.CostEstimate.Namespaces = []NamespaceCost{{Namespace: "shop", CPUCores: 2, MonthlyTotal: 58.4}}
+	md = rg.ExportToMarkdown(report, config.ReportLimits{})
+	for _, want := range []string{
+		"_Scoped to the shop namespace",
+		"## Nodes (cluster-wide)",
+		"| node-1 | Ready |",
+		"| shop | 2.00 cores | 0.00 GB | $58.40 |",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown report missing %q:\n%s", want, md)
+		}
+	}
+}
+
+func TestExportFindingsBySeverity(t *testing.T) {
+	rg := &ReportGenerator{}
+	report := &ComprehensiveReport{
+		Findings: k8s.ClassifyFindings([]k8s.Finding{
+			{Check: k8s.CheckLimitMissing, Kind: "Deployment", Namespace: "shop", Name: "api"},
+			{Check: k8s.CheckPrivilegedContainer, Kind: "DaemonSet", Namespace: "kube-system", Name: "agent"},
+		}),
+	}
+	report.FindingSummary = k8s.SummarizeFindings(report.Findings)
+
+	md := rg.ExportToMarkdown(report, config.ReportLimits{})
+	for _, want := range []string{
+		"2 findings: 1 critical, 0 high, 0 medium, 1 low",
+		"| Severity | Category | Check | Kind | Namespace | Name | Message | Remediation |",
+		"| critical | security | privileged-container | DaemonSet | kube-system | agent |",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown report missing %q:\n%s", want, md)
+		}
+	}
+	if strings.Index(md, "agent") > strings.Index(md, "| api |") {
+		t.Error("expected critical findings before low ones")
+	}
+
+	html := rg.ExportToHTML(report, config.ReportLimits{})
+	if !strings.Contains(html, `<td class="status-failed">critical</td>`) {
+		t.Errorf("HTML report missing critical severity cell")
+	}
+
+	report.Findings[0].Name = `<img src=x onerror=alert(1)>`
+	report.Findings[0].Message = `<script>alert(1)</script>`
+	html = rg.ExportToHTML(report, config.ReportLimits{})
+	if strings.Contains(html, "<script>") || strings.Contains(html, "<img") {
+		t.Errorf("HTML report did not escape finding fields:\n%s", html)
+	}
+	if !strings.Contains(html, "&lt;script&gt;alert(1)&lt;/script&gt;") {
+		t.Error("HTML report missing the escaped finding message")
+	}
+}
+
+func TestExportToHTMLEscapesClusterData(t *testing.T) {
+	rg := &ReportGenerator{}
+	report := &ComprehensiveReport{
+		AIAnalysis: `<iframe src="http://169.254.169.254/latest/meta-data/"></iframe>`,
+		Pods:       []PodInfo{{Name: "web", Namespace: "shop", Status: "<b>Running</b>"}},
+		Events: []EventInfo{{Reason: "BackOff", Object: "pod/web",
+			Message: `<script>fetch("http://169.254.169.254/")</script>`, Count: 1}},
+	}
+
+	html := rg.ExportToHTML(report, config.ReportLimits{})
+	for _, raw := range []string{"<script>", "<iframe", "<b>Running"} {
+		if strings.Contains(html, raw) {
+			t.Errorf("HTML report contains unescaped %q", raw)
+		}
+	}
+	if !strings.Contains(html, "&lt;script&gt;fetch(") {
+		t.Error("HTML report missing the escaped event message")
+	}
+}
+
+func TestExportSecurityReport(t *testing.T) {
+	rg := &ReportGenerator{}
+	report := &SecurityReport{
+		Namespace: "shop",
+		Summary:   SecurityInfo{PrivilegedPods: 1, Secrets: 4},
+		Findings: k8s.ClassifyFindings([]k8s.Finding{
+			{Check: k8s.CheckPrivilegedContainer, Kind: "Deployment", Namespace: "shop", Name: "agent", Message: "container \"agent\" runs privileged"},
+		}),
+		Errors: []string{"roles: forbidden"},
+	}
+	report.FindingSummary = k8s.SummarizeFindings(report.Findings)
+
+	md := rg.ExportSecurityToMarkdown(report)
+	for _, want := range []string{
+		"# K13s Security Report: shop",
+		"1 findings: 1 critical, 0 high, 0 medium, 0 low",
+		"| Privileged Pods | 1 |",
+		"| critical | security | privileged-container | Deployment | shop | agent |",
+		"- roles: forbidden",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("security markdown missing %q:\n%s", want, md)
+		}
+	}
+	if strings.Contains(md, "Cluster Roles") {
+		t.Error("namespace security report should not count cluster roles")
+	}
+
+	html := rg.ExportSecurityToHTML(report)
+	if !strings.Contains(html, "runs privileged") || strings.Contains(html, "Pods (") {
+		t.Error("HTML security report should list findings without workload sections")
+	}
+}
+
+func TestDiffReports(t *testing.T) {
+	before := &ComprehensiveReport{
+		HealthScore: 90,
+		Workloads:   WorkloadSummary{TotalPods: 10, RunningPods: 10},
+		Deployments: []DeploymentInfo{
+			{Name: "web", Namespace: "shop", Ready: "3/3"},
+			{Name: "legacy", Namespace: "shop", Ready: "1/1"},
+		},
+		Services: []ServiceInfo{{Name: "web", Namespace: "shop", Type: "ClusterIP"}},
+		Images:   []ImageInfo{{Image: "web:1.0"}},
+		Events:   []EventInfo{{Reason: "BackOff", Object: "Pod/web-1"}},
+		Findings: []k8s.Finding{{Check: k8s.CheckPDBMissing, Kind: "Deployment", Namespace: "shop", Name: "legacy"}},
+	}
+	after := &ComprehensiveReport{
+		HealthScore: 75,
+		Workloads:   WorkloadSummary{TotalPods: 12, RunningPods: 10, FailedPods: 2},
+		Deployments: []DeploymentInfo{
+			{Name: "web", Namespace: "shop", Ready: "1/3"},
+			{Name: "api", Namespace: "shop", Ready: "2/2"},
+		},
+		Services: []ServiceInfo{
+			{Name: "web", Namespace: "shop", Type: "ClusterIP"},
+			{Name: "api", Namespace: "shop", Type: "LoadBalancer"},
+		},
+		Images: []ImageInfo{{Image: "web:1.1"}},
+		Events: []EventInfo{
+			{Reason: "BackOff", Object: "Pod/web-1"},
+			{Reason: "FailedScheduling", Object: "Pod/api-1"},
+		},
+		Findings: []k8s.Finding{{Check: k8s.CheckPDBMissing, Kind: "Deployment", Namespace: "shop", Name: "api"}},
+	}
+
+	d := DiffReports(before, after, 0.025)
+
+	if d.HealthScoreDelta != -15 || d.PodDelta != 2 || d.FailedPodDelta != 2 {
+		t.Errorf("unexpected deltas: health %v, pods %d, failed %d", d.HealthScoreDelta, d.PodDelta, d.FailedPodDelta)
+	}
+	if fmt.Sprint(d.AddedDeployments) != "[shop/api]" || fmt.Sprint(d.RemovedDeployments) != "[shop/legacy]" {
+		t.Errorf("unexpected deployment changes: +%v -%v", d.AddedDeployments, d.RemovedDeployments)
+	}
+	if len(d.ReplicaChanges) != 1 || d.ReplicaChanges[0] != (ReplicaChange{Deployment: "shop/web", Before: "3/3", After: "1/3"}) {
+		t.Errorf("unexpected replica changes: %+v", d.ReplicaChanges)
+	}
+	if fmt.Sprint(d.AddedServices) != "[shop/api]" || len(d.RemovedServices) != 0 {
+		t.Errorf("unexpected service changes: +%v -%v", d.AddedServices, d.RemovedServices)
+	}
+	if fmt.Sprint(d.AddedImages) != "[web:1.1]" || fmt.Sprint(d.RemovedImages) != "[web:1.0]" {
+		t.Errorf("unexpected image changes: +%v -%v", d.AddedImages, d.RemovedImages)
+	}
+	if len(d.NewWarnings) != 1 || d.NewWarnings[0].Reason != "FailedScheduling" {
+		t.Errorf("unexpected new warnings: %+v", d.NewWarnings)
+	}
+	if len(d.NewFindings) != 1 || d.NewFindings[0].Name != "api" || len(d.ResolvedFindings) != 1 || d.ResolvedFindings[0].Name != "legacy" {
+		t.Errorf("unexpected finding changes: new %+v resolved %+v", d.NewFindings, d.ResolvedFindings)
+	}
+	if want := 0.025 * k8s.HoursPerMonth; d.LoadBalancerCostDelta != want {
+		t.Errorf("expected LoadBalancer cost delta %v, got %v", want, d.LoadBalancerCostDelta)
+	}
+}
+
+func TestExportCSVForExcel(t *testing.T) {
+	rg := &ReportGenerator{}
+	report := &ComprehensiveReport{
+		GeneratedBy: "test-user",
+		Pods:        []PodInfo{{Name: "café-1", Namespace: "default"}},
+	}
+
+	data, err := rg.ExportToCSV(report, config.ReportLimits{}, true)
+	if err != nil {
+		t.Fatalf("ExportToCSV failed: %v", err)
+	}
+	if !bytes.HasPrefix(data, []byte(utf8BOM)) {
+		t.Error("CSV should start with a UTF-8 BOM")
+	}
+
+	zipData, err := rg.ExportToCSVZip(report, config.ReportLimits{}, true)
+	if err != nil {
+		t.Fatalf("ExportToCSVZip failed: %v", err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(zipData), int64(len(zipData)))
+	if err != nil {
+		t.Fatalf("invalid zip: %v", err)
+	}
+	files := make(map[string]string)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("failed to open %s: %v", f.Name, err)
+		}
+		b, _ := io.ReadAll(rc)
+		rc.Close()
+		files[f.Name] = string(b)
+	}
+	pods, ok := files["pods.csv"]
+	if !ok {
+		t.Fatalf("expected pods.csv in zip, got %v", zr.File)
+	}
+	if pods != utf8BOM+"Name,Namespace,Status,Ready,Restarts,Node,IP,Age\ncafé-1,default,,,0,,,\n" {
+		t.Errorf("unexpected pods.csv: %q", pods)
+	}
+	for name, content := range files {
+		if strings.Contains(content, "===") {
+			t.Errorf("%s should not contain section separator rows", name)
+		}
+	}
+}
+
+func TestExportMutableImageTags(t *testing.T) {
+	rg := &ReportGenerator{}
+	report := &ComprehensiveReport{
+		Images: []ImageInfo{
+			{Image: "nginx", Registry: "docker.io", Repository: "nginx", Tag: "latest", MutableTag: true, PodCount: 3},
+			{Image: "envoy:1.30", Registry: "docker.io", Repository: "envoy", Tag: "1.30", PodCount: 1},
+		},
+		SecurityInfo: SecurityInfo{MutableTagImages: 1},
+	}
+
+	html := rg.ExportToHTML(report, config.ReportLimits{})
+	if !strings.Contains(html, `<tr class="mutable-tag"><td>docker.io/nginx</td>`) {
+		t.Error("HTML report should highlight the image with a mutable tag")
+	}
+	if strings.Contains(html, `<tr class="mutable-tag"><td>docker.io/envoy</td>`) {
+		t.Error("HTML report should not highlight a versioned image")
+	}
+	if !strings.Contains(html, "1 images use the latest tag or no tag") {
+		t.Error("HTML security summary should recommend pinning mutable tags")
+	}
+
+	csvData, err := rg.ExportToCSV(report, config.ReportLimits{}, false)
+	if err != nil {
+		t.Fatalf("ExportToCSV failed: %v", err)
+	}
+	for _, want := range []string{"Digest Pinned,Mutable Tag", "nginx,docker.io,nginx,latest,false,true", "Images with Mutable Tags,1"} {
+		if !strings.Contains(string(csvData), want) {
+			t.Errorf("CSV report missing %q", want)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package web
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Roles of web users, from least to most privileged. Viewers read
+// resources, logs, metrics and reports and chat with the AI; operators may
+// also approve AI tool calls, run resource actions, open terminals and port
+// forwards; admins may also change settings and manage users.
+const (
+	RoleViewer   = "viewer"
+	RoleOperator = "operator"
+	RoleAdmin    = "admin"
+)
+
+// DefaultRole is given to local users created without a role, and to LDAP
+// and token users outside the configured admin and operator groups
+const DefaultRole = RoleViewer
+
+// roleRanks orders the roles; a role holds the privileges of lower ones
+var roleRanks = map[string]int{
+	RoleViewer:   1,
+	RoleOperator: 2,
+	RoleAdmin:    3,
+}
+
+// NormalizeRole returns the role named by role, case-insensitively. The
+// "user" role of earlier versions is an operator. Unknown roles are an error.
+func NormalizeRole(role string) (string, error) {
+	role = strings.ToLower(strings.TrimSpace(role))
+	switch role {
+	case "":
+		return DefaultRole, nil
+	case "user":
+		return RoleOperator, nil
+	}
+	if _, ok := roleRanks[role]; !ok {
+		return "", fmt.Errorf("unknown role %q: use %s, %s or %s", role, RoleViewer, RoleOperator, RoleAdmin)
+	}
+	return role, nil
+}
+
+// RoleAllows reports whether role has at least the privileges of required
+func RoleAllows(role, required string) bool {
+	rank, ok := roleRanks[role]
+	return ok && rank >= roleRanks[required]
+}
+
+// RequireRole returns a middleware that authenticates the request and
+// rejects users below role with 403. With auth disabled every caller is
+// treated as admin, as in HandleCurrentUser.
+func (am *AuthManager) RequireRole(role string) func(http.HandlerFunc) http.HandlerFunc {
+	return func(next http.HandlerFunc) http.HandlerFunc {
+		return am.AuthMiddleware(func(w http.ResponseWriter, r *http.Request) {
+			if am.config.Enabled && !RoleAllows(r.Header.Get("X-User-Role"), role) {
+				http.Error(w, fmt.Sprintf("Forbidden: %s role required", role), http.StatusForbidden)
+				return
+			}
+			next(w, r)
+		})
+	}
+}
+
+// RequireRoleToWrite is RequireRole for endpoints that are read with GET:
+// viewers may read, other methods need role
+func (am *AuthManager) RequireRoleToWrite(role string) func(http.HandlerFunc) http.HandlerFunc {
+	return func(next http.HandlerFunc) http.HandlerFunc {
+		read := am.RequireRole(RoleViewer)(next)
+		write := am.RequireRole(role)(next)
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodGet || r.Method == http.MethodHead {
+				read(w, r)
+				return
+			}
+			write(w, r)
+		}
+	}
+}
@@ -0,0 +1,284 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/db"
+	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/k8s"
+)
+
+// SecurityReport is the security posture of a cluster or namespace, without
+// the workload inventory and cost sections of the full report
+type SecurityReport struct {
+	GeneratedAt    time.Time          `json:"generated_at"`
+	GeneratedBy    string             `json:"generated_by"`
+	Namespace      string             `json:"namespace,omitempty"` // set for namespace-scoped reports
+	Summary        SecurityInfo       `json:"summary"`
+	Findings       []k8s.Finding      `json:"findings"` // ordered by severity
+	FindingSummary k8s.FindingSummary `json:"finding_summary"`
+
+	// Errors lists the checks or counts that failed, e.g. for lack of RBAC
+	// permissions; the report is incomplete without them
+	Errors []string `json:"errors,omitempty"`
+}
+
+// GenerateSecurityReport runs the security checks for a namespace ("" for
+// the whole cluster) and counts the objects they cover
+func (rg *ReportGenerator) GenerateSecurityReport(ctx context.Context, username, namespace string) *SecurityReport {
+	client := rg.server.k8sClient
+	report := &SecurityReport{
+		GeneratedAt: time.Now(),
+		GeneratedBy: username,
+		Namespace:   namespace,
+	}
+	count := func(what string, n int, err error) int {
+		if err != nil {
+			report.Errors = append(report.Errors, fmt.Sprintf("%s: %v", what, err))
+		}
+		return n
+	}
+
+	pods, err := client.ListPods(ctx, namespace)
+	count("pods", len(pods), err)
+	for _, pod := range pods {
+		report.Summary.countPod(pod)
+	}
+	secrets, err := client.ListSecrets(ctx, namespace)
+	report.Summary.Secrets = count("secrets", len(secrets), err)
+	sas, err := client.ListServiceAccounts(ctx, namespace)
+	report.Summary.ServiceAccounts = count("serviceaccounts", len(sas), err)
+	roles, err := client.ListRoles(ctx, namespace)
+	report.Summary.Roles = count("roles", len(roles), err)
+	bindings, err := client.ListRoleBindings(ctx, namespace)
+	report.Summary.RoleBindings = count("rolebindings", len(bindings), err)
+	if namespace == "" {
+		clusterRoles, err := client.ListClusterRoles(ctx)
+		report.Summary.ClusterRoles = count("clusterroles", len(clusterRoles), err)
+		clusterBindings, err := client.ListClusterRoleBindings(ctx)
+		report.Summary.ClusterRoleBindings = count("clusterrolebindings", len(clusterBindings), err)
+	}
+
+	findings, err := client.AnalyzeSecurity(ctx, namespace)
+	if err != nil {
+		report.Errors = append(report.Errors, err.Error())
+	}
+	report.Findings = findings
+	report.FindingSummary = k8s.SummarizeFindings(findings)
+	return report
+}
+
+// securitySummaryRows lists the object counts of a security report
+func securitySummaryRows(report *SecurityReport) [][]string {
+	s := report.Summary
+	rows := [][]string{
+		{"Privileged Pods", fmt.Sprintf("%d", s.PrivilegedPods)},
+		{"Host Network Pods", fmt.Sprintf("%d", s.HostNetworkPods)},
+		{"Root Containers", fmt.Sprintf("%d", s.RootContainers)},
+		{"Pods on Default ServiceAccount", fmt.Sprintf("%d", s.DefaultSAPods)},
+		{"Pods with API Token", fmt.Sprintf("%d", s.TokenMountedPods)},
+		{"Secrets", fmt.Sprintf("%d", s.Secrets)},
+		{"Service Accounts", fmt.Sprintf("%d", s.ServiceAccounts)},
+		{"Roles", fmt.Sprintf("%d", s.Roles)},
+		{"Role Bindings", fmt.Sprintf("%d", s.RoleBindings)},
+	}
+	if report.Namespace == "" {
+		rows = append(rows,
+			[]string{"Cluster Roles", fmt.Sprintf("%d", s.ClusterRoles)},
+			[]string{"Cluster Role Bindings", fmt.Sprintf("%d", s.ClusterRoleBindings)})
+	}
+	return rows
+}
+
+func securityReportTitle(report *SecurityReport) string {
+	if report.Namespace != "" {
+		return "K13s Security Report: " + report.Namespace
+	}
+	return "K13s Cluster Security Report"
+}
+
+// ExportSecurityToCSV renders the summary and findings of a security report
+func (rg *ReportGenerator) ExportSecurityToCSV(report *SecurityReport, bom bool) ([]byte, error) {
+	var buf bytes.Buffer
+	if bom {
+		buf.WriteString(utf8BOM)
+	}
+	writer := csv.NewWriter(&buf)
+	writer.Write([]string{securityReportTitle(report)})
+	writer.Write([]string{"Generated At", report.GeneratedAt.Format(time.RFC3339)})
+	writer.Write([]string{"Generated By", report.GeneratedBy})
+	writer.Write([]string{""})
+
+	writer.Write([]string{"=== SUMMARY ==="})
+	writer.WriteAll(securitySummaryRows(report))
+	writer.Write([]string{""})
+
+	writer.Write([]string{"=== FINDINGS ==="})
+	writer.Write([]string{"Severity", "Category", "Check", "Kind", "Namespace", "Name", "Message", "Remediation"})
+	for _, f := range report.Findings {
+		writer.Write([]string{f.Severity, f.Category, f.Check, f.Kind, f.Namespace, f.Name, f.Message, f.Remediation})
+	}
+
+	writer.Flush()
+	return buf.Bytes(), writer.Error()
+}
+
+// ExportSecurityToMarkdown renders a security report as Markdown
+func (rg *ReportGenerator) ExportSecurityToMarkdown(report *SecurityReport) string {
+	var sb strings.Builder
+	row := func(cells ...string) {
+		for i, c := range cells {
+			cells[i] = mdCell(c)
+		}
+		sb.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+	table := func(headers ...string) {
+		row(headers...)
+		sb.WriteString(strings.Repeat("|---", len(headers)) + "|\n")
+	}
+
+	sum := report.FindingSummary
+	sb.WriteString(fmt.Sprintf("# %s\n\n", securityReportTitle(report)))
+	sb.WriteString(fmt.Sprintf("**Generated:** %s | **By:** %s\n",
+		report.GeneratedAt.Format("2006-01-02 15:04:05"), report.GeneratedBy))
+	sb.WriteString(fmt.Sprintf("\n%d findings: %d critical, %d high, %d medium, %d low\n",
+		sum.Total, sum.Critical, sum.High, sum.Medium, sum.Low))
+
+	sb.WriteString("\n## Summary\n\n")
+	table("Metric", "Count")
+	for _, r := range securitySummaryRows(report) {
+		row(r...)
+	}
+
+	sb.WriteString("\n## Findings\n\n")
+	if len(report.Findings) == 0 {
+		sb.WriteString("No security findings.\n")
+	} else {
+		table("Severity", "Category", "Check", "Kind", "Namespace", "Name", "Message", "Remediation")
+		for _, f := range report.Findings {
+			row(f.Severity, f.Category, f.Check, f.Kind, f.Namespace, f.Name, f.Message, f.Remediation)
+		}
+	}
+
+	if len(report.Errors) > 0 {
+		sb.WriteString("\n## Incomplete Checks\n\n")
+		for _, e := range report.Errors {
+			sb.WriteString("- " + mdCell(e) + "\n")
+		}
+	}
+
+	sb.WriteString("\n---\n_Generated by k13s - AI-Powered Kubernetes Dashboard_\n")
+	return sb.String()
+}
+
+// ExportSecurityToHTML renders a security report as a standalone HTML page
+func (rg *ReportGenerator) ExportSecurityToHTML(report *SecurityReport) string {
+	var sb strings.Builder
+	title := html.EscapeString(securityReportTitle(report))
+	sum := report.FindingSummary
+
+	sb.WriteString(fmt.Sprintf(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="UTF-8">
+<title>%s</title>
+<style>
+%s</style>
+</head>
+<body>
+<h1>🔒 %s</h1>
+<p><strong>Generated:</strong> %s | <strong>By:</strong> %s</p>
+`, title, reportCSS, title, report.GeneratedAt.Format("2006-01-02 15:04:05"), html.EscapeString(report.GeneratedBy)))
+
+	sb.WriteString(`<div style="text-align: center;">`)
+	for _, c := range []struct {
+		label string
+		n     int
+	}{{"Critical", sum.Critical}, {"High", sum.High}, {"Medium", sum.Medium}, {"Low", sum.Low}} {
+		sb.WriteString(fmt.Sprintf(`<div class="metric-card"><div class="metric-value">%d</div><div class="metric-label">%s</div></div>`, c.n, c.label))
+	}
+	sb.WriteString(`</div>`)
+
+	sb.WriteString(`<h2>Summary</h2><table><tr><th>Metric</th><th>Count</th></tr>`)
+	for _, r := range securitySummaryRows(report) {
+		sb.WriteString(fmt.Sprintf(`<tr><td>%s</td><td>%s</td></tr>`, r[0], r[1]))
+	}
+	sb.WriteString(`</table>`)
+
+	sb.WriteString(`<h2>🔎 Findings</h2>`)
+	if len(report.Findings) == 0 {
+		sb.WriteString(`<p>No security findings.</p>`)
+	} else {
+		sb.WriteString(`<table><tr><th>Severity</th><th>Category</th><th>Check</th><th>Kind</th><th>Namespace</th><th>Name</th><th>Message</th><th>Remediation</th></tr>`)
+		for _, f := range report.Findings {
+			sb.WriteString(fmt.Sprintf(`<tr><td class="%s">%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td></tr>`,
+				severityClass(f.Severity), html.EscapeString(f.Severity), html.EscapeString(f.Category), html.EscapeString(f.Check), html.EscapeString(f.Kind),
+				html.EscapeString(f.Namespace), html.EscapeString(f.Name), html.EscapeString(f.Message), html.EscapeString(f.Remediation)))
+		}
+		sb.WriteString(`</table>`)
+	}
+
+	if len(report.Errors) > 0 {
+		sb.WriteString(`<div class="warning">Some checks could not run:<ul>`)
+		for _, e := range report.Errors {
+			sb.WriteString(`<li>` + html.EscapeString(e) + `</li>`)
+		}
+		sb.WriteString(`</ul></div>`)
+	}
+
+	sb.WriteString(`<div class="footer">Generated by k13s - AI-Powered Kubernetes Dashboard</div>`)
+	sb.WriteString(`</body></html>`)
+	return sb.String()
+}
+
+// handleSecurityReport serves /api/reports?type=security in the requested format
+func (rg *ReportGenerator) handleSecurityReport(w http.ResponseWriter, r *http.Request, username, namespace, format string) {
+	report := rg.GenerateSecurityReport(r.Context(), username, namespace)
+
+	scope := "cluster"
+	filename := "k13s-security-"
+	if namespace != "" {
+		scope = "namespace/" + namespace
+		filename += namespace + "-"
+	}
+	filename += report.GeneratedAt.Format("20060102-150405")
+	db.RecordAudit(db.AuditEntry{
+		User:     username,
+		Action:   "generate_security_report",
+		Resource: scope,
+		Details:  fmt.Sprintf("Format: %s, Findings: %d", format, report.FindingSummary.Total),
+	})
+
+	switch format {
+	case "csv":
+		data, err := rg.ExportSecurityToCSV(report, r.URL.Query().Get("bom") != "false")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s.csv", filename))
+		w.Write(data)
+
+	case "html":
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s.html", filename))
+		w.Write([]byte(rg.ExportSecurityToHTML(report)))
+
+	case "md":
+		w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s.md", filename))
+		w.Write([]byte(rg.ExportSecurityToMarkdown(report)))
+
+	default: // json
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(report)
+	}
+}
@@ -1,12 +1,17 @@
 package web
 
 import (
+	"bufio"
 	"context"
+	"crypto/tls"
 	"embed"
 	"encoding/json"
 	"fmt"
 	"io/fs"
+	"net"
 	"net/http"
+	"os"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -15,7 +20,10 @@ import (
 	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/config"
 	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/db"
 	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/k8s"
+	"gopkg.in/yaml.v2"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 //go:embed static/*
@@ -29,6 +37,7 @@ type Server struct {
 	reportGenerator *ReportGenerator
 	port            int
 	server          *http.Server
+	serverMu        sync.Mutex
 
 	// Tool approval management
 	pendingApprovals     map[string]*PendingToolApproval
@@ -59,9 +68,24 @@ type K8sResourceResponse struct {
 	Kind      string                   `json:"kind"`
 	Items     []map[string]interface{} `json:"items"`
 	Error     string                   `json:"error,omitempty"`
+	Hint      string                   `json:"hint,omitempty"`
 	Timestamp time.Time                `json:"timestamp"`
 }
 
+// K8sObjectResponse carries a single object rendered as YAML, describe
+// output (Content) or JSON (Object)
+type K8sObjectResponse struct {
+	Kind      string                 `json:"kind"`
+	Namespace string                 `json:"namespace,omitempty"`
+	Name      string                 `json:"name"`
+	Output    string                 `json:"output"`
+	Content   string                 `json:"content,omitempty"`
+	Object    map[string]interface{} `json:"object,omitempty"`
+	Error     string                 `json:"error,omitempty"`
+	Hint      string                 `json:"hint,omitempty"`
+	Timestamp time.Time              `json:"timestamp"`
+}
+
 type SSEWriter struct {
 	w       http.ResponseWriter
 	flusher http.Flusher
@@ -84,6 +108,20 @@ func NewServer(cfg *config.Config, port int) (*Server, error) {
 	var aiClient *ai.Client
 	var err error
 
+	// Check the auth settings before connecting to anything
+	authMode := cfg.Auth.Mode
+	switch authMode {
+	case "":
+		authMode = config.AuthModeLocal
+	case config.AuthModeLocal, config.AuthModeOIDC:
+	default:
+		return nil, fmt.Errorf("unknown auth mode %q: use %s or %s", authMode, config.AuthModeLocal, config.AuthModeOIDC)
+	}
+	adminPassword, err := defaultAdminPassword(&cfg.Auth)
+	if err != nil {
+		return nil, err
+	}
+
 	fmt.Printf("Starting k13s web server...\n")
 	fmt.Printf("  LLM Provider: %s, Model: %s\n", cfg.LLM.Provider, cfg.LLM.Model)
 
@@ -115,13 +153,14 @@ func NewServer(cfg *config.Config, port int) (*Server, error) {
 	// Initialize auth manager
 	authConfig := &AuthConfig{
 		Enabled:         cfg.EnableAudit, // Use audit flag to control auth for now
-		AuthMode:        "local",         // Use local authentication
+		AuthMode:        authMode,
 		SessionDuration: 24 * time.Hour,
 		DefaultAdmin:    "admin",
-		DefaultPassword: "admin123",
+		DefaultPassword: adminPassword,
+		OIDC:            &cfg.Auth.OIDC,
 	}
 	authManager := NewAuthManager(authConfig)
-	fmt.Printf("  Authentication: %s\n", map[bool]string{true: "Enabled", false: "Disabled"}[authConfig.Enabled])
+	fmt.Printf("  Authentication: %s (%s)\n", map[bool]string{true: "Enabled", false: "Disabled"}[authConfig.Enabled], authMode)
 
 	server := &Server{
 		cfg:              cfg,
@@ -138,38 +177,74 @@ func NewServer(cfg *config.Config, port int) (*Server, error) {
 	return server, nil
 }
 
+// defaultAdminPassword returns the password of the default admin:
+// K13S_ADMIN_PASSWORD, or K13S_PASSWORD as earlier container images named
+// it, or "" to have one generated and printed once. The well-known
+// passwords earlier versions shipped are refused unless the
+// -insecure-default-admin flag is set, when InsecureDefaultPassword is also
+// used if no password is given.
+func defaultAdminPassword(auth *config.AuthConfig) (string, error) {
+	password := os.Getenv("K13S_ADMIN_PASSWORD")
+	if password == "" {
+		password = os.Getenv("K13S_PASSWORD")
+	}
+	switch {
+	case auth.InsecureDefaultAdmin:
+		fmt.Fprintf(os.Stderr, "  WARNING: -insecure-default-admin is set; do not expose this server\n")
+		if password == "" {
+			password = InsecureDefaultPassword
+		}
+	case shippedDefaultPasswords[password]:
+		return "", fmt.Errorf("the admin password is a well-known default (%q): set K13S_ADMIN_PASSWORD to another, unset it to have one generated, or pass -insecure-default-admin", password)
+	}
+	return password, nil
+}
+
 func (s *Server) Start() error {
 	mux := http.NewServeMux()
 
 	// Public routes (no auth required)
 	mux.HandleFunc("/api/health", s.handleHealth)
+	mux.HandleFunc("/healthz", s.handleHealthz)
+	mux.HandleFunc("/readyz", s.handleReadyz)
 	mux.HandleFunc("/api/auth/login", s.authManager.HandleLogin)
 	mux.HandleFunc("/api/auth/logout", s.authManager.HandleLogout)
 
-	// Protected routes
-	mux.HandleFunc("/api/auth/me", s.authManager.AuthMiddleware(s.authManager.HandleCurrentUser))
-	mux.HandleFunc("/api/chat", s.authManager.AuthMiddleware(s.handleChat))
-	mux.HandleFunc("/api/chat/stream", s.authManager.AuthMiddleware(s.handleChatStream))
-	mux.HandleFunc("/api/chat/agentic", s.authManager.AuthMiddleware(s.handleAgenticChat))
-	mux.HandleFunc("/api/tool/approve", s.authManager.AuthMiddleware(s.handleToolApprove))
-	mux.HandleFunc("/api/k8s/", s.authManager.AuthMiddleware(s.handleK8sResource))
-	mux.HandleFunc("/api/audit", s.authManager.AuthMiddleware(s.handleAuditLogs))
-	mux.HandleFunc("/api/reports", s.authManager.AuthMiddleware(s.reportGenerator.HandleReports))
-	mux.HandleFunc("/api/settings", s.authManager.AuthMiddleware(s.handleSettings))
-	mux.HandleFunc("/api/settings/llm", s.authManager.AuthMiddleware(s.handleLLMSettings))
+	// Protected routes, by the least role allowed (see roles.go)
+	viewer := s.authManager.RequireRole(RoleViewer)
+	operator := s.authManager.RequireRole(RoleOperator)
+	admin := s.authManager.RequireRole(RoleAdmin)
+
+	mux.HandleFunc("/api/auth/me", viewer(s.authManager.HandleCurrentUser))
+	mux.HandleFunc("/api/auth/users", admin(s.authManager.HandleUsers))
+	mux.HandleFunc("/api/chat", viewer(s.handleChat))
+	mux.HandleFunc("/api/chat/stream", viewer(s.handleChatStream))
+	mux.HandleFunc("/api/chat/agentic", operator(s.handleAgenticChat))
+	mux.HandleFunc("/api/tool/approve", operator(s.handleToolApprove))
+	mux.HandleFunc("/api/k8s/", viewer(s.handleK8sResource))
+	mux.HandleFunc("/api/k8s/logs", viewer(s.handlePodLogs))
+	mux.HandleFunc("/api/k8s/watch/", viewer(s.handleK8sWatch))
+	mux.HandleFunc("/api/actions/", operator(s.handleResourceAction))
+	mux.HandleFunc("/api/audit", viewer(s.handleAuditLogs))
+	mux.HandleFunc("/api/reports", viewer(s.reportGenerator.HandleReports))
+	mux.HandleFunc("/api/reports/diff", viewer(s.reportGenerator.HandleReportDiff))
+	mux.HandleFunc("/api/reports/history", viewer(s.reportGenerator.HandleReportHistory))
+	mux.HandleFunc("/api/reports/ai", viewer(s.reportGenerator.HandleReportAIStream))
+	mux.HandleFunc("/api/settings", s.authManager.RequireRoleToWrite(RoleAdmin)(s.handleSettings))
+	mux.HandleFunc("/api/settings/llm", admin(s.handleLLMSettings))
 
 	// WebSocket terminal handler
 	terminalHandler := NewTerminalHandler(s.k8sClient)
-	mux.HandleFunc("/api/terminal/", s.authManager.AuthMiddleware(terminalHandler.HandleTerminal))
+	mux.HandleFunc("/api/terminal/", operator(terminalHandler.HandleTerminal))
 
 	// Metrics endpoints
-	mux.HandleFunc("/api/metrics/pods", s.authManager.AuthMiddleware(s.handlePodMetrics))
-	mux.HandleFunc("/api/metrics/nodes", s.authManager.AuthMiddleware(s.handleNodeMetrics))
+	mux.HandleFunc("/api/metrics/pods", viewer(s.handlePodMetrics))
+	mux.HandleFunc("/api/metrics/nodes", viewer(s.handleNodeMetrics))
 
 	// Port forwarding endpoints
-	mux.HandleFunc("/api/portforward/start", s.authManager.AuthMiddleware(s.handlePortForwardStart))
-	mux.HandleFunc("/api/portforward/list", s.authManager.AuthMiddleware(s.handlePortForwardList))
-	mux.HandleFunc("/api/portforward/", s.authManager.AuthMiddleware(s.handlePortForwardStop))
+	mux.HandleFunc("/api/portforward/start", operator(s.handlePortForwardStart))
+	mux.HandleFunc("/api/portforward/list", viewer(s.handlePortForwardList))
+	mux.HandleFunc("/api/portforward/", operator(s.handlePortForwardStop))
 
 	// Static files
 	staticFS, err := fs.Sub(staticFiles, "static")
@@ -179,23 +254,84 @@ func (s *Server) Start() error {
 		mux.Handle("/", http.FileServer(http.FS(staticFS)))
 	}
 
-	s.server = &http.Server{
-		Addr:    fmt.Sprintf(":%d", s.port),
-		Handler: corsMiddleware(mux),
+	// Request contexts derive from baseCtx, which is cancelled when Stop
+	// begins shutting down so streaming handlers (logs, watches, chat) return
+	// instead of holding their connections open until shutdownTimeout
+	baseCtx, cancelRequests := context.WithCancel(context.Background())
+	srv := &http.Server{
+		Addr:        net.JoinHostPort(s.cfg.BindAddress, strconv.Itoa(s.port)),
+		Handler:     corsMiddleware(gzipMiddleware(mux)),
+		BaseContext: func(net.Listener) context.Context { return baseCtx },
+	}
+	srv.RegisterOnShutdown(cancelRequests)
+
+	useTLS, selfSigned, err := tlsMode(s.cfg.BindAddress, s.cfg.TLS)
+	if err != nil {
+		cancelRequests()
+		return err
+	}
+	if selfSigned {
+		cert, err := selfSignedCertificate(s.cfg.BindAddress)
+		if err != nil {
+			return fmt.Errorf("failed to generate self-signed certificate: %w", err)
+		}
+		srv.TLSConfig = &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}
+		fmt.Printf("  TLS: self-signed certificate (set tls.cert_file/tls.key_file to use your own)\n")
+	} else if useTLS {
+		fmt.Printf("  TLS: %s\n", s.cfg.TLS.CertFile)
+	} else if !isLoopback(s.cfg.BindAddress) {
+		fmt.Printf("  TLS: disabled, serving plain HTTP on %s\n", srv.Addr)
+	}
+
+	s.serverMu.Lock()
+	s.server = srv
+	s.serverMu.Unlock()
+
+	scheme := "http"
+	if useTLS {
+		scheme = "https"
 	}
+	host := s.cfg.BindAddress
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "localhost"
+	}
+	fmt.Printf("\n  Web server started at %s://%s (listening on %s)\n", scheme, net.JoinHostPort(host, strconv.Itoa(s.port)), srv.Addr)
 
-	fmt.Printf("\n  Web server started at http://localhost:%d\n", s.port)
-	return s.server.ListenAndServe()
+	if useTLS {
+		// Empty file names make ListenAndServeTLS use srv.TLSConfig
+		certFile, keyFile := s.cfg.TLS.CertFile, s.cfg.TLS.KeyFile
+		if selfSigned {
+			certFile, keyFile = "", ""
+		}
+		err = srv.ListenAndServeTLS(certFile, keyFile)
+	} else {
+		err = srv.ListenAndServe()
+	}
+	if err != http.ErrServerClosed {
+		return err
+	}
+	return nil
 }
 
+// shutdownTimeout bounds how long Stop waits for in-flight requests
+const shutdownTimeout = 10 * time.Second
+
+// Stop cancels in-flight request contexts and drains the handlers before
+// closing the audit database, so handlers still running can finish their
+// writes.
 func (s *Server) Stop() error {
-	db.Close()
-	if s.server != nil {
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	s.serverMu.Lock()
+	srv := s.server
+	s.serverMu.Unlock()
+
+	var err error
+	if srv != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
 		defer cancel()
-		return s.server.Shutdown(ctx)
+		err = srv.Shutdown(ctx)
 	}
-	return nil
+	db.Close()
+	return err
 }
 
 func corsMiddleware(next http.Handler) http.Handler {
@@ -229,6 +365,60 @@ func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(status)
 }
 
+// readyzTimeout bounds the API server check done by /readyz
+const readyzTimeout = 3 * time.Second
+
+// handleHealthz is the liveness probe: it only reports that the process is
+// serving requests and does no other work.
+func (s *Server) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Write([]byte("ok\n"))
+}
+
+// handleReadyz is the readiness probe: it returns 503 until the Kubernetes
+// API server is reachable and the audit database is open.
+func (s *Server) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), readyzTimeout)
+	defer cancel()
+
+	var failures []string
+	if err := s.checkK8sReachable(ctx); err != nil {
+		failures = append(failures, fmt.Sprintf("k8s: %v", err))
+	}
+	if db.DB == nil {
+		failures = append(failures, "database: not initialized")
+	} else if err := db.DB.PingContext(ctx); err != nil {
+		failures = append(failures, fmt.Sprintf("database: %v", err))
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	if len(failures) > 0 {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(strings.Join(failures, "\n") + "\n"))
+		return
+	}
+	w.Write([]byte("ok\n"))
+}
+
+// checkK8sReachable asks the API server for its version, giving up when ctx
+// is done since discovery calls do not take a context.
+func (s *Server) checkK8sReachable(ctx context.Context) error {
+	if s.k8sClient == nil || s.k8sClient.Clientset == nil {
+		return fmt.Errorf("client not initialized")
+	}
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := s.k8sClient.GetServerVersion()
+		errCh <- err
+	}()
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 func (s *Server) handleChat(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
@@ -356,6 +546,17 @@ func (s *Server) handleK8sResource(w http.ResponseWriter, r *http.Request) {
 	parts := strings.Split(path, "/")
 	resource := parts[0]
 
+	// /api/k8s/{resource}/{namespace}/{name} or /api/k8s/{resource}/{name}
+	// (cluster-scoped) address a single object
+	switch {
+	case len(parts) == 3 && parts[2] != "":
+		s.handleK8sObject(w, r, resource, parts[1], parts[2])
+		return
+	case len(parts) == 2 && parts[1] != "":
+		s.handleK8sObject(w, r, resource, "", parts[1])
+		return
+	}
+
 	namespace := r.URL.Query().Get("namespace")
 	if namespace == "" {
 		namespace = "default"
@@ -385,17 +586,8 @@ func (s *Server) handleK8sResource(w http.ResponseWriter, r *http.Request) {
 		err = e
 		if err == nil {
 			items = make([]map[string]interface{}, len(pods))
-			for i, pod := range pods {
-				items[i] = map[string]interface{}{
-					"name":      pod.Name,
-					"namespace": pod.Namespace,
-					"status":    string(pod.Status.Phase),
-					"ready":     getPodReadyCount(&pod),
-					"restarts":  getPodRestarts(&pod),
-					"age":       time.Since(pod.CreationTimestamp.Time).Round(time.Second).String(),
-					"node":      pod.Spec.NodeName,
-					"ip":        pod.Status.PodIP,
-				}
+			for i := range pods {
+				items[i] = podItem(&pods[i])
 			}
 		}
 
@@ -404,19 +596,8 @@ func (s *Server) handleK8sResource(w http.ResponseWriter, r *http.Request) {
 		err = e
 		if err == nil {
 			items = make([]map[string]interface{}, len(deps))
-			for i, dep := range deps {
-				replicas := int32(1)
-				if dep.Spec.Replicas != nil {
-					replicas = *dep.Spec.Replicas
-				}
-				items[i] = map[string]interface{}{
-					"name":      dep.Name,
-					"namespace": dep.Namespace,
-					"ready":     fmt.Sprintf("%d/%d", dep.Status.ReadyReplicas, replicas),
-					"upToDate":  dep.Status.UpdatedReplicas,
-					"available": dep.Status.AvailableReplicas,
-					"age":       time.Since(dep.CreationTimestamp.Time).Round(time.Second).String(),
-				}
+			for i := range deps {
+				items[i] = deploymentItem(&deps[i])
 			}
 		}
 
@@ -425,20 +606,8 @@ func (s *Server) handleK8sResource(w http.ResponseWriter, r *http.Request) {
 		err = e
 		if err == nil {
 			items = make([]map[string]interface{}, len(svcs))
-			for i, svc := range svcs {
-				ports := make([]string, len(svc.Spec.Ports))
-				for j, p := range svc.Spec.Ports {
-					ports[j] = fmt.Sprintf("%d/%s", p.Port, p.Protocol)
-				}
-				items[i] = map[string]interface{}{
-					"name":       svc.Name,
-					"namespace":  svc.Namespace,
-					"type":       string(svc.Spec.Type),
-					"clusterIP":  svc.Spec.ClusterIP,
-					"externalIP": getExternalIPs(&svc),
-					"ports":      strings.Join(ports, ", "),
-					"age":        time.Since(svc.CreationTimestamp.Time).Round(time.Second).String(),
-				}
+			for i := range svcs {
+				items[i] = serviceItem(&svcs[i])
 			}
 		}
 
@@ -447,12 +616,8 @@ func (s *Server) handleK8sResource(w http.ResponseWriter, r *http.Request) {
 		err = e
 		if err == nil {
 			items = make([]map[string]interface{}, len(nss))
-			for i, ns := range nss {
-				items[i] = map[string]interface{}{
-					"name":   ns.Name,
-					"status": string(ns.Status.Phase),
-					"age":    time.Since(ns.CreationTimestamp.Time).Round(time.Second).String(),
-				}
+			for i := range nss {
+				items[i] = namespaceItem(&nss[i])
 			}
 		}
 
@@ -461,14 +626,8 @@ func (s *Server) handleK8sResource(w http.ResponseWriter, r *http.Request) {
 		err = e
 		if err == nil {
 			items = make([]map[string]interface{}, len(nodes))
-			for i, node := range nodes {
-				items[i] = map[string]interface{}{
-					"name":    node.Name,
-					"status":  getNodeStatus(&node),
-					"roles":   getNodeRoles(&node),
-					"version": node.Status.NodeInfo.KubeletVersion,
-					"age":     time.Since(node.CreationTimestamp.Time).Round(time.Second).String(),
-				}
+			for i := range nodes {
+				items[i] = nodeItem(&nodes[i])
 			}
 		}
 
@@ -477,16 +636,8 @@ func (s *Server) handleK8sResource(w http.ResponseWriter, r *http.Request) {
 		err = e
 		if err == nil {
 			items = make([]map[string]interface{}, len(events))
-			for i, ev := range events {
-				items[i] = map[string]interface{}{
-					"name":      ev.Name,
-					"namespace": ev.Namespace,
-					"type":      ev.Type,
-					"reason":    ev.Reason,
-					"message":   ev.Message,
-					"count":     ev.Count,
-					"lastSeen":  ev.LastTimestamp.Time.Format(time.RFC3339),
-				}
+			for i := range events {
+				items[i] = eventItem(&events[i])
 			}
 		}
 
@@ -496,9 +647,11 @@ func (s *Server) handleK8sResource(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err != nil {
+		ce := k8s.ClassifyError(err, resource, namespace)
 		json.NewEncoder(w).Encode(K8sResourceResponse{
 			Kind:      resource,
-			Error:     err.Error(),
+			Error:     ce.Message,
+			Hint:      ce.Suggestion,
 			Timestamp: time.Now(),
 		})
 		return
@@ -511,6 +664,153 @@ func (s *Server) handleK8sResource(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// handleK8sObject serves one object as ?output=yaml (default), describe or
+// json. Secret values are always redacted.
+func (s *Server) handleK8sObject(w http.ResponseWriter, r *http.Request, resource, namespace, name string) {
+	output := r.URL.Query().Get("output")
+	if output == "" {
+		output = "yaml"
+	}
+	if output != "yaml" && output != "describe" && output != "json" {
+		http.Error(w, fmt.Sprintf("Unsupported output: %s (use yaml, describe or json)", output), http.StatusBadRequest)
+		return
+	}
+
+	gvr, ok := s.k8sClient.GetGVR(resource)
+	if !ok {
+		http.Error(w, fmt.Sprintf("Unknown resource type: %s", resource), http.StatusNotFound)
+		return
+	}
+
+	username := r.Header.Get("X-Username")
+	if username == "" {
+		username = "anonymous"
+	}
+
+	db.RecordAudit(db.AuditEntry{
+		User:     username,
+		Action:   "view",
+		Resource: resource,
+		Details:  fmt.Sprintf("namespace=%s name=%s output=%s", namespace, name, output),
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+
+	resp := K8sObjectResponse{
+		Kind:      gvr.Resource,
+		Namespace: namespace,
+		Name:      name,
+		Output:    output,
+		Timestamp: time.Now(),
+	}
+
+	var err error
+	if output == "describe" {
+		resp.Content, err = s.k8sClient.DescribeResource(r.Context(), gvr.Resource, namespace, name)
+	} else {
+		var obj *unstructured.Unstructured
+		obj, err = s.k8sClient.GetResource(r.Context(), namespace, name, gvr)
+		if err == nil {
+			k8s.RedactSecret(obj)
+			if output == "json" {
+				resp.Object = obj.Object
+			} else {
+				var data []byte
+				data, err = yaml.Marshal(obj.Object)
+				resp.Content = string(data)
+			}
+		}
+	}
+
+	if err != nil {
+		ce := k8s.ClassifyError(err, resource, namespace)
+		resp.Error = ce.Message
+		resp.Hint = ce.Suggestion
+	}
+
+	json.NewEncoder(w).Encode(resp)
+}
+
+// defaultLogTailLines is used when /api/k8s/logs is called without tail
+const defaultLogTailLines = 500
+
+// handlePodLogs streams pod logs as server-sent events, one event per line,
+// followed by [DONE]. The stream stops when the client disconnects.
+func (s *Server) handlePodLogs(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	namespace := query.Get("namespace")
+	if namespace == "" {
+		namespace = "default"
+	}
+	pod := query.Get("pod")
+	if pod == "" {
+		http.Error(w, "pod is required", http.StatusBadRequest)
+		return
+	}
+	container := query.Get("container")
+	follow := query.Get("follow") == "true"
+
+	tail := int64(defaultLogTailLines)
+	if v := query.Get("tail"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			http.Error(w, "tail must be a number", http.StatusBadRequest)
+			return
+		}
+		tail = n
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	username := r.Header.Get("X-Username")
+	if username == "" {
+		username = "anonymous"
+	}
+
+	db.RecordAudit(db.AuditEntry{
+		User:     username,
+		Action:   "view_logs",
+		Resource: "pods",
+		Details:  fmt.Sprintf("namespace=%s pod=%s container=%s follow=%t", namespace, pod, container, follow),
+	})
+
+	stream, err := s.k8sClient.GetPodLogsStream(r.Context(), namespace, pod, container, tail, follow)
+	if err != nil {
+		ce := k8s.ClassifyError(err, "pods", namespace)
+		http.Error(w, ce.Message, http.StatusBadGateway)
+		return
+	}
+	defer stream.Close()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	sse := &SSEWriter{w: w, flusher: flusher}
+
+	scanner := bufio.NewScanner(stream)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if err := sse.Write(scanner.Text()); err != nil {
+			return
+		}
+	}
+	if err := scanner.Err(); err != nil && r.Context().Err() == nil {
+		sse.Write(fmt.Sprintf("[ERROR] %s", err.Error()))
+	}
+
+	sse.Write("[DONE]")
+}
+
 func (s *Server) handleAuditLogs(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
@@ -654,6 +954,86 @@ func truncateString(s string, maxLen int) string {
 	return s[:maxLen] + "..."
 }
 
+// podItem is a pod as /api/k8s/pods and its watch list it
+func podItem(pod *corev1.Pod) map[string]interface{} {
+	return map[string]interface{}{
+		"name":      pod.Name,
+		"namespace": pod.Namespace,
+		"status":    string(pod.Status.Phase),
+		"ready":     getPodReadyCount(pod),
+		"restarts":  getPodRestarts(pod),
+		"age":       time.Since(pod.CreationTimestamp.Time).Round(time.Second).String(),
+		"node":      pod.Spec.NodeName,
+		"ip":        pod.Status.PodIP,
+	}
+}
+
+// deploymentItem is a deployment as /api/k8s/deployments and its watch list it
+func deploymentItem(dep *appsv1.Deployment) map[string]interface{} {
+	replicas := int32(1)
+	if dep.Spec.Replicas != nil {
+		replicas = *dep.Spec.Replicas
+	}
+	return map[string]interface{}{
+		"name":      dep.Name,
+		"namespace": dep.Namespace,
+		"ready":     fmt.Sprintf("%d/%d", dep.Status.ReadyReplicas, replicas),
+		"upToDate":  dep.Status.UpdatedReplicas,
+		"available": dep.Status.AvailableReplicas,
+		"age":       time.Since(dep.CreationTimestamp.Time).Round(time.Second).String(),
+	}
+}
+
+// serviceItem is a service as /api/k8s/services and its watch list it
+func serviceItem(svc *corev1.Service) map[string]interface{} {
+	ports := make([]string, len(svc.Spec.Ports))
+	for j, p := range svc.Spec.Ports {
+		ports[j] = fmt.Sprintf("%d/%s", p.Port, p.Protocol)
+	}
+	return map[string]interface{}{
+		"name":       svc.Name,
+		"namespace":  svc.Namespace,
+		"type":       string(svc.Spec.Type),
+		"clusterIP":  svc.Spec.ClusterIP,
+		"externalIP": getExternalIPs(svc),
+		"ports":      strings.Join(ports, ", "),
+		"age":        time.Since(svc.CreationTimestamp.Time).Round(time.Second).String(),
+	}
+}
+
+// namespaceItem is a namespace as /api/k8s/namespaces and its watch list it
+func namespaceItem(ns *corev1.Namespace) map[string]interface{} {
+	return map[string]interface{}{
+		"name":   ns.Name,
+		"status": string(ns.Status.Phase),
+		"age":    time.Since(ns.CreationTimestamp.Time).Round(time.Second).String(),
+	}
+}
+
+// nodeItem is a node as /api/k8s/nodes and its watch list it
+func nodeItem(node *corev1.Node) map[string]interface{} {
+	return map[string]interface{}{
+		"name":    node.Name,
+		"status":  getNodeStatus(node),
+		"roles":   getNodeRoles(node),
+		"version": node.Status.NodeInfo.KubeletVersion,
+		"age":     time.Since(node.CreationTimestamp.Time).Round(time.Second).String(),
+	}
+}
+
+// eventItem is an event as /api/k8s/events and its watch list it
+func eventItem(ev *corev1.Event) map[string]interface{} {
+	return map[string]interface{}{
+		"name":      ev.Name,
+		"namespace": ev.Namespace,
+		"type":      ev.Type,
+		"reason":    ev.Reason,
+		"message":   ev.Message,
+		"count":     ev.Count,
+		"lastSeen":  ev.LastTimestamp.Time.Format(time.RFC3339),
+	}
+}
+
 func getPodReadyCount(pod *corev1.Pod) string {
 	ready := 0
 	total := len(pod.Status.ContainerStatuses)
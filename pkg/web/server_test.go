@@ -1,12 +1,17 @@
 package web
 
 import (
+	"compress/gzip"
+	"crypto/x509"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/config"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -406,3 +411,98 @@ func TestK8sResourceResponse_JSON(t *testing.T) {
 		t.Errorf("decoded items count = %d, want %d", len(decoded.Items), len(resp.Items))
 	}
 }
+
+func TestTLSMode(t *testing.T) {
+	tests := []struct {
+		name           string
+		bind           string
+		cfg            config.TLSConfig
+		wantTLS        bool
+		wantSelfSigned bool
+		wantErr        bool
+	}{
+		{"loopback defaults to HTTP", "127.0.0.1", config.TLSConfig{}, false, false, false},
+		{"localhost defaults to HTTP", "localhost", config.TLSConfig{}, false, false, false},
+		{"all interfaces default to self-signed", "", config.TLSConfig{}, true, true, false},
+		{"specific interface defaults to self-signed", "10.0.0.5", config.TLSConfig{}, true, true, false},
+		{"certificate files", "0.0.0.0", config.TLSConfig{CertFile: "tls.crt", KeyFile: "tls.key"}, true, false, false},
+		{"self-signed on loopback", "127.0.0.1", config.TLSConfig{SelfSigned: true}, true, true, false},
+		{"disabled", "0.0.0.0", config.TLSConfig{Disable: true}, false, false, false},
+		{"certificate without key", "0.0.0.0", config.TLSConfig{CertFile: "tls.crt"}, false, false, true},
+		{"key without certificate", "127.0.0.1", config.TLSConfig{KeyFile: "tls.key", SelfSigned: true}, false, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useTLS, selfSigned, err := tlsMode(tt.bind, tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("tlsMode(%q) error = %v, wantErr %v", tt.bind, err, tt.wantErr)
+			}
+			if useTLS != tt.wantTLS || selfSigned != tt.wantSelfSigned {
+				t.Errorf("tlsMode(%q) = %v, %v; want %v, %v", tt.bind, useTLS, selfSigned, tt.wantTLS, tt.wantSelfSigned)
+			}
+		})
+	}
+}
+
+func TestSelfSignedCertificate(t *testing.T) {
+	cert, err := selfSignedCertificate("10.0.0.5")
+	if err != nil {
+		t.Fatalf("selfSignedCertificate failed: %v", err)
+	}
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		t.Fatalf("failed to parse certificate: %v", err)
+	}
+	for _, host := range []string{"localhost", "127.0.0.1", "10.0.0.5"} {
+		if err := leaf.VerifyHostname(host); err != nil {
+			t.Errorf("certificate not valid for %s: %v", host, err)
+		}
+	}
+}
+
+func TestGzipMiddleware(t *testing.T) {
+	body := strings.Repeat(`{"name":"pod"}`, 100)
+	handler := gzipMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/k8s/pods", nil)
+	req.Header.Set("Accept-Encoding", "gzip, deflate")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Header().Get("Content-Encoding") != "gzip" {
+		t.Fatalf("expected gzip encoding, got %q", w.Header().Get("Content-Encoding"))
+	}
+	gr, err := gzip.NewReader(w.Body)
+	if err != nil {
+		t.Fatalf("invalid gzip body: %v", err)
+	}
+	got, _ := io.ReadAll(gr)
+	if string(got) != body {
+		t.Errorf("decompressed body mismatch: %q", got)
+	}
+
+	// Without Accept-Encoding the response is sent as-is
+	req = httptest.NewRequest(http.MethodGet, "/api/k8s/pods", nil)
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if w.Header().Get("Content-Encoding") != "" || w.Body.String() != body {
+		t.Error("expected uncompressed response without Accept-Encoding: gzip")
+	}
+
+	// Event streams are never compressed
+	sse := gzipMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.(http.Flusher).Flush()
+		w.Write([]byte("data: hello\n\n"))
+	}))
+	req = httptest.NewRequest(http.MethodGet, "/api/k8s/logs", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	w = httptest.NewRecorder()
+	sse.ServeHTTP(w, req)
+	if w.Header().Get("Content-Encoding") != "" || w.Body.String() != "data: hello\n\n" {
+		t.Errorf("expected uncompressed event stream, got %q", w.Body.String())
+	}
+}
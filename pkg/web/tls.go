@@ -0,0 +1,88 @@
+package web
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"math/big"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/config"
+)
+
+// selfSignedValidity is how long a generated certificate is valid; a new one
+// is generated on every start.
+const selfSignedValidity = 365 * 24 * time.Hour
+
+// isLoopback reports whether the web server binds only the loopback
+// interface. An empty host means all interfaces.
+func isLoopback(host string) bool {
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// tlsMode decides how the web server is served: with the configured
+// certificate files, with a generated self-signed certificate, or over plain
+// HTTP. Plain HTTP is the default only for loopback bindings. Setting only
+// one of the certificate files is an error rather than a silent fallback to
+// a self-signed certificate.
+func tlsMode(bindAddress string, cfg config.TLSConfig) (useTLS, selfSigned bool, err error) {
+	switch {
+	case cfg.CertFile != "" && cfg.KeyFile != "":
+		return true, false, nil
+	case cfg.CertFile != "" || cfg.KeyFile != "":
+		return false, false, fmt.Errorf("tls.cert_file and tls.key_file must be set together")
+	case cfg.SelfSigned:
+		return true, true, nil
+	case cfg.Disable || isLoopback(bindAddress):
+		return false, false, nil
+	default:
+		return true, true, nil
+	}
+}
+
+// selfSignedCertificate generates an in-memory certificate for localhost,
+// the loopback addresses and bindAddress when it is a specific host.
+func selfSignedCertificate(bindAddress string) (tls.Certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	now := time.Now()
+	tmpl := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{Organization: []string{"k13s"}, CommonName: "localhost"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(selfSignedValidity),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		DNSNames:              []string{"localhost"},
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback},
+	}
+	if ip := net.ParseIP(bindAddress); ip != nil && !ip.IsUnspecified() && !ip.IsLoopback() {
+		tmpl.IPAddresses = append(tmpl.IPAddresses, ip)
+	} else if ip == nil && bindAddress != "" && !isLoopback(bindAddress) {
+		tmpl.DNSNames = append(tmpl.DNSNames, bindAddress)
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, nil
+}
@@ -0,0 +1,222 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/db"
+	"github.com/kube-ai-dashbaord/kube-ai-dashboard-cli/pkg/k8s"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// watchHeartbeat is how often an idle watch stream sends a comment, well
+// inside the idle timeouts of common proxies and load balancers
+var watchHeartbeat = 30 * time.Second
+
+// watchRetry is how long a browser waits before resuming a closed stream
+const watchRetry = 3 * time.Second
+
+// WatchEvent is one change streamed by /api/k8s/watch/. Item has the shape
+// of the items /api/k8s/<resource> lists.
+type WatchEvent struct {
+	Type            string                 `json:"type"`
+	ResourceVersion string                 `json:"resourceVersion,omitempty"`
+	Item            map[string]interface{} `json:"item,omitempty"`
+	Error           string                 `json:"error,omitempty"`
+}
+
+// Watch event types besides the ADDED, MODIFIED and DELETED of Kubernetes.
+// After EXPIRED the client must start over without a resource version.
+const (
+	watchEventExpired = "EXPIRED"
+	watchEventError   = "ERROR"
+)
+
+// writeEventWithID sends a named event with an id, which the browser echoes
+// in Last-Event-ID when it reconnects
+func (s *SSEWriter) writeEventWithID(id, event, data string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var sb strings.Builder
+	if id != "" {
+		fmt.Fprintf(&sb, "id: %s\n", id)
+	}
+	if event != "" {
+		fmt.Fprintf(&sb, "event: %s\n", event)
+	}
+	fmt.Fprintf(&sb, "data: %s\n\n", data)
+	if _, err := fmt.Fprint(s.w, sb.String()); err != nil {
+		return err
+	}
+	s.flusher.Flush()
+	return nil
+}
+
+// writeRaw sends lines that are not an event, e.g. a comment or the retry
+// delay
+func (s *SSEWriter) writeRaw(text string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := fmt.Fprint(s.w, text); err != nil {
+		return err
+	}
+	s.flusher.Flush()
+	return nil
+}
+
+// watchItem converts a watched object to its list item and returns its
+// resource version
+func watchItem(obj runtime.Object) (map[string]interface{}, string, bool) {
+	var item map[string]interface{}
+	switch o := obj.(type) {
+	case *corev1.Pod:
+		item = podItem(o)
+	case *appsv1.Deployment:
+		item = deploymentItem(o)
+	case *corev1.Service:
+		item = serviceItem(o)
+	case *corev1.Namespace:
+		item = namespaceItem(o)
+	case *corev1.Node:
+		item = nodeItem(o)
+	case *corev1.Event:
+		item = eventItem(o)
+	default:
+		return nil, "", false
+	}
+
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return item, "", true
+	}
+	return item, accessor.GetResourceVersion(), true
+}
+
+// handleK8sWatch streams the changes to a resource as server-sent events:
+// GET /api/k8s/watch/<resource>?namespace=. It takes the same namespace as
+// /api/k8s/<resource>. Each event carries its resource version as the SSE
+// id, so a reconnecting EventSource resumes where it left off through
+// Last-Event-ID; fetch clients pass ?resourceVersion= instead. Without a
+// version the stream starts with an ADDED event per existing object.
+// Bookmarks only advance the id, and comments are sent while idle.
+func (s *Server) handleK8sWatch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	resource := strings.TrimPrefix(r.URL.Path, "/api/k8s/watch/")
+	if !k8s.WatchableResources[resource] {
+		http.Error(w, fmt.Sprintf("Unknown resource type: %s", resource), http.StatusNotFound)
+		return
+	}
+
+	namespace := r.URL.Query().Get("namespace")
+	if namespace == "" {
+		namespace = "default"
+	}
+	resourceVersion := r.URL.Query().Get("resourceVersion")
+	if resourceVersion == "" {
+		resourceVersion = r.Header.Get("Last-Event-ID")
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	username := r.Header.Get("X-Username")
+	if username == "" {
+		username = "anonymous"
+	}
+	db.RecordAudit(db.AuditEntry{
+		User:     username,
+		Action:   "watch",
+		Resource: resource,
+		Details:  fmt.Sprintf("namespace=%s", namespace),
+	})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+
+	sse := &SSEWriter{w: w, flusher: flusher}
+	send := func(id string, ev WatchEvent) error {
+		data, err := json.Marshal(ev)
+		if err != nil {
+			return err
+		}
+		return sse.writeEventWithID(id, ev.Type, string(data))
+	}
+
+	watcher, err := s.k8sClient.WatchResource(r.Context(), resource, namespace, resourceVersion)
+	if err != nil {
+		if apierrors.IsResourceExpired(err) || apierrors.IsGone(err) {
+			send("", WatchEvent{Type: watchEventExpired, Error: err.Error()})
+			return
+		}
+		ce := k8s.ClassifyError(err, resource, namespace)
+		send("", WatchEvent{Type: watchEventError, Error: ce.Message})
+		return
+	}
+	defer watcher.Stop()
+
+	if err := sse.writeRaw(fmt.Sprintf("retry: %d\n\n", watchRetry.Milliseconds())); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(watchHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			if err := sse.writeRaw(": heartbeat\n\n"); err != nil {
+				return
+			}
+		case ev, ok := <-watcher.ResultChan():
+			if !ok {
+				// The API server ends watches after a timeout; the client
+				// resumes from the last id
+				return
+			}
+
+			switch ev.Type {
+			case watch.Bookmark:
+				if accessor, err := meta.Accessor(ev.Object); err == nil {
+					sse.writeRaw(fmt.Sprintf("id: %s\n\n", accessor.GetResourceVersion()))
+				}
+			case watch.Error:
+				err := apierrors.FromObject(ev.Object)
+				if apierrors.IsResourceExpired(err) || apierrors.IsGone(err) {
+					send("", WatchEvent{Type: watchEventExpired, Error: err.Error()})
+				} else {
+					send("", WatchEvent{Type: watchEventError, Error: err.Error()})
+				}
+				return
+			default:
+				item, rv, ok := watchItem(ev.Object)
+				if !ok {
+					continue
+				}
+				if err := send(rv, WatchEvent{Type: string(ev.Type), ResourceVersion: rv, Item: item}); err != nil {
+					return
+				}
+			}
+		}
+	}
+}